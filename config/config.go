@@ -10,9 +10,32 @@ import (
 
 type Config struct {
 	Server struct {
-		Port int `yaml:"port"`
+		Port    int    `yaml:"port"`
+		Name    string `yaml:"name"`     // Display name announced to clients and shown on login (default "Cinea")
+		BaseURL string `yaml:"base_url"` // External URL of this server, used when building links in emails (e.g. https://cinea.example.com)
 	} `yaml:"server"`
 
+	Discovery struct {
+		Enabled bool `yaml:"enabled"` // Announce the server on the LAN over SSDP so clients can auto-discover it
+	} `yaml:"discovery"`
+
+	Branding struct {
+		AssetDir string `yaml:"asset_dir"` // Directory uploaded branding images are written to (default "branding")
+	} `yaml:"branding"`
+
+	Avatars struct {
+		AssetDir string `yaml:"asset_dir"` // Directory uploaded user avatars are written to (default "avatars")
+	} `yaml:"avatars"`
+
+	CORS struct {
+		AllowedOrigins   []string `yaml:"allowed_origins"`   // Origins allowed to call the API (default http://localhost:8937); "*" allows any
+		AllowedMethods   []string `yaml:"allowed_methods"`   // HTTP methods allowed cross-origin (defaults to the methods the API uses)
+		AllowedHeaders   []string `yaml:"allowed_headers"`   // Request headers allowed cross-origin (defaults cover auth and API key headers)
+		ExposedHeaders   []string `yaml:"exposed_headers"`   // Response headers exposed to cross-origin scripts (default Link)
+		AllowCredentials *bool    `yaml:"allow_credentials"` // Allow cookies and Authorization headers cross-origin (default true)
+		MaxAge           int      `yaml:"max_age"`           // How long preflight results may be cached, in seconds (default 300)
+	} `yaml:"cors"`
+
 	Logging struct {
 		Level    string `yaml:"level"`
 		Rotation struct {
@@ -22,16 +45,70 @@ type Config struct {
 		} `yaml:"rotation"`
 	} `yaml:"logging"`
 
+	Tracing struct {
+		Enabled     bool    `yaml:"enabled"`      // Enable OTLP trace export; disabled leaves all instrumentation as no-ops
+		Endpoint    string  `yaml:"endpoint"`     // OTLP HTTP collector endpoint as host:port (default localhost:4318)
+		Insecure    bool    `yaml:"insecure"`     // Use plain HTTP to the collector instead of TLS
+		SampleRatio float64 `yaml:"sample_ratio"` // Fraction of traces sampled, 0-1 (default 1.0)
+	} `yaml:"tracing"`
+
 	Auth struct {
-		JWTSecret     string `yaml:"jwt_secret"`
-		TokenLifetime string `yaml:"token_lifetime"`
+		JWTSecret       string `yaml:"jwt_secret"`
+		TokenLifetime   string `yaml:"token_lifetime"`   // Lifetime of an access token (default 15m)
+		RefreshLifetime string `yaml:"refresh_lifetime"` // Lifetime of a refresh token (default 720h)
+
+		OIDC struct {
+			Provider     string   `yaml:"provider"`      // Display name stored against linked users (e.g. google, authentik, keycloak)
+			Issuer       string   `yaml:"issuer"`        // Issuer URL; endpoints are discovered from it. Empty disables OIDC login
+			ClientID     string   `yaml:"client_id"`     //
+			ClientSecret string   `yaml:"client_secret"` //
+			RedirectURL  string   `yaml:"redirect_url"`  // Must match the provider's registered callback, e.g. https://host/api/auth/oidc/callback
+			Scopes       []string `yaml:"scopes"`        // Defaults to openid, profile, email
+		} `yaml:"oidc"`
 	} `yaml:"auth"`
 
+	Mail struct {
+		SMTP struct {
+			Host     string `yaml:"host"` // SMTP server hostname; empty disables outgoing mail
+			Port     int    `yaml:"port"` // SMTP server port (default 587)
+			Username string `yaml:"username"`
+			Password string `yaml:"password"`
+			From     string `yaml:"from"` // From address on outgoing mail (e.g. cinea@example.com)
+		} `yaml:"smtp"`
+	} `yaml:"mail"`
+
+	Notifications struct {
+		Discord struct {
+			WebhookURL string `yaml:"webhook_url"` // Discord webhook URL; empty disables the channel
+		} `yaml:"discord"`
+		Telegram struct {
+			BotToken string `yaml:"bot_token"` // Telegram bot token; both fields must be set to enable the channel
+			ChatID   string `yaml:"chat_id"`   // Chat the bot posts notifications to
+		} `yaml:"telegram"`
+		Gotify struct {
+			URL   string `yaml:"url"`   // Gotify server URL; both fields must be set to enable the channel
+			Token string `yaml:"token"` // Gotify application token
+		} `yaml:"gotify"`
+	} `yaml:"notifications"`
+
+	Timeouts struct {
+		TMDbRequest string `yaml:"tmdb_request"` // Per-request timeout for TMDb API calls (e.g. 10s)
+		Probe       string `yaml:"probe"`        // Timeout for a single ffprobe run (e.g. 2m)
+		DBQuery     string `yaml:"db_query"`     // Timeout for a single database query (e.g. 30s)
+	} `yaml:"timeouts"`
+
 	DB struct {
 		Driver string `yaml:"driver"`
 		SQLite struct {
-			Path string `yaml:"path"`
+			Path        string `yaml:"path"`
+			JournalMode string `yaml:"journal_mode"` // SQLite journal mode (default WAL)
+			BusyTimeout string `yaml:"busy_timeout"` // How long a locked connection waits before failing (default 5s)
 		} `yaml:"sqlite"`
+		Pool struct {
+			MaxOpenConns    int    `yaml:"max_open_conns"`    // Maximum open connections (default 25)
+			MaxIdleConns    int    `yaml:"max_idle_conns"`    // Maximum idle connections kept around (default 5)
+			ConnMaxLifetime string `yaml:"conn_max_lifetime"` // How long a connection is reused before being replaced (default 1h)
+		} `yaml:"pool"`
 		Postgres struct {
 			Host     string `yaml:"host"`
 			Port     int    `yaml:"port"`
@@ -49,27 +126,64 @@ type Config struct {
 		} `yaml:"mariadb"`
 	}
 
+	Cache struct {
+		Enabled bool   `yaml:"enabled"` // Enable the in-memory read-through cache for movie/series lookups
+		Size    int    `yaml:"size"`    // Maximum cached entries (default 1024)
+		TTL     string `yaml:"ttl"`     // How long cached entries stay valid (default 5m)
+	} `yaml:"cache"`
+
 	Meta struct {
 		TMDb struct {
 			BearerToken  string
 			Language     string
+			Languages    []string `yaml:"languages"` // Additional languages to store translations for
 			IncludeAdult bool
+
+			BreakerThreshold int    `yaml:"breaker_threshold"` // Consecutive failures before pausing TMDb lookups (default 5)
+			BreakerCooldown  string `yaml:"breaker_cooldown"`  // How long lookups stay paused before retrying (default 5m)
+			RateLimit        int    `yaml:"rate_limit"`        // Maximum TMDb requests per second (default 20)
+			CacheTTL         string `yaml:"cache_ttl"`         // How long TMDb responses are cached in memory (default 6h)
+			DiskCacheTTL     string `yaml:"disk_cache_ttl"`    // How long TMDb responses persist in the database cache (default 72h)
 		} `yaml:"tmdb"`
 	} `yaml:"meta"`
 
 	Backup struct {
-		Enabled    bool
-		AutoBackup bool
-		BackupDir  string
-		Interval   string
+		Enabled        bool
+		AutoBackup     bool   // Run backups automatically on the interval via the scheduler
+		BackupDir      string // Directory backup archives are written to
+		Interval       string // How often automatic backups run (default 24h)
+		Retention      int    // Number of archives to keep (default 7)
+		IncludeArtwork bool   // Also archive the artwork cache directory
 	}
 
+	Playback struct {
+		MaxStreamsPerUser int `yaml:"max_streams_per_user"` // Maximum simultaneous streams per user; 0 disables the limit
+		MaxStreamsTotal   int `yaml:"max_streams_total"`    // Maximum simultaneous streams server-wide; 0 disables the limit
+	} `yaml:"playback"`
+
+	FFmpeg struct {
+		AutoDownload  bool   `yaml:"auto_download"`  // Download platform-appropriate static builds on first run when the bundled bin/ folder is absent
+		FFmpegURL     string `yaml:"ffmpeg_url"`     // Override the download URL for the ffmpeg build
+		FFmpegSHA256  string `yaml:"ffmpeg_sha256"`  // Expected SHA-256 of the ffmpeg download; empty skips verification with a warning
+		FFprobeURL    string `yaml:"ffprobe_url"`    // Override the download URL for the ffprobe build
+		FFprobeSHA256 string `yaml:"ffprobe_sha256"` // Expected SHA-256 of the ffprobe download; empty skips verification with a warning
+	} `yaml:"ffmpeg"`
+
+	Maintenance struct {
+		WindowStart  string `yaml:"window_start"`  // Start of the daily maintenance window as HH:MM (e.g. 02:00); empty disables the window
+		WindowEnd    string `yaml:"window_end"`    // End of the maintenance window as HH:MM (e.g. 05:00)
+		AllowRestart bool   `yaml:"allow_restart"` // Allow the server to restart itself for updates during the window
+	} `yaml:"maintenance"`
+
 	Jobs struct {
 		Cleanup struct {
 			Enabled        bool   // Enable the scheduled cleanup job
 			DeleteOrphaned bool   // Cleanup files which don't have corresponding database entries
 			DeleteMissing  bool   // Cleanup database entries where files have been deleted and the database entries remain
 			CleanInterval  string // String representation of cleanup duration (e.g. 2d = 2 days, 36h = 36 hours, 30d = 30 days)
+			GracePeriod    string `yaml:"grace_period"`    // How long a file must stay missing before its entry is removed (e.g. 48h, default 24h)
+			DryRun         bool   `yaml:"dry_run"`         // Report what cleanup would do without changing anything
+			TrashRetention string `yaml:"trash_retention"` // How long deleted items stay in the recycle bin before the scheduled purge (e.g. 168h, default 720h = 30 days)
 		} `yaml:"cleanup"`
 		Scanner struct {
 			MovieDirs    []string // List of directories to search for movies
@@ -77,7 +191,33 @@ type Config struct {
 			AutoScan     bool     // Enable autoscan to periodically scan directories at specified intervals
 			WatchDirs    bool     // Can be used with or without autoscan, will watch the media directories for changes and import any new media
 			ScanInterval string   // Specify the intervals the autoscan runs (e.g. 2d = 2 days, 36h = 36 hours, 30d = 30 days)
+
+			FollowSymlinks bool `yaml:"follow_symlinks"` // Follow symlinked directories while scanning (cycles are detected and skipped)
+			DeferMetadata  bool `yaml:"defer_metadata"`  // Import items with filename-derived titles immediately and queue TMDb matching for the metadata task
+
+			MatchConfidence float64 `yaml:"match_confidence"` // Minimum match score (0-1) before a TMDb result is auto-applied (default 0.6)
+
+			IgnorePatterns []string `yaml:"ignore_patterns"`  // Filename tokens treated as junk and skipped (replaces the built-in sample/trailer list when set)
+			MinFileSizeMB  int      `yaml:"min_file_size_mb"` // Skip video files smaller than this many megabytes; 0 disables the size filter
+
+			VideoExtensions []string          `yaml:"video_extensions"` // Replaces the built-in video extension list when set (e.g. [".mp4", ".mkv"])
+			AudioExtensions []string          `yaml:"audio_extensions"` // Replaces the built-in audio extension list when set
+			ImageExtensions []string          `yaml:"image_extensions"` // Replaces the built-in image extension list when set
+			BookExtensions  []string          `yaml:"book_extensions"`  // Replaces the built-in book extension list when set
+			ExtensionTypes  map[string]string `yaml:"extension_types"`  // Maps unusual extensions to a media type (e.g. ".m2ts": "video", ".iso": "ignore")
 		} `yaml:"scanner"`
+		Subtitles struct {
+			DropDir       string `yaml:"drop_dir"`       // Folder watched for dropped subtitle files; empty disables the watcher
+			SweepInterval string `yaml:"sweep_interval"` // How often the drop folder is checked (e.g. 5m, default 15m)
+		} `yaml:"subtitles"`
+		Photos struct {
+			ThumbnailDir   string `yaml:"thumbnail_dir"`   // Directory thumbnails are written to (default "thumbnails")
+			ThumbnailWidth int    `yaml:"thumbnail_width"` // Maximum thumbnail width in pixels (default 320)
+		} `yaml:"photos"`
+		Artwork struct {
+			CacheDir    string   `yaml:"cache_dir"`    // Directory cached artwork and transcoded variants are written to (default "artwork")
+			PregenSizes []string `yaml:"pregen_sizes"` // TMDb sizes the pregeneration task warms (default w342 and w780)
+		} `yaml:"artwork"`
 	} `yaml:"jobs"`
 }
 
@@ -111,6 +251,11 @@ func Load(path string) (*Config, error) {
 	// Expand environment variables in paths
 	config.DB.SQLite.Path = os.ExpandEnv(config.DB.SQLite.Path)
 	config.Backup.BackupDir = os.ExpandEnv(config.Backup.BackupDir)
+	config.Jobs.Subtitles.DropDir = os.ExpandEnv(config.Jobs.Subtitles.DropDir)
+	config.Jobs.Photos.ThumbnailDir = os.ExpandEnv(config.Jobs.Photos.ThumbnailDir)
+	config.Jobs.Artwork.CacheDir = os.ExpandEnv(config.Jobs.Artwork.CacheDir)
+	config.Branding.AssetDir = os.ExpandEnv(config.Branding.AssetDir)
+	config.Avatars.AssetDir = os.ExpandEnv(config.Avatars.AssetDir)
 
 	// Expand movie directories
 	for i, dir := range config.Jobs.Scanner.MovieDirs {
@@ -124,3 +269,339 @@ func Load(path string) (*Config, error) {
 
 	return config, nil
 }
+
+// ServerName returns the display name announced to clients, defaulting
+// to "Cinea".
+func (c *Config) ServerName() string {
+	if c.Server.Name == "" {
+		return "Cinea"
+	}
+	return c.Server.Name
+}
+
+// BrandingAssetDir returns the directory uploaded branding images are
+// written to, defaulting to "branding".
+func (c *Config) BrandingAssetDir() string {
+	if c.Branding.AssetDir != "" {
+		return c.Branding.AssetDir
+	}
+	return "branding"
+}
+
+// AvatarAssetDir returns the directory uploaded user avatars are written
+// to, defaulting to "avatars".
+func (c *Config) AvatarAssetDir() string {
+	if c.Avatars.AssetDir != "" {
+		return c.Avatars.AssetDir
+	}
+	return "avatars"
+}
+
+// BackupRetention returns how many backup archives are kept, defaulting
+// to 7; older archives are pruned after each backup.
+func (c *Config) BackupRetention() int {
+	if c.Backup.Retention > 0 {
+		return c.Backup.Retention
+	}
+	return 7
+}
+
+// CleanupGracePeriod returns how long a file must stay missing before the
+// cleanup job removes its database entry, defaulting to 24 hours.
+func (c *Config) CleanupGracePeriod() time.Duration {
+	return timeoutOr(c.Jobs.Cleanup.GracePeriod, 24*time.Hour)
+}
+
+// TrashRetention returns how long deleted items stay restorable in the
+// recycle bin before the scheduled purge removes them, defaulting to 30
+// days.
+func (c *Config) TrashRetention() time.Duration {
+	return timeoutOr(c.Jobs.Cleanup.TrashRetention, 30*24*time.Hour)
+}
+
+// CORSAllowedOrigins returns the origins allowed to call the API,
+// defaulting to the bundled web client's development address.
+func (c *Config) CORSAllowedOrigins() []string {
+	if len(c.CORS.AllowedOrigins) > 0 {
+		return c.CORS.AllowedOrigins
+	}
+	return []string{"http://localhost:8937"}
+}
+
+// CORSAllowedMethods returns the methods allowed cross-origin, defaulting
+// to the methods the API uses.
+func (c *Config) CORSAllowedMethods() []string {
+	if len(c.CORS.AllowedMethods) > 0 {
+		return c.CORS.AllowedMethods
+	}
+	return []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+}
+
+// CORSAllowedHeaders returns the request headers allowed cross-origin,
+// defaulting to the headers the API and web client send.
+func (c *Config) CORSAllowedHeaders() []string {
+	if len(c.CORS.AllowedHeaders) > 0 {
+		return c.CORS.AllowedHeaders
+	}
+	return []string{"Accept", "Authorization", "Content-Type", "If-None-Match", "X-API-Version", "X-CSRF-Token", "X-API-Key", "X-Parental-PIN", "HX-Request", "HX-Target"}
+}
+
+// CORSExposedHeaders returns the response headers exposed to cross-origin
+// scripts. The defaults cover pagination links, cache revalidation tags,
+// and the API version/deprecation signals.
+func (c *Config) CORSExposedHeaders() []string {
+	if len(c.CORS.ExposedHeaders) > 0 {
+		return c.CORS.ExposedHeaders
+	}
+	return []string{"Link", "ETag", "X-API-Version", "Deprecation", "Sunset"}
+}
+
+// CORSAllowCredentials reports whether cookies and Authorization headers
+// are allowed cross-origin, defaulting to true.
+func (c *Config) CORSAllowCredentials() bool {
+	if c.CORS.AllowCredentials != nil {
+		return *c.CORS.AllowCredentials
+	}
+	return true
+}
+
+// CORSMaxAge returns how long browsers may cache preflight results, in
+// seconds, defaulting to 300.
+func (c *Config) CORSMaxAge() int {
+	if c.CORS.MaxAge > 0 {
+		return c.CORS.MaxAge
+	}
+	return 300
+}
+
+// TMDbRequestTimeout returns the configured timeout for a single TMDb API
+// request, defaulting to 10 seconds.
+func (c *Config) TMDbRequestTimeout() time.Duration {
+	return timeoutOr(c.Timeouts.TMDbRequest, 10*time.Second)
+}
+
+// ProbeTimeout returns the configured timeout for a single ffprobe run,
+// defaulting to 2 minutes. A corrupt file can otherwise hang probe
+// indefinitely and stall a whole scan.
+func (c *Config) ProbeTimeout() time.Duration {
+	return timeoutOr(c.Timeouts.Probe, 2*time.Minute)
+}
+
+// DBQueryTimeout returns the configured timeout for a single database
+// query, defaulting to 30 seconds.
+func (c *Config) DBQueryTimeout() time.Duration {
+	return timeoutOr(c.Timeouts.DBQuery, 30*time.Second)
+}
+
+// SQLiteJournalMode returns the configured SQLite journal mode, defaulting
+// to WAL so concurrent readers don't block on a writing scan.
+func (c *Config) SQLiteJournalMode() string {
+	if c.DB.SQLite.JournalMode != "" {
+		return c.DB.SQLite.JournalMode
+	}
+	return "WAL"
+}
+
+// SQLiteBusyTimeout returns how long a locked SQLite connection waits
+// before giving up, defaulting to 5 seconds.
+func (c *Config) SQLiteBusyTimeout() time.Duration {
+	return timeoutOr(c.DB.SQLite.BusyTimeout, 5*time.Second)
+}
+
+// DBMaxOpenConns returns the connection pool's open connection cap,
+// defaulting to 25.
+func (c *Config) DBMaxOpenConns() int {
+	if c.DB.Pool.MaxOpenConns > 0 {
+		return c.DB.Pool.MaxOpenConns
+	}
+	return 25
+}
+
+// DBMaxIdleConns returns how many idle connections the pool keeps,
+// defaulting to 5.
+func (c *Config) DBMaxIdleConns() int {
+	if c.DB.Pool.MaxIdleConns > 0 {
+		return c.DB.Pool.MaxIdleConns
+	}
+	return 5
+}
+
+// DBConnMaxLifetime returns how long a pooled connection is reused before
+// being replaced, defaulting to an hour.
+func (c *Config) DBConnMaxLifetime() time.Duration {
+	return timeoutOr(c.DB.Pool.ConnMaxLifetime, time.Hour)
+}
+
+// CacheSize returns how many entries the media lookup cache may hold,
+// defaulting to 1024.
+func (c *Config) CacheSize() int {
+	if c.Cache.Size > 0 {
+		return c.Cache.Size
+	}
+	return 1024
+}
+
+// CacheTTL returns how long cached media lookups stay valid, defaulting
+// to 5 minutes.
+func (c *Config) CacheTTL() time.Duration {
+	return timeoutOr(c.Cache.TTL, 5*time.Minute)
+}
+
+// TMDbBreakerThreshold returns how many consecutive TMDb failures trip the
+// circuit breaker, defaulting to 5.
+func (c *Config) TMDbBreakerThreshold() int {
+	if c.Meta.TMDb.BreakerThreshold > 0 {
+		return c.Meta.TMDb.BreakerThreshold
+	}
+	return 5
+}
+
+// TMDbBreakerCooldown returns how long TMDb lookups stay paused after the
+// breaker trips, defaulting to 5 minutes.
+func (c *Config) TMDbBreakerCooldown() time.Duration {
+	return timeoutOr(c.Meta.TMDb.BreakerCooldown, 5*time.Minute)
+}
+
+// TMDbRateLimit returns the maximum TMDb requests per second, defaulting
+// to 20.
+func (c *Config) TMDbRateLimit() int {
+	if c.Meta.TMDb.RateLimit > 0 {
+		return c.Meta.TMDb.RateLimit
+	}
+	return 20
+}
+
+// TMDbCacheTTL returns how long TMDb responses are cached in memory,
+// defaulting to 6 hours.
+func (c *Config) TMDbCacheTTL() time.Duration {
+	return timeoutOr(c.Meta.TMDb.CacheTTL, 6*time.Hour)
+}
+
+// TMDbDiskCacheTTL returns how long TMDb responses persist in the
+// database-backed cache shared across scans and restarts, defaulting to
+// 72 hours.
+func (c *Config) TMDbDiskCacheTTL() time.Duration {
+	return timeoutOr(c.Meta.TMDb.DiskCacheTTL, 72*time.Hour)
+}
+
+// AccessTokenLifetime returns how long an access token stays valid,
+// defaulting to 15 minutes. Access tokens are short-lived; clients use
+// their refresh token to obtain a new one.
+func (c *Config) AccessTokenLifetime() time.Duration {
+	return timeoutOr(c.Auth.TokenLifetime, 15*time.Minute)
+}
+
+// RefreshTokenLifetime returns how long a refresh token stays valid,
+// defaulting to 30 days.
+func (c *Config) RefreshTokenLifetime() time.Duration {
+	return timeoutOr(c.Auth.RefreshLifetime, 30*24*time.Hour)
+}
+
+// ScannerMatchConfidence returns the minimum match score before a TMDb
+// search result is applied automatically, defaulting to 0.6. Matches below
+// the threshold are left for manual identification.
+func (c *Config) ScannerMatchConfidence() float64 {
+	v := c.Jobs.Scanner.MatchConfidence
+	if v > 0 && v <= 1 {
+		return v
+	}
+	return 0.6
+}
+
+// MailEnabled reports whether outgoing mail is configured.
+func (c *Config) MailEnabled() bool {
+	return c.Mail.SMTP.Host != "" && c.Mail.SMTP.From != ""
+}
+
+// SMTPPort returns the configured SMTP port, defaulting to 587.
+func (c *Config) SMTPPort() int {
+	if c.Mail.SMTP.Port > 0 {
+		return c.Mail.SMTP.Port
+	}
+	return 587
+}
+
+// PhotoThumbnailDir returns the directory photo thumbnails are written to,
+// defaulting to "thumbnails".
+func (c *Config) PhotoThumbnailDir() string {
+	if c.Jobs.Photos.ThumbnailDir != "" {
+		return c.Jobs.Photos.ThumbnailDir
+	}
+	return "thumbnails"
+}
+
+// PhotoThumbnailWidth returns the maximum width of generated photo
+// thumbnails, defaulting to 320 pixels.
+func (c *Config) PhotoThumbnailWidth() int {
+	if c.Jobs.Photos.ThumbnailWidth > 0 {
+		return c.Jobs.Photos.ThumbnailWidth
+	}
+	return 320
+}
+
+// ArtworkCacheDir returns the directory cached artwork is written to,
+// defaulting to "artwork".
+func (c *Config) ArtworkCacheDir() string {
+	if c.Jobs.Artwork.CacheDir != "" {
+		return c.Jobs.Artwork.CacheDir
+	}
+	return "artwork"
+}
+
+// ArtworkPregenSizes returns the TMDb size buckets the pregeneration task
+// warms, defaulting to the poster and backdrop sizes the web client uses.
+func (c *Config) ArtworkPregenSizes() []string {
+	if len(c.Jobs.Artwork.PregenSizes) > 0 {
+		return c.Jobs.Artwork.PregenSizes
+	}
+	return []string{"w342", "w780"}
+}
+
+// MaintenanceWindowConfigured reports whether a valid maintenance window is
+// set. Heavy scheduled tasks are confined to the window when one exists.
+func (c *Config) MaintenanceWindowConfigured() bool {
+	start, startErr := parseClock(c.Maintenance.WindowStart)
+	end, endErr := parseClock(c.Maintenance.WindowEnd)
+	return startErr == nil && endErr == nil && start != end
+}
+
+// InMaintenanceWindow reports whether t falls inside the configured
+// maintenance window. Windows that cross midnight (e.g. 23:00-03:00) are
+// handled; without a valid window this always returns false.
+func (c *Config) InMaintenanceWindow(t time.Time) bool {
+	start, startErr := parseClock(c.Maintenance.WindowStart)
+	end, endErr := parseClock(c.Maintenance.WindowEnd)
+	if startErr != nil || endErr != nil || start == end {
+		return false
+	}
+
+	minute := t.Hour()*60 + t.Minute()
+	if start < end {
+		return minute >= start && minute < end
+	}
+	// Window crosses midnight
+	return minute >= start || minute < end
+}
+
+// parseClock parses an HH:MM wall-clock value into minutes since midnight.
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// timeoutOr returns the configured duration if it parses and is positive,
+// otherwise the fallback.
+func timeoutOr(configured string, fallback time.Duration) time.Duration {
+	if configured == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(configured)
+	if err != nil || d <= 0 {
+		log.Printf("Invalid timeout %q, using default %v", configured, fallback)
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+	"github.com/samcharles93/cinea/internal/handler"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/service/backup"
+	"github.com/samcharles93/cinea/internal/service/scheduler"
+	"github.com/samcharles93/cinea/web"
+)
+
+// Handlers bundles the HTTP layer, constructed from the already-built
+// service layer.
+type Handlers struct {
+	Auth        handler.AuthHandler
+	APIKey      handler.APIKeyHandler
+	Movie       handler.MovieHandler
+	Series      handler.SeriesHandler
+	Search      handler.SearchHandler
+	Person      handler.PersonHandler
+	Taxonomy    handler.TaxonomyHandler
+	Collection  handler.CollectionHandler
+	Identify    handler.IdentifyHandler
+	DeepLink    handler.DeepLinkHandler
+	Image       handler.ImageHandler
+	User        handler.UserHandler
+	Library     handler.LibraryHandler
+	Music       handler.MusicHandler
+	Photo       handler.PhotoHandler
+	Audiobook   handler.AudiobookHandler
+	Playback    handler.PlaybackHandler
+	Cast        handler.CastHandler
+	Activity    handler.ActivityHandler
+	Dashboard   handler.DashboardHandler
+	Scheduler   handler.SchedulerHandler
+	Admin       handler.AdminHandler
+	Webhook     handler.WebhookHandler
+	Integration handler.IntegrationHandler
+	System      handler.SystemHandler
+	Branding    handler.BrandingHandler
+	Recommend   handler.RecommendationHandler
+	Docs        handler.DocsHandler
+	WS          handler.WSHandler
+	Web         handler.WebHandler
+}
+
+// NewHandlers constructs the HTTP layer.
+func NewHandlers(
+	cfg *config.Config,
+	appLogger logger.Logger,
+	repos *Repositories,
+	services *Services,
+	schedulerService scheduler.Scheduler,
+	ffmpegService ffmpeg.Service,
+	backupService backup.Service,
+	webService web.WebService,
+) *Handlers {
+	// Initialise the JWT verifier
+	jwtVerifier := auth.NewJWTVerifier(services.TokenAuth, repos.RefreshToken, repos.APIKey, repos.User)
+
+	watchlistHandler := handler.NewWatchlistHandler(services.Auth, services.Watchlist)
+	watchHistoryHandler := handler.NewWatchHistoryHandler(services.Auth, services.WatchHistory)
+	favoriteHandler := handler.NewFavoriteHandler(services.Auth, services.Favorite)
+	ratingHandler := handler.NewRatingHandler(services.Auth, services.Rating)
+	profileHandler := handler.NewProfileHandler(services.Auth, services.Profile)
+
+	return &Handlers{
+		Auth:       handler.NewAuthHandler(services.Auth, services.OIDC, services.Account, services.QuickConnect, jwtVerifier),
+		APIKey:     handler.NewAPIKeyHandler(services.APIKey, jwtVerifier),
+		Movie:      handler.NewMovieHandler(services.Media, services.Person, services.TMDb, jwtVerifier),
+		Series:     handler.NewSeriesHandler(services.Media, services.TMDb, jwtVerifier),
+		Search:     handler.NewSearchHandler(services.Media, jwtVerifier),
+		Person:     handler.NewPersonHandler(services.Person, jwtVerifier),
+		Taxonomy:   handler.NewTaxonomyHandler(services.Taxonomy, jwtVerifier),
+		Collection: handler.NewCollectionHandler(services.Collection, jwtVerifier),
+		Identify:   handler.NewIdentifyHandler(services.Identify, jwtVerifier),
+		DeepLink:   handler.NewDeepLinkHandler(services.Media, jwtVerifier),
+		Image:      handler.NewImageHandler(services.Images, jwtVerifier),
+		User: handler.NewUserHandler(
+			services.Auth,
+			services.User,
+			jwtVerifier,
+			watchlistHandler,
+			watchHistoryHandler,
+			favoriteHandler,
+			ratingHandler,
+			profileHandler,
+		),
+		Library:     handler.NewLibraryHandler(services.Library, jwtVerifier),
+		Music:       handler.NewMusicHandler(services.Music, jwtVerifier),
+		Photo:       handler.NewPhotoHandler(services.Photo, jwtVerifier),
+		Audiobook:   handler.NewAudiobookHandler(services.Audiobook, jwtVerifier),
+		Playback:    handler.NewPlaybackHandler(services.Playback, services.Streams, jwtVerifier),
+		Cast:        handler.NewCastHandler(services.Cast, jwtVerifier),
+		Activity:    handler.NewActivityHandler(services.Activity, jwtVerifier),
+		Dashboard:   handler.NewDashboardHandler(services.Dashboard, jwtVerifier),
+		Scheduler:   handler.NewSchedulerHandler(cfg, schedulerService, jwtVerifier),
+		Admin:       handler.NewAdminHandler(ffmpegService, services.Audit, services.Notify, backupService, services.Dedupe, services.Trash, jwtVerifier),
+		Webhook:     handler.NewWebhookHandler(services.Webhook, jwtVerifier),
+		Integration: handler.NewIntegrationHandler(services.Scanner, appLogger, jwtVerifier),
+		System:      handler.NewSystemHandler(cfg, services.User, services.OIDC, services.Branding, ffmpegService),
+		Branding:    handler.NewBrandingHandler(services.Branding, jwtVerifier),
+		Recommend:   handler.NewRecommendationHandler(services.Recommend, jwtVerifier),
+		Docs:        handler.NewDocsHandler(),
+		WS:          handler.NewWSHandler(services.Realtime, jwtVerifier),
+		Web:         handler.NewWebHandler(webService),
+	}
+}
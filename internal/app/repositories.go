@@ -0,0 +1,102 @@
+// Package app holds the composition root: one provider per layer, each
+// taking its dependencies as explicit parameters. Constructing a layer
+// before its inputs exist is a compile error rather than a nil-pointer at
+// runtime, and tests can build a partial graph from the same providers.
+package app
+
+import (
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/cache"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Repositories bundles every repository, constructed together from the
+// shared database handle.
+type Repositories struct {
+	Tx             repository.TxManager
+	Library        repository.LibraryRepository
+	User           repository.UserRepository
+	Movie          repository.MovieRepository
+	Series         repository.SeriesRepository
+	Season         repository.SeasonRepository
+	Episode        repository.EpisodeRepository
+	Scheduler      repository.SchedulerRepository
+	WatchHistory   repository.WatchHistoryRepository
+	Watchlist      repository.WatchlistRepository
+	Favorite       repository.FavoriteRepository
+	Rating         repository.RatingRepository
+	Person         repository.PersonRepository
+	Credit         repository.CreditRepository
+	Taxonomy       repository.TaxonomyRepository
+	Collection     repository.CollectionRepository
+	Translation    repository.TranslationRepository
+	SearchHistory  repository.SearchHistoryRepository
+	Artist         repository.ArtistRepository
+	Album          repository.AlbumRepository
+	Track          repository.TrackRepository
+	Photo          repository.PhotoRepository
+	Audiobook      repository.AudiobookRepository
+	RefreshToken   repository.RefreshTokenRepository
+	ActionToken    repository.ActionTokenRepository
+	APIKey         repository.APIKeyRepository
+	AuditLog       repository.AuditLogRepository
+	Branding       repository.BrandingRepository
+	Recommendation repository.RecommendationRepository
+	Subtitle       repository.SubtitleRepository
+	ClientProfile  repository.ClientProfileRepository
+	MetadataCache  repository.MetadataCacheRepository
+	Profile        repository.ProfileRepository
+	Webhook        repository.WebhookRepository
+}
+
+// NewRepositories constructs the repository layer. With caching enabled,
+// the movie and series repositories are wrapped in a read-through cache
+// so dashboard and browse lookups don't hit the database every time.
+func NewRepositories(cfg *config.Config, db *gorm.DB, appLogger logger.Logger) *Repositories {
+	repos := &Repositories{
+		Tx:             repository.NewTxManager(db),
+		Library:        repository.NewLibraryRepository(db, appLogger),
+		User:           repository.NewUserRepository(db, appLogger),
+		Movie:          repository.NewMovieRepository(db, appLogger),
+		Series:         repository.NewSeriesRepository(db, appLogger),
+		Season:         repository.NewSeasonRepository(db, appLogger),
+		Episode:        repository.NewEpisodeRepository(db, appLogger),
+		Scheduler:      repository.NewSchedulerRepository(db),
+		WatchHistory:   repository.NewWatchHistoryRepository(db, appLogger),
+		Watchlist:      repository.NewWatchlistRepository(db, appLogger),
+		Favorite:       repository.NewFavoriteRepository(db, appLogger),
+		Rating:         repository.NewRatingRepository(db, appLogger),
+		Person:         repository.NewPersonRepository(db, appLogger),
+		Credit:         repository.NewCreditRepository(db, appLogger),
+		Taxonomy:       repository.NewTaxonomyRepository(db, appLogger),
+		Collection:     repository.NewCollectionRepository(db, appLogger),
+		Translation:    repository.NewTranslationRepository(db, appLogger),
+		SearchHistory:  repository.NewSearchHistoryRepository(db, appLogger),
+		Artist:         repository.NewArtistRepository(db, appLogger),
+		Album:          repository.NewAlbumRepository(db, appLogger),
+		Track:          repository.NewTrackRepository(db, appLogger),
+		Photo:          repository.NewPhotoRepository(db, appLogger),
+		Audiobook:      repository.NewAudiobookRepository(db, appLogger),
+		RefreshToken:   repository.NewRefreshTokenRepository(db, appLogger),
+		ActionToken:    repository.NewActionTokenRepository(db, appLogger),
+		APIKey:         repository.NewAPIKeyRepository(db, appLogger),
+		AuditLog:       repository.NewAuditLogRepository(db, appLogger),
+		Branding:       repository.NewBrandingRepository(db, appLogger),
+		Recommendation: repository.NewRecommendationRepository(db, appLogger),
+		Subtitle:       repository.NewSubtitleRepository(db, appLogger),
+		ClientProfile:  repository.NewClientProfileRepository(db, appLogger),
+		MetadataCache:  repository.NewMetadataCacheRepository(db, appLogger),
+		Profile:        repository.NewProfileRepository(db, appLogger),
+		Webhook:        repository.NewWebhookRepository(db, appLogger),
+	}
+
+	if cfg.Cache.Enabled {
+		store := cache.NewLRU(cfg.CacheSize(), cfg.CacheTTL())
+		repos.Movie = repository.NewCachedMovieRepository(repos.Movie, store)
+		repos.Series = repository.NewCachedSeriesRepository(repos.Series, store)
+	}
+
+	return repos
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/mailer"
+	"github.com/samcharles93/cinea/internal/notify"
+	"github.com/samcharles93/cinea/internal/service"
+	"github.com/samcharles93/cinea/internal/service/cleanup"
+	"github.com/samcharles93/cinea/internal/service/extractor"
+	"github.com/samcharles93/cinea/internal/service/images"
+	"github.com/samcharles93/cinea/internal/service/metadata"
+	"github.com/samcharles93/cinea/internal/service/scanner"
+	"github.com/samcharles93/cinea/internal/service/subtitles"
+	"github.com/samcharles93/cinea/internal/ws"
+)
+
+// Services bundles the service layer plus the shared infrastructure built
+// alongside it (JWT auth, event bus).
+type Services struct {
+	TokenAuth *jwtauth.JWTAuth
+	Bus       events.Bus
+	Realtime  *ws.Hub
+	Notify    notify.Service
+
+	Auth         service.AuthService
+	APIKey       service.APIKeyService
+	OIDC         service.OIDCService
+	Account      service.AccountService
+	QuickConnect service.QuickConnectService
+	User         service.UserService
+	Profile      service.ProfileService
+	Library      service.LibraryService
+	Music        service.MusicService
+	Photo        service.PhotoService
+	Audiobook    service.AudiobookService
+	Media        service.MediaService
+	Parental     service.ParentalService
+	Person       service.PersonService
+	Taxonomy     service.TaxonomyService
+	Collection   service.CollectionService
+	Identify     service.IdentifyService
+	Favorite     service.FavoriteService
+	Rating       service.RatingService
+	Watchlist    service.WatchlistService
+	WatchHistory service.WatchHistoryService
+	Playback     service.PlaybackService
+	Streams      service.StreamSessionService
+	Cast         service.CastService
+	Activity     service.ActivityService
+	Dashboard    service.DashboardService
+	Audit        service.AuditService
+	Dedupe       service.DedupeService
+	Trash        service.TrashService
+	Webhook      service.WebhookService
+	Branding     service.BrandingService
+	Recommend    service.RecommendationService
+	Images       images.Service
+	Scanner      scanner.Service
+	TMDb         *metadata.TMDbService
+	Cleanup      cleanup.Service
+	Extractor    extractor.Service
+	Subtitles    subtitles.Service
+}
+
+// NewServices constructs the service layer on top of the repositories.
+func NewServices(cfg *config.Config, appLogger logger.Logger, repos *Repositories, ffmpegService ffmpeg.Service) *Services {
+	tokenAuth := jwtauth.New("HS256", []byte(cfg.Auth.JWTSecret), nil)
+
+	tmdbService := metadata.NewTMDbService(cfg, appLogger, repos.MetadataCache)
+	extractorService := extractor.NewExtractor(appLogger, ffmpegService)
+
+	// The event bus decouples publishers (scanner, auth, playback) from
+	// subscribers (notifications); build it before anything that uses it
+	bus := events.NewBus(appLogger)
+	mailerService := mailer.NewService(cfg, appLogger)
+	notifyService := notify.NewService(cfg, appLogger, mailerService, repos.User, repos.Watchlist, repos.Episode, repos.Series, bus)
+	realtimeHub := ws.NewHub(appLogger, bus)
+
+	// The OIDC service issues tokens through the auth service once a user
+	// is established, so build that first
+	authService := service.NewAuthService(repos.User, repos.RefreshToken, repos.Profile, cfg, appLogger, tokenAuth, bus)
+
+	// The library service can trigger ad-hoc scans, so the scanner has to
+	// exist before it
+	scannerService := scanner.NewScannerService(
+		cfg,
+		appLogger,
+		repos.Tx,
+		repos.Library,
+		repos.Movie,
+		repos.Series,
+		repos.Season,
+		repos.Episode,
+		repos.Person,
+		repos.Credit,
+		repos.Taxonomy,
+		repos.Collection,
+		repos.Translation,
+		repos.Artist,
+		repos.Album,
+		repos.Track,
+		repos.Photo,
+		repos.Audiobook,
+		tmdbService,
+		extractorService,
+		bus,
+	)
+
+	parentalService := service.NewParentalService(
+		appLogger,
+		repos.User,
+		repos.Movie,
+		repos.Series,
+		repos.Episode,
+		repos.Taxonomy,
+	)
+
+	return &Services{
+		TokenAuth: tokenAuth,
+		Bus:       bus,
+		Realtime:  realtimeHub,
+		Notify:    notifyService,
+
+		Auth:   authService,
+		APIKey: service.NewAPIKeyService(repos.APIKey),
+		OIDC:   service.NewOIDCService(cfg, appLogger, repos.User, authService),
+		Account: service.NewAccountService(
+			cfg,
+			appLogger,
+			repos.User,
+			repos.ActionToken,
+			repos.RefreshToken,
+			mailerService,
+		),
+		QuickConnect: service.NewQuickConnectService(appLogger, authService),
+		User:         service.NewUserService(cfg, repos.User, repos.Library, bus),
+		Library:      service.NewLibraryService(appLogger, repos.Library, scannerService, bus),
+		Music:        service.NewMusicService(repos.Artist, repos.Album, repos.Track),
+		Photo:        service.NewPhotoService(repos.Photo),
+		Audiobook:    service.NewAudiobookService(repos.Audiobook),
+		Media: service.NewMediaService(
+			repos.Tx,
+			repos.Movie,
+			repos.Series,
+			repos.Season,
+			repos.Episode,
+			repos.Taxonomy,
+			repos.Translation,
+			repos.SearchHistory,
+			repos.WatchHistory,
+			parentalService,
+		),
+		Parental:   parentalService,
+		Person:     service.NewPersonService(repos.Person, repos.Credit),
+		Taxonomy:   service.NewTaxonomyService(repos.Taxonomy),
+		Collection: service.NewCollectionService(repos.Collection),
+		Identify: service.NewIdentifyService(
+			repos.Movie,
+			repos.Series,
+			repos.Taxonomy,
+			repos.Collection,
+			tmdbService,
+		),
+		Favorite:     service.NewFavoriteService(repos.Favorite),
+		Rating:       service.NewRatingService(repos.Rating, repos.Movie, repos.Series),
+		Watchlist:    service.NewWatchlistService(repos.Watchlist),
+		Profile:      service.NewProfileService(repos.Profile),
+		WatchHistory: service.NewWatchHistoryService(repos.WatchHistory, repos.Season, repos.Episode),
+		Activity: service.NewActivityService(
+			appLogger,
+			repos.User,
+			repos.WatchHistory,
+			repos.Rating,
+			repos.Watchlist,
+			repos.Movie,
+			repos.Series,
+			repos.Episode,
+		),
+		Audit:    service.NewAuditService(appLogger, repos.AuditLog, bus),
+		Dedupe:   service.NewDedupeService(appLogger, repos.Movie, repos.Episode),
+		Trash:    service.NewTrashService(appLogger, repos.Movie, repos.Series, repos.Episode),
+		Webhook:  service.NewWebhookService(appLogger, repos.Webhook, bus),
+		Branding: service.NewBrandingService(cfg, appLogger, repos.Branding, bus),
+		Recommend: service.NewRecommendationService(
+			appLogger,
+			repos.User,
+			repos.WatchHistory,
+			repos.Movie,
+			repos.Series,
+			repos.Episode,
+			repos.Credit,
+			repos.Taxonomy,
+			repos.Recommendation,
+			tmdbService,
+		),
+		Playback: service.NewPlaybackService(
+			appLogger,
+			repos.Movie,
+			repos.Episode,
+			repos.ClientProfile,
+			repos.User,
+			parentalService,
+			ffmpegService,
+			bus,
+		),
+		Streams:   service.NewStreamSessionService(cfg, appLogger),
+		Cast:      service.NewCastService(repos.Movie, repos.Series, repos.Episode),
+		Images:    images.NewService(cfg, appLogger, repos.Movie, repos.Series, ffmpegService),
+		TMDb:      tmdbService,
+		Extractor: extractorService,
+		Scanner:   scannerService,
+		Cleanup:   cleanup.NewCleanupService(cfg, appLogger, repos.Library, repos.Movie, repos.Series, repos.Episode),
+		Subtitles: subtitles.NewService(
+			cfg,
+			appLogger,
+			repos.Movie,
+			repos.Episode,
+			repos.Subtitle,
+		),
+	}
+}
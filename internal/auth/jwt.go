@@ -2,11 +2,14 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/repository"
 	"gorm.io/gorm"
 )
 
@@ -16,27 +19,108 @@ type contextKey string
 // userContextKey is the context key for storing the user
 const userContextKey contextKey = "user"
 
+// requestUserContextKey holds the mutable slot the request logger seeds
+// before authentication runs
+const requestUserContextKey contextKey = "requestUser"
+
+// impersonatorContextKey is the context key for the admin behind an
+// impersonated session
+const impersonatorContextKey contextKey = "impersonator"
+
+// activeProfileContextKey holds the household profile the session is
+// scoped to, taken from the token's "pid" claim
+const activeProfileContextKey contextKey = "activeProfile"
+
+// Impersonator identifies the admin acting as another user, taken from
+// the token's "act" claim.
+type Impersonator struct {
+	ID       uint
+	Username string
+}
+
+// RequestUser is a holder the logging middleware places in the context;
+// Verify fills it in so the access log can report the username even
+// though it wraps the auth middleware.
+type RequestUser struct {
+	Username string
+}
+
+// WithRequestUser seeds a RequestUser holder into the context and
+// returns both.
+func WithRequestUser(ctx context.Context) (context.Context, *RequestUser) {
+	holder := &RequestUser{}
+	return context.WithValue(ctx, requestUserContextKey, holder), holder
+}
+
 // JWTVerifier is a middleware to verify JWTs and add user info to the context
 type JWTVerifier struct {
-	TokenAuth *jwtauth.JWTAuth
+	TokenAuth  *jwtauth.JWTAuth
+	tokenRepo  repository.RefreshTokenRepository
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
 }
 
-func NewJWTVerifier(tokenAuth *jwtauth.JWTAuth) *JWTVerifier {
-	return &JWTVerifier{TokenAuth: tokenAuth}
+func NewJWTVerifier(
+	tokenAuth *jwtauth.JWTAuth,
+	tokenRepo repository.RefreshTokenRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+) *JWTVerifier {
+	return &JWTVerifier{
+		TokenAuth:  tokenAuth,
+		tokenRepo:  tokenRepo,
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+	}
 }
 
-// Verify is the JWT verification middleware.
+// Verify is the JWT verification middleware. Requests carrying an X-API-Key
+// header authenticate as the key's owner instead, restricted to the key's
+// scopes via RequireScope/RequireSession. The key is also accepted as an
+// `apikey` query parameter for callers that cannot set headers, such as
+// Sonarr/Radarr webhook connections.
 func (j *JWTVerifier) Verify(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Initialize context with the token and claims
-		ctx := r.Context()
-		token, claims, err := jwtauth.FromContext(ctx)
+		rawKey := r.Header.Get("X-API-Key")
+		if rawKey == "" {
+			rawKey = r.URL.Query().Get("apikey")
+		}
+		if rawKey != "" {
+			j.verifyAPIKey(w, r, rawKey, next)
+			return
+		}
 
+		// Extract and verify the bearer token here rather than relying on
+		// an outer jwtauth.Verifier middleware; none is mounted in the
+		// router. The cookie lookup covers the web UI's sessions.
+		ctx := r.Context()
+		token, err := jwtauth.VerifyRequest(j.TokenAuth, r, jwtauth.TokenFromHeader, jwtauth.TokenFromCookie)
 		if err != nil || token == nil {
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
+		// Services that read the token themselves (session checks, admin
+		// guards) expect to find it in the context the jwtauth way
+		ctx = jwtauth.NewContext(ctx, token, nil)
+
+		claims, err := token.AsMap(ctx)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		// Access tokens issued against a refresh session carry its ID in
+		// the "sid" claim; reject them once the session is revoked. Tokens
+		// without a session (the web UI's cookie tokens) only expire.
+		if sidFloat, ok := claims["sid"].(float64); ok {
+			session, err := j.tokenRepo.FindByID(ctx, uint(sidFloat))
+			if err != nil || session == nil || !session.IsValid() {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
 		// Get user data from claims
 		userData, ok := claims["user"].(map[string]interface{})
 		if !ok {
@@ -63,12 +147,93 @@ func (j *JWTVerifier) Verify(next http.Handler) http.Handler {
 			Role:     role,
 		}
 
+		// Report the username outward to the request logger
+		if holder, ok := ctx.Value(requestUserContextKey).(*RequestUser); ok {
+			holder.Username = username
+		}
+
+		// Impersonation tokens carry the acting admin in the "act" claim;
+		// expose it so handlers can flag the session
+		if actData, ok := claims["act"].(map[string]interface{}); ok {
+			if actIDFloat, ok := actData["id"].(float64); ok {
+				actUsername, _ := actData["username"].(string)
+				ctx = context.WithValue(ctx, impersonatorContextKey, &Impersonator{
+					ID:       uint(actIDFloat),
+					Username: actUsername,
+				})
+			}
+		}
+
+		// Profile-scoped tokens carry the active household profile in the
+		// "pid" claim; personal lists are filtered by it
+		if pidFloat, ok := claims["pid"].(float64); ok {
+			ctx = context.WithValue(ctx, activeProfileContextKey, uint(pidFloat))
+		}
+
 		// Add the user to the context
 		ctx = context.WithValue(ctx, userContextKey, user)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// verifyAPIKey resolves an X-API-Key header to its owning user and records
+// the key's scopes in the context for the scope middleware to enforce.
+func (j *JWTVerifier) verifyAPIKey(w http.ResponseWriter, r *http.Request, rawKey string, next http.Handler) {
+	ctx := r.Context()
+
+	key, err := j.apiKeyRepo.FindByHash(ctx, HashAPIKey(rawKey))
+	if err != nil || key == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := j.userRepo.FindByID(ctx, key.UserID)
+	if err != nil || user == nil || !user.IsActive {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	scopes, err := ParseScopes(key.ScopeList())
+	if err != nil {
+		// A stored scope no longer parsing means the known set shrank;
+		// treat the key as holding none rather than failing the request
+		scopes = nil
+	}
+
+	// Report the username outward to the request logger
+	if holder, ok := ctx.Value(requestUserContextKey).(*RequestUser); ok {
+		holder.Username = user.Username
+	}
+
+	// Best effort; losing a last-used timestamp is not worth a failed request
+	_ = j.apiKeyRepo.Touch(ctx, key.ID)
+
+	ctx = context.WithValue(ctx, userContextKey, user)
+	ctx = context.WithValue(ctx, scopesContextKey, scopes)
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// HashAPIKey returns the hex SHA-256 of a raw API key; only the hash is
+// ever stored or compared.
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetImpersonatorFromContext returns the admin behind an impersonated
+// session, or nil when the request is an ordinary one.
+func GetImpersonatorFromContext(ctx context.Context) *Impersonator {
+	imp, _ := ctx.Value(impersonatorContextKey).(*Impersonator)
+	return imp
+}
+
+// ActiveProfileID returns the household profile the session is scoped to,
+// zero when the session acts as the account owner.
+func ActiveProfileID(ctx context.Context) uint {
+	profileID, _ := ctx.Value(activeProfileContextKey).(uint)
+	return profileID
+}
+
 // GetUserFromContext retrieves the user from the request context.
 func GetUserFromContext(ctx context.Context) (*entity.User, error) {
 	user, ok := ctx.Value(userContextKey).(*entity.User)
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+// parentalPINContextKey is the context key for the parental override PIN
+const parentalPINContextKey contextKey = "parentalPIN"
+
+// ParentalPINHeader carries the user's parental override PIN; the
+// parental service compares it against the stored hash to lift content
+// restrictions for the request.
+const ParentalPINHeader = "X-Parental-PIN"
+
+// ExtractParentalPIN copies the parental override PIN header into the
+// context so services can honor it without seeing the request.
+func ExtractParentalPIN(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pin := r.Header.Get(ParentalPINHeader); pin != "" {
+			r = r.WithContext(context.WithValue(r.Context(), parentalPINContextKey, pin))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ParentalPINFromContext returns the override PIN supplied with the
+// request, or an empty string.
+func ParentalPINFromContext(ctx context.Context) string {
+	pin, _ := ctx.Value(parentalPINContextKey).(string)
+	return pin
+}
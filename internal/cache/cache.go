@@ -0,0 +1,121 @@
+// Package cache provides the read-through cache the hot repository
+// lookups sit behind. The Store interface is deliberately small so a
+// shared backend (e.g. Redis) can replace the in-memory LRU without
+// touching the repositories that use it.
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store is a key-value cache with TTL semantics. Implementations must be
+// safe for concurrent use. Get returns false for missing or expired
+// entries; DeletePrefix removes every entry whose key starts with the
+// prefix, which is how repositories invalidate on writes.
+type Store interface {
+	Get(key string) (any, bool)
+	Set(key string, value any)
+	Delete(key string)
+	DeletePrefix(prefix string)
+}
+
+// lruStore is an in-memory LRU with a fixed capacity and a single TTL.
+// Eviction is by recency once the capacity is reached; expiry is checked
+// lazily on Get.
+type lruStore struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List // front = most recently used
+	entries  map[string]*list.Element
+}
+
+type lruEntry struct {
+	key      string
+	value    any
+	storedAt time.Time
+}
+
+// NewLRU returns an in-memory Store holding at most capacity entries,
+// each valid for ttl.
+func NewLRU(capacity int, ttl time.Duration) Store {
+	return &lruStore{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (s *lruStore) Get(key string) (any, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := elem.Value.(*lruEntry)
+	if time.Since(entry.storedAt) > s.ttl {
+		s.remove(elem)
+		return nil, false
+	}
+
+	s.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+func (s *lruStore) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		entry := elem.Value.(*lruEntry)
+		entry.value = value
+		entry.storedAt = time.Now()
+		s.order.MoveToFront(elem)
+		return
+	}
+
+	for len(s.entries) >= s.capacity {
+		oldest := s.order.Back()
+		if oldest == nil {
+			break
+		}
+		s.remove(oldest)
+	}
+
+	elem := s.order.PushFront(&lruEntry{key: key, value: value, storedAt: time.Now()})
+	s.entries[key] = elem
+}
+
+func (s *lruStore) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		s.remove(elem)
+	}
+}
+
+func (s *lruStore) DeletePrefix(prefix string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, elem := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			s.remove(elem)
+		}
+	}
+}
+
+// remove drops an element from both the order list and the index; the
+// caller must hold the mutex.
+func (s *lruStore) remove(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*lruEntry).key)
+}
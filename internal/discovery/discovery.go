@@ -0,0 +1,204 @@
+// Package discovery announces the server on the local network over SSDP
+// so client apps can find it without the user typing an address. The
+// announcer multicasts periodic alive notifications and answers M-SEARCH
+// queries for the Cinea search target with the public info endpoint as
+// the location.
+package discovery
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/version"
+)
+
+const (
+	// SearchTarget is the SSDP ST/NT value clients search for.
+	SearchTarget = "urn:cinea:service:MediaServer:1"
+
+	ssdpAddress = "239.255.255.250:1900"
+
+	// announceInterval must stay well under the advertised max-age so
+	// caches never expire a live server.
+	announceInterval = 5 * time.Minute
+	maxAge           = 30 * time.Minute
+)
+
+type Service interface {
+	Start(ctx context.Context) error
+	Shutdown()
+}
+
+type service struct {
+	config    *config.Config
+	appLogger logger.Logger
+
+	usn      string
+	conn     *net.UDPConn
+	done     chan struct{}
+	finished chan struct{}
+}
+
+func NewService(cfg *config.Config, appLogger logger.Logger) Service {
+	return &service{
+		config:    cfg,
+		appLogger: appLogger,
+		usn:       "uuid:" + randomUUID(),
+		done:      make(chan struct{}),
+		finished:  make(chan struct{}),
+	}
+}
+
+// Start joins the SSDP multicast group and begins announcing. Failing to
+// join is reported to the caller so it can log and continue; discovery is
+// a convenience, never a reason not to serve.
+func (s *service) Start(ctx context.Context) error {
+	group, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return fmt.Errorf("failed to resolve SSDP multicast address: %w", err)
+	}
+
+	conn, err := net.ListenMulticastUDP("udp4", nil, group)
+	if err != nil {
+		return fmt.Errorf("failed to join SSDP multicast group: %w", err)
+	}
+	s.conn = conn
+
+	go s.respond()
+	go s.announce(group)
+
+	s.appLogger.Info().
+		Str("location", s.location()).
+		Msg("SSDP discovery announcements started")
+	return nil
+}
+
+// Shutdown sends a byebye notification and stops both loops.
+func (s *service) Shutdown() {
+	close(s.done)
+	if group, err := net.ResolveUDPAddr("udp4", ssdpAddress); err == nil {
+		s.notify(group, "ssdp:byebye")
+	}
+	s.conn.Close()
+	<-s.finished
+}
+
+// announce multicasts alive notifications on an interval until shutdown.
+func (s *service) announce(group *net.UDPAddr) {
+	s.notify(group, "ssdp:alive")
+
+	ticker := time.NewTicker(announceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.notify(group, "ssdp:alive")
+		}
+	}
+}
+
+// respond answers M-SEARCH queries addressed to our search target.
+func (s *service) respond() {
+	defer close(s.finished)
+
+	buf := make([]byte, 2048)
+	for {
+		n, sender, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-s.done:
+			default:
+				s.appLogger.Warn().Err(err).Msg("SSDP listener stopped unexpectedly")
+			}
+			return
+		}
+
+		request := string(buf[:n])
+		if !strings.HasPrefix(request, "M-SEARCH") {
+			continue
+		}
+		if !strings.Contains(request, SearchTarget) && !strings.Contains(request, "ssdp:all") {
+			continue
+		}
+
+		response := strings.Join([]string{
+			"HTTP/1.1 200 OK",
+			"CACHE-CONTROL: max-age=" + fmt.Sprintf("%d", int(maxAge.Seconds())),
+			"ST: " + SearchTarget,
+			"USN: " + s.usn + "::" + SearchTarget,
+			"LOCATION: " + s.location(),
+			"SERVER: Cinea/" + version.Version,
+			"EXT: ",
+			"", "",
+		}, "\r\n")
+
+		// Respond from an ephemeral port as the spec requires
+		out, err := net.DialUDP("udp4", nil, sender)
+		if err != nil {
+			continue
+		}
+		_, _ = out.Write([]byte(response))
+		out.Close()
+	}
+}
+
+// notify multicasts a NOTIFY of the given subtype (ssdp:alive/ssdp:byebye).
+func (s *service) notify(group *net.UDPAddr, subtype string) {
+	message := strings.Join([]string{
+		"NOTIFY * HTTP/1.1",
+		"HOST: " + ssdpAddress,
+		"CACHE-CONTROL: max-age=" + fmt.Sprintf("%d", int(maxAge.Seconds())),
+		"NT: " + SearchTarget,
+		"NTS: " + subtype,
+		"USN: " + s.usn + "::" + SearchTarget,
+		"LOCATION: " + s.location(),
+		"SERVER: Cinea/" + version.Version,
+		"", "",
+	}, "\r\n")
+
+	out, err := net.DialUDP("udp4", nil, group)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Msg("Failed to send SSDP notification")
+		return
+	}
+	defer out.Close()
+	_, _ = out.Write([]byte(message))
+}
+
+// location points clients at the unauthenticated identity endpoint.
+func (s *service) location() string {
+	return fmt.Sprintf("http://%s:%d/api/system/info/public", outboundIP(), s.config.Server.Port)
+}
+
+// outboundIP finds the LAN address the server would use to reach out; no
+// packets are sent by the probe.
+func outboundIP() string {
+	conn, err := net.Dial("udp4", "239.255.255.250:1900")
+	if err != nil {
+		return "127.0.0.1"
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP.String()
+}
+
+// randomUUID generates the announcer's instance ID; SSDP only needs it to
+// be unique, not stable across restarts.
+func randomUUID() string {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "00000000-0000-0000-0000-000000000000"
+	}
+	raw[6] = (raw[6] & 0x0f) | 0x40
+	raw[8] = (raw[8] & 0x3f) | 0x80
+	hexed := hex.EncodeToString(raw)
+	return strings.Join([]string{hexed[0:8], hexed[8:12], hexed[12:16], hexed[16:20], hexed[20:32]}, "-")
+}
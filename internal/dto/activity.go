@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// ActivityItemDTO is a single entry in the household activity feed: a
+// household member watched, rated or listed something.
+type ActivityItemDTO struct {
+	UserID   uint   `json:"user_id"`
+	Username string `json:"username"`
+
+	// Action is one of "watched", "rated" or "watchlisted"
+	Action    string  `json:"action"`
+	MediaType string  `json:"media_type"`
+	MediaID   uint    `json:"media_id"`
+	Title     string  `json:"title,omitempty"`
+	Score     float32 `json:"score,omitempty"` // Only set for ratings
+
+	Timestamp time.Time `json:"timestamp"`
+}
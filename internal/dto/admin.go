@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"sort"
+
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+)
+
+// FFmpegCapabilitiesDTO describes the installed ffmpeg build for the admin
+// UI. Available false means the server is running in degraded mode.
+type FFmpegCapabilitiesDTO struct {
+	Available bool     `json:"available"`
+	Version   string   `json:"version,omitempty"`
+	Decoders  []string `json:"decoders,omitempty"`
+	Encoders  []string `json:"encoders,omitempty"`
+	Filters   []string `json:"filters,omitempty"`
+}
+
+func FFmpegCapabilitiesToDTO(caps *ffmpeg.Capabilities) *FFmpegCapabilitiesDTO {
+	return &FFmpegCapabilitiesDTO{
+		Available: true,
+		Version:   caps.Version,
+		Decoders:  sortedNames(caps.Decoders),
+		Encoders:  sortedNames(caps.Encoders),
+		Filters:   sortedNames(caps.Filters),
+	}
+}
+
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+type APIKeyDTO struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// APIKeyCreatedDTO carries the raw key alongside the stored record; the key
+// is shown once at creation and cannot be retrieved again.
+type APIKeyCreatedDTO struct {
+	APIKeyDTO
+	Key string `json:"key"`
+}
+
+func APIKeyToDTO(key *entity.APIKey) *APIKeyDTO {
+	scopes := key.ScopeList()
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &APIKeyDTO{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     scopes,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+type AudiobookDTO struct {
+	ID       uint                  `json:"id"`
+	Title    string                `json:"title"`
+	Author   string                `json:"author,omitempty"`
+	Narrator string                `json:"narrator,omitempty"`
+	Duration int                   `json:"duration,omitempty"` // Seconds
+	Files    []AudiobookFileDTO    `json:"files,omitempty"`
+	Chapters []AudiobookChapterDTO `json:"chapters,omitempty"`
+}
+
+type AudiobookFileDTO struct {
+	ID       uint   `json:"id"`
+	Position int    `json:"position"`
+	Duration int    `json:"duration,omitempty"` // Seconds
+	Codec    string `json:"codec,omitempty"`
+}
+
+type AudiobookChapterDTO struct {
+	ID       uint    `json:"id"`
+	FileID   uint    `json:"file_id"`
+	Title    string  `json:"title"`
+	Position int     `json:"position"`
+	StartSec float64 `json:"start_sec"`
+	EndSec   float64 `json:"end_sec"`
+}
+
+type AudiobookProgressDTO struct {
+	AudiobookID uint    `json:"audiobook_id"`
+	FileID      uint    `json:"file_id"`
+	PositionSec float64 `json:"position_sec"`
+}
+
+// UpdateAudiobookProgressRequest is the body for the position sync endpoint.
+type UpdateAudiobookProgressRequest struct {
+	FileID      uint    `json:"file_id"`
+	PositionSec float64 `json:"position_sec"`
+}
+
+func AudiobookToDTO(book *entity.Audiobook) *AudiobookDTO {
+	out := &AudiobookDTO{
+		ID:       book.ID,
+		Title:    book.Title,
+		Author:   book.Author,
+		Narrator: book.Narrator,
+		Duration: book.Duration,
+	}
+	for _, file := range book.Files {
+		out.Files = append(out.Files, AudiobookFileDTO{
+			ID:       file.ID,
+			Position: file.Position,
+			Duration: file.Duration,
+			Codec:    file.Codec,
+		})
+	}
+	for _, chapter := range book.Chapters {
+		out.Chapters = append(out.Chapters, AudiobookChapterDTO{
+			ID:       chapter.ID,
+			FileID:   chapter.FileID,
+			Title:    chapter.Title,
+			Position: chapter.Position,
+			StartSec: chapter.StartSec,
+			EndSec:   chapter.EndSec,
+		})
+	}
+	return out
+}
+
+func AudiobooksToDTO(books []*entity.Audiobook) []*AudiobookDTO {
+	out := make([]*AudiobookDTO, 0, len(books))
+	for _, book := range books {
+		out = append(out, AudiobookToDTO(book))
+	}
+	return out
+}
+
+func AudiobookProgressToDTO(progress *entity.AudiobookProgress) *AudiobookProgressDTO {
+	return &AudiobookProgressDTO{
+		AudiobookID: progress.AudiobookID,
+		FileID:      progress.FileID,
+		PositionSec: progress.PositionSec,
+	}
+}
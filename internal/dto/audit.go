@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// AuditListParams filters the admin audit query; zero values leave the
+// corresponding filter off.
+type AuditListParams struct {
+	Limit  int
+	Offset int
+	Action string
+	Actor  string
+	From   time.Time
+	To     time.Time
+}
+
+type AuditLogDTO struct {
+	ID       uint      `json:"id"`
+	Action   string    `json:"action"`
+	ActorID  *uint     `json:"actor_id,omitempty"`
+	Actor    string    `json:"actor,omitempty"`
+	RemoteIP string    `json:"remote_ip,omitempty"`
+	Detail   string    `json:"detail,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+type AuditPageDTO struct {
+	Entries []AuditLogDTO `json:"entries"`
+	Total   int64         `json:"total"`
+	Limit   int           `json:"limit"`
+	Offset  int           `json:"offset"`
+}
+
+func AuditLogToDTO(entry *entity.AuditLog) AuditLogDTO {
+	return AuditLogDTO{
+		ID:       entry.ID,
+		Action:   entry.Action,
+		ActorID:  entry.ActorID,
+		Actor:    entry.Actor,
+		RemoteIP: entry.RemoteIP,
+		Detail:   entry.Detail,
+		Time:     entry.CreatedAt,
+	}
+}
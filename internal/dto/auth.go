@@ -11,9 +11,39 @@ type LoginRequest struct {
 	Password string `json:"password"`
 }
 
-type AuthResponse struct {
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type VerifyEmailRequest struct {
 	Token string `json:"token"`
-	User  struct {
+}
+
+// QuickConnectInitiateResponse starts a PIN pairing session: the code is
+// shown on the device, the secret is what the device polls with.
+type QuickConnectInitiateResponse struct {
+	Code      string `json:"code"`
+	Secret    string `json:"secret"`
+	ExpiresIn int    `json:"expires_in"` // Seconds until the code expires
+}
+
+type QuickConnectApproveRequest struct {
+	Code string `json:"code"`
+}
+
+type AuthResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	User         struct {
 		ID       uint   `json:"id"`
 		Username string `json:"username"`
 		Email    string `json:"email"`
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// BackupDTO describes one backup archive in the backup directory.
+type BackupDTO struct {
+	File      string    `json:"file"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BackupRestoreRequest names the archive to restore from.
+type BackupRestoreRequest struct {
+	File string `json:"file"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+// BrandingDTO is the server's configured look, served unauthenticated so
+// clients can style the login page.
+type BrandingDTO struct {
+	ServerName  string `json:"server_name"`
+	AccentColor string `json:"accent_color,omitempty"`
+
+	// LogoURL and LoginBackgroundURL are relative API paths, only set when
+	// the corresponding image has been uploaded
+	LogoURL            string `json:"logo_url,omitempty"`
+	LoginBackgroundURL string `json:"login_background_url,omitempty"`
+}
+
+// UpdateBrandingRequest is the body for the admin branding endpoint; nil
+// fields are left unchanged and empty strings reset to the default.
+type UpdateBrandingRequest struct {
+	ServerName  *string `json:"server_name"`
+	AccentColor *string `json:"accent_color"`
+}
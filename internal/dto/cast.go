@@ -0,0 +1,25 @@
+package dto
+
+// CastMetadataDTO is the standardized metadata cast receivers (Chromecast,
+// AirPlay) need to render a now-playing screen: display titles, the source
+// MIME type, the duration, and artwork with explicit dimensions.
+type CastMetadataDTO struct {
+	MediaType string `json:"media_type"`
+	ID        uint   `json:"id"`
+	Title     string `json:"title"`
+	// Subtitle carries the secondary line, e.g. "Series - S01E02" for an
+	// episode.
+	Subtitle    string `json:"subtitle,omitempty"`
+	ContentType string `json:"content_type"`
+	// Duration of the item in seconds; omitted when unknown.
+	Duration int             `json:"duration,omitempty"`
+	Images   []*CastImageDTO `json:"images"`
+}
+
+// CastImageDTO is one artwork variant. Receivers refuse artwork without
+// explicit dimensions, so each variant lists its size.
+type CastImageDTO struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/samcharles93/cinea/internal/entity"
+
+type CollectionDTO struct {
+	ID           uint   `json:"id"`
+	TMDbID       int    `json:"tmdb_id"`
+	Name         string `json:"name"`
+	Overview     string `json:"overview"`
+	PosterPath   string `json:"poster_path"`
+	BackdropPath string `json:"backdrop_path"`
+
+	Movies []*MovieDTO `json:"movies,omitempty"`
+}
+
+func CollectionToDTO(collection *entity.Collection) *CollectionDTO {
+	if collection == nil {
+		return nil
+	}
+	return &CollectionDTO{
+		ID:           collection.ID,
+		TMDbID:       collection.TMDbID,
+		Name:         collection.Name,
+		Overview:     collection.Overview,
+		PosterPath:   collection.PosterPath,
+		BackdropPath: collection.BackdropPath,
+	}
+}
+
+func CollectionsToDTO(collections []*entity.Collection) []*CollectionDTO {
+	collectionDTOs := make([]*CollectionDTO, len(collections))
+	for i, collection := range collections {
+		collectionDTOs[i] = CollectionToDTO(collection)
+	}
+	return collectionDTOs
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+// ContinueWatchingItemDTO is a partially watched item on the dashboard's
+// "Continue Watching" row.
+type ContinueWatchingItemDTO struct {
+	MediaType string `json:"media_type"`
+	MediaID   uint   `json:"media_id"`
+	Title     string `json:"title"`
+
+	// SeriesID/SeriesTitle are only set for episodes
+	SeriesID    uint   `json:"series_id,omitempty"`
+	SeriesTitle string `json:"series_title,omitempty"`
+
+	PosterPath string    `json:"poster_path,omitempty"`
+	Progress   float64   `json:"progress"`
+	WatchedAt  time.Time `json:"watched_at"`
+}
+
+// NextUpItemDTO is the next unwatched episode of a series the user has been
+// following, shown on the dashboard's "Next Up" row.
+type NextUpItemDTO struct {
+	SeriesID    uint   `json:"series_id"`
+	SeriesTitle string `json:"series_title"`
+
+	EpisodeID     uint   `json:"episode_id"`
+	SeasonNumber  int    `json:"season_number"`
+	EpisodeNumber int    `json:"episode_number"`
+	Title         string `json:"title"`
+	StillPath     string `json:"still_path,omitempty"`
+
+	// LastWatchedAt is when the user last watched an episode of the series,
+	// used to order the row
+	LastWatchedAt time.Time `json:"last_watched_at"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+// DuplicateFileDTO is one file inside a duplicate group, with the
+// quality columns an admin needs to decide which copy to keep.
+type DuplicateFileDTO struct {
+	ID               uint   `json:"id"`
+	MediaType        string `json:"media_type"`
+	Title            string `json:"title"`
+	LibraryID        uint   `json:"library_id"`
+	FilePath         string `json:"file_path"`
+	FileSize         int64  `json:"file_size"`
+	Codec            string `json:"codec,omitempty"`
+	ResolutionWidth  int    `json:"resolution_width,omitempty"`
+	ResolutionHeight int    `json:"resolution_height,omitempty"`
+	Keep             bool   `json:"keep"`
+}
+
+// DuplicateGroupDTO is a set of files sharing one content hash.
+type DuplicateGroupDTO struct {
+	ContentHash string             `json:"content_hash"`
+	Files       []DuplicateFileDTO `json:"files"`
+}
+
+// DedupeResolveRequest controls a resolve run. DryRun reports what would
+// be removed without touching anything.
+type DedupeResolveRequest struct {
+	DryRun bool `json:"dry_run"`
+}
+
+// DedupeResolveResultDTO summarises a resolve run: how many groups were
+// processed and which copies were (or would be) soft-deleted.
+type DedupeResolveResultDTO struct {
+	DryRun  bool               `json:"dry_run"`
+	Groups  int                `json:"groups"`
+	Removed []DuplicateFileDTO `json:"removed"`
+}
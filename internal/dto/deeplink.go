@@ -0,0 +1,27 @@
+package dto
+
+import "fmt"
+
+// DeepLinkDTO describes a library item behind a stable
+// /library/{libraryId}/item/{itemId} deep link, so the web UI and mobile
+// clients resolve shared links the same way.
+type DeepLinkDTO struct {
+	LibraryID uint   `json:"library_id"`
+	ItemID    uint   `json:"item_id"`
+	MediaType string `json:"media_type"` // movie, series or episode
+	Title     string `json:"title"`
+
+	// SeriesID is set for episodes so clients can land on the parent
+	// series detail view
+	SeriesID uint `json:"series_id,omitempty"`
+
+	// WebPath is the shareable in-server path; APIPath is the canonical
+	// API resource for the item
+	WebPath string `json:"web_path"`
+	APIPath string `json:"api_path"`
+}
+
+// DeepLinkWebPath builds the stable shareable path for an item.
+func DeepLinkWebPath(libraryID, itemID uint) string {
+	return fmt.Sprintf("/library/%d/item/%d", libraryID, itemID)
+}
@@ -0,0 +1,27 @@
+package dto
+
+import "github.com/samcharles93/cinea/internal/entity"
+
+type FavoriteDTO struct {
+	ID        uint   `json:"id"`
+	UserID    uint   `json:"user_id"`
+	MediaType string `json:"media_type"`
+	MediaID   uint   `json:"media_id"`
+}
+
+func FavoriteToDTO(favorite *entity.Favorite) *FavoriteDTO {
+	return &FavoriteDTO{
+		ID:        favorite.ID,
+		UserID:    favorite.UserID,
+		MediaType: favorite.MediaType,
+		MediaID:   favorite.MediaID,
+	}
+}
+
+func FavoritesToDTO(favorites []entity.Favorite) []*FavoriteDTO {
+	favoriteDTOs := make([]*FavoriteDTO, len(favorites))
+	for i := range favorites {
+		favoriteDTOs[i] = FavoriteToDTO(&favorites[i])
+	}
+	return favoriteDTOs
+}
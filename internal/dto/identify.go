@@ -0,0 +1,20 @@
+package dto
+
+type IdentifyCandidateDTO struct {
+	TMDbID     int    `json:"tmdb_id"`
+	Title      string `json:"title"`
+	Year       string `json:"year,omitempty"`
+	Overview   string `json:"overview,omitempty"`
+	PosterPath string `json:"poster_path,omitempty"`
+}
+
+type IdentifyRequestDTO struct {
+	TMDbID int `json:"tmdb_id"`
+}
+
+type UnmatchedItemDTO struct {
+	ID        uint   `json:"id"`
+	MediaType string `json:"media_type"`
+	Title     string `json:"title"`
+	FilePath  string `json:"file_path,omitempty"`
+}
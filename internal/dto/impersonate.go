@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// ImpersonationResponse is returned when an admin starts impersonating
+// another user. The token has no refresh token behind it and simply
+// expires; clients revert by discarding it and resuming the admin's own
+// session.
+type ImpersonationResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+}
+
+// ImpersonatorDTO identifies the admin behind an impersonated session,
+// so clients can show an impersonation banner.
+type ImpersonatorDTO struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+type LibraryDTO struct {
+	ID                    uint             `json:"id"`
+	Name                  string           `json:"name"`
+	Type                  string           `json:"type"`
+	Description           string           `json:"description,omitempty"`
+	AutoScan              bool             `json:"auto_scan"`
+	AnimeNaming           bool             `json:"anime_naming"`
+	ExtraExtensions       string           `json:"extra_extensions,omitempty"`
+	AudioLanguagePriority string           `json:"audio_language_priority,omitempty"`
+	LastScanned           *time.Time       `json:"last_scanned,omitempty"`
+	Paths                 []LibraryPathDTO `json:"paths"`
+}
+
+type LibraryPathDTO struct {
+	ID          uint   `json:"id"`
+	Path        string `json:"path"`
+	Enabled     bool   `json:"enabled"`
+	ContentType string `json:"content_type,omitempty"` // Overrides the library type for this path
+}
+
+type CreateLibraryRequest struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"` // "movie" or "tv"
+	Description string   `json:"description"`
+	Paths       []string `json:"paths"`
+}
+
+// UpdateLibraryRequest carries a partial update; only non-nil fields are
+// applied.
+type UpdateLibraryRequest struct {
+	Name                  *string `json:"name,omitempty"`
+	Type                  *string `json:"type,omitempty"`
+	Description           *string `json:"description,omitempty"`
+	AutoScan              *bool   `json:"auto_scan,omitempty"`
+	AnimeNaming           *bool   `json:"anime_naming,omitempty"`
+	ExtraExtensions       *string `json:"extra_extensions,omitempty"`
+	AudioLanguagePriority *string `json:"audio_language_priority,omitempty"`
+}
+
+type AddLibraryPathRequest struct {
+	Path        string `json:"path"`
+	ContentType string `json:"content_type,omitempty"` // Optional type override for a mixed library
+}
+
+func LibraryToDTO(lib *entity.Library) *LibraryDTO {
+	out := &LibraryDTO{
+		ID:                    lib.ID,
+		Name:                  lib.Name,
+		Type:                  string(lib.Type),
+		Description:           lib.Description,
+		AutoScan:              lib.AutoScan,
+		AnimeNaming:           lib.AnimeNaming,
+		ExtraExtensions:       lib.ExtraExtensions,
+		AudioLanguagePriority: lib.AudioLanguagePriority,
+		Paths:                 make([]LibraryPathDTO, 0, len(lib.Paths)),
+	}
+
+	if !lib.LastScanned.IsZero() {
+		scanned := lib.LastScanned
+		out.LastScanned = &scanned
+	}
+
+	for _, path := range lib.Paths {
+		out.Paths = append(out.Paths, LibraryPathDTO{
+			ID:          path.ID,
+			Path:        path.Path,
+			Enabled:     path.Enabled,
+			ContentType: string(path.ContentType),
+		})
+	}
+
+	return out
+}
+
+func LibrariesToDTO(libs []*entity.Library) []*LibraryDTO {
+	out := make([]*LibraryDTO, 0, len(libs))
+	for _, lib := range libs {
+		out = append(out, LibraryToDTO(lib))
+	}
+	return out
+}
@@ -3,14 +3,74 @@ package dto
 import "github.com/samcharles93/cinea/internal/entity"
 
 type MovieDTO struct {
-	ID    uint   `json:"id"`
-	Title string `json:"title"`
+	ID         uint   `json:"id"`
+	Title      string `json:"title"`
+	Overview   string `json:"overview"`
+	PosterPath string `json:"poster_path"`
+
+	// Runtime in minutes, original language as an ISO 639-1 code and
+	// production countries as ISO 3166-1 codes, all as reported by TMDb
+	Runtime             int      `json:"runtime,omitempty"`
+	OriginalLanguage    string   `json:"original_language,omitempty"`
+	ProductionCountries []string `json:"production_countries,omitempty"`
+
+	// Age certification for the configured region, as reported by TMDb
+	Certification string `json:"certification,omitempty"`
+
+	// TMDb score next to the aggregate of local user ratings; the count
+	// is zero when nobody in the household has rated the item
+	VoteAverage          float64 `json:"vote_average,omitempty"`
+	CommunityRating      float64 `json:"community_rating,omitempty"`
+	CommunityRatingCount int     `json:"community_rating_count,omitempty"`
+
+	// Audio language profile, used for track auto-selection and badges
+	AudioLanguages []string `json:"audio_languages,omitempty"`
+	DefaultAudio   string   `json:"default_audio,omitempty"`
+	DualAudio      bool     `json:"dual_audio,omitempty"`
+
+	// Accessibility flags, used for badges and preference-driven track
+	// auto-selection
+	AudioDescription bool `json:"audio_description,omitempty"`
+	SDHSubtitles     bool `json:"sdh_subtitles,omitempty"`
+
+	// Watched reflects the authenticated user's watch history; always
+	// false for unauthenticated callers
+	Watched bool `json:"watched"`
+}
+
+type CreateMovieDTO struct {
+	TMDbID int    `json:"tmdb_id"`
+	Title  string `json:"title"`
+}
+
+type UpdateMovieDTO struct {
+	Title    string `json:"title"`
+	Overview string `json:"overview"`
 }
 
 func MovieToDTO(movie *entity.Movie) *MovieDTO {
 	return &MovieDTO{
-		ID:    movie.ID,
-		Title: movie.Title,
+		ID:         movie.ID,
+		Title:      movie.Title,
+		Overview:   movie.Overview,
+		PosterPath: movie.PosterPath,
+
+		Runtime:             movie.Runtime,
+		OriginalLanguage:    movie.OriginalLanguage,
+		ProductionCountries: movie.CountryList(),
+
+		Certification: movie.Certification,
+
+		VoteAverage:          movie.VoteAverage,
+		CommunityRating:      movie.CommunityRating,
+		CommunityRatingCount: movie.CommunityRatingCount,
+
+		AudioLanguages: movie.AudioLanguageList(),
+		DefaultAudio:   movie.PreferredAudioLanguage(movie.Library.AudioPriorityList()),
+		DualAudio:      movie.IsDualAudio(),
+
+		AudioDescription: movie.HasAudioDescription,
+		SDHSubtitles:     movie.HasSDHSubtitles,
 	}
 }
 
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+type ArtistDTO struct {
+	ID     uint       `json:"id"`
+	Name   string     `json:"name"`
+	Albums []AlbumDTO `json:"albums,omitempty"`
+}
+
+type AlbumDTO struct {
+	ID       uint       `json:"id"`
+	ArtistID uint       `json:"artist_id"`
+	Title    string     `json:"title"`
+	Year     int        `json:"year,omitempty"`
+	Tracks   []TrackDTO `json:"tracks,omitempty"`
+}
+
+type TrackDTO struct {
+	ID          uint   `json:"id"`
+	AlbumID     uint   `json:"album_id"`
+	ArtistID    uint   `json:"artist_id"`
+	Title       string `json:"title"`
+	TrackNumber int    `json:"track_number,omitempty"`
+	DiscNumber  int    `json:"disc_number,omitempty"`
+	Duration    int    `json:"duration,omitempty"` // Seconds
+	Container   string `json:"container,omitempty"`
+	Codec       string `json:"codec,omitempty"`
+}
+
+func ArtistToDTO(artist *entity.Artist) *ArtistDTO {
+	out := &ArtistDTO{
+		ID:   artist.ID,
+		Name: artist.Name,
+	}
+	for _, album := range artist.Albums {
+		album := album
+		out.Albums = append(out.Albums, *AlbumToDTO(&album))
+	}
+	return out
+}
+
+func ArtistsToDTO(artists []*entity.Artist) []*ArtistDTO {
+	out := make([]*ArtistDTO, 0, len(artists))
+	for _, artist := range artists {
+		out = append(out, ArtistToDTO(artist))
+	}
+	return out
+}
+
+func AlbumToDTO(album *entity.Album) *AlbumDTO {
+	out := &AlbumDTO{
+		ID:       album.ID,
+		ArtistID: album.ArtistID,
+		Title:    album.Title,
+		Year:     album.Year,
+	}
+	for _, track := range album.Tracks {
+		track := track
+		out.Tracks = append(out.Tracks, *TrackToDTO(&track))
+	}
+	return out
+}
+
+func TrackToDTO(track *entity.Track) *TrackDTO {
+	return &TrackDTO{
+		ID:          track.ID,
+		AlbumID:     track.AlbumID,
+		ArtistID:    track.ArtistID,
+		Title:       track.Title,
+		TrackNumber: track.TrackNumber,
+		DiscNumber:  track.DiscNumber,
+		Duration:    track.Duration,
+		Container:   track.Container,
+		Codec:       track.Codec,
+	}
+}
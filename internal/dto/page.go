@@ -0,0 +1,32 @@
+package dto
+
+// MediaListParams carries the pagination, sorting, and filtering options
+// accepted by the movie and series list endpoints.
+type MediaListParams struct {
+	Limit  int
+	Offset int
+	Sort   string // title, added, release, rating or runtime (movies only)
+	Order  string // asc or desc
+	Genre  string
+	Year   int
+	// Language matches the original language (ISO 639-1); Country matches
+	// one of the production countries (ISO 3166-1).
+	Language string
+	Country  string
+	// Runtime bounds in minutes; MinRuntime is inclusive, MaxRuntime
+	// exclusive. Zero means unbounded. Movies only.
+	MinRuntime int
+	MaxRuntime int
+	// Watched filters on the authenticated user's watch history; nil
+	// leaves both watched and unwatched items in.
+	Watched *bool
+}
+
+// PageDTO wraps a list response with its paging window so clients can
+// request the next slice.
+type PageDTO struct {
+	Items  interface{} `json:"items"`
+	Total  int64       `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
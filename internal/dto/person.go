@@ -0,0 +1,63 @@
+package dto
+
+import "github.com/samcharles93/cinea/internal/entity"
+
+type PersonDTO struct {
+	ID          uint   `json:"id"`
+	TMDbID      int    `json:"tmdb_id"`
+	Name        string `json:"name"`
+	ProfilePath string `json:"profile_path"`
+
+	Credits []CreditDTO `json:"credits,omitempty"`
+}
+
+type CreditDTO struct {
+	ID         uint   `json:"id"`
+	PersonID   uint   `json:"person_id"`
+	PersonName string `json:"person_name,omitempty"`
+	MediaType  string `json:"media_type"`
+	MediaID    uint   `json:"media_id"`
+	PersonType string `json:"person_type"`
+	Character  string `json:"character,omitempty"`
+	Department string `json:"department,omitempty"`
+	Job        string `json:"job,omitempty"`
+	CastOrder  int    `json:"cast_order"`
+}
+
+func PersonToDTO(person *entity.Person) *PersonDTO {
+	if person == nil {
+		return nil
+	}
+	return &PersonDTO{
+		ID:          person.ID,
+		TMDbID:      person.TMDbID,
+		Name:        person.Name,
+		ProfilePath: person.ProfilePath,
+	}
+}
+
+func CreditToDTO(credit *entity.Credit) *CreditDTO {
+	if credit == nil {
+		return nil
+	}
+	return &CreditDTO{
+		ID:         credit.ID,
+		PersonID:   credit.PersonID,
+		PersonName: credit.Person.Name,
+		MediaType:  credit.MediaType,
+		MediaID:    credit.MediaID,
+		PersonType: string(credit.PersonType),
+		Character:  credit.Character,
+		Department: credit.Department,
+		Job:        credit.Job,
+		CastOrder:  credit.CastOrder,
+	}
+}
+
+func CreditsToDTO(credits []entity.Credit) []*CreditDTO {
+	creditDTOs := make([]*CreditDTO, len(credits))
+	for i := range credits {
+		creditDTOs[i] = CreditToDTO(&credits[i])
+	}
+	return creditDTOs
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+type PhotoDTO struct {
+	ID            uint      `json:"id"`
+	Title         string    `json:"title"`
+	Album         string    `json:"album,omitempty"`
+	TakenAt       time.Time `json:"taken_at"`
+	CameraMake    string    `json:"camera_make,omitempty"`
+	CameraModel   string    `json:"camera_model,omitempty"`
+	Latitude      float64   `json:"latitude,omitempty"`
+	Longitude     float64   `json:"longitude,omitempty"`
+	HasGPS        bool      `json:"has_gps"`
+	Width         int       `json:"width,omitempty"`
+	Height        int       `json:"height,omitempty"`
+	ThumbnailPath string    `json:"thumbnail_path,omitempty"`
+}
+
+type PhotoAlbumDTO struct {
+	Name   string     `json:"name"`
+	Photos []PhotoDTO `json:"photos"`
+}
+
+func PhotoToDTO(photo *entity.Photo) *PhotoDTO {
+	return &PhotoDTO{
+		ID:            photo.ID,
+		Title:         photo.Title,
+		Album:         photo.Album,
+		TakenAt:       photo.TakenAt,
+		CameraMake:    photo.CameraMake,
+		CameraModel:   photo.CameraModel,
+		Latitude:      photo.Latitude,
+		Longitude:     photo.Longitude,
+		HasGPS:        photo.HasGPS,
+		Width:         photo.ResolutionWidth,
+		Height:        photo.ResolutionHeight,
+		ThumbnailPath: photo.ThumbnailPath,
+	}
+}
+
+func PhotosToDTO(photos []*entity.Photo) []*PhotoDTO {
+	out := make([]*PhotoDTO, 0, len(photos))
+	for _, photo := range photos {
+		out = append(out, PhotoToDTO(photo))
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// StreamSessionDTO describes one of a user's active stream sessions,
+// counted against the configured concurrency limits.
+type StreamSessionDTO struct {
+	ID        string    `json:"id"`
+	MediaType string    `json:"media_type"`
+	MediaID   uint      `json:"media_id"`
+	Client    string    `json:"client,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// ClientProfileDTO represents a named set of forced playback settings for a
+// class of device.
+type ClientProfileDTO struct {
+	ID                   uint   `json:"id"`
+	Name                 string `json:"name"`
+	MaxHeight            int    `json:"max_height"`
+	ForceAudioTranscode  bool   `json:"force_audio_transcode"`
+	DisableHDR           bool   `json:"disable_hdr"`
+	DownmixStereo        bool   `json:"downmix_stereo"`
+	LoudnessCompensation bool   `json:"loudness_compensation"`
+}
+
+// ClientProfileRequest carries the settings for creating or updating a
+// client profile.
+type ClientProfileRequest struct {
+	Name                 string `json:"name"`
+	MaxHeight            int    `json:"max_height"`
+	ForceAudioTranscode  bool   `json:"force_audio_transcode"`
+	DisableHDR           bool   `json:"disable_hdr"`
+	DownmixStereo        bool   `json:"downmix_stereo"`
+	LoudnessCompensation bool   `json:"loudness_compensation"`
+}
+
+// PlaybackOptions carries the per-session selections a client makes via
+// query parameters on the playback-info endpoint.
+type PlaybackOptions struct {
+	Profile       string
+	SubtitleTrack int  // embedded subtitle stream index; -1 selects none
+	Version       uint // alternate movie version ID; 0 plays the primary file
+	// Downmix and Loudnorm override the client profile's defaults when
+	// non-nil, letting a session opt in or out of the stereo fold-down.
+	Downmix  *bool
+	Loudnorm *bool
+}
+
+// MovieVersionDTO identifies one playable file of a movie for the version
+// selector; ID 0 is the movie's primary file.
+type MovieVersionDTO struct {
+	ID       uint   `json:"id"`
+	Label    string `json:"label"`
+	Codec    string `json:"codec,omitempty"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	FileSize int64  `json:"file_size,omitempty"`
+}
+
+// PlaybackInfoDTO describes an item's source media together with the
+// playback directives that follow from the selected client profile.
+type PlaybackInfoDTO struct {
+	MediaType string `json:"media_type"`
+	ID        uint   `json:"id"`
+
+	Container      string   `json:"container"`
+	VideoCodec     string   `json:"video_codec"`
+	Width          int      `json:"width"`
+	Height         int      `json:"height"`
+	AudioChannels  int      `json:"audio_channels"`
+	AudioLanguages []string `json:"audio_languages,omitempty"`
+
+	SubtitleTracks []*SubtitleTrackDTO `json:"subtitle_tracks,omitempty"`
+
+	// Versions lists the movie's playable files when it has alternates;
+	// a client picks one by passing its ID via ?version=
+	Versions []*MovieVersionDTO `json:"versions,omitempty"`
+
+	// Directives derived from the client profile
+	Profile        string `json:"profile,omitempty"`
+	TranscodeVideo bool   `json:"transcode_video"`
+	TranscodeAudio bool   `json:"transcode_audio"`
+	MaxHeight      int    `json:"max_height,omitempty"`
+	ToneMapHDR     bool   `json:"tone_map_hdr"`
+
+	// Selections derived from the user's stored playback preferences;
+	// PlaybackSpeed is applied client-side
+	SelectedAudioLanguage string  `json:"selected_audio_language,omitempty"`
+	SelectedSubtitleIndex *int    `json:"selected_subtitle_index,omitempty"`
+	PlaybackSpeed         float64 `json:"playback_speed,omitempty"`
+
+	// DownmixStereo folds multi-channel audio down to stereo (ffmpeg pan
+	// filter); LoudnessCompensation runs loudnorm over the result so
+	// dialogue stays audible on small speakers.
+	DownmixStereo        bool `json:"downmix_stereo"`
+	LoudnessCompensation bool `json:"loudness_compensation"`
+
+	// BurnSubtitle burns the selected image-based subtitle track into the
+	// video during transcode. The player must restart the session to
+	// switch tracks, which SubtitleRestartRequired signals.
+	BurnSubtitle            bool `json:"burn_subtitle"`
+	BurnSubtitleIndex       int  `json:"burn_subtitle_index,omitempty"`
+	SubtitleRestartRequired bool `json:"subtitle_restart_required"`
+}
+
+// SubtitleTrackDTO describes a subtitle track embedded in the source file.
+// Image-based tracks (PGS, VOBSUB) can't be converted to text and are
+// burned into the video instead when selected.
+type SubtitleTrackDTO struct {
+	Index      int    `json:"index"`
+	Codec      string `json:"codec"`
+	Language   string `json:"language,omitempty"`
+	Title      string `json:"title,omitempty"`
+	Forced     bool   `json:"forced,omitempty"`
+	ImageBased bool   `json:"image_based"`
+}
+
+func ClientProfileToDTO(profile *entity.ClientProfile) *ClientProfileDTO {
+	return &ClientProfileDTO{
+		ID:                   profile.ID,
+		Name:                 profile.Name,
+		MaxHeight:            profile.MaxHeight,
+		ForceAudioTranscode:  profile.ForceAudioTranscode,
+		DisableHDR:           profile.DisableHDR,
+		DownmixStereo:        profile.DownmixStereo,
+		LoudnessCompensation: profile.LoudnessCompensation,
+	}
+}
+
+func ClientProfilesToDTO(profiles []*entity.ClientProfile) []*ClientProfileDTO {
+	dtos := make([]*ClientProfileDTO, len(profiles))
+	for i, profile := range profiles {
+		dtos[i] = ClientProfileToDTO(profile)
+	}
+	return dtos
+}
@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// ProfileDTO represents a household sub-profile under an account.
+type ProfileDTO struct {
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	Avatar string `json:"avatar,omitempty"`
+	Kid    bool   `json:"kid,omitempty"`
+}
+
+type CreateProfileRequest struct {
+	Name   string `json:"name"`
+	Avatar string `json:"avatar,omitempty"`
+	Kid    bool   `json:"kid,omitempty"`
+}
+
+// UpdateProfileRequest carries a partial profile update; only the fields
+// that are set are applied.
+type UpdateProfileRequest struct {
+	Name   *string `json:"name,omitempty"`
+	Avatar *string `json:"avatar,omitempty"`
+	Kid    *bool   `json:"kid,omitempty"`
+}
+
+// SelectProfileResponse carries the profile-scoped token a client uses
+// after picking a profile at login.
+type SelectProfileResponse struct {
+	Token     string      `json:"token"`
+	ExpiresAt time.Time   `json:"expires_at"`
+	Profile   *ProfileDTO `json:"profile"`
+}
+
+func ProfileToDTO(profile *entity.Profile) *ProfileDTO {
+	if profile == nil {
+		return nil
+	}
+	return &ProfileDTO{
+		ID:     profile.ID,
+		Name:   profile.Name,
+		Avatar: profile.Avatar,
+		Kid:    profile.Kid,
+	}
+}
+
+func ProfilesToDTO(profiles []*entity.Profile) []*ProfileDTO {
+	dtos := make([]*ProfileDTO, len(profiles))
+	for i, profile := range profiles {
+		dtos[i] = ProfileToDTO(profile)
+	}
+	return dtos
+}
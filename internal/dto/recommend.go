@@ -0,0 +1,21 @@
+package dto
+
+// RecommendationItemDTO is a single recommended title. Items already in
+// the library carry a media ID; items sourced from TMDb only carry their
+// TMDb ID until they are added.
+type RecommendationItemDTO struct {
+	MediaType  string  `json:"media_type"`
+	MediaID    uint    `json:"media_id,omitempty"`
+	TMDbID     int     `json:"tmdb_id,omitempty"`
+	Title      string  `json:"title"`
+	PosterPath string  `json:"poster_path,omitempty"`
+	Overview   string  `json:"overview,omitempty"`
+	InLibrary  bool    `json:"in_library"`
+	Score      float64 `json:"score"`
+}
+
+// RecommendationRowDTO is one "Because you watched…" row.
+type RecommendationRowDTO struct {
+	Title string                   `json:"title"`
+	Items []*RecommendationItemDTO `json:"items"`
+}
@@ -0,0 +1,136 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+type ScheduledTaskDTO struct {
+	ID          uint       `json:"id"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Description string     `json:"description,omitempty"`
+	Enabled     bool       `json:"enabled"`
+	Interval    string     `json:"interval"`
+	Status      string     `json:"status"`
+	Config      string     `json:"config,omitempty"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+	NextRun     *time.Time `json:"next_run,omitempty"`
+	LastSummary string     `json:"last_summary,omitempty"`
+	Timeout     string     `json:"timeout,omitempty"`
+	MaxRetries  int        `json:"max_retries,omitempty"`
+	// Progress is the live progress of the current run; only present while
+	// the task is running
+	Progress *TaskProgressDTO `json:"progress,omitempty"`
+}
+
+// TaskProgressDTO is a running task's live progress. Total is zero when
+// the amount of work is not known up front.
+type TaskProgressDTO struct {
+	Current int    `json:"current"`
+	Total   int    `json:"total,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// UpdateTaskRequest carries a partial task edit; only non-nil fields are
+// applied. Config is the task type's JSON config and is validated against
+// its schema before being saved.
+type UpdateTaskRequest struct {
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Interval   *string `json:"interval,omitempty"`
+	Config     *string `json:"config,omitempty"`
+	Timeout    *string `json:"timeout,omitempty"`
+	MaxRetries *int    `json:"max_retries,omitempty"`
+}
+
+// CreateTaskRequest adds a user-defined task. Type must be a registered
+// task type and Interval a duration or cron expression.
+type CreateTaskRequest struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Interval string `json:"interval"`
+	Config   string `json:"config,omitempty"`
+}
+
+// TaskRunDTO is one entry in a task's run history.
+type TaskRunDTO struct {
+	ID         uint      `json:"id"`
+	TaskID     uint      `json:"task_id"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	Summary    string    `json:"summary,omitempty"`
+}
+
+func TaskRunToDTO(run *entity.TaskRun) *TaskRunDTO {
+	return &TaskRunDTO{
+		ID:         run.ID,
+		TaskID:     run.TaskID,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		Status:     string(run.Status),
+		Error:      run.Error,
+		Summary:    run.Summary,
+	}
+}
+
+func TaskRunsToDTO(runs []*entity.TaskRun) []*TaskRunDTO {
+	out := make([]*TaskRunDTO, 0, len(runs))
+	for _, run := range runs {
+		out = append(out, TaskRunToDTO(run))
+	}
+	return out
+}
+
+func ScheduledTaskToDTO(task *entity.ScheduledTask) *ScheduledTaskDTO {
+	out := &ScheduledTaskDTO{
+		ID:          task.ID,
+		Name:        task.Name,
+		Type:        task.Type,
+		Description: task.Description,
+		Enabled:     task.Enabled,
+		Interval:    task.Interval,
+		Status:      string(task.Status),
+		Config:      task.Config,
+		LastSummary: task.LastSummary,
+		Timeout:     task.Timeout,
+		MaxRetries:  task.MaxRetries,
+	}
+	if !task.LastRun.IsZero() {
+		lastRun := task.LastRun
+		out.LastRun = &lastRun
+	}
+	if !task.NextRun.IsZero() {
+		nextRun := task.NextRun
+		out.NextRun = &nextRun
+	}
+	if task.Progress != nil {
+		out.Progress = &TaskProgressDTO{
+			Current: task.Progress.Current,
+			Total:   task.Progress.Total,
+			Message: task.Progress.Message,
+		}
+	}
+	return out
+}
+
+func ScheduledTasksToDTO(tasks []*entity.ScheduledTask) []*ScheduledTaskDTO {
+	out := make([]*ScheduledTaskDTO, 0, len(tasks))
+	for _, task := range tasks {
+		out = append(out, ScheduledTaskToDTO(task))
+	}
+	return out
+}
+
+// MaintenanceWindowDTO describes the configured maintenance window. Heavy
+// scheduled tasks and automatic restarts are confined to the window when one
+// is configured.
+type MaintenanceWindowDTO struct {
+	Configured   bool   `json:"configured"`
+	WindowStart  string `json:"window_start,omitempty"`
+	WindowEnd    string `json:"window_end,omitempty"`
+	AllowRestart bool   `json:"allow_restart"`
+	Active       bool   `json:"active"`
+}
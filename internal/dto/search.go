@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+// RecentSearchDTO is one entry of a user's search history.
+type RecentSearchDTO struct {
+	Query      string    `json:"query"`
+	SearchedAt time.Time `json:"searched_at"`
+}
+
+// SearchParams carries an advanced search expression plus its paging
+// window. The expression grammar is documented in internal/searchquery.
+type SearchParams struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// SearchSuggestionDTO is one entry of the live-search dropdown.
+type SearchSuggestionDTO struct {
+	MediaType  string  `json:"media_type"`
+	ID         uint    `json:"id"`
+	Title      string  `json:"title"`
+	Year       int     `json:"year,omitempty"`
+	PosterPath string  `json:"poster_path,omitempty"`
+	Score      float64 `json:"score"`
+}
+
+// SearchResultsDTO groups the movie and series matches of one search,
+// each with its own total so clients can page the groups independently.
+type SearchResultsDTO struct {
+	Movies      []*MovieDTO  `json:"movies"`
+	MovieTotal  int64        `json:"movie_total"`
+	Series      []*SeriesDTO `json:"series"`
+	SeriesTotal int64        `json:"series_total"`
+	Limit       int          `json:"limit"`
+	Offset      int          `json:"offset"`
+}
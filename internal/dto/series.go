@@ -12,6 +12,20 @@ type SeriesDTO struct {
 	PosterPath   string `json:"poster_path"`
 	SeasonCount  int    `json:"season_count"`
 
+	// Original language as an ISO 639-1 code and production countries as
+	// ISO 3166-1 codes, as reported by TMDb
+	OriginalLanguage    string   `json:"original_language,omitempty"`
+	ProductionCountries []string `json:"production_countries,omitempty"`
+
+	// Content rating for the configured region, as reported by TMDb
+	Certification string `json:"certification,omitempty"`
+
+	// TMDb score next to the aggregate of local user ratings; the count
+	// is zero when nobody in the household has rated the item
+	VoteAverage          float64 `json:"vote_average,omitempty"`
+	CommunityRating      float64 `json:"community_rating,omitempty"`
+	CommunityRatingCount int     `json:"community_rating_count,omitempty"`
+
 	// Seasons will be populated when converting from entity.Series
 	Seasons []SeasonDTO `json:"seasons,omitempty"`
 }
@@ -36,6 +50,20 @@ type EpisodeDTO struct {
 	Overview      string `json:"overview"`
 	AirDate       string `json:"air_date"`
 	StillPath     string `json:"still_path,omitempty"`
+
+	// Audio language profile, used for track auto-selection and badges
+	AudioLanguages []string `json:"audio_languages,omitempty"`
+	DefaultAudio   string   `json:"default_audio,omitempty"`
+	DualAudio      bool     `json:"dual_audio,omitempty"`
+
+	// Accessibility flags, used for badges and preference-driven track
+	// auto-selection
+	AudioDescription bool `json:"audio_description,omitempty"`
+	SDHSubtitles     bool `json:"sdh_subtitles,omitempty"`
+
+	// Watched reflects the authenticated user's watch history; always
+	// false for unauthenticated callers
+	Watched bool `json:"watched"`
 }
 
 type CreateSeriesDTO struct {
@@ -43,6 +71,11 @@ type CreateSeriesDTO struct {
 	Title  string `json:"title"`
 }
 
+type UpdateSeriesDTO struct {
+	Title    string `json:"title"`
+	Overview string `json:"overview"`
+}
+
 // SeriesToDTO converts an entity.Series to a SeriesDTO with basic season information
 func SeriesToDTO(series *entity.Series) *SeriesDTO {
 	if series == nil {
@@ -57,7 +90,17 @@ func SeriesToDTO(series *entity.Series) *SeriesDTO {
 		BackdropPath: series.BackdropPath,
 		PosterPath:   series.PosterPath,
 		SeasonCount:  series.SeasonCount(),
-		Seasons:      make([]SeasonDTO, 0, len(series.Seasons)),
+
+		OriginalLanguage:    series.OriginalLanguage,
+		ProductionCountries: series.CountryList(),
+
+		Certification: series.Certification,
+
+		VoteAverage:          series.VoteAverage,
+		CommunityRating:      series.CommunityRating,
+		CommunityRatingCount: series.CommunityRatingCount,
+
+		Seasons: make([]SeasonDTO, 0, len(series.Seasons)),
 	}
 
 	// Add basic season information, without episodes
@@ -188,5 +231,12 @@ func GetEpisodeDetails(episode *entity.Episode) *EpisodeDTO {
 		Overview:      episode.Overview,
 		AirDate:       airDateStr,
 		StillPath:     episode.StillPath,
+
+		AudioLanguages: episode.AudioLanguageList(),
+		DefaultAudio:   episode.PreferredAudioLanguage(episode.Library.AudioPriorityList()),
+		DualAudio:      episode.IsDualAudio(),
+
+		AudioDescription: episode.HasAudioDescription,
+		SDHSubtitles:     episode.HasSDHSubtitles,
 	}
 }
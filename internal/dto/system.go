@@ -0,0 +1,31 @@
+package dto
+
+// PublicSystemInfoDTO is the unauthenticated identity card clients read
+// before logging in: what this server is called, which build it runs and
+// how a user can sign in.
+type PublicSystemInfoDTO struct {
+	ServerName     string   `json:"server_name"`
+	Version        string   `json:"version"`
+	SetupCompleted bool     `json:"setup_completed"`
+	AuthMethods    []string `json:"auth_methods"`
+
+	// Branding carries the admin-configured look so clients can style the
+	// login page
+	Branding *BrandingDTO `json:"branding,omitempty"`
+}
+
+// SystemHealthDTO is the liveness answer load balancers and monitors poll.
+type SystemHealthDTO struct {
+	Status     string              `json:"status"`
+	Version    string              `json:"version"`
+	Transcoder TranscoderHealthDTO `json:"transcoder"`
+}
+
+// TranscoderHealthDTO reports the FFmpeg binaries' state: whether they are
+// usable, the detected build version and where they came from ("bundled",
+// "downloaded", "system" or "local").
+type TranscoderHealthDTO struct {
+	Available bool   `json:"available"`
+	Version   string `json:"version,omitempty"`
+	Source    string `json:"source,omitempty"`
+}
@@ -0,0 +1,80 @@
+package dto
+
+import "github.com/samcharles93/cinea/internal/entity"
+
+type GenreDTO struct {
+	ID     uint   `json:"id"`
+	TMDbID int    `json:"tmdb_id"`
+	Name   string `json:"name"`
+}
+
+type StudioDTO struct {
+	ID     uint   `json:"id"`
+	TMDbID int    `json:"tmdb_id"`
+	Name   string `json:"name"`
+}
+
+type TagDTO struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type CreateTagDTO struct {
+	Name string `json:"name"`
+}
+
+func GenreToDTO(genre *entity.Genre) *GenreDTO {
+	if genre == nil {
+		return nil
+	}
+	return &GenreDTO{
+		ID:     genre.ID,
+		TMDbID: genre.TMDbID,
+		Name:   genre.Name,
+	}
+}
+
+func GenresToDTO(genres []*entity.Genre) []*GenreDTO {
+	genreDTOs := make([]*GenreDTO, len(genres))
+	for i, genre := range genres {
+		genreDTOs[i] = GenreToDTO(genre)
+	}
+	return genreDTOs
+}
+
+func StudioToDTO(studio *entity.Studio) *StudioDTO {
+	if studio == nil {
+		return nil
+	}
+	return &StudioDTO{
+		ID:     studio.ID,
+		TMDbID: studio.TMDbID,
+		Name:   studio.Name,
+	}
+}
+
+func StudiosToDTO(studios []*entity.Studio) []*StudioDTO {
+	studioDTOs := make([]*StudioDTO, len(studios))
+	for i, studio := range studios {
+		studioDTOs[i] = StudioToDTO(studio)
+	}
+	return studioDTOs
+}
+
+func TagToDTO(tag *entity.Tag) *TagDTO {
+	if tag == nil {
+		return nil
+	}
+	return &TagDTO{
+		ID:   tag.ID,
+		Name: tag.Name,
+	}
+}
+
+func TagsToDTO(tags []*entity.Tag) []*TagDTO {
+	tagDTOs := make([]*TagDTO, len(tags))
+	for i, tag := range tags {
+		tagDTOs[i] = TagToDTO(tag)
+	}
+	return tagDTOs
+}
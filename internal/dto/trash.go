@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+// TrashItemDTO is one soft-deleted item in the admin recycle bin.
+type TrashItemDTO struct {
+	ID        uint      `json:"id"`
+	MediaType string    `json:"media_type"` // "movie", "series" or "episode"
+	Title     string    `json:"title"`
+	FilePath  string    `json:"file_path,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
@@ -1,19 +1,113 @@
 package dto
 
-import "github.com/samcharles93/cinea/internal/entity"
+import (
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
 
 type UserDTO struct {
 	ID       uint   `json:"id"`
 	Username string `json:"username"`
 	Email    string `json:"email"`
 	Role     string `json:"role"`
+
+	// Relative URL of the user's avatar image, empty when none is uploaded
+	AvatarURL string `json:"avatar_url,omitempty"`
+
+	// Accessibility preferences applied by clients during track
+	// auto-selection
+	PreferAudioDescription bool `json:"prefer_audio_description"`
+	PreferSDH              bool `json:"prefer_sdh"`
+
+	// Whether the user's activity appears in the household feed
+	ShareActivity bool `json:"share_activity"`
+
+	// Email notification opt-ins
+	NotifyNewEpisodes  bool `json:"notify_new_episodes"`
+	NotifyTaskFailures bool `json:"notify_task_failures"`
+	NotifyNewLogin     bool `json:"notify_new_login"`
+
+	// Where clients land after sign-in: "dashboard" or "library:<id>"
+	DefaultLandingPage string `json:"default_landing_page,omitempty"`
+
+	// Parental controls set by administrators; the override PIN itself is
+	// never exposed
+	MaxContentRating string   `json:"max_content_rating,omitempty"`
+	BlockedTags      []string `json:"blocked_tags,omitempty"`
+	HasParentalPIN   bool     `json:"has_parental_pin,omitempty"`
+
+	// Playback preferences honored by the playback endpoints
+	PreferredAudioLanguage    string  `json:"preferred_audio_language,omitempty"`
+	PreferredSubtitleLanguage string  `json:"preferred_subtitle_language,omitempty"`
+	SubtitleMode              string  `json:"subtitle_mode,omitempty"`
+	DefaultQuality            string  `json:"default_quality,omitempty"`
+	PlaybackSpeed             float64 `json:"playback_speed,omitempty"`
+
+	// Set when the session is an admin impersonating this user, so
+	// clients can show a banner
+	ImpersonatedBy *ImpersonatorDTO `json:"impersonated_by,omitempty"`
+}
+
+// UpdatePreferencesRequest carries a partial preference update; only the
+// fields that are set are applied.
+type UpdatePreferencesRequest struct {
+	PreferAudioDescription *bool   `json:"prefer_audio_description,omitempty"`
+	PreferSDH              *bool   `json:"prefer_sdh,omitempty"`
+	ShareActivity          *bool   `json:"share_activity,omitempty"`
+	DefaultLandingPage     *string `json:"default_landing_page,omitempty"`
+
+	NotifyNewEpisodes  *bool `json:"notify_new_episodes,omitempty"`
+	NotifyTaskFailures *bool `json:"notify_task_failures,omitempty"`
+	NotifyNewLogin     *bool `json:"notify_new_login,omitempty"`
+
+	PreferredAudioLanguage    *string  `json:"preferred_audio_language,omitempty"`
+	PreferredSubtitleLanguage *string  `json:"preferred_subtitle_language,omitempty"`
+	SubtitleMode              *string  `json:"subtitle_mode,omitempty"`
+	DefaultQuality            *string  `json:"default_quality,omitempty"`
+	PlaybackSpeed             *float64 `json:"playback_speed,omitempty"`
+}
+
+// UpdateRestrictionsRequest carries a partial parental-control update,
+// applied by administrators only. Setting ParentalPIN to an empty string
+// removes the override PIN; an empty MaxContentRating lifts the rating
+// limit.
+type UpdateRestrictionsRequest struct {
+	MaxContentRating *string   `json:"max_content_rating,omitempty"`
+	BlockedTags      *[]string `json:"blocked_tags,omitempty"`
+	ParentalPIN      *string   `json:"parental_pin,omitempty"`
 }
 
 func UserToDTO(user *entity.User) *UserDTO {
-	return &UserDTO{
+	userDTO := &UserDTO{
 		ID:       user.ID,
 		Username: user.Username,
 		Email:    user.Email,
 		Role:     string(user.Role),
+
+		PreferAudioDescription: user.PreferAudioDescription,
+		PreferSDH:              user.PreferSDH,
+
+		ShareActivity: user.ShareActivity,
+
+		NotifyNewEpisodes:  user.NotifyNewEpisodes,
+		NotifyTaskFailures: user.NotifyTaskFailures,
+		NotifyNewLogin:     user.NotifyNewLogin,
+
+		DefaultLandingPage: user.DefaultLandingPage,
+
+		MaxContentRating: user.MaxContentRating,
+		BlockedTags:      user.BlockedTagList(),
+		HasParentalPIN:   user.ParentalPIN != "",
+
+		PreferredAudioLanguage:    user.PreferredAudioLanguage,
+		PreferredSubtitleLanguage: user.PreferredSubtitleLanguage,
+		SubtitleMode:              user.SubtitleMode,
+		DefaultQuality:            user.DefaultQuality,
+		PlaybackSpeed:             user.PlaybackSpeed,
+	}
+	if user.AvatarPath != "" {
+		userDTO.AvatarURL = fmt.Sprintf("/api/user/%d/avatar", user.ID)
 	}
+	return userDTO
 }
@@ -0,0 +1,9 @@
+package dto
+
+// MarkWatchedRequest is the body for the mark watched/unwatched
+// endpoints. MediaType is "movie", "episode", "season" or "series";
+// seasons and series expand to all of their episodes.
+type MarkWatchedRequest struct {
+	MediaType string `json:"media_type"`
+	MediaID   uint   `json:"media_id"`
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// WebhookDTO describes a registered webhook. The signing secret is never
+// returned; HasSecret only reports whether one is set.
+type WebhookDTO struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Enabled   bool      `json:"enabled"`
+	HasSecret bool      `json:"has_secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookRequest carries the settings for creating a webhook. On update
+// an empty secret keeps the existing one.
+type WebhookRequest struct {
+	Name    string   `json:"name"`
+	URL     string   `json:"url"`
+	Secret  string   `json:"secret"`
+	Events  []string `json:"events"`
+	Enabled *bool    `json:"enabled"`
+}
+
+// WebhookDeliveryDTO is one entry in a webhook's delivery log.
+type WebhookDeliveryDTO struct {
+	ID         uint      `json:"id"`
+	Event      string    `json:"event"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Attempts   int       `json:"attempts"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func WebhookToDTO(webhook *entity.Webhook) *WebhookDTO {
+	return &WebhookDTO{
+		ID:        webhook.ID,
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Events:    webhook.EventList(),
+		Enabled:   webhook.Enabled,
+		HasSecret: webhook.Secret != "",
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func WebhooksToDTO(webhooks []*entity.Webhook) []*WebhookDTO {
+	dtos := make([]*WebhookDTO, len(webhooks))
+	for i, webhook := range webhooks {
+		dtos[i] = WebhookToDTO(webhook)
+	}
+	return dtos
+}
+
+func WebhookDeliveryToDTO(delivery *entity.WebhookDelivery) *WebhookDeliveryDTO {
+	return &WebhookDeliveryDTO{
+		ID:         delivery.ID,
+		Event:      delivery.Event,
+		StatusCode: delivery.StatusCode,
+		Attempts:   delivery.Attempts,
+		Success:    delivery.Success,
+		Error:      delivery.Error,
+		CreatedAt:  delivery.CreatedAt,
+	}
+}
+
+func WebhookDeliveriesToDTO(deliveries []*entity.WebhookDelivery) []*WebhookDeliveryDTO {
+	dtos := make([]*WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = WebhookDeliveryToDTO(delivery)
+	}
+	return dtos
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ActionTokenPurpose identifies what a one-time token authorises.
+type ActionTokenPurpose string
+
+const (
+	ActionPasswordReset     ActionTokenPurpose = "password_reset"
+	ActionEmailVerification ActionTokenPurpose = "email_verification"
+)
+
+// ActionToken is a one-time token sent to a user by email to authorise an
+// account action such as a password reset or email verification. Only a
+// SHA-256 hash of the token is stored; the raw value appears once in the
+// email link and is never persisted.
+type ActionToken struct {
+	gorm.Model
+	UserID    uint               `gorm:"not null;index"`
+	TokenHash string             `gorm:"uniqueIndex;not null" json:"-"`
+	Purpose   ActionTokenPurpose `gorm:"type:string;not null"`
+	ExpiresAt time.Time          `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+// IsValid reports whether the token is unused and not yet expired.
+func (t *ActionToken) IsValid() bool {
+	return t.UsedAt == nil && time.Now().Before(t.ExpiresAt)
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey is a long-lived credential for automation clients. Only a SHA-256
+// hash of the key is stored; the raw value is returned to the creator once
+// and never persisted. Scopes is a comma-separated list limiting what the
+// key may do — keys never gain the full reach of an interactive session.
+type APIKey struct {
+	gorm.Model
+	UserID     uint   `gorm:"not null;index"`
+	Name       string `gorm:"not null"`
+	KeyHash    string `gorm:"uniqueIndex;not null" json:"-"`
+	Prefix     string `gorm:"not null"`
+	Scopes     string `gorm:"not null"`
+	LastUsedAt *time.Time
+}
+
+// ScopeList returns the granted scopes as a slice.
+func (k *APIKey) ScopeList() []string {
+	return splitCSV(k.Scopes)
+}
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+// Audiobook is a single book, which may span one m4b with embedded chapters
+// or a directory of sequential audio files. The book is derived from the
+// parent directory at scan time, like a music album.
+type Audiobook struct {
+	gorm.Model
+	LibraryID uint   `gorm:"index;not null"`
+	Title     string `gorm:"size:255;not null"`
+	Author    string `gorm:"size:255;index"`
+	Narrator  string `gorm:"size:255"`
+	Duration  int    // Seconds, summed across files
+
+	Files    []AudiobookFile    `gorm:"foreignKey:AudiobookID"`
+	Chapters []AudiobookChapter `gorm:"foreignKey:AudiobookID"`
+}
+
+// AudiobookFile is one audio file belonging to a book. Position orders the
+// files for multi-file books.
+type AudiobookFile struct {
+	LibraryItem
+	AudiobookID uint `gorm:"index;not null"`
+	Position    int
+	Duration    int // Seconds
+}
+
+// AudiobookChapter is a chapter marker, either embedded in the file (m4b) or
+// synthesised one-per-file for multi-file books.
+type AudiobookChapter struct {
+	gorm.Model
+	AudiobookID uint `gorm:"index;not null"`
+	FileID      uint `gorm:"not null"`
+	Title       string
+	Position    int     // Order within the book
+	StartSec    float64 // Offset within the file
+	EndSec      float64
+}
+
+// AudiobookProgress tracks each user's listening position in a book, so
+// long-form audio can resume exactly where the listener stopped.
+type AudiobookProgress struct {
+	gorm.Model
+	UserID      uint `gorm:"uniqueIndex:idx_audiobook_progress;not null"`
+	AudiobookID uint `gorm:"uniqueIndex:idx_audiobook_progress;not null"`
+	FileID      uint `gorm:"not null"`
+	PositionSec float64
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+// AuditLog records one security-relevant action: logins and failed
+// logins, account lifecycle changes, library deletions and settings
+// edits. Entries are written by the audit subscriber on the event bus
+// and are never updated afterwards.
+type AuditLog struct {
+	gorm.Model
+	Action   string `gorm:"not null;index"`
+	ActorID  *uint  `gorm:"index"` // Nil for failed logins against unknown accounts
+	Actor    string
+	RemoteIP string
+	Detail   string
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "gorm.io/gorm"
+
+// Branding holds the admin-configurable look of the server: display name,
+// accent color and uploaded artwork. A single row (created on first edit)
+// holds the whole configuration; zero-value fields fall back to the
+// defaults baked into the clients.
+type Branding struct {
+	gorm.Model
+	ServerName string
+	// AccentColor is a CSS hex color like "#1db954"
+	AccentColor string
+	// LogoPath and LoginBackgroundPath are paths on the server's disk,
+	// never exposed to clients directly
+	LogoPath            string
+	LoginBackgroundPath string
+}
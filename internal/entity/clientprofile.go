@@ -0,0 +1,18 @@
+package entity
+
+import "gorm.io/gorm"
+
+// ClientProfile is a named set of forced playback settings for a class of
+// device (e.g. "Living room TV"). Clients select a profile at login or
+// device registration and the playback-info endpoint applies its limits.
+type ClientProfile struct {
+	gorm.Model
+	Name string `gorm:"uniqueIndex;not null;size:128"`
+
+	MaxHeight           int  `gorm:"default:0"`     // Maximum video height in pixels; 0 means unlimited
+	ForceAudioTranscode bool `gorm:"default:false"` // Always transcode audio for devices with poor codec support
+	DisableHDR          bool `gorm:"default:false"` // Tone-map HDR content to SDR for displays without HDR support
+
+	DownmixStereo        bool `gorm:"default:false"` // Fold 5.1/7.1 audio down to stereo for laptop/phone speakers
+	LoudnessCompensation bool `gorm:"default:false"` // Normalize loudness during the downmix so dialogue stays audible
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+// Collection is a TMDb movie collection (franchise), e.g. "The Matrix
+// Collection". Movies reference their collection via Movie.CollectionID.
+type Collection struct {
+	gorm.Model
+	TMDbID       int    `gorm:"column:tmdb_id;uniqueIndex"`
+	Name         string `gorm:"size:256;not null"`
+	Overview     string
+	PosterPath   string
+	BackdropPath string
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -9,11 +10,13 @@ import (
 type LibraryType string
 
 const (
-	LibraryTypeMovie LibraryType = "movie"
-	LibraryTypeTV    LibraryType = "tv"
+	LibraryTypeMovie     LibraryType = "movie"
+	LibraryTypeTV        LibraryType = "tv"
+	LibraryTypeMusic     LibraryType = "music"
+	LibraryTypePhotos    LibraryType = "photos"
+	LibraryTypeAudiobook LibraryType = "audiobook"
 
 	// Can be built upon:
-	// LibraryTypeMusic LibraryType = "music"
 	// LibraryTypeBook LibraryType = "book"
 )
 
@@ -26,8 +29,24 @@ type Library struct {
 
 	Paths []LibraryPath `gorm:"foreignKey:LibraryID"`
 
-	AutoScan     bool          `gorm:"default:true"`
-	ScanInterval time.Duration `gorm:"default:12h"`
+	// ExtraExtensions is a comma-separated list of additional file
+	// extensions scanned for this library (e.g. ".m2ts,.vob")
+	ExtraExtensions string
+
+	// AudioLanguagePriority is a comma-separated list of language codes
+	// tried in order when auto-selecting an audio track (e.g. "jpn,eng")
+	AudioLanguagePriority string
+
+	// AnimeNaming enables the anime filename parser for this library:
+	// [Group] prefixes, absolute episode numbers and v2 release suffixes
+	// (e.g. "[Subs] Show - 28v2 [1080p].mkv")
+	AnimeNaming bool
+
+	AutoScan bool `gorm:"default:true"`
+	// ScanInterval is stored as nanoseconds; a duration-string default
+	// tag can't parse into the int column, so 0 means "use the global
+	// scan interval"
+	ScanInterval time.Duration
 	LastScanned  time.Time
 
 	Items []LibraryItem `gorm:"foreignKey:LibraryID"`
@@ -38,6 +57,11 @@ type LibraryPath struct {
 	LibraryID uint   `gorm:"not null"`
 	Path      string `gorm:"not null"`
 	Enabled   bool   `gorm:"default:true"`
+
+	// ContentType overrides the library's type for files under this path,
+	// letting one library mix content (e.g. a movies path and a tv path).
+	// Empty inherits the library type.
+	ContentType LibraryType `gorm:"type:string"`
 }
 
 type LibraryItem struct {
@@ -52,4 +76,75 @@ type LibraryItem struct {
 	ResolutionWidth  int
 	ResolutionHeight int
 	AudioChannels    int
+
+	// ContentHash fingerprints the file for duplicate detection: a hash
+	// over the file size and its first and last chunks, computed during
+	// scans. Empty for items scanned before hashing existed.
+	ContentHash string
+	FileSize    int64
+
+	// MissingSince records when a cleanup run first found the item's file
+	// gone; the entry is only soft-deleted once the grace period elapses,
+	// and the mark clears if the file reappears
+	MissingSince *time.Time
+
+	// AudioLanguages is a comma-separated list of the item's distinct audio
+	// track languages in stream order (e.g. "jpn,eng")
+	AudioLanguages string
+
+	// Accessibility flags detected from track dispositions and tags during
+	// extraction
+	HasAudioDescription bool `gorm:"default:false"` // An audio track narrating on-screen action for visually impaired viewers
+	HasSDHSubtitles     bool `gorm:"default:false"` // A subtitle track with sound cues for deaf and hard-of-hearing viewers
+}
+
+// AudioPriorityList returns the library's audio language priority as a
+// slice, empty when none is configured.
+func (l *Library) AudioPriorityList() []string {
+	return splitCSV(l.AudioLanguagePriority)
+}
+
+// AudioLanguageList returns the item's audio languages as a slice.
+func (i *LibraryItem) AudioLanguageList() []string {
+	return splitCSV(i.AudioLanguages)
+}
+
+// IsDualAudio reports whether the item carries audio in more than one
+// language, used for "Dual Audio" badges.
+func (i *LibraryItem) IsDualAudio() bool {
+	return len(i.AudioLanguageList()) >= 2
+}
+
+// PreferredAudioLanguage returns the first language from the priority list
+// the item actually has, falling back to the item's first audio track. Used
+// for audio track auto-selection.
+func (i *LibraryItem) PreferredAudioLanguage(priority []string) string {
+	languages := i.AudioLanguageList()
+	if len(languages) == 0 {
+		return ""
+	}
+
+	for _, wanted := range priority {
+		for _, language := range languages {
+			if strings.EqualFold(language, wanted) {
+				return language
+			}
+		}
+	}
+	return languages[0]
+}
+
+func splitCSV(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	parts := strings.Split(value, ",")
+	out := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
 }
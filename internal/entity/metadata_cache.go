@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MetadataCacheEntry stores a metadata provider response so scans, refresh
+// tasks and the discovery endpoints share lookups across process restarts.
+// Key is the normalized request (provider URL including query/ID); the
+// entry is ignored once ExpiresAt passes.
+type MetadataCacheEntry struct {
+	gorm.Model
+	Key       string    `gorm:"uniqueIndex;not null"`
+	Payload   []byte    `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
@@ -12,9 +12,32 @@ type Movie struct {
 	Overview      string
 	ReleaseDate   time.Time
 	Runtime       int
-	BackdropPath  string
-	PosterPath    string
-	VoteAverage   float64
-	VoteCount     int
-	LastScanned   time.Time
+	// OriginalLanguage is the TMDb ISO 639-1 code (e.g. "ja");
+	// ProductionCountries is a comma-separated list of ISO 3166-1 codes
+	OriginalLanguage    string `gorm:"index"`
+	ProductionCountries string
+	BackdropPath        string
+	PosterPath          string
+	VoteAverage         float64
+	VoteCount           int
+	// Community score aggregated from local user ratings, kept current by
+	// the rating service; the count is zero when nobody has rated the item
+	CommunityRating      float64 `gorm:"default:0"`
+	CommunityRatingCount int     `gorm:"default:0"`
+	// Certification is the TMDb age rating for the configured region
+	// (e.g. "PG-13"); empty when none is on record
+	Certification string `gorm:"index"`
+	CollectionID  uint   `gorm:"index"`
+	// ManualMatch marks the movie as identified by an operator so scans
+	// and refreshes don't overwrite its metadata
+	ManualMatch bool `gorm:"default:false"`
+	// MetadataPending marks the movie as imported without a TMDb match
+	// (e.g. while the provider was down) so a later refresh can fill it in
+	MetadataPending bool `gorm:"default:false;index"`
+	LastScanned     time.Time
+}
+
+// CountryList returns the movie's production countries as a slice.
+func (m *Movie) CountryList() []string {
+	return splitCSV(m.ProductionCountries)
 }
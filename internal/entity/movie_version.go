@@ -0,0 +1,14 @@
+package entity
+
+// MovieVersion is an alternate file for a movie the library already has:
+// the same film in another resolution or edition (4K, Director's Cut).
+// The movie's own LibraryItem stays the primary version; alternates hang
+// off the movie row instead of importing as duplicate movies.
+type MovieVersion struct {
+	LibraryItem
+	MovieID uint `gorm:"not null;index"`
+
+	// Label names the version for client pickers, e.g. "2160p" or
+	// "Director's Cut"; derived from the filename and resolution at import
+	Label string
+}
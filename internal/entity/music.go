@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+// Artist is a music artist, created from the artist tags of scanned tracks.
+type Artist struct {
+	gorm.Model
+	Name     string `gorm:"size:255;not null;uniqueIndex"`
+	SortName string `gorm:"size:255"`
+
+	Albums []Album `gorm:"foreignKey:ArtistID"`
+}
+
+// Album groups an artist's tracks, keyed by the album tag.
+type Album struct {
+	gorm.Model
+	ArtistID uint   `gorm:"not null;index"`
+	Artist   Artist `gorm:"foreignKey:ArtistID"`
+	Title    string `gorm:"size:255;not null"`
+	Year     int
+
+	Tracks []Track `gorm:"foreignKey:AlbumID"`
+}
+
+// Track is a single audio file with its ID3/Vorbis tag metadata.
+type Track struct {
+	LibraryItem
+	AlbumID     uint   `gorm:"not null;index"`
+	Album       Album  `gorm:"foreignKey:AlbumID"`
+	ArtistID    uint   `gorm:"not null;index"`
+	Artist      Artist `gorm:"foreignKey:ArtistID"`
+	Title       string `gorm:"not null"`
+	TrackNumber int
+	DiscNumber  int
+	Duration    int // Seconds
+}
@@ -1,10 +1,31 @@
 package entity
 
+import (
+	"gorm.io/gorm"
+)
+
+// Person represents a cast or crew member sourced from a metadata provider
 type Person struct {
-	ID         int
-	Name       string
-	PersonType PersonType
-	Sources    []string
+	gorm.Model
+	TMDbID      int    `gorm:"column:tmdb_id;uniqueIndex"`
+	Name        string `gorm:"not null"`
+	ProfilePath string
+
+	Credits []Credit `gorm:"foreignKey:PersonID"`
+}
+
+// Credit links a person to a media item in a particular role
+type Credit struct {
+	gorm.Model
+	PersonID   uint       `gorm:"not null;index"`
+	Person     Person     `gorm:"foreignKey:PersonID"`
+	MediaType  string     `gorm:"not null;index:idx_credits_media"`
+	MediaID    uint       `gorm:"not null;index:idx_credits_media"`
+	PersonType PersonType `gorm:"type:string"`
+	Character  string
+	Department string
+	Job        string
+	CastOrder  int
 }
 
 type PersonType string
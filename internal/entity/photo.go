@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+)
+
+// Photo is a single image in a photos library. Album is derived from the
+// photo's parent directory; the EXIF fields are extracted at scan time.
+type Photo struct {
+	LibraryItem
+	Title string `gorm:"not null"`
+	Album string `gorm:"index"`
+
+	// TakenAt comes from EXIF DateTimeOriginal, falling back to the file's
+	// modification time for photos without EXIF data
+	TakenAt     time.Time `gorm:"index"`
+	CameraMake  string
+	CameraModel string
+	Latitude    float64
+	Longitude   float64
+	HasGPS      bool `gorm:"default:false"`
+
+	ThumbnailPath string
+}
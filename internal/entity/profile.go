@@ -0,0 +1,17 @@
+package entity
+
+import "gorm.io/gorm"
+
+// Profile is a lightweight sub-profile under one account so household
+// members share a login but keep separate watch history, watchlists and
+// favorites. Profile 0 is the implicit account owner; rows scoped to it
+// predate profiles and belong to the account itself.
+type Profile struct {
+	gorm.Model
+	UserID uint   `gorm:"not null;index"`
+	Name   string `gorm:"size:64;not null"`
+	Avatar string `gorm:"default:''"`
+	// Kid marks the profile for a child so clients can present a
+	// simplified UI; content restrictions stay per-account
+	Kid bool `gorm:"default:false"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "gorm.io/gorm"
+
+// Recommendation is one cached entry of a user's "Because you watched…"
+// rows. Rows are regenerated wholesale by the recommendations task, so the
+// table only ever holds the latest batch per user.
+type Recommendation struct {
+	gorm.Model
+	UserID uint `gorm:"not null;index"`
+
+	// The watched item this recommendation was derived from
+	SeedMediaType string `gorm:"not null"`
+	SeedMediaID   uint   `gorm:"not null"`
+	SeedTitle     string
+
+	MediaType string `gorm:"not null"`
+	// MediaID is zero when the recommended title is not in the library
+	MediaID    uint
+	TMDbID     int
+	Title      string
+	PosterPath string
+	Overview   string
+
+	// Source is "library" (genre/people similarity) or "tmdb"
+	Source string `gorm:"size:16"`
+	Score  float64
+}
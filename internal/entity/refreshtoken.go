@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a server-side session record backing a rotating refresh
+// token. Only a SHA-256 hash of the token is stored; the raw value is
+// returned to the client once and never persisted.
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint       `gorm:"not null;index"`
+	TokenHash string     `gorm:"uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	RevokedAt *time.Time `gorm:"index"`
+}
+
+// IsValid reports whether the token can still be used to refresh or to
+// back an access token.
+func (t *RefreshToken) IsValid() bool {
+	return t.RevokedAt == nil && time.Now().Before(t.ExpiresAt)
+}
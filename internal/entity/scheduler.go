@@ -19,10 +19,54 @@ type ScheduledTask struct {
 	Name        string `gorm:"uniqueIndex;not null"`
 	Type        string `gorm:"not null"`
 	Description string
-	Enabled     bool   `gorm:"default:true"`
+	Enabled     bool `gorm:"default:true"`
+	// Interval is either a duration ("24h", runs on a fixed interval) or a
+	// quartz cron expression ("0 0 3 * * *", runs at wall-clock times)
 	Interval    string `gorm:"not null"`
 	LastRun     time.Time
 	NextRun     time.Time
 	Status      TaskStatus
 	Config      string
+	LastSummary string // Human-readable report of the last run, e.g. a scan's diff summary
+	// Timeout caps a single run attempt as a duration ("30m"); empty means
+	// the run may take as long as it needs
+	Timeout string
+	// MaxRetries is how many times a failed run is retried, with exponential
+	// backoff between attempts
+	MaxRetries int
+	// Progress is the live progress of the current run; the scheduler keeps
+	// it in memory while the task runs, it is never stored
+	Progress *TaskProgress `gorm:"-"`
+}
+
+// TaskProgress is a running task's live progress. Total is zero when the
+// amount of work is not known up front.
+type TaskProgress struct {
+	Current int
+	Total   int
+	Message string
+}
+
+// TaskLease is a short-lived, database-backed lock on a task name. When
+// multiple instances share one database, a run only proceeds on the node
+// that acquires the lease, so each task fires on exactly one node. A lease
+// left behind by a crashed node expires and can be taken over.
+type TaskLease struct {
+	gorm.Model
+	TaskName  string `gorm:"uniqueIndex;not null"`
+	Holder    string `gorm:"not null"` // instance ID of the node holding the lease
+	ExpiresAt time.Time
+}
+
+// TaskRun records a single execution of a scheduled task for the admin
+// run-history view. Rows are append-only; the task itself only keeps the
+// latest status and summary.
+type TaskRun struct {
+	gorm.Model
+	TaskID     uint `gorm:"not null;index"`
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Status     TaskStatus
+	Error      string
+	Summary    string // What the run processed, e.g. a scan's diff summary
 }
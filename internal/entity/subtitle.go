@@ -0,0 +1,13 @@
+package entity
+
+import "gorm.io/gorm"
+
+// SubtitleTrack is an external subtitle file registered against a movie or
+// episode, e.g. one picked up from the subtitle drop folder.
+type SubtitleTrack struct {
+	gorm.Model
+	MediaType string `gorm:"not null;index:idx_subtitle_media"`
+	MediaID   uint   `gorm:"not null;index:idx_subtitle_media"`
+	Language  string
+	FilePath  string `gorm:"uniqueIndex;not null"`
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"gorm.io/gorm"
+)
+
+// Genre is a metadata genre (e.g. Action) sourced from TMDb
+type Genre struct {
+	gorm.Model
+	TMDbID int    `gorm:"column:tmdb_id;index"`
+	Name   string `gorm:"size:64;uniqueIndex;not null"`
+}
+
+// Studio is a production company or broadcast network
+type Studio struct {
+	gorm.Model
+	TMDbID int    `gorm:"column:tmdb_id;index"`
+	Name   string `gorm:"size:128;uniqueIndex;not null"`
+}
+
+// Tag is a user-defined label that can be attached to any media item
+type Tag struct {
+	gorm.Model
+	Name      string `gorm:"size:64;uniqueIndex;not null"`
+	CreatedBy uint
+}
+
+// MediaGenre links a genre to a movie or series
+type MediaGenre struct {
+	gorm.Model
+	GenreID   uint   `gorm:"not null;index"`
+	Genre     Genre  `gorm:"foreignKey:GenreID"`
+	MediaType string `gorm:"size:16;not null;index:idx_media_genre"`
+	MediaID   uint   `gorm:"not null;index:idx_media_genre"`
+}
+
+// MediaStudio links a studio to a movie or series
+type MediaStudio struct {
+	gorm.Model
+	StudioID  uint   `gorm:"not null;index"`
+	Studio    Studio `gorm:"foreignKey:StudioID"`
+	MediaType string `gorm:"size:16;not null;index:idx_media_studio"`
+	MediaID   uint   `gorm:"not null;index:idx_media_studio"`
+}
+
+// MediaTag links a tag to a movie or series
+type MediaTag struct {
+	gorm.Model
+	TagID     uint   `gorm:"not null;index"`
+	Tag       Tag    `gorm:"foreignKey:TagID"`
+	MediaType string `gorm:"size:16;not null;index:idx_media_tag"`
+	MediaID   uint   `gorm:"not null;index:idx_media_tag"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "gorm.io/gorm"
+
+// MediaTranslation stores a localized title and overview for a movie or
+// series so DTOs can be served in the viewer's preferred language.
+type MediaTranslation struct {
+	gorm.Model
+	MediaType string `gorm:"not null;uniqueIndex:idx_media_translation"` // "movie" or "series"
+	MediaID   uint   `gorm:"not null;uniqueIndex:idx_media_translation"`
+	Language  string `gorm:"not null;uniqueIndex:idx_media_translation"` // BCP 47 tag, e.g. "fr-FR"
+	Title     string
+	Overview  string
+}
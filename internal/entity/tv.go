@@ -11,11 +11,28 @@ type Series struct {
 	TMDbID        uint
 	Overview      string
 	FirstAirDate  time.Time
-	BackdropPath  string
-	PosterPath    string
-	VoteAverage   float64
-	VoteCount     int
-	LastScanned   time.Time
+	// OriginalLanguage is the TMDb ISO 639-1 code (e.g. "ja");
+	// ProductionCountries is a comma-separated list of ISO 3166-1 codes
+	OriginalLanguage    string `gorm:"index"`
+	ProductionCountries string
+	BackdropPath        string
+	PosterPath          string
+	VoteAverage         float64
+	VoteCount           int
+	// Community score aggregated from local user ratings, kept current by
+	// the rating service; the count is zero when nobody has rated the item
+	CommunityRating      float64 `gorm:"default:0"`
+	CommunityRatingCount int     `gorm:"default:0"`
+	// Certification is the TMDb content rating for the configured region
+	// (e.g. "TV-MA"); empty when none is on record
+	Certification string `gorm:"index"`
+	// ManualMatch marks the series as identified by an operator so scans
+	// and refreshes don't overwrite its metadata
+	ManualMatch bool `gorm:"default:false"`
+	// MetadataPending marks the series as imported without a TMDb match
+	// (e.g. while the provider was down) so a later refresh can fill it in
+	MetadataPending bool `gorm:"default:false;index"`
+	LastScanned     time.Time
 
 	AirsDayOfWeek *time.Weekday
 	AirsTime      *time.Time
@@ -27,6 +44,11 @@ func (s Series) SeasonCount() int {
 	return len(s.Seasons)
 }
 
+// CountryList returns the series' production countries as a slice.
+func (s *Series) CountryList() []string {
+	return splitCSV(s.ProductionCountries)
+}
+
 type Season struct {
 	LibraryItem
 	SeriesID     uint   `gorm:"not null"`
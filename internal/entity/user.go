@@ -31,6 +31,44 @@ type User struct {
 	PreferredLanguage string `gorm:"default:'en-US'"`
 	Theme             string `gorm:"default:'light'"`
 
+	// AvatarPath is the on-disk path of the uploaded avatar image, empty
+	// when none has been uploaded
+	AvatarPath string `gorm:"default:''" json:"-"`
+
+	// DefaultLandingPage is where clients land after sign-in: the
+	// dashboard or a specific library ("library:<id>")
+	DefaultLandingPage string `gorm:"default:'dashboard'"`
+
+	// Accessibility preferences applied during track auto-selection
+	PreferAudioDescription bool `gorm:"default:false"` // Prefer audio description tracks when the item has one
+	PreferSDH              bool `gorm:"default:false"` // Prefer SDH/CC subtitle tracks when the item has one
+
+	// ShareActivity controls whether the user's watches, ratings and list
+	// additions appear in the household activity feed
+	ShareActivity bool `gorm:"default:true"`
+
+	// Email notification opt-ins, honored only while an SMTP server is
+	// configured. Task failure mail is sent to administrators alone
+	NotifyNewEpisodes  bool `gorm:"default:false"` // New episodes of series on the user's watchlist
+	NotifyTaskFailures bool `gorm:"default:false"` // Failed scheduled task runs
+	NotifyNewLogin     bool `gorm:"default:false"` // Sign-ins to the user's account
+
+	// Parental controls, set by administrators. An empty MaxContentRating
+	// leaves the user unrestricted; BlockedTags is a comma-separated list
+	// of genre/tag names hidden from the user; ParentalPIN holds the
+	// bcrypt hash of the override PIN, empty when none is configured
+	MaxContentRating string `gorm:"default:''"`
+	BlockedTags      string `gorm:"default:''"`
+	ParentalPIN      string `gorm:"default:''" json:"-"`
+
+	// Playback preferences honored by the playback endpoints during
+	// track auto-selection
+	PreferredAudioLanguage    string  `gorm:"default:''"`    // ISO 639 code; empty keeps the library's priority order
+	PreferredSubtitleLanguage string  `gorm:"default:''"`    // ISO 639 code used by subtitle auto-selection
+	SubtitleMode              string  `gorm:"default:'off'"` // off, forced or always
+	DefaultQuality            string  `gorm:"default:''"`    // Client profile applied when the request names none
+	PlaybackSpeed             float64 `gorm:"default:1"`     // Playback rate applied client-side
+
 	// Relationships
 	WatchHistory []WatchHistory `gorm:"foreignKey:UserID" json:"-"`
 	Watchlist    []Watchlist    `gorm:"foreignKey:UserID" json:"-"`
@@ -38,6 +76,11 @@ type User struct {
 	Ratings      []Rating       `gorm:"foreignKey:UserID" json:"-"`
 }
 
+// BlockedTagList returns the user's blocked genre/tag names as a slice.
+func (u *User) BlockedTagList() []string {
+	return splitCSV(u.BlockedTags)
+}
+
 // UserRole defines the type of user
 type UserRole string
 
@@ -47,6 +90,21 @@ const (
 	RoleGuest UserRole = "guest"
 )
 
+// Landing page values: the dashboard, or "library:<id>" built with
+// LandingPageLibraryPrefix for a specific library.
+const (
+	LandingPageDashboard     = "dashboard"
+	LandingPageLibraryPrefix = "library:"
+)
+
+// Subtitle auto-selection modes: never select a track, only forced
+// tracks, or always the preferred-language track.
+const (
+	SubtitleModeOff    = "off"
+	SubtitleModeForced = "forced"
+	SubtitleModeAlways = "always"
+)
+
 type LibraryAccess struct {
 	gorm.Model
 	UserID    uint `gorm:"not null"`
@@ -54,32 +112,46 @@ type LibraryAccess struct {
 	CanManage bool `gorm:"default:false"`
 }
 
-// WatchHistory tracks what users have watched
+// WatchHistory tracks what users have watched. ProfileID scopes the entry
+// to a household profile; zero means the account owner.
 type WatchHistory struct {
 	gorm.Model
 	UserID    uint      `gorm:"not null"`
+	ProfileID uint      `gorm:"index;default:0"`
 	MediaType string    `gorm:"not null"`
 	MediaID   uint      `gorm:"not null"`
 	Progress  float64   `gorm:"default:0"`
 	WatchedAt time.Time `gorm:"not null"`
 }
 
-// Watchlist tracks what users want to watch
+// Watchlist tracks what users want to watch, scoped per household profile
+// like WatchHistory.
 type Watchlist struct {
 	gorm.Model
 	UserID    uint   `gorm:"not null"`
+	ProfileID uint   `gorm:"index;default:0"`
 	MediaType string `gorm:"not null"`
 	MediaID   uint   `gorm:"not null"`
 }
 
-// Favorite tracks user's favorite content
+// Favorite tracks user's favorite content, scoped per household profile
+// like WatchHistory.
 type Favorite struct {
 	gorm.Model
 	UserID    uint   `gorm:"not null"`
+	ProfileID uint   `gorm:"index;default:0"`
 	MediaType string `gorm:"not null"`
 	MediaID   uint   `gorm:"not null"`
 }
 
+// SearchHistory records a user's recent search queries so the UI can
+// offer them back and ranking can learn from them
+type SearchHistory struct {
+	gorm.Model
+	UserID uint   `gorm:"not null;index"`
+	Query  string `gorm:"not null"`
+}
+
 // Rating stores user ratings for content
 type Rating struct {
 	gorm.Model
@@ -0,0 +1,45 @@
+package entity
+
+import "gorm.io/gorm"
+
+// Webhook is an admin-registered URL that receives selected bus events as
+// signed HTTP POSTs. Events is a comma-separated list of event types the
+// hook subscribes to; the secret signs each delivery and is never exposed
+// through the API after creation.
+type Webhook struct {
+	gorm.Model
+	Name    string `gorm:"not null"`
+	URL     string `gorm:"not null"`
+	Secret  string `gorm:"not null" json:"-"`
+	Events  string `gorm:"not null"`
+	Enabled bool   `gorm:"default:true"`
+}
+
+// EventList returns the subscribed event types as a slice.
+func (w *Webhook) EventList() []string {
+	return splitCSV(w.Events)
+}
+
+// SubscribesTo reports whether the hook wants the given event type.
+func (w *Webhook) SubscribesTo(eventType string) bool {
+	for _, event := range w.EventList() {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery records one delivery attempt series for auditing and
+// debugging: the final status, how many attempts it took and the error
+// that ended a failed run.
+type WebhookDelivery struct {
+	gorm.Model
+	WebhookID  uint   `gorm:"not null;index"`
+	Event      string `gorm:"not null"`
+	Payload    string
+	StatusCode int
+	Attempts   int
+	Success    bool
+	Error      string
+}
@@ -12,12 +12,80 @@ var (
 	ErrBadRequest    = errors.New("bad request")
 	ErrInternal      = errors.New("internal server error")
 	ErrAlreadyExists = errors.New("resource already exists")
+
+	// Specialised sentinels that wrap the generic ones above, so existing
+	// errors.Is checks keep working while handlers can surface a more
+	// specific machine-readable code.
+	ErrInvalidCredentials = fmt.Errorf("username or password is incorrect: %w", ErrUnauthorized)
+	ErrRestrictedContent  = fmt.Errorf("content is restricted: %w", ErrForbidden)
+	ErrQuotaExceeded      = fmt.Errorf("quota exceeded: %w", ErrForbidden)
+)
+
+// Machine-readable error codes carried in the error envelope. Clients
+// should branch on these rather than on the human-readable message,
+// which is localized.
+const (
+	CodeNotFound           = "not_found"
+	CodeUnauthorized       = "unauthorized"
+	CodeInvalidCredentials = "invalid_credentials"
+	CodeForbidden          = "forbidden"
+	CodeRestrictedContent  = "restricted_content"
+	CodeBadRequest         = "bad_request"
+	CodeAlreadyExists      = "already_exists"
+	CodeQuotaExceeded      = "quota_exceeded"
+	CodeInternal           = "internal"
 )
 
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-	Code    int    `json:"code,omitempty"`
+	Error     string `json:"error"`
+	Message   string `json:"message,omitempty"`
+	Code      int    `json:"code,omitempty"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// CodeOf maps an error chain to its machine-readable code. The more
+// specific sentinels are checked before the generic ones they wrap.
+func CodeOf(err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidCredentials):
+		return CodeInvalidCredentials
+	case errors.Is(err, ErrRestrictedContent):
+		return CodeRestrictedContent
+	case errors.Is(err, ErrQuotaExceeded):
+		return CodeQuotaExceeded
+	case errors.Is(err, ErrNotFound):
+		return CodeNotFound
+	case errors.Is(err, ErrUnauthorized):
+		return CodeUnauthorized
+	case errors.Is(err, ErrForbidden):
+		return CodeForbidden
+	case errors.Is(err, ErrBadRequest):
+		return CodeBadRequest
+	case errors.Is(err, ErrAlreadyExists):
+		return CodeAlreadyExists
+	default:
+		return CodeInternal
+	}
+}
+
+// CodeForStatus maps an HTTP status to the generic error code used when
+// the error chain itself carries no sentinel.
+func CodeForStatus(status int) string {
+	switch status {
+	case 400:
+		return CodeBadRequest
+	case 401:
+		return CodeUnauthorized
+	case 403:
+		return CodeForbidden
+	case 404:
+		return CodeNotFound
+	case 409:
+		return CodeAlreadyExists
+	default:
+		return CodeInternal
+	}
 }
 
 // NotFoundError returns a not found error with additional context
@@ -0,0 +1,191 @@
+// Package events provides the in-process pub/sub bus the scanner, cleanup
+// and notification subsystems communicate over. Publishers raise typed
+// events without knowing who consumes them, so new integrations (SSE,
+// webhooks) subscribe without touching the publishing code.
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/logger"
+)
+
+type Type string
+
+const (
+	ItemCreated      Type = "item.created"
+	ItemDeleted      Type = "item.deleted"
+	ScanStarted      Type = "scan.started"
+	ScanCompleted    Type = "scan.completed"
+	UserRegistered   Type = "user.registered"
+	UserDeleted      Type = "user.deleted"
+	UserLogin        Type = "user.login"
+	UserLoginFailed  Type = "user.login_failed"
+	UserImpersonated Type = "user.impersonated"
+	LibraryDeleted   Type = "library.deleted"
+	SettingsChanged  Type = "settings.changed"
+	PlaybackStarted  Type = "playback.started"
+	TranscoderStatus Type = "transcoder.status"
+	TaskFailed       Type = "task.failed"
+	TaskProgress     Type = "task.progress"
+)
+
+// Event is one occurrence on the bus. Payload carries one of the typed
+// payload structs below, matching the event type.
+type Event struct {
+	Type      Type
+	Timestamp time.Time
+	Payload   any
+}
+
+// ItemPayload accompanies item.created and item.deleted.
+type ItemPayload struct {
+	LibraryID uint
+	ItemID    uint
+	MediaType string // "movie" or "episode"
+	Path      string
+}
+
+// ScanStartedPayload accompanies scan.started.
+type ScanStartedPayload struct {
+	Scope string // Library name, or "all libraries"
+}
+
+// ScanPayload accompanies scan.completed.
+type ScanPayload struct {
+	Scope     string // Library name, or "all libraries"
+	Summary   string
+	Added     int
+	Updated   int
+	Moved     int
+	Skipped   int
+	Unmatched int
+	Errors    int
+}
+
+// UserPayload accompanies user.registered and user.deleted.
+type UserPayload struct {
+	UserID   uint
+	Username string
+}
+
+// LoginPayload accompanies user.login and user.login_failed. UserID is
+// zero when the attempted username matched no account.
+type LoginPayload struct {
+	UserID   uint
+	Username string
+}
+
+// ImpersonationPayload accompanies user.impersonated.
+type ImpersonationPayload struct {
+	AdminID        uint
+	AdminUsername  string
+	TargetID       uint
+	TargetUsername string
+}
+
+// LibraryPayload accompanies library.deleted.
+type LibraryPayload struct {
+	LibraryID uint
+	Name      string
+}
+
+// SettingsPayload accompanies settings.changed.
+type SettingsPayload struct {
+	Setting string // What was changed, e.g. "scheduler.task"
+	Detail  string
+}
+
+// PlaybackPayload accompanies playback.started.
+type PlaybackPayload struct {
+	UserID    uint
+	MediaType string
+	MediaID   uint
+}
+
+// TaskPayload accompanies task.failed, raised when a scheduled task's run
+// returns an error.
+type TaskPayload struct {
+	Task  string
+	Error string
+}
+
+// TaskProgressPayload accompanies task.progress, raised while a scheduled
+// task runs. Total is zero when the amount of work is not known up front.
+type TaskProgressPayload struct {
+	Task    string
+	Current int
+	Total   int
+	Message string
+}
+
+// TranscoderPayload accompanies transcoder.status, raised when ffmpeg
+// becomes unavailable (degraded mode) or is recovered.
+type TranscoderPayload struct {
+	Available bool
+	Reason    string
+}
+
+// Handler receives an event. Handlers run synchronously on the publisher's
+// goroutine and must not block; anything slow should hand off internally.
+type Handler func(ctx context.Context, event Event)
+
+type Bus interface {
+	Publish(ctx context.Context, eventType Type, payload any)
+	Subscribe(eventType Type, handler Handler)
+}
+
+type bus struct {
+	appLogger logger.Logger
+
+	mu       sync.RWMutex
+	handlers map[Type][]Handler
+}
+
+func NewBus(appLogger logger.Logger) Bus {
+	return &bus{
+		appLogger: appLogger,
+		handlers:  make(map[Type][]Handler),
+	}
+}
+
+// Subscribe registers a handler for an event type. Subscriptions are
+// expected to happen during startup; there is no unsubscribe.
+func (b *bus) Subscribe(eventType Type, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish delivers the event to all subscribers in registration order. A
+// panicking handler is recovered and logged so one bad subscriber cannot
+// take down the publisher.
+func (b *bus) Publish(ctx context.Context, eventType Type, payload any) {
+	b.mu.RLock()
+	handlers := b.handlers[eventType]
+	b.mu.RUnlock()
+
+	event := Event{
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+
+	for _, handler := range handlers {
+		b.dispatch(ctx, handler, event)
+	}
+}
+
+func (b *bus) dispatch(ctx context.Context, handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.appLogger.Error().
+				Str("event", string(event.Type)).
+				Interface("panic", r).
+				Msg("Event handler panicked")
+		}
+	}()
+	handler(ctx, event)
+}
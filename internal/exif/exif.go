@@ -0,0 +1,230 @@
+// Package exif implements a minimal EXIF reader for the tags the photo
+// scanner cares about: when a picture was taken, on what camera, and where.
+// It only walks the JPEG APP1 segment's TIFF structure; anything malformed
+// simply yields empty metadata rather than an error.
+package exif
+
+import (
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+// Metadata holds the subset of EXIF tags Cinea extracts.
+type Metadata struct {
+	TakenAt     time.Time
+	CameraMake  string
+	CameraModel string
+	Latitude    float64
+	Longitude   float64
+	HasGPS      bool
+}
+
+// Tag IDs in IFD0, the Exif sub-IFD and the GPS sub-IFD.
+const (
+	tagMake             = 0x010F
+	tagModel            = 0x0110
+	tagExifIFDPointer   = 0x8769
+	tagGPSIFDPointer    = 0x8825
+	tagDateTimeOriginal = 0x9003
+	tagGPSLatitudeRef   = 0x0001
+	tagGPSLatitude      = 0x0002
+	tagGPSLongitudeRef  = 0x0003
+	tagGPSLongitude     = 0x0004
+)
+
+// Decode reads EXIF metadata from a JPEG stream. Files without EXIF data
+// return an empty Metadata and no error; only I/O failures are reported.
+func Decode(r io.Reader) (*Metadata, error) {
+	data, err := io.ReadAll(io.LimitReader(r, 1<<20)) // EXIF lives in the first segments
+	if err != nil {
+		return nil, err
+	}
+
+	tiff, ok := findExifSegment(data)
+	if !ok {
+		return &Metadata{}, nil
+	}
+	return parseTIFF(tiff), nil
+}
+
+// findExifSegment walks the JPEG segment markers looking for APP1 with the
+// "Exif" header and returns the embedded TIFF block.
+func findExifSegment(data []byte) ([]byte, bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 { // SOI
+		return nil, false
+	}
+
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return nil, false
+		}
+		marker := data[offset+1]
+		length := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		if length < 2 || offset+2+length > len(data) {
+			return nil, false
+		}
+
+		if marker == 0xE1 { // APP1
+			segment := data[offset+4 : offset+2+length]
+			if len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+				return segment[6:], true
+			}
+		}
+
+		// Stop at the start of scan; EXIF always precedes image data
+		if marker == 0xDA {
+			break
+		}
+		offset += 2 + length
+	}
+	return nil, false
+}
+
+// tiffReader resolves offsets within the TIFF block using its byte order.
+type tiffReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func parseTIFF(data []byte) *Metadata {
+	meta := &Metadata{}
+	if len(data) < 8 {
+		return meta
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return meta
+	}
+
+	t := &tiffReader{data: data, order: order}
+	ifd0 := int(order.Uint32(data[4:8]))
+
+	var exifIFD, gpsIFD int
+	t.walkIFD(ifd0, func(tag uint16, value []byte, format uint16, count int) {
+		switch tag {
+		case tagMake:
+			meta.CameraMake = asciiValue(value)
+		case tagModel:
+			meta.CameraModel = asciiValue(value)
+		case tagExifIFDPointer:
+			exifIFD = int(order.Uint32(value))
+		case tagGPSIFDPointer:
+			gpsIFD = int(order.Uint32(value))
+		}
+	})
+
+	if exifIFD > 0 {
+		t.walkIFD(exifIFD, func(tag uint16, value []byte, format uint16, count int) {
+			if tag == tagDateTimeOriginal {
+				if taken, err := time.ParseInLocation("2006:01:02 15:04:05", asciiValue(value), time.Local); err == nil {
+					meta.TakenAt = taken
+				}
+			}
+		})
+	}
+
+	if gpsIFD > 0 {
+		var latRef, lonRef string
+		var lat, lon float64
+		var haveLat, haveLon bool
+		t.walkIFD(gpsIFD, func(tag uint16, value []byte, format uint16, count int) {
+			switch tag {
+			case tagGPSLatitudeRef:
+				latRef = asciiValue(value)
+			case tagGPSLongitudeRef:
+				lonRef = asciiValue(value)
+			case tagGPSLatitude:
+				lat, haveLat = t.degrees(value, count)
+			case tagGPSLongitude:
+				lon, haveLon = t.degrees(value, count)
+			}
+		})
+		if haveLat && haveLon {
+			if latRef == "S" {
+				lat = -lat
+			}
+			if lonRef == "W" {
+				lon = -lon
+			}
+			meta.Latitude = lat
+			meta.Longitude = lon
+			meta.HasGPS = true
+		}
+	}
+
+	return meta
+}
+
+// formatSize maps TIFF entry formats to their per-component byte size.
+var formatSize = map[uint16]int{1: 1, 2: 1, 3: 2, 4: 4, 5: 8, 7: 1, 9: 4, 10: 8}
+
+// walkIFD calls fn for each entry in the IFD at offset with the entry's
+// resolved value bytes.
+func (t *tiffReader) walkIFD(offset int, fn func(tag uint16, value []byte, format uint16, count int)) {
+	if offset < 0 || offset+2 > len(t.data) {
+		return
+	}
+	entries := int(t.order.Uint16(t.data[offset : offset+2]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(t.data) {
+			return
+		}
+		tag := t.order.Uint16(t.data[entry : entry+2])
+		format := t.order.Uint16(t.data[entry+2 : entry+4])
+		count := int(t.order.Uint32(t.data[entry+4 : entry+8]))
+
+		size, ok := formatSize[format]
+		if !ok || count < 0 || count > len(t.data) {
+			continue
+		}
+
+		total := size * count
+		value := t.data[entry+8 : entry+12]
+		if total > 4 {
+			valueOffset := int(t.order.Uint32(value))
+			if valueOffset+total > len(t.data) {
+				continue
+			}
+			value = t.data[valueOffset : valueOffset+total]
+		} else {
+			value = value[:total]
+		}
+		fn(tag, value, format, count)
+	}
+}
+
+// degrees converts the three GPS rationals (degrees, minutes, seconds) to a
+// decimal coordinate.
+func (t *tiffReader) degrees(value []byte, count int) (float64, bool) {
+	if count < 3 || len(value) < 24 {
+		return 0, false
+	}
+	parts := make([]float64, 3)
+	for i := range parts {
+		num := t.order.Uint32(value[i*8 : i*8+4])
+		den := t.order.Uint32(value[i*8+4 : i*8+8])
+		if den == 0 {
+			return 0, false
+		}
+		parts[i] = float64(num) / float64(den)
+	}
+	return parts[0] + parts[1]/60 + parts[2]/3600, true
+}
+
+// asciiValue trims the NUL terminator and whitespace from an ASCII tag.
+func asciiValue(value []byte) string {
+	end := len(value)
+	for end > 0 && (value[end-1] == 0 || value[end-1] == ' ') {
+		end--
+	}
+	return string(value[:end])
+}
@@ -0,0 +1,123 @@
+package ffmpeg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Capabilities is the matrix of what the installed ffmpeg build can do.
+// Builds differ wildly between architectures and packagings, so nothing
+// should assume an encoder exists without checking here first.
+type Capabilities struct {
+	Version  string
+	Decoders map[string]bool
+	Encoders map[string]bool
+	Filters  map[string]bool
+}
+
+// HasDecoder reports whether the build can decode the named codec.
+func (c *Capabilities) HasDecoder(name string) bool {
+	return c != nil && c.Decoders[name]
+}
+
+// HasEncoder reports whether the build can encode with the named encoder.
+func (c *Capabilities) HasEncoder(name string) bool {
+	return c != nil && c.Encoders[name]
+}
+
+// HasFilter reports whether the build provides the named filter.
+func (c *Capabilities) HasFilter(name string) bool {
+	return c != nil && c.Filters[name]
+}
+
+// Capabilities returns the probed capability matrix, probing on first use
+// and caching the result until ffmpeg becomes unavailable.
+func (s *service) Capabilities(ctx context.Context) (*Capabilities, error) {
+	s.mu.RLock()
+	cached := s.capabilities
+	s.mu.RUnlock()
+	if cached != nil {
+		return cached, nil
+	}
+
+	if !s.Available() {
+		return nil, fmt.Errorf("ffmpeg is not available; capabilities unknown")
+	}
+
+	caps, err := s.probeCapabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.capabilities = caps
+	s.mu.Unlock()
+
+	s.appLogger.Info().
+		Str("version", caps.Version).
+		Int("decoders", len(caps.Decoders)).
+		Int("encoders", len(caps.Encoders)).
+		Int("filters", len(caps.Filters)).
+		Msg("Probed ffmpeg capabilities")
+	return caps, nil
+}
+
+// probeCapabilities runs the ffmpeg listing commands and parses the matrix.
+func (s *service) probeCapabilities(ctx context.Context) (*Capabilities, error) {
+	caps := &Capabilities{}
+
+	version, err := s.RunFFmpeg(ctx, []string{"-hide_banner", "-version"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to probe ffmpeg version: %w", err)
+	}
+	if line, _, ok := bytes.Cut(version, []byte("\n")); ok || len(line) > 0 {
+		caps.Version = strings.TrimPrefix(strings.TrimSpace(string(line)), "ffmpeg version ")
+	}
+
+	listings := []struct {
+		flag string
+		into *map[string]bool
+	}{
+		{"-decoders", &caps.Decoders},
+		{"-encoders", &caps.Encoders},
+		{"-filters", &caps.Filters},
+	}
+	for _, listing := range listings {
+		output, err := s.RunFFmpeg(ctx, []string{"-hide_banner", listing.flag})
+		if err != nil {
+			return nil, fmt.Errorf("failed to probe ffmpeg %s: %w", strings.TrimPrefix(listing.flag, "-"), err)
+		}
+		*listing.into = parseListing(output)
+	}
+
+	return caps, nil
+}
+
+// parseListing extracts the name column from ffmpeg's -decoders/-encoders/
+// -filters output. Entries follow a "----" separator line as
+// " <flags> <name> <description>".
+func parseListing(output []byte) map[string]bool {
+	names := make(map[string]bool)
+	seenSeparator := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !seenSeparator {
+			if strings.Contains(line, "----") {
+				seenSeparator = true
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		names[fields[1]] = true
+	}
+	return names
+}
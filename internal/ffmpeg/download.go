@@ -0,0 +1,217 @@
+package ffmpeg
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// downloadTimeout bounds one binary download; static builds are tens of
+// megabytes, so this is generous even on slow links.
+const downloadTimeout = 10 * time.Minute
+
+// buildURLs are the download locations of one platform's static build, one
+// archive per binary.
+type buildURLs struct {
+	ffmpeg  string
+	ffprobe string
+}
+
+// defaultBuilds maps GOOS/GOARCH to the ffbinaries prebuilt static builds.
+// Each archive is a zip containing the single binary. The URLs (and the
+// expected checksums) can be overridden through the ffmpeg config section,
+// which is also the way to cover platforms missing from this table.
+var defaultBuilds = map[string]buildURLs{
+	"linux/amd64": {
+		ffmpeg:  "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffmpeg-6.1-linux-64.zip",
+		ffprobe: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffprobe-6.1-linux-64.zip",
+	},
+	"linux/arm64": {
+		ffmpeg:  "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffmpeg-6.1-linux-arm-64.zip",
+		ffprobe: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffprobe-6.1-linux-arm-64.zip",
+	},
+	"windows/amd64": {
+		ffmpeg:  "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffmpeg-6.1-win-64.zip",
+		ffprobe: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffprobe-6.1-win-64.zip",
+	},
+	"darwin/amd64": {
+		ffmpeg:  "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffmpeg-6.1-osx-64.zip",
+		ffprobe: "https://github.com/ffbinaries/ffbinaries-prebuilt/releases/download/v6.1/ffprobe-6.1-osx-64.zip",
+	},
+}
+
+// downloadBinaries fetches static ffmpeg and ffprobe builds into the target
+// directory, verifying checksums when the config provides them.
+func (s *service) downloadBinaries() error {
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+	builds := defaultBuilds[platform]
+
+	ffmpegURL := s.config.FFmpeg.FFmpegURL
+	if ffmpegURL == "" {
+		ffmpegURL = builds.ffmpeg
+	}
+	ffprobeURL := s.config.FFmpeg.FFprobeURL
+	if ffprobeURL == "" {
+		ffprobeURL = builds.ffprobe
+	}
+	if ffmpegURL == "" || ffprobeURL == "" {
+		return fmt.Errorf("no static FFmpeg build is known for %s; set ffmpeg.ffmpeg_url and ffmpeg.ffprobe_url", platform)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.ffmpegPath), 0755); err != nil {
+		return fmt.Errorf("failed to create target ffmpeg directory: %w", err)
+	}
+
+	if err := s.downloadBinary(ffmpegURL, s.config.FFmpeg.FFmpegSHA256, "ffmpeg", s.ffmpegPath); err != nil {
+		return err
+	}
+	return s.downloadBinary(ffprobeURL, s.config.FFmpeg.FFprobeSHA256, "ffprobe", s.ffprobePath)
+}
+
+// downloadBinary fetches one archive, verifies it against the expected
+// SHA-256 when one is configured, extracts the named binary and moves it
+// into place atomically.
+func (s *service) downloadBinary(url, wantSHA256, name, destPath string) error {
+	s.appLogger.Info().Str("url", url).Str("binary", name).Msg("Downloading FFmpeg build")
+
+	client := &http.Client{Timeout: downloadTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download %s: unexpected status %s", name, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", name, err)
+	}
+
+	if wantSHA256 != "" {
+		sum := sha256.Sum256(data)
+		if !strings.EqualFold(hex.EncodeToString(sum[:]), wantSHA256) {
+			return fmt.Errorf("checksum mismatch for %s download: got %x", name, sum)
+		}
+	} else {
+		s.appLogger.Warn().Str("binary", name).Msg("No checksum configured for FFmpeg download; skipping verification")
+	}
+
+	binary, err := extractBinary(data, url, name)
+	if err != nil {
+		return fmt.Errorf("failed to extract %s: %w", name, err)
+	}
+
+	// Write next to the destination and rename so a failed download never
+	// leaves a truncated binary behind
+	tmpPath := destPath + ".download"
+	if err := os.WriteFile(tmpPath, binary, 0755); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Rename(tmpPath, destPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to install %s: %w", name, err)
+	}
+	return nil
+}
+
+// extractBinary pulls the named binary out of a downloaded archive. Zip and
+// tar.gz archives are searched for the binary by name; anything else is
+// assumed to be the raw binary itself.
+func extractBinary(data []byte, url, name string) ([]byte, error) {
+	switch {
+	case strings.HasSuffix(url, ".zip"):
+		reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range reader.File {
+			if matchesBinary(file.Name, name) {
+				rc, err := file.Open()
+				if err != nil {
+					return nil, err
+				}
+				defer rc.Close()
+				return io.ReadAll(rc)
+			}
+		}
+	case strings.HasSuffix(url, ".tar.gz"), strings.HasSuffix(url, ".tgz"):
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader := tar.NewReader(gz)
+		for {
+			header, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			if header.Typeflag == tar.TypeReg && matchesBinary(header.Name, name) {
+				return io.ReadAll(reader)
+			}
+		}
+	default:
+		return data, nil
+	}
+	return nil, fmt.Errorf("archive contains no %s binary", name)
+}
+
+// matchesBinary reports whether an archive entry is the wanted binary,
+// ignoring its directory and a Windows .exe extension.
+func matchesBinary(entryName, name string) bool {
+	base := filepath.Base(filepath.ToSlash(entryName))
+	return base == name || base == name+".exe"
+}
+
+// useSystemBinaries points the service at ffmpeg and ffprobe found on PATH,
+// the last resort when neither bundled nor downloaded builds are available.
+func (s *service) useSystemBinaries() error {
+	ffmpegPath, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return fmt.Errorf("no ffmpeg on PATH: %w", err)
+	}
+	ffprobePath, err := exec.LookPath("ffprobe")
+	if err != nil {
+		return fmt.Errorf("no ffprobe on PATH: %w", err)
+	}
+
+	s.ffmpegPath = ffmpegPath
+	s.ffprobePath = ffprobePath
+	s.appLogger.Info().Str("ffmpeg", ffmpegPath).Str("ffprobe", ffprobePath).Msg("Using system FFmpeg binaries")
+	return nil
+}
+
+// detectVersion parses the build's version from `ffmpeg -version` output
+// ("ffmpeg version 6.1.1 Copyright ..."); it returns "" when the binary
+// cannot be run.
+func (s *service) detectVersion() string {
+	out, err := exec.Command(s.ffmpegPath, "-version").Output()
+	if err != nil {
+		s.appLogger.Warn().Err(err).Msg("Failed to read the FFmpeg version")
+		return ""
+	}
+
+	fields := strings.Fields(strings.SplitN(string(out), "\n", 2)[0])
+	if len(fields) >= 3 && fields[0] == "ffmpeg" && fields[1] == "version" {
+		return fields[2]
+	}
+	return ""
+}
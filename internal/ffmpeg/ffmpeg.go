@@ -4,6 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/samcharles93/cinea/internal/telemetry"
 )
 
 // RunFFmpeg executes an FFmpeg command with the provided arguments
@@ -12,10 +18,15 @@ func (s *service) RunFFmpeg(ctx context.Context, args []string) ([]byte, error)
 		return nil, fmt.Errorf("failed to ensure FFmpeg is installed: %w", err)
 	}
 
+	ctx, span := telemetry.Tracer().Start(ctx, "ffmpeg.run")
+	span.SetAttributes(attribute.String("ffmpeg.args", strings.Join(args, " ")))
+	defer span.End()
+
 	cmd := exec.CommandContext(ctx, s.ffmpegPath, args...)
 	output, err := cmd.CombinedOutput()
 
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return output, fmt.Errorf("ffmpeg command failed: %w", err)
 	}
 
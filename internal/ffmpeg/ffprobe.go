@@ -3,10 +3,16 @@ package ffmpeg
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strconv"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/samcharles93/cinea/internal/telemetry"
 )
 
 // RunFFprobe executes an FFprobe command with the provided arguments
@@ -15,11 +21,25 @@ func (s *service) RunFFprobe(ctx context.Context, args []string) ([]byte, error)
 		return nil, fmt.Errorf("failed to ensure FFprobe is installed: %w", err)
 	}
 
+	// Bound the run so a corrupt file can't hang probe indefinitely
+	timeout := s.config.ProbeTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ctx, span := telemetry.Tracer().Start(ctx, "ffprobe.run")
+	span.SetAttributes(attribute.String("ffprobe.args", strings.Join(args, " ")))
+	defer span.End()
+
 	s.appLogger.Debug().Strs("args", args).Msg("Running FFprobe command")
+	start := time.Now()
 	cmd := exec.CommandContext(ctx, s.ffprobePath, args...)
 	output, err := cmd.Output()
 
 	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			s.appLogger.Error().Dur("timeout", timeout).Strs("args", args).Msg("FFprobe run timed out")
+			return nil, fmt.Errorf("ffprobe timed out after %s: %w", timeout, ctx.Err())
+		}
 		if exitError, ok := err.(*exec.ExitError); ok {
 			s.appLogger.Warn().Err(err).Str("stderr", string(exitError.Stderr)).Msg("FFprobe command had a non-zero exit code")
 			return output, fmt.Errorf("ffprobe command failed with stderr: %s, error: %w",
@@ -30,11 +50,18 @@ func (s *service) RunFFprobe(ctx context.Context, args []string) ([]byte, error)
 		}
 	}
 
+	s.appLogger.Debug().Dur("duration", time.Since(start)).Msg("FFprobe command completed")
 	return output, nil
 }
 
 // ExtractMetadata extracts metadata from the given media file using ffprobe
 func (s *service) ExtractMetadata(ctx context.Context, filePath string) (*MediaMetadata, error) {
+	// In degraded mode return empty-but-usable metadata immediately so scans
+	// can still import items for browsing and direct play
+	if !s.Available() {
+		return &MediaMetadata{Filename: filePath}, fmt.Errorf("ffmpeg is not available; metadata extraction is disabled")
+	}
+
 	s.appLogger.Info().
 		Str("filepath", filePath).
 		Msg("Starting media file metadata extraction")
@@ -43,7 +70,7 @@ func (s *service) ExtractMetadata(ctx context.Context, filePath string) (*MediaM
 	args := []string{
 		"-v", "quiet",
 		"-print_format", "json",
-		"-show_format", "-show_streams",
+		"-show_format", "-show_streams", "-show_chapters",
 		"-i", filePath,
 	}
 
@@ -121,6 +148,11 @@ func (s *service) parseFFprobeJSONOutput(output []byte) (*MediaMetadata, error)
 			Tags         map[string]string `json:"tags"`
 			SideDataList []SideData        `json:"side_data_list"`
 		} `json:"streams"`
+		Chapters []struct {
+			StartTime string            `json:"start_time"`
+			EndTime   string            `json:"end_time"`
+			Tags      map[string]string `json:"tags"`
+		} `json:"chapters"`
 	}
 
 	if err := json.Unmarshal(output, &ffprobeData); err != nil {
@@ -167,6 +199,20 @@ func (s *service) parseFFprobeJSONOutput(output []byte) (*MediaMetadata, error)
 	}
 	metadata.BitRate = bitRate
 
+	for _, chapter := range ffprobeData.Chapters {
+		start, startErr := strconv.ParseFloat(chapter.StartTime, 64)
+		end, endErr := strconv.ParseFloat(chapter.EndTime, 64)
+		if startErr != nil || endErr != nil {
+			s.appLogger.Warn().Str("start", chapter.StartTime).Str("end", chapter.EndTime).Msg("Could not parse chapter times")
+			continue
+		}
+		metadata.Chapters = append(metadata.Chapters, ChapterMetadata{
+			Title: chapter.Tags["title"],
+			Start: start,
+			End:   end,
+		})
+	}
+
 	for _, stream := range ffprobeData.Streams {
 		switch stream.CodecType {
 		case "video":
@@ -4,19 +4,30 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
+	"time"
 
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/events"
 	"github.com/samcharles93/cinea/internal/logger"
 )
 
+// retryInterval is how often a degraded server retries ffmpeg acquisition.
+const retryInterval = 5 * time.Minute
+
 type Service interface {
 	Install() error
 	SetPaths() error
 	CheckInstallation() (bool, error)
 	EnsureInstalled() error
+	Available() bool
+	Version() string
+	Source() string
+	RetryInstall(ctx context.Context, bus events.Bus)
+	Capabilities(ctx context.Context) (*Capabilities, error)
 	ExtractMetadata(ctx context.Context, filePath string) (*MediaMetadata, error)
 	GetFFmpegPath() string
 	GetFFprobePath() string
@@ -25,27 +36,109 @@ type Service interface {
 }
 
 type service struct {
+	config      *config.Config
 	ffmpegPath  string
 	ffprobePath string
 	appLogger   logger.Logger
+
+	mu           sync.RWMutex
+	available    bool
+	capabilities *Capabilities
+	version      string // parsed from `ffmpeg -version` once the binaries work
+	source       string // how the binaries were obtained: bundled, downloaded, system or local
 }
 
-func NewFFMpegService(appLogger logger.Logger) (Service, error) {
+// NewFFMpegService builds the ffmpeg service. A failed binary install is not
+// fatal: the server starts in degraded mode (browsing and direct play only)
+// and acquisition can be retried in the background via RetryInstall.
+func NewFFMpegService(cfg *config.Config, appLogger logger.Logger) (Service, error) {
 	svc := &service{
+		config:    cfg,
 		appLogger: appLogger,
 	}
 
-	if err := svc.Install(); err != nil {
-		return nil, fmt.Errorf("failed to install ffmpeg: %w", err)
-	}
-
 	if err := svc.SetPaths(); err != nil {
 		return nil, fmt.Errorf("failed to set ffmpeg paths: %w", err)
 	}
 
+	if err := svc.EnsureInstalled(); err != nil {
+		appLogger.Warn().Err(err).Msg("FFmpeg is unavailable; starting in degraded mode (browse and direct play only)")
+		return svc, nil
+	}
+
 	return svc, nil
 }
 
+// Available reports whether the ffmpeg binaries are installed and usable.
+// While false, extraction and transcode work is disabled.
+func (s *service) Available() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.available
+}
+
+func (s *service) setAvailable(available bool) {
+	s.mu.Lock()
+	s.available = available
+	if !available {
+		// A re-acquired build may differ; force fresh capability and
+		// version probes
+		s.capabilities = nil
+		s.version = ""
+	}
+	s.mu.Unlock()
+}
+
+// Version returns the detected FFmpeg version ("6.1.1"), or "" while the
+// binaries are unavailable.
+func (s *service) Version() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+// Source reports how the binaries were obtained: "bundled" (copied from the
+// app's bin folder), "downloaded" (static build fetched on first run),
+// "system" (found on PATH) or "local" (already present in the config
+// directory).
+func (s *service) Source() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.source
+}
+
+func (s *service) setSource(source string) {
+	s.mu.Lock()
+	s.source = source
+	s.mu.Unlock()
+}
+
+// RetryInstall periodically retries ffmpeg acquisition until it succeeds or
+// the context ends, announcing recovery on the bus so admins hear about it.
+// Run it in a goroutine when the server starts degraded.
+func (s *service) RetryInstall(ctx context.Context, bus events.Bus) {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if s.Available() {
+				return
+			}
+			if err := s.EnsureInstalled(); err != nil {
+				s.appLogger.Warn().Err(err).Dur("retry_in", retryInterval).Msg("FFmpeg still unavailable")
+				continue
+			}
+			s.appLogger.Info().Msg("FFmpeg acquired; leaving degraded mode")
+			bus.Publish(ctx, events.TranscoderStatus, events.TranscoderPayload{Available: true})
+			return
+		}
+	}
+}
+
 // SetPaths initializes the paths to the FFmpeg and FFprobe binaries
 func (s *service) SetPaths() error {
 	configDir, err := os.UserConfigDir()
@@ -170,18 +263,60 @@ func (s *service) CheckInstallation() (bool, error) {
 	return true, nil
 }
 
-// EnsureInstalled checks if FFmpeg is installed and installs it if not
+// EnsureInstalled checks if FFmpeg is installed and acquires it if not,
+// keeping the availability flag in step with the outcome.
 func (s *service) EnsureInstalled() error {
 	installed, err := s.CheckInstallation()
 	if err != nil {
+		s.setAvailable(false)
 		return err
 	}
 
 	if !installed {
 		s.appLogger.Info().Msg("FFmpeg not found, installing...")
-		return s.Install()
+		if err := s.acquire(); err != nil {
+			s.setAvailable(false)
+			return err
+		}
+	} else if s.Source() == "" {
+		s.setSource("local")
 	}
 
-	log.Println("FFmpeg is already installed")
+	s.setAvailable(true)
+
+	if s.Version() == "" {
+		version := s.detectVersion()
+		s.mu.Lock()
+		s.version = version
+		s.mu.Unlock()
+	}
 	return nil
 }
+
+// acquire obtains the binaries, trying each source in turn: the bundled
+// bin/ folder shipped next to the executable, a static build download when
+// enabled in the config, and finally whatever is on PATH.
+func (s *service) acquire() error {
+	installErr := s.Install()
+	if installErr == nil {
+		s.setSource("bundled")
+		return nil
+	}
+	s.appLogger.Warn().Err(installErr).Msg("No bundled FFmpeg binaries")
+
+	if s.config.FFmpeg.AutoDownload {
+		if downloadErr := s.downloadBinaries(); downloadErr == nil {
+			s.setSource("downloaded")
+			return nil
+		} else {
+			s.appLogger.Warn().Err(downloadErr).Msg("FFmpeg download failed")
+		}
+	}
+
+	if systemErr := s.useSystemBinaries(); systemErr == nil {
+		s.setSource("system")
+		return nil
+	}
+
+	return fmt.Errorf("failed to acquire FFmpeg: no bundled binaries (%v) and no system ffmpeg on PATH", installErr)
+}
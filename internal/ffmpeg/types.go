@@ -19,9 +19,18 @@ type MediaMetadata struct {
 	AudioTracks      []AudioTrackMetadata
 	VideoTracks      []VideoTrackMetadata
 	SubtitleTracks   []SubtitleTrackMetadata
+	Chapters         []ChapterMetadata
 	Tags             map[string]string
 }
 
+// ChapterMetadata stores an embedded chapter marker (e.g. from an m4b
+// audiobook), with start and end in seconds.
+type ChapterMetadata struct {
+	Title string
+	Start float64
+	End   float64
+}
+
 // AudioTrackMetadata stores information about a single audio track
 type AudioTrackMetadata struct {
 	Index       int
@@ -0,0 +1,70 @@
+// Package fuzzy scores approximate string matches so search suggestions
+// tolerate misspelled titles. Matching is trigram-based: strings are
+// normalized, split into character trigrams and compared with the
+// Sørensen–Dice coefficient, which degrades gracefully on transposed or
+// missing letters without needing an index.
+package fuzzy
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalize lowercases a string and collapses everything that isn't a
+// letter or digit into single spaces, so "Se7en!" and "se7en" compare
+// equal.
+func Normalize(s string) string {
+	var b strings.Builder
+	lastSpace := true
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			lastSpace = false
+		} else if !lastSpace {
+			b.WriteRune(' ')
+			lastSpace = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Similarity returns the trigram similarity of two strings in [0, 1];
+// 1 means the normalized forms are identical. Inputs are normalized
+// internally.
+func Similarity(a, b string) float64 {
+	a, b = Normalize(a), Normalize(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	ta, tb := trigrams(a), trigrams(b)
+	common := 0
+	for t, n := range ta {
+		if m, ok := tb[t]; ok {
+			common += min(n, m)
+		}
+	}
+
+	total := 0
+	for _, n := range ta {
+		total += n
+	}
+	for _, n := range tb {
+		total += n
+	}
+	return 2 * float64(common) / float64(total)
+}
+
+// trigrams counts the character trigrams of a normalized string, padded
+// so short strings and word boundaries still produce useful grams.
+func trigrams(s string) map[string]int {
+	runes := []rune("  " + s + " ")
+	grams := make(map[string]int, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		grams[string(runes[i:i+3])]++
+	}
+	return grams
+}
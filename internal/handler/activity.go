@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type ActivityHandler interface {
+	RegisterRoutes(r chi.Router)
+	GetFeed(w http.ResponseWriter, r *http.Request)
+}
+
+type activityHandler struct {
+	activityService service.ActivityService
+	jwtVerifier     *auth.JWTVerifier
+}
+
+func NewActivityHandler(activityService service.ActivityService, jwtVerifier *auth.JWTVerifier) ActivityHandler {
+	return &activityHandler{
+		activityService: activityService,
+		jwtVerifier:     jwtVerifier,
+	}
+}
+
+func (h *activityHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/activity", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireSession)
+			r.Get("/", h.GetFeed)
+		})
+	})
+}
+
+// GetFeed returns the household activity feed, rendered on the dashboard
+// and available to any authenticated household member.
+func (h *activityHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	items, err := h.activityService.GetFeed(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
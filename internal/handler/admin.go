@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+	"github.com/samcharles93/cinea/internal/notify"
+	"github.com/samcharles93/cinea/internal/service"
+	"github.com/samcharles93/cinea/internal/service/backup"
+)
+
+type AdminHandler interface {
+	RegisterRoutes(r chi.Router)
+	GetFFmpeg(w http.ResponseWriter, r *http.Request)
+	ListAuditLog(w http.ResponseWriter, r *http.Request)
+	ListNotificationChannels(w http.ResponseWriter, r *http.Request)
+	TestNotificationChannels(w http.ResponseWriter, r *http.Request)
+	ListBackups(w http.ResponseWriter, r *http.Request)
+	CreateBackup(w http.ResponseWriter, r *http.Request)
+	RestoreBackup(w http.ResponseWriter, r *http.Request)
+	ListDuplicates(w http.ResponseWriter, r *http.Request)
+	ResolveDuplicates(w http.ResponseWriter, r *http.Request)
+	ListTrash(w http.ResponseWriter, r *http.Request)
+	RestoreTrashItem(w http.ResponseWriter, r *http.Request)
+	PurgeTrashItem(w http.ResponseWriter, r *http.Request)
+}
+
+type adminHandler struct {
+	ffmpegService ffmpeg.Service
+	auditService  service.AuditService
+	notifyService notify.Service
+	backupService backup.Service
+	dedupeService service.DedupeService
+	trashService  service.TrashService
+	jwtVerifier   *auth.JWTVerifier
+}
+
+func NewAdminHandler(ffmpegService ffmpeg.Service, auditService service.AuditService, notifyService notify.Service, backupService backup.Service, dedupeService service.DedupeService, trashService service.TrashService, jwtVerifier *auth.JWTVerifier) AdminHandler {
+	return &adminHandler{
+		ffmpegService: ffmpegService,
+		auditService:  auditService,
+		notifyService: notifyService,
+		backupService: backupService,
+		dedupeService: dedupeService,
+		trashService:  trashService,
+		jwtVerifier:   jwtVerifier,
+	}
+}
+
+func (h *adminHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/admin", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(h.requireAdmin)
+			r.Use(auth.RequireSession)
+			r.Get("/ffmpeg", h.GetFFmpeg)
+			r.Get("/audit", h.ListAuditLog)
+			r.Get("/notifications", h.ListNotificationChannels)
+			r.Post("/notifications/test", h.TestNotificationChannels)
+			r.Get("/backups", h.ListBackups)
+			r.Post("/backups", h.CreateBackup)
+			r.Post("/backups/restore", h.RestoreBackup)
+			r.Get("/duplicates", h.ListDuplicates)
+			r.Post("/duplicates/resolve", h.ResolveDuplicates)
+			r.Get("/trash", h.ListTrash)
+			r.Post("/trash/{mediaType}/{id}/restore", h.RestoreTrashItem)
+			r.Delete("/trash/{mediaType}/{id}", h.PurgeTrashItem)
+		})
+	})
+}
+
+// requireAdmin restricts the admin endpoints to administrators
+func (h *adminHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := auth.GetUserFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if user.Role != entity.RoleAdmin {
+			http.Error(w, "Insufficient access", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetFFmpeg returns the installed ffmpeg build's capability matrix, or an
+// unavailable marker when the server runs in degraded mode.
+func (h *adminHandler) GetFFmpeg(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !h.ffmpegService.Available() {
+		json.NewEncoder(w).Encode(&dto.FFmpegCapabilitiesDTO{Available: false})
+		return
+	}
+
+	caps, err := h.ffmpegService.Capabilities(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(dto.FFmpegCapabilitiesToDTO(caps))
+}
+
+// ListAuditLog returns a page of audit log entries, newest first. The page
+// can be narrowed by action, actor and an RFC 3339 time window.
+func (h *adminHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	params := &dto.AuditListParams{
+		Action: r.URL.Query().Get("action"),
+		Actor:  r.URL.Query().Get("actor"),
+	}
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		params.Limit = limit
+	}
+	if raw := r.URL.Query().Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			return
+		}
+		params.Offset = offset
+	}
+	if raw := r.URL.Query().Get("from"); raw != "" {
+		from, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "Invalid from timestamp", http.StatusBadRequest)
+			return
+		}
+		params.From = from
+	}
+	if raw := r.URL.Query().Get("to"); raw != "" {
+		to, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "Invalid to timestamp", http.StatusBadRequest)
+			return
+		}
+		params.To = to
+	}
+
+	page, err := h.auditService.List(r.Context(), params)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// ListNotificationChannels returns the names of the external notification
+// channels enabled in the config.
+func (h *adminHandler) ListNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.notifyService.Channels())
+}
+
+// TestNotificationChannels sends a test notification through every
+// configured channel so admins can verify their setup; delivery failures
+// land in the application log.
+func (h *adminHandler) TestNotificationChannels(w http.ResponseWriter, r *http.Request) {
+	h.notifyService.Send(r.Context(), notify.Event{
+		Title:   "Test notification",
+		Message: "This is a test notification from your Cinea server.",
+	})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListBackups returns the archives in the backup directory, newest first.
+func (h *adminHandler) ListBackups(w http.ResponseWriter, r *http.Request) {
+	backups, err := h.backupService.ListBackups(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(backups)
+}
+
+// CreateBackup writes a new backup archive and returns its details.
+func (h *adminHandler) CreateBackup(w http.ResponseWriter, r *http.Request) {
+	created, err := h.backupService.CreateBackup(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// ListDuplicates returns the groups of files sharing a content hash,
+// best-quality copy first in each group.
+func (h *adminHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	groups, err := h.dedupeService.Report(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(groups)
+}
+
+// ResolveDuplicates keeps the best-quality copy in each duplicate group
+// and soft-deletes the rest; with dry_run set it only reports what would
+// be removed.
+func (h *adminHandler) ResolveDuplicates(w http.ResponseWriter, r *http.Request) {
+	var req dto.DedupeResolveRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, err := h.dedupeService.Resolve(r.Context(), req.DryRun)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// ListTrash returns the soft-deleted items in the recycle bin, newest
+// deletions first.
+func (h *adminHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	items, err := h.trashService.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+// RestoreTrashItem brings a soft-deleted item back into the library.
+func (h *adminHandler) RestoreTrashItem(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.trashService.Restore(r.Context(), chi.URLParam(r, "mediaType"), id); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PurgeTrashItem removes an item from the recycle bin for good, ahead of
+// the scheduled retention purge.
+func (h *adminHandler) PurgeTrashItem(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.trashService.Purge(r.Context(), chi.URLParam(r, "mediaType"), id); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreBackup applies the named archive. SQLite restores are staged and
+// need a server restart; Postgres/MariaDB restores apply immediately.
+func (h *adminHandler) RestoreBackup(w http.ResponseWriter, r *http.Request) {
+	var req dto.BackupRestoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.File == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.backupService.Restore(r.Context(), req.File); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
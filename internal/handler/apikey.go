@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type APIKeyHandler interface {
+	RegisterRoutes(r chi.Router)
+	ListKeys(w http.ResponseWriter, r *http.Request)
+	CreateKey(w http.ResponseWriter, r *http.Request)
+	RevokeKey(w http.ResponseWriter, r *http.Request)
+}
+
+type apiKeyHandler struct {
+	apiKeyService service.APIKeyService
+	jwtVerifier   *auth.JWTVerifier
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService, jwtVerifier *auth.JWTVerifier) APIKeyHandler {
+	return &apiKeyHandler{
+		apiKeyService: apiKeyService,
+		jwtVerifier:   jwtVerifier,
+	}
+}
+
+func (h *apiKeyHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/apikeys", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			// Keys manage keys for no one: minting or revoking requires
+			// an interactive session, so the first key is always obtained
+			// by logging in first
+			r.Use(auth.RequireSession)
+			r.Get("/", h.ListKeys)
+			r.Post("/", h.CreateKey)
+			r.Delete("/{id}", h.RevokeKey)
+		})
+	})
+}
+
+func (h *apiKeyHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	keys, err := h.apiKeyService.List(r.Context(), user.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(keys)
+}
+
+// CreateKey mints a new scoped key; the response is the only time the raw
+// key is ever returned.
+func (h *apiKeyHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.apiKeyService.Create(r.Context(), user.ID, &req)
+	if err != nil {
+		if errors.Is(err, errors.ErrBadRequest) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+func (h *apiKeyHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid key ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.apiKeyService.Revoke(r.Context(), user.ID, id); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
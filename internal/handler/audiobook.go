@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type AudiobookHandler interface {
+	RegisterRoutes(r chi.Router)
+	ListBooks(w http.ResponseWriter, r *http.Request)
+	GetBook(w http.ResponseWriter, r *http.Request)
+	GetProgress(w http.ResponseWriter, r *http.Request)
+	UpdateProgress(w http.ResponseWriter, r *http.Request)
+}
+
+type audiobookHandler struct {
+	audiobookService service.AudiobookService
+	jwtVerifier      *auth.JWTVerifier
+}
+
+func NewAudiobookHandler(audiobookService service.AudiobookService, jwtVerifier *auth.JWTVerifier) AudiobookHandler {
+	return &audiobookHandler{
+		audiobookService: audiobookService,
+		jwtVerifier:      jwtVerifier,
+	}
+}
+
+func (h *audiobookHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/audiobooks", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/", h.ListBooks)
+			r.Get("/{id}", h.GetBook)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireSession)
+			r.Get("/{id}/progress", h.GetProgress)
+			r.Put("/{id}/progress", h.UpdateProgress)
+		})
+	})
+}
+
+func (h *audiobookHandler) ListBooks(w http.ResponseWriter, r *http.Request) {
+	books, err := h.audiobookService.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(books)
+}
+
+func (h *audiobookHandler) GetBook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid audiobook ID", http.StatusBadRequest)
+		return
+	}
+
+	book, err := h.audiobookService.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(book)
+}
+
+func (h *audiobookHandler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid audiobook ID", http.StatusBadRequest)
+		return
+	}
+
+	progress, err := h.audiobookService.GetProgress(r.Context(), user.ID, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(progress)
+}
+
+func (h *audiobookHandler) UpdateProgress(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid audiobook ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateAudiobookProgressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	progress, err := h.audiobookService.UpdateProgress(r.Context(), user.ID, id, &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(progress)
+}
@@ -1,12 +1,15 @@
 package handler
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/samcharles93/cinea/internal/auth"
 	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
 	"github.com/samcharles93/cinea/internal/service"
 )
 
@@ -16,17 +19,34 @@ type AuthHandler interface {
 	GetCurrentUser(w http.ResponseWriter, r *http.Request)
 	Register(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
+	Refresh(w http.ResponseWriter, r *http.Request)
+	Revoke(w http.ResponseWriter, r *http.Request)
+	OIDCLogin(w http.ResponseWriter, r *http.Request)
+	OIDCCallback(w http.ResponseWriter, r *http.Request)
+	ForgotPassword(w http.ResponseWriter, r *http.Request)
+	ResetPassword(w http.ResponseWriter, r *http.Request)
+	RequestEmailVerification(w http.ResponseWriter, r *http.Request)
+	VerifyEmail(w http.ResponseWriter, r *http.Request)
+	QuickConnectInitiate(w http.ResponseWriter, r *http.Request)
+	QuickConnectApprove(w http.ResponseWriter, r *http.Request)
+	QuickConnectPoll(w http.ResponseWriter, r *http.Request)
 }
 
 type authHandler struct {
-	authSvc     service.AuthService
-	jwtVerifier *auth.JWTVerifier
+	authSvc         service.AuthService
+	oidcSvc         service.OIDCService
+	accountSvc      service.AccountService
+	quickConnectSvc service.QuickConnectService
+	jwtVerifier     *auth.JWTVerifier
 }
 
-func NewAuthHandler(authSvc service.AuthService) AuthHandler {
+func NewAuthHandler(authSvc service.AuthService, oidcSvc service.OIDCService, accountSvc service.AccountService, quickConnectSvc service.QuickConnectService, jwtVerifier *auth.JWTVerifier) AuthHandler {
 	return &authHandler{
-		authSvc:     authSvc,
-		jwtVerifier: auth.NewJWTVerifier(),
+		authSvc:         authSvc,
+		oidcSvc:         oidcSvc,
+		accountSvc:      accountSvc,
+		quickConnectSvc: quickConnectSvc,
+		jwtVerifier:     jwtVerifier,
 	}
 }
 
@@ -35,6 +55,17 @@ func (h *authHandler) RegisterRoutes(r chi.Router) {
 		r.Post("/register", h.Register)
 		r.Post("/login", h.Login)
 		r.Post("/logout", h.Logout)
+		r.Post("/refresh", h.Refresh)
+		r.Post("/revoke", h.Revoke)
+		r.Get("/oidc/login", h.OIDCLogin)
+		r.Get("/oidc/callback", h.OIDCCallback)
+		r.Post("/password/forgot", h.ForgotPassword)
+		r.Post("/password/reset", h.ResetPassword)
+		r.Post("/verify-email/confirm", h.VerifyEmail)
+		r.Post("/quickconnect/initiate", h.QuickConnectInitiate)
+		r.Get("/quickconnect/poll", h.QuickConnectPoll)
+		r.With(h.jwtVerifier.Verify).Post("/quickconnect/approve", h.QuickConnectApprove)
+		r.With(h.jwtVerifier.Verify).Post("/verify-email", h.RequestEmailVerification)
 		r.With(h.jwtVerifier.Verify).Get("/me", h.GetCurrentUser)
 	})
 }
@@ -54,7 +85,11 @@ func (h *authHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	userDTO, err := h.authSvc.Authenticate(r.Context(), req.Username, req.Password)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		if errors.Is(err, errors.ErrUnauthorized) {
+			writeLocalizedError(w, r, http.StatusUnauthorized, err)
+			return
+		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -70,6 +105,9 @@ func (h *authHandler) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userDTO := dto.UserToDTO(user)
+	if imp := auth.GetImpersonatorFromContext(r.Context()); imp != nil {
+		userDTO.ImpersonatedBy = &dto.ImpersonatorDTO{ID: imp.ID, Username: imp.Username}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(userDTO)
@@ -88,7 +126,7 @@ func (h *authHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.authSvc.CreateUser(r.Context(), req.Username, req.Email, req.Password)
+	resp, err := h.authSvc.CreateUser(r.Context(), req.Username, req.Email, req.Password)
 	if err != nil {
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
@@ -96,7 +134,7 @@ func (h *authHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode()
+	json.NewEncoder(w).Encode(resp)
 }
 
 func (h *authHandler) Logout(w http.ResponseWriter, r *http.Request) {
@@ -104,3 +142,264 @@ func (h *authHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	// The frontend should handle removing the token
 	w.WriteHeader(http.StatusOK)
 }
+
+// Refresh exchanges a refresh token for a new access/refresh token pair.
+func (h *authHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.RefreshToken == "" {
+		http.Error(w, "Missing refresh token", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.authSvc.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Revoke invalidates a refresh token and the access tokens issued against it.
+func (h *authHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.RefreshToken == "" {
+		http.Error(w, "Missing refresh token", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.authSvc.Revoke(r.Context(), req.RefreshToken); err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// OIDCLogin redirects the browser to the configured OIDC provider. A random
+// state value is stored in a short-lived cookie and checked on callback.
+func (h *authHandler) OIDCLogin(w http.ResponseWriter, r *http.Request) {
+	if !h.oidcSvc.Enabled() {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	state := hex.EncodeToString(raw)
+
+	authURL, err := h.oidcSvc.AuthURL(r.Context(), state)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     "oidc_state",
+		Value:    state,
+		Path:     "/",
+		MaxAge:   600,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCCallback completes the login: the state is checked against the cookie,
+// the code is exchanged and the resulting access token is set as the session
+// cookie before redirecting to the app.
+func (h *authHandler) OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	if !h.oidcSvc.Enabled() {
+		http.Error(w, "OIDC login is not configured", http.StatusNotFound)
+		return
+	}
+
+	stateCookie, err := r.Cookie("oidc_state")
+	if err != nil || stateCookie.Value == "" || r.URL.Query().Get("state") != stateCookie.Value {
+		http.Error(w, "Invalid state", http.StatusBadRequest)
+		return
+	}
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		http.Error(w, "Missing authorization code", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.oidcSvc.HandleCallback(r.Context(), code)
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Clear the state cookie and establish the session
+	http.SetCookie(w, &http.Cookie{
+		Name:     "oidc_state",
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     "token",
+		Value:    resp.Token,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// ForgotPassword sends a password reset link to the given email. It always
+// returns 202 so the endpoint cannot be used to probe for registered
+// addresses.
+func (h *authHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Email == "" {
+		http.Error(w, "Missing email", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.accountSvc.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword sets a new password using a reset token from email.
+func (h *authHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Token == "" || req.Password == "" {
+		http.Error(w, "Missing token or password", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.accountSvc.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		http.Error(w, "Invalid or expired token", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RequestEmailVerification sends a verification link to the calling user's
+// email address.
+func (h *authHandler) RequestEmailVerification(w http.ResponseWriter, r *http.Request) {
+	user, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.accountSvc.RequestEmailVerification(r.Context(), user); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// VerifyEmail confirms a verification token from email.
+func (h *authHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req dto.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Token == "" {
+		http.Error(w, "Missing token", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.accountSvc.VerifyEmail(r.Context(), req.Token); err != nil {
+		http.Error(w, "Invalid or expired token", http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// QuickConnectInitiate starts a PIN pairing session for a device: it
+// returns the code to display and the secret to poll with.
+func (h *authHandler) QuickConnectInitiate(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.quickConnectSvc.Initiate(r.Context())
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// QuickConnectApprove lets a logged-in user approve the code a device is
+// displaying.
+func (h *authHandler) QuickConnectApprove(w http.ResponseWriter, r *http.Request) {
+	user, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.QuickConnectApproveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Code == "" {
+		http.Error(w, "Missing code", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.quickConnectSvc.Approve(r.Context(), req.Code, user); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// QuickConnectPoll is called by the device with its secret until the code
+// is approved; 202 means keep polling.
+func (h *authHandler) QuickConnectPoll(w http.ResponseWriter, r *http.Request) {
+	secret := r.URL.Query().Get("secret")
+	if secret == "" {
+		http.Error(w, "Missing secret", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.quickConnectSvc.Poll(r.Context(), secret)
+	if err != nil {
+		http.Error(w, "Unknown or expired code", http.StatusGone)
+		return
+	}
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
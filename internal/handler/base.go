@@ -2,9 +2,14 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
 	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/i18n"
 	"github.com/samcharles93/cinea/internal/logger"
 )
 
@@ -28,15 +33,10 @@ func (h *BaseHandler) writeJSON(w http.ResponseWriter, status int, data interfac
 	}
 }
 
-func (h *BaseHandler) writeJSONError(w http.ResponseWriter, status int, err error) {
-	resp := errors.ErrorResponse{
-		Error: http.StatusText(status),
-		Code:  status,
-	}
+func (h *BaseHandler) writeJSONError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	resp := localizedErrorResponse(r, status, err)
 
 	if err != nil {
-		resp.Message = err.Error()
-
 		switch status {
 		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
 			h.logger.Debug().Err(err).Int("status", status).Msg("Client error")
@@ -49,19 +49,138 @@ func (h *BaseHandler) writeJSONError(w http.ResponseWriter, status int, err erro
 }
 
 // HandleError determines the appropriate HTTP status code based on the error type
-func (h *BaseHandler) HandleError(w http.ResponseWriter, err error) {
+func (h *BaseHandler) HandleError(w http.ResponseWriter, r *http.Request, err error) {
 	switch {
 	case errors.Is(err, errors.ErrNotFound):
-		h.writeJSONError(w, http.StatusNotFound, err)
+		h.writeJSONError(w, r, http.StatusNotFound, err)
 	case errors.Is(err, errors.ErrUnauthorized):
-		h.writeJSONError(w, http.StatusUnauthorized, err)
+		h.writeJSONError(w, r, http.StatusUnauthorized, err)
 	case errors.Is(err, errors.ErrForbidden):
-		h.writeJSONError(w, http.StatusForbidden, err)
+		h.writeJSONError(w, r, http.StatusForbidden, err)
 	case errors.Is(err, errors.ErrBadRequest):
-		h.writeJSONError(w, http.StatusBadRequest, err)
+		h.writeJSONError(w, r, http.StatusBadRequest, err)
 	case errors.Is(err, errors.ErrAlreadyExists):
-		h.writeJSONError(w, http.StatusConflict, err)
+		h.writeJSONError(w, r, http.StatusConflict, err)
 	default:
-		h.writeJSONError(w, http.StatusInternalServerError, err)
+		h.writeJSONError(w, r, http.StatusInternalServerError, err)
 	}
 }
+
+// requestLocale resolves the message catalog locale for a request: the
+// authenticated user's preferred language wins, then Accept-Language.
+func requestLocale(r *http.Request) string {
+	preferred := ""
+	if user, err := auth.GetUserFromContext(r.Context()); err == nil {
+		preferred = user.PreferredLanguage
+	}
+	return i18n.Match(preferred, r.Header.Get("Accept-Language"))
+}
+
+// localizedErrorResponse builds the error envelope for err: a
+// machine-readable code, a human message in the caller's language, and
+// the raw error text as detail for debugging.
+func localizedErrorResponse(r *http.Request, status int, err error) errors.ErrorResponse {
+	resp := errors.ErrorResponse{
+		Error: http.StatusText(status),
+		Code:  status,
+	}
+	if err == nil {
+		return resp
+	}
+
+	code := errors.CodeOf(err)
+	if code == errors.CodeInternal && status != http.StatusInternalServerError {
+		code = errors.CodeForStatus(status)
+	}
+	resp.ErrorCode = code
+	resp.Message = i18n.T(requestLocale(r), "error."+code)
+	if status != http.StatusInternalServerError {
+		resp.Detail = err.Error()
+	}
+	return resp
+}
+
+// statusForError maps the service error sentinels onto HTTP statuses;
+// unknown errors are internal.
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errors.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, errors.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, errors.ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, errors.ErrAlreadyExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeLocalizedError writes the localized error envelope; it is the
+// shared helper for handlers that do not embed BaseHandler.
+func writeLocalizedError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(localizedErrorResponse(r, status, err))
+}
+
+// parseListParams reads the pagination, sorting, and filtering query
+// parameters shared by the movie and series list endpoints.
+func parseListParams(r *http.Request) (*dto.MediaListParams, error) {
+	query := r.URL.Query()
+	params := &dto.MediaListParams{
+		Sort:     query.Get("sort"),
+		Order:    query.Get("order"),
+		Genre:    query.Get("genre"),
+		Language: query.Get("language"),
+		Country:  query.Get("country"),
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid limit '%s'", raw)
+		}
+		params.Limit = limit
+	}
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("invalid offset '%s'", raw)
+		}
+		params.Offset = offset
+	}
+	if raw := query.Get("year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < 0 {
+			return nil, fmt.Errorf("invalid year '%s'", raw)
+		}
+		params.Year = year
+	}
+	if raw := query.Get("min_runtime"); raw != "" {
+		minutes, err := strconv.Atoi(raw)
+		if err != nil || minutes < 0 {
+			return nil, fmt.Errorf("invalid min_runtime '%s'", raw)
+		}
+		params.MinRuntime = minutes
+	}
+	if raw := query.Get("max_runtime"); raw != "" {
+		minutes, err := strconv.Atoi(raw)
+		if err != nil || minutes < 0 {
+			return nil, fmt.Errorf("invalid max_runtime '%s'", raw)
+		}
+		params.MaxRuntime = minutes
+	}
+	if raw := query.Get("watched"); raw != "" {
+		watched, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid watched filter '%s'", raw)
+		}
+		params.Watched = &watched
+	}
+
+	return params, nil
+}
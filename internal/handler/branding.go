@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service"
+	"github.com/samcharles93/cinea/internal/upload"
+)
+
+type BrandingHandler interface {
+	RegisterRoutes(r chi.Router)
+	Get(w http.ResponseWriter, r *http.Request)
+	GetImage(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	UploadImage(w http.ResponseWriter, r *http.Request)
+}
+
+type brandingHandler struct {
+	brandingService service.BrandingService
+	jwtVerifier     *auth.JWTVerifier
+}
+
+func NewBrandingHandler(brandingService service.BrandingService, jwtVerifier *auth.JWTVerifier) BrandingHandler {
+	return &brandingHandler{
+		brandingService: brandingService,
+		jwtVerifier:     jwtVerifier,
+	}
+}
+
+func (h *brandingHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/branding", func(r chi.Router) {
+		// Deliberately unauthenticated: clients style the login page
+		// before anyone is logged in
+		r.Get("/", h.Get)
+		r.Get("/{kind}", h.GetImage)
+
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(h.requireAdmin)
+			r.Use(auth.RequireSession)
+			r.Put("/", h.Update)
+			r.With(upload.MaxBytes(upload.MaxImageSize)).Put("/{kind}", h.UploadImage)
+		})
+	})
+}
+
+// requireAdmin restricts branding edits to administrators
+func (h *brandingHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := auth.GetUserFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if user.Role != entity.RoleAdmin {
+			http.Error(w, "Insufficient access", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Get returns the server's branding for clients to render.
+func (h *brandingHandler) Get(w http.ResponseWriter, r *http.Request) {
+	branding, err := h.brandingService.Get(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(branding)
+}
+
+// GetImage serves an uploaded branding image (logo or background).
+func (h *brandingHandler) GetImage(w http.ResponseWriter, r *http.Request) {
+	path, err := h.brandingService.ImagePath(r.Context(), chi.URLParam(r, "kind"))
+	if err != nil {
+		http.Error(w, "Invalid branding image", http.StatusBadRequest)
+		return
+	}
+	if path == "" {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	http.ServeFile(w, r, path)
+}
+
+// Update applies server name and accent color edits.
+func (h *brandingHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateBrandingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	branding, err := h.brandingService.Update(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(branding)
+}
+
+// UploadImage stores a branding image; the request body is the raw image.
+func (h *brandingHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	if err := h.brandingService.SetImage(r.Context(), chi.URLParam(r, "kind"), r.Body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
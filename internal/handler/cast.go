@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type CastHandler interface {
+	RegisterRoutes(r chi.Router)
+	GetMetadata(w http.ResponseWriter, r *http.Request)
+}
+
+type castHandler struct {
+	castService service.CastService
+	jwtVerifier *auth.JWTVerifier
+}
+
+func NewCastHandler(castService service.CastService, jwtVerifier *auth.JWTVerifier) CastHandler {
+	return &castHandler{
+		castService: castService,
+		jwtVerifier: jwtVerifier,
+	}
+}
+
+func (h *castHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/cast", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeStream))
+			r.Get("/{mediaType}/{id}", h.GetMetadata)
+		})
+	})
+}
+
+// GetMetadata returns the cast-receiver metadata for a movie or episode.
+func (h *castHandler) GetMetadata(w http.ResponseWriter, r *http.Request) {
+	mediaType := chi.URLParam(r, "mediaType")
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	meta, err := h.castService.GetCastMetadata(r.Context(), mediaType, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, errors.ErrBadRequest):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(meta)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type CollectionHandler interface {
+	RegisterRoutes(r chi.Router)
+	List(w http.ResponseWriter, r *http.Request)
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+type collectionHandler struct {
+	collectionService service.CollectionService
+	jwtVerifier       *auth.JWTVerifier
+}
+
+func NewCollectionHandler(collectionService service.CollectionService, jwtVerifier *auth.JWTVerifier) CollectionHandler {
+	return &collectionHandler{
+		collectionService: collectionService,
+		jwtVerifier:       jwtVerifier,
+	}
+}
+
+func (h *collectionHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/collections", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/", h.List)
+			r.Get("/{id}", h.Get)
+		})
+	})
+}
+
+func (h *collectionHandler) List(w http.ResponseWriter, r *http.Request) {
+	collections, err := h.collectionService.ListCollections(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(collections)
+}
+
+func (h *collectionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := h.collectionService.GetCollectionByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if collection == nil {
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(collection)
+}
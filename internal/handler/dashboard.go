@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type DashboardHandler interface {
+	RegisterRoutes(r chi.Router)
+	GetContinueWatching(w http.ResponseWriter, r *http.Request)
+	GetNextUp(w http.ResponseWriter, r *http.Request)
+}
+
+type dashboardHandler struct {
+	dashboardService service.DashboardService
+	jwtVerifier      *auth.JWTVerifier
+}
+
+func NewDashboardHandler(dashboardService service.DashboardService, jwtVerifier *auth.JWTVerifier) DashboardHandler {
+	return &dashboardHandler{
+		dashboardService: dashboardService,
+		jwtVerifier:      jwtVerifier,
+	}
+}
+
+func (h *dashboardHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/dashboard", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireSession)
+			r.Get("/continue-watching", h.GetContinueWatching)
+			r.Get("/next-up", h.GetNextUp)
+		})
+	})
+}
+
+// GetContinueWatching returns the user's in-progress items, most recently
+// watched first.
+func (h *dashboardHandler) GetContinueWatching(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	items, err := h.dashboardService.ContinueWatching(r.Context(), user.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+// GetNextUp returns the next unwatched episode for each series the user
+// has been watching.
+func (h *dashboardHandler) GetNextUp(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	items, err := h.dashboardService.NextUp(r.Context(), user.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+// DeepLinkHandler resolves the stable /library/{libraryId}/item/{itemId}
+// deep-link schema shared by the web UI and mobile clients.
+type DeepLinkHandler interface {
+	RegisterRoutes(r chi.Router)
+	RegisterWebRoutes(r chi.Router)
+	Resolve(w http.ResponseWriter, r *http.Request)
+}
+
+type deepLinkHandler struct {
+	mediaService service.MediaService
+	jwtVerifier  *auth.JWTVerifier
+}
+
+func NewDeepLinkHandler(mediaService service.MediaService, jwtVerifier *auth.JWTVerifier) DeepLinkHandler {
+	return &deepLinkHandler{
+		mediaService: mediaService,
+		jwtVerifier:  jwtVerifier,
+	}
+}
+
+func (h *deepLinkHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/links", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/library/{libraryId}/item/{itemId}", h.Resolve)
+		})
+	})
+}
+
+// RegisterWebRoutes registers the shareable in-server path on the web
+// router, forwarding to the bundled client's detail view.
+func (h *deepLinkHandler) RegisterWebRoutes(r chi.Router) {
+	r.Get("/library/{libraryId}/item/{itemId}", h.redirectWeb)
+}
+
+// Resolve returns what a deep link points at so clients can route to the
+// right detail view.
+func (h *deepLinkHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	libraryID, err := parseID(chi.URLParam(r, "libraryId"))
+	if err != nil {
+		http.Error(w, "Invalid library ID", http.StatusBadRequest)
+		return
+	}
+	itemID, err := parseID(chi.URLParam(r, "itemId"))
+	if err != nil {
+		http.Error(w, "Invalid item ID", http.StatusBadRequest)
+		return
+	}
+
+	link, err := h.mediaService.ResolveLibraryItem(r.Context(), libraryID, itemID)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(link)
+}
+
+// redirectWeb forwards a shared deep link to the web client's detail
+// page. The redirect is stateless; the detail view itself enforces
+// authentication and restrictions.
+func (h *deepLinkHandler) redirectWeb(w http.ResponseWriter, r *http.Request) {
+	itemID := chi.URLParam(r, "itemId")
+	if _, err := parseID(itemID); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.Redirect(w, r, "/media/"+itemID, http.StatusSeeOther)
+}
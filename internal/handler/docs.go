@@ -0,0 +1,67 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// openapiSpec is the hand-maintained OpenAPI description of the API; keep
+// it in sync when adding or changing routes.
+//
+//go:embed openapi.yaml
+var openapiSpec []byte
+
+// swaggerPage loads Swagger UI from a CDN and points it at the spec, so no
+// UI assets need to ship with the server.
+const swaggerPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Cinea API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    SwaggerUIBundle({
+      url: "/api/docs/openapi.yaml",
+      dom_id: "#swagger-ui",
+    });
+  </script>
+</body>
+</html>
+`
+
+type DocsHandler interface {
+	RegisterRoutes(r chi.Router)
+	Spec(w http.ResponseWriter, r *http.Request)
+	UI(w http.ResponseWriter, r *http.Request)
+}
+
+type docsHandler struct{}
+
+func NewDocsHandler() DocsHandler {
+	return &docsHandler{}
+}
+
+// RegisterRoutes mounts the API documentation. The docs are public: they
+// describe the API without exposing any library content.
+func (h *docsHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/docs", func(r chi.Router) {
+		r.Get("/", h.UI)
+		r.Get("/openapi.yaml", h.Spec)
+	})
+}
+
+func (h *docsHandler) UI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(swaggerPage))
+}
+
+func (h *docsHandler) Spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Write(openapiSpec)
+}
@@ -7,7 +7,7 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/samcharles93/cinea/internal/dto"
-	"github.com/samcharles93/cinea/internal/services"
+	"github.com/samcharles93/cinea/internal/service"
 )
 
 type FavoriteHandler interface {
@@ -17,11 +17,11 @@ type FavoriteHandler interface {
 }
 
 type favoriteHandler struct {
-	authSvc     services.AuthService
-	favoriteSvc services.FavoriteService
+	authSvc     service.AuthService
+	favoriteSvc service.FavoriteService
 }
 
-func NewFavoriteHandler(authSvc services.AuthService, favoriteSvc services.FavoriteService) FavoriteHandler {
+func NewFavoriteHandler(authSvc service.AuthService, favoriteSvc service.FavoriteService) FavoriteHandler {
 	return &favoriteHandler{
 		authSvc:     authSvc,
 		favoriteSvc: favoriteSvc,
@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type IdentifyHandler interface {
+	RegisterRoutes(r chi.Router)
+	Search(w http.ResponseWriter, r *http.Request)
+	ListUnmatched(w http.ResponseWriter, r *http.Request)
+	IdentifyMovie(w http.ResponseWriter, r *http.Request)
+	IdentifySeries(w http.ResponseWriter, r *http.Request)
+}
+
+type identifyHandler struct {
+	identifyService service.IdentifyService
+	jwtVerifier     *auth.JWTVerifier
+}
+
+func NewIdentifyHandler(identifyService service.IdentifyService, jwtVerifier *auth.JWTVerifier) IdentifyHandler {
+	return &identifyHandler{
+		identifyService: identifyService,
+		jwtVerifier:     jwtVerifier,
+	}
+}
+
+func (h *identifyHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/identify", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(h.requireAdmin)
+			r.Use(auth.RequireScope(auth.ScopeWriteMetadata))
+			r.Get("/search", h.Search)
+			r.Get("/unmatched", h.ListUnmatched)
+			r.Post("/movie/{id}", h.IdentifyMovie)
+			r.Post("/series/{id}", h.IdentifySeries)
+		})
+	})
+}
+
+// requireAdmin restricts the identify endpoints to administrators
+func (h *identifyHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := auth.GetUserFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if user.Role != entity.RoleAdmin {
+			http.Error(w, "Insufficient access", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *identifyHandler) Search(w http.ResponseWriter, r *http.Request) {
+	mediaType := r.URL.Query().Get("type")
+	query := r.URL.Query().Get("query")
+	year := r.URL.Query().Get("year")
+
+	candidates, err := h.identifyService.SearchCandidates(r.Context(), mediaType, query, year)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(candidates)
+}
+
+func (h *identifyHandler) ListUnmatched(w http.ResponseWriter, r *http.Request) {
+	items, err := h.identifyService.ListUnmatched(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
+
+func (h *identifyHandler) IdentifyMovie(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid movie ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.IdentifyRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.TMDbID == 0 {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	movie, err := h.identifyService.IdentifyMovie(r.Context(), id, req.TMDbID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(movie)
+}
+
+func (h *identifyHandler) IdentifySeries(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid series ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.IdentifyRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.TMDbID == 0 {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	series, err := h.identifyService.IdentifySeries(r.Context(), id, uint(req.TMDbID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(series)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"path/filepath"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service/images"
+)
+
+// ImageHandler serves library artwork through the local proxy cache,
+// negotiating WebP/AVIF delivery via the Accept header.
+type ImageHandler interface {
+	RegisterRoutes(r chi.Router)
+	GetArtwork(w http.ResponseWriter, r *http.Request)
+}
+
+type imageHandler struct {
+	imageSvc    images.Service
+	jwtVerifier *auth.JWTVerifier
+}
+
+func NewImageHandler(imageSvc images.Service, jwtVerifier *auth.JWTVerifier) ImageHandler {
+	return &imageHandler{
+		imageSvc:    imageSvc,
+		jwtVerifier: jwtVerifier,
+	}
+}
+
+func (h *imageHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/images", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/tmdb/{size}/{file}", h.GetArtwork)
+		})
+	})
+}
+
+func (h *imageHandler) GetArtwork(w http.ResponseWriter, r *http.Request) {
+	size := chi.URLParam(r, "size")
+	file := chi.URLParam(r, "file")
+
+	format := h.imageSvc.NegotiateFormat(r.Context(), r.Header.Get("Accept"))
+	path, err := h.imageSvc.Artwork(r.Context(), size, file, format)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	// ServeFile can't be trusted to know the newer image types, and the
+	// body depends on what the client accepts
+	switch filepath.Ext(path) {
+	case ".webp":
+		w.Header().Set("Content-Type", "image/webp")
+	case ".avif":
+		w.Header().Set("Content-Type", "image/avif")
+	}
+	w.Header().Set("Vary", "Accept")
+	// TMDb artwork file names are content-addressed, so cached responses
+	// never go stale
+	w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
+	http.ServeFile(w, r, path)
+}
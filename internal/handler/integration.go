@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/service/scanner"
+)
+
+// IntegrationHandler receives webhooks from external import tools. The
+// endpoints are compatible with Sonarr and Radarr "On Import"
+// notifications and trigger a targeted scan of just the imported folder
+// instead of a full library scan. Authenticate the connection with an API
+// key carrying the trigger:scan scope, either as an X-API-Key header or
+// an `apikey` query parameter on the webhook URL.
+type IntegrationHandler interface {
+	RegisterRoutes(r chi.Router)
+	SonarrWebhook(w http.ResponseWriter, r *http.Request)
+	RadarrWebhook(w http.ResponseWriter, r *http.Request)
+}
+
+type integrationHandler struct {
+	scannerSvc  scanner.Service
+	appLogger   logger.Logger
+	jwtVerifier *auth.JWTVerifier
+}
+
+func NewIntegrationHandler(scannerSvc scanner.Service, appLogger logger.Logger, jwtVerifier *auth.JWTVerifier) IntegrationHandler {
+	return &integrationHandler{
+		scannerSvc:  scannerSvc,
+		appLogger:   appLogger,
+		jwtVerifier: jwtVerifier,
+	}
+}
+
+func (h *integrationHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/integrations", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeTriggerScan))
+			r.Post("/sonarr", h.SonarrWebhook)
+			r.Post("/radarr", h.RadarrWebhook)
+		})
+	})
+}
+
+// sonarrPayload is the subset of Sonarr's webhook body the receiver needs.
+type sonarrPayload struct {
+	EventType string `json:"eventType"`
+	Series    struct {
+		Path string `json:"path"`
+	} `json:"series"`
+}
+
+// radarrPayload is the subset of Radarr's webhook body the receiver needs.
+type radarrPayload struct {
+	EventType string `json:"eventType"`
+	Movie     struct {
+		FolderPath string `json:"folderPath"`
+	} `json:"movie"`
+}
+
+// SonarrWebhook handles Sonarr notifications; an import queues a scan of
+// the series folder.
+func (h *integrationHandler) SonarrWebhook(w http.ResponseWriter, r *http.Request) {
+	var payload sonarrPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.handleImport(w, "sonarr", payload.EventType, payload.Series.Path)
+}
+
+// RadarrWebhook handles Radarr notifications; an import queues a scan of
+// the movie folder.
+func (h *integrationHandler) RadarrWebhook(w http.ResponseWriter, r *http.Request) {
+	var payload radarrPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.handleImport(w, "radarr", payload.EventType, payload.Movie.FolderPath)
+}
+
+// handleImport answers Test events with 200 so the connection check in the
+// sending application passes, and queues a targeted scan for import
+// events. Other event types are acknowledged and ignored.
+func (h *integrationHandler) handleImport(w http.ResponseWriter, source, eventType, path string) {
+	if eventType == "Test" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Sonarr raises "Download" on import; Radarr additionally raises
+	// "MovieFileDelete"/"Rename" style events this receiver doesn't act on
+	if (eventType != "Download" && eventType != "MovieAdded") || path == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.appLogger.Info().
+		Str("source", source).
+		Str("path", path).
+		Msg("Import notification received, queueing targeted scan")
+
+	// Detach from the request context so the scan survives the response
+	scanPath := filepath.Clean(path)
+	go func() {
+		if err := h.scannerSvc.ScanImportedPath(context.Background(), scanPath); err != nil {
+			h.appLogger.Warn().Err(err).Str("path", scanPath).Msg("Targeted import scan failed")
+		}
+	}()
+
+	w.WriteHeader(http.StatusAccepted)
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type LibraryHandler interface {
+	RegisterRoutes(r chi.Router)
+	ListLibraries(w http.ResponseWriter, r *http.Request)
+	GetLibrary(w http.ResponseWriter, r *http.Request)
+	CreateLibrary(w http.ResponseWriter, r *http.Request)
+	UpdateLibrary(w http.ResponseWriter, r *http.Request)
+	DeleteLibrary(w http.ResponseWriter, r *http.Request)
+	ScanLibrary(w http.ResponseWriter, r *http.Request)
+	AddPath(w http.ResponseWriter, r *http.Request)
+	RemovePath(w http.ResponseWriter, r *http.Request)
+}
+
+type libraryHandler struct {
+	libraryService service.LibraryService
+	jwtVerifier    *auth.JWTVerifier
+}
+
+func NewLibraryHandler(libraryService service.LibraryService, jwtVerifier *auth.JWTVerifier) LibraryHandler {
+	return &libraryHandler{
+		libraryService: libraryService,
+		jwtVerifier:    jwtVerifier,
+	}
+}
+
+func (h *libraryHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/libraries", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/", h.ListLibraries)
+			r.Get("/{id}", h.GetLibrary)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(h.requireAdmin)
+			r.Use(auth.RequireScope(auth.ScopeTriggerScan))
+			r.Post("/{id}/scan", h.ScanLibrary)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(h.requireAdmin)
+			r.Use(auth.RequireSession)
+			r.Post("/", h.CreateLibrary)
+			r.Patch("/{id}", h.UpdateLibrary)
+			r.Delete("/{id}", h.DeleteLibrary)
+			r.Post("/{id}/paths", h.AddPath)
+			r.Delete("/{id}/paths/{pathID}", h.RemovePath)
+		})
+	})
+}
+
+// requireAdmin restricts library management to administrators
+func (h *libraryHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := auth.GetUserFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if user.Role != entity.RoleAdmin {
+			http.Error(w, "Insufficient access", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *libraryHandler) ListLibraries(w http.ResponseWriter, r *http.Request) {
+	libraries, err := h.libraryService.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(libraries)
+}
+
+func (h *libraryHandler) GetLibrary(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid library ID", http.StatusBadRequest)
+		return
+	}
+
+	lib, err := h.libraryService.Get(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lib)
+}
+
+func (h *libraryHandler) CreateLibrary(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateLibraryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lib, err := h.libraryService.Create(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(lib)
+}
+
+func (h *libraryHandler) UpdateLibrary(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid library ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateLibraryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lib, err := h.libraryService.Update(r.Context(), id, &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lib)
+}
+
+// DeleteLibrary removes a library; ?cascade=true deletes its imported items
+// as well.
+func (h *libraryHandler) DeleteLibrary(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid library ID", http.StatusBadRequest)
+		return
+	}
+
+	cascade := r.URL.Query().Get("cascade") == "true"
+	if err := h.libraryService.Delete(r.Context(), id, cascade); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ScanLibrary queues a scan of one library and returns immediately.
+func (h *libraryHandler) ScanLibrary(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid library ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.libraryService.Scan(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *libraryHandler) AddPath(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid library ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.AddLibraryPathRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	lib, err := h.libraryService.AddPath(r.Context(), id, req.Path, req.ContentType)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(lib)
+}
+
+func (h *libraryHandler) RemovePath(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid library ID", http.StatusBadRequest)
+		return
+	}
+	pathID, err := parseID(chi.URLParam(r, "pathID"))
+	if err != nil {
+		http.Error(w, "Invalid path ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.libraryService.RemovePath(r.Context(), id, pathID); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -9,27 +9,30 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/samcharles93/cinea/internal/auth"
 	"github.com/samcharles93/cinea/internal/service"
-	"github.com/samcharles93/cinea/internal/services/metadata"
+	"github.com/samcharles93/cinea/internal/service/metadata"
 )
 
 type MovieHandler interface {
 	RegisterRoutes(r chi.Router)
 	List(w http.ResponseWriter, r *http.Request)
 	Get(w http.ResponseWriter, r *http.Request)
+	GetCredits(w http.ResponseWriter, r *http.Request)
 	Stream(w http.ResponseWriter, r *http.Request)
 }
 
 type movieHandler struct {
-	movieService service.MediaService
-	tmdb         *metadata.TMDbService
-	jwtVerifier  *auth.JWTVerifier
+	movieService  service.MediaService
+	personService service.PersonService
+	tmdb          *metadata.TMDbService
+	jwtVerifier   *auth.JWTVerifier
 }
 
-func NewMovieHandler(movieService service.MediaService, tmdb *metadata.TMDbService, jwtVerifier *auth.JWTVerifier) MovieHandler {
+func NewMovieHandler(movieService service.MediaService, personService service.PersonService, tmdb *metadata.TMDbService, jwtVerifier *auth.JWTVerifier) MovieHandler {
 	return &movieHandler{
-		movieService: movieService,
-		tmdb:         tmdb,
-		jwtVerifier:  jwtVerifier,
+		movieService:  movieService,
+		personService: personService,
+		tmdb:          tmdb,
+		jwtVerifier:   jwtVerifier,
 	}
 }
 
@@ -37,45 +40,74 @@ func (h *movieHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/movies", func(r chi.Router) {
 		r.Group(func(r chi.Router) {
 			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
 			r.Get("/", h.List)
 			r.Get("/{id}", h.Get)
+			r.Get("/{id}/credits", h.GetCredits)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeStream))
 			r.Get("/{id}/stream", h.Stream)
 		})
 	})
 }
 
 func (h *movieHandler) List(w http.ResponseWriter, r *http.Request) {
-	movies, err := h.movieService.GetAllMovies(r.Context())
+	params, err := parseListParams(r)
+	if err != nil {
+		h.writeJSONError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	page, err := h.movieService.ListMovies(r.Context(), params)
 	if err != nil {
-		h.writeJSONError(w, http.StatusInternalServerError, err)
+		h.writeJSONError(w, r, statusForError(err), err)
 		return
 	}
 
-	h.writeJSON(w, http.StatusOK, movies)
+	h.writeJSON(w, http.StatusOK, page)
 }
 
 func (h *movieHandler) Get(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		h.writeJSONError(w, http.StatusBadRequest, errors.New("invalid ID format"))
+		h.writeJSONError(w, r, http.StatusBadRequest, errors.New("invalid ID format"))
 		return
 	}
 
-	movie, err := h.movieService.GetMovieByID(r.Context(), uint(id))
+	movie, err := h.movieService.GetMovieByID(r.Context(), uint(id), requestLanguage(r))
 	if err != nil {
-		h.writeJSONError(w, http.StatusInternalServerError, err)
+		h.writeJSONError(w, r, statusForError(err), err)
 		return
 	}
 
 	if movie == nil {
-		h.writeJSONError(w, http.StatusNotFound, errors.New("movie not found"))
+		h.writeJSONError(w, r, http.StatusNotFound, errors.New("movie not found"))
 		return
 	}
 
 	h.writeJSON(w, http.StatusOK, movie)
 }
 
+func (h *movieHandler) GetCredits(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		h.writeJSONError(w, r, http.StatusBadRequest, errors.New("invalid ID format"))
+		return
+	}
+
+	credits, err := h.personService.GetMediaCredits(r.Context(), "movie", uint(id))
+	if err != nil {
+		h.writeJSONError(w, r, statusForError(err), err)
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, credits)
+}
+
 func (h *movieHandler) Stream(w http.ResponseWriter, r *http.Request) {
 	// TODO: Implement streaming logic
 	http.Error(w, "Not implemented", http.StatusNotImplemented)
@@ -87,6 +119,6 @@ func (h *movieHandler) writeJSON(w http.ResponseWriter, status int, data interfa
 	json.NewEncoder(w).Encode(data)
 }
 
-func (h *movieHandler) writeJSONError(w http.ResponseWriter, status int, err error) {
-	h.writeJSON(w, status, map[string]string{"error": err.Error()})
+func (h *movieHandler) writeJSONError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	writeLocalizedError(w, r, status, err)
 }
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type MusicHandler interface {
+	RegisterRoutes(r chi.Router)
+	ListArtists(w http.ResponseWriter, r *http.Request)
+	GetArtist(w http.ResponseWriter, r *http.Request)
+	GetAlbum(w http.ResponseWriter, r *http.Request)
+}
+
+type musicHandler struct {
+	musicService service.MusicService
+	jwtVerifier  *auth.JWTVerifier
+}
+
+func NewMusicHandler(musicService service.MusicService, jwtVerifier *auth.JWTVerifier) MusicHandler {
+	return &musicHandler{
+		musicService: musicService,
+		jwtVerifier:  jwtVerifier,
+	}
+}
+
+func (h *musicHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/music", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/artists", h.ListArtists)
+			r.Get("/artists/{id}", h.GetArtist)
+			r.Get("/albums/{id}", h.GetAlbum)
+		})
+	})
+}
+
+func (h *musicHandler) ListArtists(w http.ResponseWriter, r *http.Request) {
+	artists, err := h.musicService.ListArtists(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(artists)
+}
+
+func (h *musicHandler) GetArtist(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid artist ID", http.StatusBadRequest)
+		return
+	}
+
+	artist, err := h.musicService.GetArtist(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(artist)
+}
+
+func (h *musicHandler) GetAlbum(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid album ID", http.StatusBadRequest)
+		return
+	}
+
+	album, err := h.musicService.GetAlbum(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(album)
+}
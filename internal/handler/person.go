@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type PersonHandler interface {
+	RegisterRoutes(r chi.Router)
+	Get(w http.ResponseWriter, r *http.Request)
+}
+
+type personHandler struct {
+	personService service.PersonService
+	jwtVerifier   *auth.JWTVerifier
+}
+
+func NewPersonHandler(personService service.PersonService, jwtVerifier *auth.JWTVerifier) PersonHandler {
+	return &personHandler{
+		personService: personService,
+		jwtVerifier:   jwtVerifier,
+	}
+}
+
+func (h *personHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/people", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/{id}", h.Get)
+		})
+	})
+}
+
+func (h *personHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid person ID", http.StatusBadRequest)
+		return
+	}
+
+	person, err := h.personService.GetPersonByID(r.Context(), id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if person == nil {
+		http.Error(w, "Person not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(person)
+}
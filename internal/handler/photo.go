@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type PhotoHandler interface {
+	RegisterRoutes(r chi.Router)
+	Timeline(w http.ResponseWriter, r *http.Request)
+	ListAlbums(w http.ResponseWriter, r *http.Request)
+	GetAlbum(w http.ResponseWriter, r *http.Request)
+}
+
+type photoHandler struct {
+	photoService service.PhotoService
+	jwtVerifier  *auth.JWTVerifier
+}
+
+func NewPhotoHandler(photoService service.PhotoService, jwtVerifier *auth.JWTVerifier) PhotoHandler {
+	return &photoHandler{
+		photoService: photoService,
+		jwtVerifier:  jwtVerifier,
+	}
+}
+
+func (h *photoHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/photos", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/timeline", h.Timeline)
+			r.Get("/albums", h.ListAlbums)
+			r.Get("/albums/{name}", h.GetAlbum)
+		})
+	})
+}
+
+func (h *photoHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	photos, err := h.photoService.Timeline(r.Context(), limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(photos)
+}
+
+func (h *photoHandler) ListAlbums(w http.ResponseWriter, r *http.Request) {
+	albums, err := h.photoService.ListAlbums(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(albums)
+}
+
+func (h *photoHandler) GetAlbum(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "Album name is required", http.StatusBadRequest)
+		return
+	}
+
+	album, err := h.photoService.GetAlbum(r.Context(), name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(album)
+}
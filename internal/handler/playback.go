@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type PlaybackHandler interface {
+	RegisterRoutes(r chi.Router)
+	GetPlaybackInfo(w http.ResponseWriter, r *http.Request)
+	BeginSession(w http.ResponseWriter, r *http.Request)
+	ListSessions(w http.ResponseWriter, r *http.Request)
+	HeartbeatSession(w http.ResponseWriter, r *http.Request)
+	EndSession(w http.ResponseWriter, r *http.Request)
+	ListProfiles(w http.ResponseWriter, r *http.Request)
+	CreateProfile(w http.ResponseWriter, r *http.Request)
+	UpdateProfile(w http.ResponseWriter, r *http.Request)
+	DeleteProfile(w http.ResponseWriter, r *http.Request)
+}
+
+type playbackHandler struct {
+	playbackService service.PlaybackService
+	streamService   service.StreamSessionService
+	jwtVerifier     *auth.JWTVerifier
+}
+
+func NewPlaybackHandler(playbackService service.PlaybackService, streamService service.StreamSessionService, jwtVerifier *auth.JWTVerifier) PlaybackHandler {
+	return &playbackHandler{
+		playbackService: playbackService,
+		streamService:   streamService,
+		jwtVerifier:     jwtVerifier,
+	}
+}
+
+func (h *playbackHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/playback", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeStream))
+			r.Get("/profiles", h.ListProfiles)
+			r.Get("/sessions", h.ListSessions)
+			r.Post("/sessions/{sessionId}/heartbeat", h.HeartbeatSession)
+			r.Delete("/sessions/{sessionId}", h.EndSession)
+			r.Get("/{mediaType}/{id}", h.GetPlaybackInfo)
+			r.Post("/{mediaType}/{id}/session", h.BeginSession)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(h.requireAdmin)
+			r.Use(auth.RequireSession)
+			r.Post("/profiles", h.CreateProfile)
+			r.Patch("/profiles/{id}", h.UpdateProfile)
+			r.Delete("/profiles/{id}", h.DeleteProfile)
+		})
+	})
+}
+
+// requireAdmin restricts profile management to administrators
+func (h *playbackHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := auth.GetUserFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if user.Role != entity.RoleAdmin {
+			http.Error(w, "Insufficient access", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// GetPlaybackInfo returns source media details and transcode directives for
+// a movie or episode. The client profile is selected via ?profile=, an
+// embedded subtitle track via ?subtitle= (stream index), an alternate movie
+// version via ?version=, and the stereo downmix via ?downmix= / ?loudnorm=
+// overriding the profile defaults.
+func (h *playbackHandler) GetPlaybackInfo(w http.ResponseWriter, r *http.Request) {
+	mediaType := chi.URLParam(r, "mediaType")
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	opts := &dto.PlaybackOptions{
+		Profile:       query.Get("profile"),
+		SubtitleTrack: -1,
+	}
+
+	if raw := query.Get("subtitle"); raw != "" {
+		opts.SubtitleTrack, err = strconv.Atoi(raw)
+		if err != nil || opts.SubtitleTrack < 0 {
+			http.Error(w, "Invalid subtitle track", http.StatusBadRequest)
+			return
+		}
+	}
+	if raw := query.Get("version"); raw != "" {
+		opts.Version, err = parseID(raw)
+		if err != nil {
+			http.Error(w, "Invalid version", http.StatusBadRequest)
+			return
+		}
+	}
+	if raw := query.Get("downmix"); raw != "" {
+		downmix, err := strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "Invalid downmix flag", http.StatusBadRequest)
+			return
+		}
+		opts.Downmix = &downmix
+	}
+	if raw := query.Get("loudnorm"); raw != "" {
+		loudnorm, err := strconv.ParseBool(raw)
+		if err != nil {
+			http.Error(w, "Invalid loudnorm flag", http.StatusBadRequest)
+			return
+		}
+		opts.Loudnorm = &loudnorm
+	}
+
+	info, err := h.playbackService.GetPlaybackInfo(r.Context(), mediaType, id, opts)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
+
+// BeginSession registers a stream session for a movie or episode before
+// playback starts. When the user or the server is at the configured stream
+// limit the error names the user's active sessions, each of which can be
+// terminated via EndSession to free a slot. The client name comes from
+// ?client=.
+func (h *playbackHandler) BeginSession(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	mediaType := chi.URLParam(r, "mediaType")
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	session, err := h.streamService.Begin(r.Context(), user.ID, mediaType, id, r.URL.Query().Get("client"))
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(session)
+}
+
+// ListSessions returns the calling user's active stream sessions.
+func (h *playbackHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := h.streamService.List(r.Context(), user.ID)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+// HeartbeatSession keeps a stream session alive while playback continues;
+// sessions that stop heartbeating are reclaimed as idle.
+func (h *playbackHandler) HeartbeatSession(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.streamService.Heartbeat(r.Context(), user.ID, chi.URLParam(r, "sessionId")); err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EndSession terminates one of the calling user's stream sessions.
+func (h *playbackHandler) EndSession(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.streamService.End(r.Context(), user.ID, chi.URLParam(r, "sessionId")); err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *playbackHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.playbackService.ListProfiles(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+func (h *playbackHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	var req dto.ClientProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.playbackService.CreateProfile(r.Context(), &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (h *playbackHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid profile ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.ClientProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.playbackService.UpdateProfile(r.Context(), id, &req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (h *playbackHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid profile ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.playbackService.DeleteProfile(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+// ProfileHandler manages the household profiles of the calling account
+// and hands out profile-scoped tokens on selection.
+type ProfileHandler interface {
+	ListProfiles(w http.ResponseWriter, r *http.Request)
+	CreateProfile(w http.ResponseWriter, r *http.Request)
+	UpdateProfile(w http.ResponseWriter, r *http.Request)
+	DeleteProfile(w http.ResponseWriter, r *http.Request)
+	SelectProfile(w http.ResponseWriter, r *http.Request)
+}
+
+type profileHandler struct {
+	authSvc    service.AuthService
+	profileSvc service.ProfileService
+}
+
+func NewProfileHandler(authSvc service.AuthService, profileSvc service.ProfileService) ProfileHandler {
+	return &profileHandler{
+		authSvc:    authSvc,
+		profileSvc: profileSvc,
+	}
+}
+
+func (h *profileHandler) ListProfiles(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	profiles, err := h.profileSvc.ListProfiles(r.Context(), userFromCtx.ID)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profiles)
+}
+
+func (h *profileHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.CreateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.profileSvc.CreateProfile(r.Context(), userFromCtx.ID, &req)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (h *profileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	profileID, err := h.profileID(r)
+	if err != nil {
+		http.Error(w, "Invalid profile ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.profileSvc.UpdateProfile(r.Context(), userFromCtx.ID, profileID, &req)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(profile)
+}
+
+func (h *profileHandler) DeleteProfile(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	profileID, err := h.profileID(r)
+	if err != nil {
+		http.Error(w, "Invalid profile ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.profileSvc.DeleteProfile(r.Context(), userFromCtx.ID, profileID); err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SelectProfile issues a token scoped to the chosen profile; clients use
+// it for the rest of the session so personal lists follow the profile.
+func (h *profileHandler) SelectProfile(w http.ResponseWriter, r *http.Request) {
+	profileID, err := h.profileID(r)
+	if err != nil {
+		http.Error(w, "Invalid profile ID", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.authSvc.SelectProfile(r.Context(), profileID)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) {
+			writeLocalizedError(w, r, http.StatusNotFound, err)
+			return
+		}
+		writeLocalizedError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (h *profileHandler) profileID(r *http.Request) (uint, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "profileId"))
+	if err != nil || id <= 0 {
+		return 0, errors.ErrBadRequest
+	}
+	return uint(id), nil
+}
@@ -7,30 +7,43 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service"
 )
 
 type RatingHandler interface {
+	GetRatings(w http.ResponseWriter, r *http.Request)
 	AddRating(w http.ResponseWriter, r *http.Request)
 	RemoveRating(w http.ResponseWriter, r *http.Request)
 }
 
 type ratingHandler struct {
-	authSvc   services.AuthService
-	ratingSvc services.RatingService
+	authSvc   service.AuthService
+	ratingSvc service.RatingService
 }
 
-func NewRatingHandler(r chi.Router, authSvc services.AuthService, ratingSvc services.RatingService) RatingHandler {
-	hdl := &ratingHandler{
+func NewRatingHandler(authSvc service.AuthService, ratingSvc service.RatingService) RatingHandler {
+	return &ratingHandler{
 		authSvc:   authSvc,
 		ratingSvc: ratingSvc,
 	}
+}
 
-	r.Route("/user", func(r chi.Router) {
-		r.Post("/ratings", hdl.AddRating)
-		r.Delete("/likes/{id}", hdl.RemoveRating)
-	})
+// GetRatings returns the calling user's ratings.
+func (h *ratingHandler) GetRatings(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
 
-	return hdl
+	ratings, err := h.ratingSvc.GetRatings(r.Context(), userFromCtx.ID)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ratings)
 }
 
 func (h *ratingHandler) AddRating(w http.ResponseWriter, r *http.Request) {
@@ -48,13 +61,15 @@ func (h *ratingHandler) AddRating(w http.ResponseWriter, r *http.Request) {
 
 	item.UserID = userFromCtx.ID
 	if err := h.ratingSvc.AddRating(r.Context(), &item); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
 }
 
+// RemoveRating deletes the caller's rating for a media item; {id} is the
+// media ID and the media type comes from the media_type query parameter.
 func (h *ratingHandler) RemoveRating(w http.ResponseWriter, r *http.Request) {
 	userFromCtx, err := h.authSvc.GetUserFromContext(r.Context())
 	if err != nil {
@@ -62,15 +77,21 @@ func (h *ratingHandler) RemoveRating(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ratingIdParam := chi.URLParam(r, "id")
-	ratingId, err := strconv.Atoi(ratingIdParam)
+	mediaIdParam := chi.URLParam(r, "id")
+	mediaId, err := strconv.Atoi(mediaIdParam)
 	if err != nil {
-		http.Error(w, "Invalid rating ID", http.StatusBadRequest)
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return
+	}
+
+	mediaType := r.URL.Query().Get("media_type")
+	if mediaType != "movie" && mediaType != "series" {
+		http.Error(w, "Invalid media type", http.StatusBadRequest)
 		return
 	}
 
-	if err := h.ratingSvc.RemoveRating(r.Context(), userFromCtx.ID, uint(ratingId), ""); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.ratingSvc.RemoveRating(r.Context(), userFromCtx.ID, uint(mediaId), mediaType); err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type RecommendationHandler interface {
+	RegisterRoutes(r chi.Router)
+	GetRows(w http.ResponseWriter, r *http.Request)
+}
+
+type recommendationHandler struct {
+	recommendationService service.RecommendationService
+	jwtVerifier           *auth.JWTVerifier
+}
+
+func NewRecommendationHandler(recommendationService service.RecommendationService, jwtVerifier *auth.JWTVerifier) RecommendationHandler {
+	return &recommendationHandler{
+		recommendationService: recommendationService,
+		jwtVerifier:           jwtVerifier,
+	}
+}
+
+func (h *recommendationHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/recommendations", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireSession)
+			r.Get("/", h.GetRows)
+		})
+	})
+}
+
+// GetRows returns the user's "Because you watched…" rows.
+func (h *recommendationHandler) GetRows(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	rows, err := h.recommendationService.GetRows(r.Context(), user.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rows)
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service/scheduler"
+)
+
+type SchedulerHandler interface {
+	RegisterRoutes(r chi.Router)
+	RestoreDefaults(w http.ResponseWriter, r *http.Request)
+	GetMaintenanceWindow(w http.ResponseWriter, r *http.Request)
+	ListTasks(w http.ResponseWriter, r *http.Request)
+	CreateTask(w http.ResponseWriter, r *http.Request)
+	UpdateTask(w http.ResponseWriter, r *http.Request)
+	RunTask(w http.ResponseWriter, r *http.Request)
+	ListTaskRuns(w http.ResponseWriter, r *http.Request)
+}
+
+type schedulerHandler struct {
+	config       *config.Config
+	schedulerSvc scheduler.Scheduler
+	jwtVerifier  *auth.JWTVerifier
+}
+
+func NewSchedulerHandler(cfg *config.Config, schedulerSvc scheduler.Scheduler, jwtVerifier *auth.JWTVerifier) SchedulerHandler {
+	return &schedulerHandler{
+		config:       cfg,
+		schedulerSvc: schedulerSvc,
+		jwtVerifier:  jwtVerifier,
+	}
+}
+
+func (h *schedulerHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/scheduler", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireSession)
+			r.Post("/restore-defaults", h.RestoreDefaults)
+			r.Get("/maintenance-window", h.GetMaintenanceWindow)
+			r.Get("/tasks", h.ListTasks)
+			r.Post("/tasks", h.CreateTask)
+			r.Patch("/tasks/{id}", h.UpdateTask)
+			r.Post("/tasks/{id}/run", h.RunTask)
+			r.Get("/tasks/{id}/runs", h.ListTaskRuns)
+		})
+	})
+}
+
+// RestoreDefaults recreates the default scheduled tasks (admin only)
+func (h *schedulerHandler) RestoreDefaults(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	if err := h.schedulerSvc.RestoreDefaultTasks(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListTasks returns all scheduled tasks (admin only)
+func (h *schedulerHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	tasks, err := h.schedulerSvc.ListTasks(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.ScheduledTasksToDTO(tasks))
+}
+
+// CreateTask adds a user-defined scheduled task (admin only)
+func (h *schedulerHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	var req dto.CreateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.schedulerSvc.CreateTask(r.Context(), req.Name, req.Type, req.Interval, req.Config)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(dto.ScheduledTaskToDTO(task))
+}
+
+// RunTask triggers an immediate run of a task (admin only)
+func (h *schedulerHandler) RunTask(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.schedulerSvc.RunTaskNow(r.Context(), id); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ListTaskRuns returns a task's run history, newest first (admin only)
+func (h *schedulerHandler) ListTaskRuns(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	limit := 50
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.schedulerSvc.ListRuns(r.Context(), id, limit)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.TaskRunsToDTO(runs))
+}
+
+// UpdateTask edits a task's enabled state, interval, typed config, runtime
+// timeout or retry count; the config is validated against the task type's
+// schema (admin only)
+func (h *schedulerHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.schedulerSvc.UpdateTaskSettings(r.Context(), id, scheduler.TaskSettings{
+		Enabled:    req.Enabled,
+		Interval:   req.Interval,
+		Config:     req.Config,
+		Timeout:    req.Timeout,
+		MaxRetries: req.MaxRetries,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.ScheduledTaskToDTO(task))
+}
+
+// GetMaintenanceWindow returns the configured maintenance window and whether
+// it is currently active (admin only)
+func (h *schedulerHandler) GetMaintenanceWindow(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	resp := dto.MaintenanceWindowDTO{
+		Configured:   h.config.MaintenanceWindowConfigured(),
+		WindowStart:  h.config.Maintenance.WindowStart,
+		WindowEnd:    h.config.Maintenance.WindowEnd,
+		AllowRestart: h.config.Maintenance.AllowRestart,
+		Active:       h.config.InMaintenanceWindow(time.Now()),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
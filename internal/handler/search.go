@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type SearchHandler interface {
+	RegisterRoutes(r chi.Router)
+	Search(w http.ResponseWriter, r *http.Request)
+	Suggest(w http.ResponseWriter, r *http.Request)
+	Recent(w http.ResponseWriter, r *http.Request)
+	ClearRecent(w http.ResponseWriter, r *http.Request)
+}
+
+type searchHandler struct {
+	mediaService service.MediaService
+	jwtVerifier  *auth.JWTVerifier
+}
+
+func NewSearchHandler(mediaService service.MediaService, jwtVerifier *auth.JWTVerifier) SearchHandler {
+	return &searchHandler{
+		mediaService: mediaService,
+		jwtVerifier:  jwtVerifier,
+	}
+}
+
+func (h *searchHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/search", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
+			r.Get("/", h.Search)
+			r.Get("/suggest", h.Suggest)
+			r.Get("/recent", h.Recent)
+			r.Delete("/recent", h.ClearRecent)
+		})
+	})
+}
+
+// Search runs an advanced library search. The q parameter mixes free text
+// with field filters, e.g. `genre:horror year:1980..1989 unwatched:true`.
+func (h *searchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	params := &dto.SearchParams{Query: query.Get("q")}
+	if params.Query == "" {
+		http.Error(w, "Search query 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, fmt.Sprintf("Invalid limit '%s'", raw), http.StatusBadRequest)
+			return
+		}
+		params.Limit = limit
+	}
+	if raw := query.Get("offset"); raw != "" {
+		offset, err := strconv.Atoi(raw)
+		if err != nil || offset < 0 {
+			http.Error(w, fmt.Sprintf("Invalid offset '%s'", raw), http.StatusBadRequest)
+			return
+		}
+		params.Offset = offset
+	}
+
+	results, err := h.mediaService.Search(r.Context(), params)
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrBadRequest):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, errors.ErrUnauthorized):
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(results)
+}
+
+// Suggest returns the top fuzzy title matches for the live-search
+// dropdown.
+func (h *searchHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := query.Get("q")
+	if q == "" {
+		http.Error(w, "Search query 'q' is required", http.StatusBadRequest)
+		return
+	}
+
+	limit := 0
+	if raw := query.Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			http.Error(w, fmt.Sprintf("Invalid limit '%s'", raw), http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	suggestions, err := h.mediaService.Suggest(r.Context(), q, limit)
+	if err != nil {
+		if errors.Is(err, errors.ErrBadRequest) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(suggestions)
+}
+
+// Recent returns the user's most recent search queries, newest first.
+func (h *searchHandler) Recent(w http.ResponseWriter, r *http.Request) {
+	recent, err := h.mediaService.RecentSearches(r.Context())
+	if err != nil {
+		if errors.Is(err, errors.ErrUnauthorized) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(recent)
+}
+
+// ClearRecent wipes the user's search history.
+func (h *searchHandler) ClearRecent(w http.ResponseWriter, r *http.Request) {
+	if err := h.mediaService.ClearSearchHistory(r.Context()); err != nil {
+		if errors.Is(err, errors.ErrUnauthorized) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -4,11 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/samcharles93/cinea/internal/auth"
-	"github.com/samcharles93/cinea/internal/repository"
-	"github.com/samcharles93/cinea/internal/services/metadata"
+	"github.com/samcharles93/cinea/internal/service"
+	"github.com/samcharles93/cinea/internal/service/metadata"
 )
 
 type SeriesHandler interface {
@@ -41,26 +42,37 @@ func (h *seriesHandler) RegisterRoutes(r chi.Router) {
 		// Protected Routes
 		r.Group(func(r chi.Router) {
 			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeReadMedia))
 			r.Get("/", h.List)
 			r.Get("/{id}", h.Get)
 			r.Get("/{id}/seasons", h.ListSeasons)
 			r.Get("/{id}/seasons/{seasonNumber}", h.GetSeason)
 			r.Get("/{id}/seasons/{seasonNumber}/episodes", h.ListEpisodes)
 			r.Get("/{id}/seasons/{seasonNumber}/episodes/{episodeNumber}", h.GetEpisode)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(auth.RequireScope(auth.ScopeStream))
 			r.Get("/{id}/episodes/{episodeId}/stream", h.StreamEpisode)
 		})
 	})
 }
 
 func (h *seriesHandler) List(w http.ResponseWriter, r *http.Request) {
-	shows, err := h.mediaService.GetAllSeries(r.Context())
+	params, err := parseListParams(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.mediaService.ListSeries(r.Context(), params)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(shows)
+	json.NewEncoder(w).Encode(page)
 }
 
 func (h *seriesHandler) Get(w http.ResponseWriter, r *http.Request) {
@@ -70,9 +82,9 @@ func (h *seriesHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	show, err := h.mediaService.GetSeriesByID(r.Context(), id)
+	show, err := h.mediaService.GetSeriesByID(r.Context(), id, requestLanguage(r))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
@@ -94,7 +106,7 @@ func (h *seriesHandler) ListSeasons(w http.ResponseWriter, r *http.Request) {
 
 	seasons, err := h.mediaService.GetAllSeasons(r.Context(), id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
@@ -117,7 +129,7 @@ func (h *seriesHandler) GetSeason(w http.ResponseWriter, r *http.Request) {
 
 	season, err := h.mediaService.GetSeasonByNumber(r.Context(), seriesID, seasonNumber)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
@@ -129,7 +141,7 @@ func (h *seriesHandler) GetSeason(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(season)
 }
---
+
 func (h *seriesHandler) ListEpisodes(w http.ResponseWriter, r *http.Request) {
 	seriesID, err := parseID(chi.URLParam(r, "id"))
 	if err != nil {
@@ -146,7 +158,7 @@ func (h *seriesHandler) ListEpisodes(w http.ResponseWriter, r *http.Request) {
 	// First get the season to get its ID
 	season, err := h.mediaService.GetSeasonByNumber(r.Context(), seriesID, seasonNumber)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
@@ -157,7 +169,7 @@ func (h *seriesHandler) ListEpisodes(w http.ResponseWriter, r *http.Request) {
 
 	episodes, err := h.mediaService.GetAllEpisodes(r.Context(), season.ID, seriesID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
@@ -186,7 +198,7 @@ func (h *seriesHandler) GetEpisode(w http.ResponseWriter, r *http.Request) {
 
 	episode, err := h.mediaService.GetEpisodeByNumber(r.Context(), seriesID, seasonNumber, episodeNumber)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeLocalizedError(w, r, statusForError(err), err)
 		return
 	}
 
@@ -212,3 +224,23 @@ func parseID(idStr string) (uint, error) {
 	}
 	return uint(id64), nil
 }
+
+// requestLanguage picks the metadata language for a request: the "lang"
+// query parameter wins, then the first Accept-Language tag. An empty
+// result means the caller's stored preference (or the default) applies.
+func requestLanguage(r *http.Request) string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return lang
+	}
+
+	header := r.Header.Get("Accept-Language")
+	if header == "" {
+		return ""
+	}
+	first := strings.Split(header, ",")[0]
+	first = strings.TrimSpace(strings.Split(first, ";")[0])
+	if first == "*" {
+		return ""
+	}
+	return first
+}
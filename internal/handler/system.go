@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+	"github.com/samcharles93/cinea/internal/service"
+	"github.com/samcharles93/cinea/internal/version"
+)
+
+type SystemHandler interface {
+	RegisterRoutes(r chi.Router)
+	PublicInfo(w http.ResponseWriter, r *http.Request)
+	Health(w http.ResponseWriter, r *http.Request)
+}
+
+type systemHandler struct {
+	config          *config.Config
+	userService     service.UserService
+	oidcService     service.OIDCService
+	brandingService service.BrandingService
+	ffmpegService   ffmpeg.Service
+}
+
+func NewSystemHandler(cfg *config.Config, userService service.UserService, oidcService service.OIDCService, brandingService service.BrandingService, ffmpegService ffmpeg.Service) SystemHandler {
+	return &systemHandler{
+		config:          cfg,
+		userService:     userService,
+		oidcService:     oidcService,
+		brandingService: brandingService,
+		ffmpegService:   ffmpegService,
+	}
+}
+
+func (h *systemHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/system", func(r chi.Router) {
+		// Deliberately unauthenticated: clients read this before login
+		r.Get("/info/public", h.PublicInfo)
+		// Deliberately unauthenticated: load balancers and monitors poll it
+		r.Get("/health", h.Health)
+	})
+}
+
+// Health reports liveness plus the transcoder's state, including the
+// detected FFmpeg version and where the binaries came from.
+func (h *systemHandler) Health(w http.ResponseWriter, r *http.Request) {
+	resp := dto.SystemHealthDTO{
+		Status:  "ok",
+		Version: version.Version,
+		Transcoder: dto.TranscoderHealthDTO{
+			Available: h.ffmpegService.Available(),
+			Version:   h.ffmpegService.Version(),
+			Source:    h.ffmpegService.Source(),
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// PublicInfo identifies the server to clients that have not logged in yet:
+// its name, build version, whether first-run setup has created an account,
+// and which login methods are available.
+func (h *systemHandler) PublicInfo(w http.ResponseWriter, r *http.Request) {
+	userCount, err := h.userService.CountUsers(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	methods := []string{"local"}
+	if h.oidcService.Enabled() {
+		methods = append(methods, "oidc")
+	}
+
+	info := dto.PublicSystemInfoDTO{
+		ServerName:     h.brandingService.ServerName(r.Context()),
+		Version:        version.Version,
+		SetupCompleted: userCount > 0,
+		AuthMethods:    methods,
+	}
+
+	// Branding is best-effort; a lookup failure should not hide the server
+	if branding, err := h.brandingService.Get(r.Context()); err == nil {
+		info.Branding = branding
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
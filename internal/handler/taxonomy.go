@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type TaxonomyHandler interface {
+	RegisterRoutes(r chi.Router)
+	ListGenres(w http.ResponseWriter, r *http.Request)
+	ListStudios(w http.ResponseWriter, r *http.Request)
+	ListTags(w http.ResponseWriter, r *http.Request)
+	CreateTag(w http.ResponseWriter, r *http.Request)
+	TagMedia(w http.ResponseWriter, r *http.Request)
+	UntagMedia(w http.ResponseWriter, r *http.Request)
+}
+
+type taxonomyHandler struct {
+	taxonomyService service.TaxonomyService
+	jwtVerifier     *auth.JWTVerifier
+}
+
+func NewTaxonomyHandler(taxonomyService service.TaxonomyService, jwtVerifier *auth.JWTVerifier) TaxonomyHandler {
+	return &taxonomyHandler{
+		taxonomyService: taxonomyService,
+		jwtVerifier:     jwtVerifier,
+	}
+}
+
+func (h *taxonomyHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Use(h.jwtVerifier.Verify)
+		r.Use(auth.RequireScope(auth.ScopeReadMedia))
+		r.Get("/genres", h.ListGenres)
+		r.Get("/studios", h.ListStudios)
+		r.Get("/tags", h.ListTags)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(h.jwtVerifier.Verify)
+		r.Use(auth.RequireScope(auth.ScopeWriteMetadata))
+		r.Route("/tags", func(r chi.Router) {
+			r.Post("/", h.CreateTag)
+			r.Post("/{id}/media/{mediaType}/{mediaId}", h.TagMedia)
+			r.Delete("/{id}/media/{mediaType}/{mediaId}", h.UntagMedia)
+		})
+	})
+}
+
+func (h *taxonomyHandler) ListGenres(w http.ResponseWriter, r *http.Request) {
+	genres, err := h.taxonomyService.ListGenres(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(genres)
+}
+
+func (h *taxonomyHandler) ListStudios(w http.ResponseWriter, r *http.Request) {
+	studios, err := h.taxonomyService.ListStudios(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(studios)
+}
+
+func (h *taxonomyHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.taxonomyService.ListTags(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tags)
+}
+
+func (h *taxonomyHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.CreateTagDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	tag, err := h.taxonomyService.CreateTag(r.Context(), req.Name, user.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(tag)
+}
+
+func (h *taxonomyHandler) TagMedia(w http.ResponseWriter, r *http.Request) {
+	tagID, mediaType, mediaID, ok := h.tagMediaParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.taxonomyService.TagMedia(r.Context(), mediaType, mediaID, tagID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *taxonomyHandler) UntagMedia(w http.ResponseWriter, r *http.Request) {
+	tagID, mediaType, mediaID, ok := h.tagMediaParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.taxonomyService.UntagMedia(r.Context(), mediaType, mediaID, tagID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *taxonomyHandler) tagMediaParams(w http.ResponseWriter, r *http.Request) (uint, string, uint, bool) {
+	tagID, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return 0, "", 0, false
+	}
+
+	mediaID, err := parseID(chi.URLParam(r, "mediaId"))
+	if err != nil {
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return 0, "", 0, false
+	}
+
+	return tagID, chi.URLParam(r, "mediaType"), mediaID, true
+}
@@ -9,56 +9,103 @@ import (
 	"github.com/samcharles93/cinea/internal/auth"
 	"github.com/samcharles93/cinea/internal/dto"
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/service"
+	"github.com/samcharles93/cinea/internal/upload"
 )
 
 type UserHandler interface {
 	RegisterRoutes(r chi.Router)
 	AdminGetUsers(w http.ResponseWriter, r *http.Request)
 	AdminDeleteUser(w http.ResponseWriter, r *http.Request)
+	AdminImpersonateUser(w http.ResponseWriter, r *http.Request)
+	AdminUpdateRestrictions(w http.ResponseWriter, r *http.Request)
 
 	UpdateLastSeen(w http.ResponseWriter, r *http.Request)
 	UpdateUserProfile(w http.ResponseWriter, r *http.Request)
-
-	AddToWatchHistory(w http.ResponseWriter, r *http.Request)
-	ClearHistory(w http.ResponseWriter, r *http.Request)
+	UploadAvatar(w http.ResponseWriter, r *http.Request)
+	GetAvatar(w http.ResponseWriter, r *http.Request)
+	GetPreferences(w http.ResponseWriter, r *http.Request)
+	UpdatePreferences(w http.ResponseWriter, r *http.Request)
 }
 
 type userHandler struct {
-	authSvc services.AuthService
-	userSvc services.UserService
+	authSvc         service.AuthService
+	userSvc         service.UserService
+	jwtVerifier     *auth.JWTVerifier
+	watchlistHdl    WatchlistHandler
+	watchHistoryHdl WatchHistoryHandler
+	favoriteHdl     FavoriteHandler
+	ratingHdl       RatingHandler
+	profileHdl      ProfileHandler
 }
 
-func NewUserHandler(authSvc services.AuthService, userSvc services.UserService) UserHandler {
+func NewUserHandler(
+	authSvc service.AuthService,
+	userSvc service.UserService,
+	jwtVerifier *auth.JWTVerifier,
+	watchlistHdl WatchlistHandler,
+	watchHistoryHdl WatchHistoryHandler,
+	favoriteHdl FavoriteHandler,
+	ratingHdl RatingHandler,
+	profileHdl ProfileHandler,
+) UserHandler {
 	return &userHandler{
-		authSvc: authSvc,
-		userSvc: userSvc,
+		authSvc:         authSvc,
+		userSvc:         userSvc,
+		jwtVerifier:     jwtVerifier,
+		watchlistHdl:    watchlistHdl,
+		watchHistoryHdl: watchHistoryHdl,
+		favoriteHdl:     favoriteHdl,
+		ratingHdl:       ratingHdl,
+		profileHdl:      profileHdl,
 	}
 }
 
 func (h *userHandler) RegisterRoutes(r chi.Router) {
 	r.Route("/user", func(r chi.Router) {
 		r.Use(h.jwtVerifier.Verify)
+		// Account management and personal lists are never reachable with
+		// an API key, whatever its scopes
+		r.Use(auth.RequireSession)
 
 		r.Get("/", h.AdminGetUsers)
 		r.Delete("/{userId}", h.AdminDeleteUser)
+		r.Post("/{userId}/impersonate", h.AdminImpersonateUser)
+		r.Patch("/{userId}/restrictions", h.AdminUpdateRestrictions)
 		// r.Post("/", h.AdminCreateUser)
 		// r.Patch("/{userId}", h.AdminUpdateUser)
 		// r.Post("/{userId}/roles", h.AdminUpdateUserRole)
 
 		// r.Post("/verify/{verificationToken}", h.VerifyEmail)
 		r.Patch("/{userId}", h.UpdateUserProfile)
+		r.With(upload.MaxBytes(upload.MaxImageSize)).Put("/avatar", h.UploadAvatar)
+		r.Get("/{userId}/avatar", h.GetAvatar)
+		r.Get("/preferences", h.GetPreferences)
+		r.Patch("/preferences", h.UpdatePreferences)
 		r.Post("/last-seen", h.UpdateLastSeen)
 
-		r.Post("/watchlist", h.AddToWatchlist)
-		// r.Delete("/watchlist/{watchlistId}", h.RemoveFromWatchlist)
+		r.Post("/watchlist", h.watchlistHdl.AddToWatchlist)
+		// r.Delete("/watchlist/{watchlistId}", h.watchlistHdl.RemoveFromWatchlist)
+
+		r.Post("/history", h.watchHistoryHdl.AddToWatchHistory)
+		r.Post("/watched", h.watchHistoryHdl.MarkWatched)
+		r.Delete("/watched", h.watchHistoryHdl.MarkUnwatched)
+		r.Delete("/history", h.watchHistoryHdl.ClearHistory)
 
-		r.Post("/history", h.AddToWatchHistory)
-		r.Delete("/history", h.ClearHistory)
+		r.Get("/favorites", h.favoriteHdl.GetFavorites)
+		r.Post("/favorites", h.favoriteHdl.AddToFavorites)
+		r.Delete("/favorites/{favoriteId}", h.favoriteHdl.RemoveFromFavorites)
 
-		r.Get("/favorites", h.GetFavorites)
-		r.Post("/favorites", h.AddToFavorites)
-		r.Delete("/favorites/{favoriteId}", h.RemoveFromFavorites)
+		r.Get("/ratings", h.ratingHdl.GetRatings)
+		r.Post("/ratings", h.ratingHdl.AddRating)
+		r.Delete("/ratings/{id}", h.ratingHdl.RemoveRating)
 
+		r.Get("/profiles", h.profileHdl.ListProfiles)
+		r.Post("/profiles", h.profileHdl.CreateProfile)
+		r.Patch("/profiles/{profileId}", h.profileHdl.UpdateProfile)
+		r.Delete("/profiles/{profileId}", h.profileHdl.DeleteProfile)
+		r.Post("/profiles/{profileId}/select", h.profileHdl.SelectProfile)
 	})
 }
 
@@ -70,12 +117,12 @@ func (h *userHandler) AdminGetUsers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Check user is an admin
-	if string(userFromCtx.Role) != string(entity.RoleAdmin) {
+	if userFromCtx.Role != entity.RoleAdmin {
 		http.Error(w, "Insufficient access", http.StatusForbidden)
 		return
 	}
 
-	users, err := h.userRepo.AdminGetUsers()
+	users, err := h.userSvc.ListUsers(r.Context())
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -97,7 +144,7 @@ func (h *userHandler) AdminDeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if string(userFromCtx.Role) != string(entity.RoleAdmin) {
+	if userFromCtx.Role != entity.RoleAdmin {
 		http.Error(w, "Insufficient access", http.StatusForbidden)
 		return
 	}
@@ -108,7 +155,7 @@ func (h *userHandler) AdminDeleteUser(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid user ID", http.StatusBadRequest)
 		return
 	}
-	if err := h.userRepo.Delete(r.Context(), uint(userId)); err != nil {
+	if err := h.userSvc.DeleteUser(r.Context(), uint(userId)); err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -116,6 +163,95 @@ func (h *userHandler) AdminDeleteUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// AdminImpersonateUser issues a short-lived token acting as the target
+// user for troubleshooting. The grant is audit-logged and clients revert
+// by discarding the token.
+func (h *userHandler) AdminImpersonateUser(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	// An impersonated session must not mint further impersonation tokens
+	if auth.GetImpersonatorFromContext(r.Context()) != nil {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	userIdParam := chi.URLParam(r, "userId")
+	userId, err := strconv.Atoi(userIdParam)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.authSvc.Impersonate(r.Context(), uint(userId))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// AdminUpdateRestrictions sets a user's parental controls: maximum
+// content rating, tag blocklist and override PIN.
+func (h *userHandler) AdminUpdateRestrictions(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if userFromCtx.Role != entity.RoleAdmin {
+		http.Error(w, "Insufficient access", http.StatusForbidden)
+		return
+	}
+
+	userIdParam := chi.URLParam(r, "userId")
+	userId, err := strconv.Atoi(userIdParam)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.UpdateRestrictionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userSvc.UpdateRestrictions(r.Context(), uint(userId), &req)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.UserToDTO(user))
+}
+
+// writeError maps service sentinels onto the localized error envelope.
+func (h *userHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, errors.ErrNotFound):
+		writeLocalizedError(w, r, http.StatusNotFound, err)
+	case errors.Is(err, errors.ErrForbidden):
+		writeLocalizedError(w, r, http.StatusForbidden, err)
+	case errors.Is(err, errors.ErrBadRequest):
+		writeLocalizedError(w, r, http.StatusBadRequest, err)
+	default:
+		writeLocalizedError(w, r, http.StatusInternalServerError, err)
+	}
+}
+
 func (h *userHandler) UpdateLastSeen(w http.ResponseWriter, r *http.Request) {
 	userFromCtx, err := auth.GetUserFromContext(r.Context())
 	if err != nil {
@@ -123,7 +259,7 @@ func (h *userHandler) UpdateLastSeen(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.userRepo.UpdateLastLogin(r.Context(), userFromCtx.ID)
+	err = h.userSvc.UpdateLastSeen(r.Context(), userFromCtx.ID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -132,7 +268,91 @@ func (h *userHandler) UpdateLastSeen(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// UploadAvatar replaces the calling user's avatar with the uploaded
+// image. The body is the raw image; validation, metadata stripping and
+// resizing happen in the service.
+func (h *userHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := h.userSvc.SetAvatar(r.Context(), userFromCtx.ID, r.Body)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.UserToDTO(user))
+}
+
+// GetAvatar serves a user's avatar image. Any authenticated household
+// member can fetch it, so the user manager and activity feed can show
+// avatars next to names.
+func (h *userHandler) GetAvatar(w http.ResponseWriter, r *http.Request) {
+	userIdParam := chi.URLParam(r, "userId")
+	userId, err := strconv.Atoi(userIdParam)
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	path, err := h.userSvc.AvatarPath(r.Context(), uint(userId))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	if path == "" {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+
+	// Avatars change between uploads, so clients revalidate rather than
+	// caching forever
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeFile(w, r, path)
+}
+
 func (h *userHandler) UpdateUserProfile(w http.ResponseWriter, r *http.Request) {
 	// TODO: Implement UpdateUserProfile
 	w.WriteHeader(http.StatusNotImplemented)
 }
+
+// UpdatePreferences applies a partial update to the calling user's
+// preferences (e.g. prefer audio description / SDH subtitles).
+func (h *userHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	userFromCtx, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.userSvc.UpdatePreferences(r.Context(), userFromCtx.ID, &req)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.UserToDTO(user))
+}
+
+// GetPreferences returns the authenticated user's stored preferences.
+func (h *userHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	user, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.UserToDTO(user))
+}
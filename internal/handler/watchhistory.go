@@ -1,23 +1,29 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
+	"github.com/samcharles93/cinea/internal/dto"
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/service"
 )
 
 type WatchHistoryHandler interface {
 	AddToWatchHistory(w http.ResponseWriter, r *http.Request)
 	ClearHistory(w http.ResponseWriter, r *http.Request)
+	MarkWatched(w http.ResponseWriter, r *http.Request)
+	MarkUnwatched(w http.ResponseWriter, r *http.Request)
 }
 
 type watchHistoryHandler struct {
-	authSvc         services.AuthService
-	watchHistorySvc services.WatchHistoryService
+	authSvc         service.AuthService
+	watchHistorySvc service.WatchHistoryService
 }
 
-func NewWatchHistoryHandler(authSvc services.AuthService, watchHistoryService services.WatchHistoryService) WatchHistoryHandler {
+func NewWatchHistoryHandler(authSvc service.AuthService, watchHistoryService service.WatchHistoryService) WatchHistoryHandler {
 	return &watchHistoryHandler{
 		authSvc:         authSvc,
 		watchHistorySvc: watchHistoryService,
@@ -62,3 +68,43 @@ func (h *watchHistoryHandler) ClearHistory(w http.ResponseWriter, r *http.Reques
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(history)
 }
+
+// MarkWatched marks a movie, episode, season or series as watched for the
+// authenticated user.
+func (h *watchHistoryHandler) MarkWatched(w http.ResponseWriter, r *http.Request) {
+	h.mark(w, r, h.watchHistorySvc.MarkWatched)
+}
+
+// MarkUnwatched removes a movie, episode, season or series from the
+// authenticated user's watch history.
+func (h *watchHistoryHandler) MarkUnwatched(w http.ResponseWriter, r *http.Request) {
+	h.mark(w, r, h.watchHistorySvc.MarkUnwatched)
+}
+
+func (h *watchHistoryHandler) mark(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, userID uint, mediaType string, mediaID uint) error) {
+	userFromCtx, err := h.authSvc.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.MarkWatchedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := apply(r.Context(), userFromCtx.ID, req.MediaType, req.MediaID); err != nil {
+		switch {
+		case errors.Is(err, errors.ErrBadRequest):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, errors.ErrNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service"
 )
 
 type WatchlistHandler interface {
@@ -12,11 +13,11 @@ type WatchlistHandler interface {
 }
 
 type watchlistHandler struct {
-	authSvc      services.AuthService
-	watchlistSvc services.WatchlistService
+	authSvc      service.AuthService
+	watchlistSvc service.WatchlistService
 }
 
-func NewWatchlistHandler(authSvc services.AuthService, watchlistSvc services.WatchlistService) WatchlistHandler {
+func NewWatchlistHandler(authSvc service.AuthService, watchlistSvc service.WatchlistService) WatchlistHandler {
 	return &watchlistHandler{
 		authSvc:      authSvc,
 		watchlistSvc: watchlistSvc,
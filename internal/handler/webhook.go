@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service"
+)
+
+type WebhookHandler interface {
+	RegisterRoutes(r chi.Router)
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	UpdateWebhook(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	ListDeliveries(w http.ResponseWriter, r *http.Request)
+	ListEventTypes(w http.ResponseWriter, r *http.Request)
+}
+
+type webhookHandler struct {
+	webhookService service.WebhookService
+	jwtVerifier    *auth.JWTVerifier
+}
+
+func NewWebhookHandler(webhookService service.WebhookService, jwtVerifier *auth.JWTVerifier) WebhookHandler {
+	return &webhookHandler{
+		webhookService: webhookService,
+		jwtVerifier:    jwtVerifier,
+	}
+}
+
+func (h *webhookHandler) RegisterRoutes(r chi.Router) {
+	r.Route("/webhooks", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(h.jwtVerifier.Verify)
+			r.Use(h.requireAdmin)
+			r.Use(auth.RequireSession)
+			r.Get("/", h.ListWebhooks)
+			r.Post("/", h.CreateWebhook)
+			r.Get("/events", h.ListEventTypes)
+			r.Patch("/{id}", h.UpdateWebhook)
+			r.Delete("/{id}", h.DeleteWebhook)
+			r.Get("/{id}/deliveries", h.ListDeliveries)
+		})
+	})
+}
+
+// requireAdmin restricts webhook management to administrators
+func (h *webhookHandler) requireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := auth.GetUserFromContext(r.Context())
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if user.Role != entity.RoleAdmin {
+			http.Error(w, "Insufficient access", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *webhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookService.List(r.Context())
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhooks)
+}
+
+func (h *webhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req dto.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.webhookService.Create(r.Context(), &req)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+func (h *webhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	webhook, err := h.webhookService.Update(r.Context(), id, &req)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(webhook)
+}
+
+func (h *webhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.webhookService.Delete(r.Context(), id); err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries returns a webhook's recent delivery log, newest first.
+func (h *webhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := parseID(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid webhook ID", http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(r.Context(), id)
+	if err != nil {
+		writeLocalizedError(w, r, statusForError(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(deliveries)
+}
+
+// ListEventTypes returns the event types webhooks may subscribe to.
+func (h *webhookHandler) ListEventTypes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.webhookService.EventTypes())
+}
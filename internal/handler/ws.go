@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/ws"
+)
+
+type WSHandler interface {
+	RegisterRoutes(r chi.Router)
+	Serve(w http.ResponseWriter, r *http.Request)
+}
+
+type wsHandler struct {
+	hub         *ws.Hub
+	jwtVerifier *auth.JWTVerifier
+}
+
+func NewWSHandler(hub *ws.Hub, jwtVerifier *auth.JWTVerifier) WSHandler {
+	return &wsHandler{
+		hub:         hub,
+		jwtVerifier: jwtVerifier,
+	}
+}
+
+func (h *wsHandler) RegisterRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Use(h.jwtVerifier.Verify)
+		r.Use(auth.RequireSession)
+		r.Get("/ws", h.Serve)
+	})
+}
+
+// Serve upgrades the request to a WebSocket and streams bus events.
+func (h *wsHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	h.hub.ServeHTTP(w, r)
+}
@@ -0,0 +1,88 @@
+// Package i18n holds the message catalogs used to localize user-facing
+// API strings such as error messages. Catalogs are small, hand-maintained
+// maps keyed by message ID; unknown locales and missing messages fall
+// back to English.
+package i18n
+
+import "strings"
+
+// DefaultLocale is the catalog used when no requested locale matches.
+const DefaultLocale = "en"
+
+var catalogs = map[string]map[string]string{
+	"en": {
+		"error.not_found":           "The requested resource was not found.",
+		"error.unauthorized":        "Authentication is required.",
+		"error.invalid_credentials": "Username or password is incorrect.",
+		"error.forbidden":           "You do not have access to this resource.",
+		"error.restricted_content":  "This content is restricted for your account.",
+		"error.bad_request":         "The request is invalid.",
+		"error.already_exists":      "The resource already exists.",
+		"error.quota_exceeded":      "Your usage quota has been exceeded.",
+		"error.internal":            "An internal error occurred.",
+	},
+	"de": {
+		"error.not_found":           "Die angeforderte Ressource wurde nicht gefunden.",
+		"error.unauthorized":        "Anmeldung erforderlich.",
+		"error.invalid_credentials": "Benutzername oder Passwort ist falsch.",
+		"error.forbidden":           "Sie haben keinen Zugriff auf diese Ressource.",
+		"error.restricted_content":  "Dieser Inhalt ist für Ihr Konto gesperrt.",
+		"error.bad_request":         "Die Anfrage ist ungültig.",
+		"error.already_exists":      "Die Ressource existiert bereits.",
+		"error.quota_exceeded":      "Ihr Nutzungskontingent ist aufgebraucht.",
+		"error.internal":            "Ein interner Fehler ist aufgetreten.",
+	},
+	"fr": {
+		"error.not_found":           "La ressource demandée est introuvable.",
+		"error.unauthorized":        "Authentification requise.",
+		"error.invalid_credentials": "Nom d'utilisateur ou mot de passe incorrect.",
+		"error.forbidden":           "Vous n'avez pas accès à cette ressource.",
+		"error.restricted_content":  "Ce contenu est restreint pour votre compte.",
+		"error.bad_request":         "La requête est invalide.",
+		"error.already_exists":      "La ressource existe déjà.",
+		"error.quota_exceeded":      "Votre quota d'utilisation est dépassé.",
+		"error.internal":            "Une erreur interne s'est produite.",
+	},
+}
+
+// Match picks the catalog locale for a request: the caller's stored
+// preference wins, then the tags of the Accept-Language header in order,
+// then English. Region subtags are ignored ("de-AT" matches "de").
+func Match(preferred, acceptLanguage string) string {
+	if locale, ok := normalize(preferred); ok {
+		return locale
+	}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag := strings.TrimSpace(strings.Split(part, ";")[0])
+		if locale, ok := normalize(tag); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// T returns the message for key in the given locale, falling back to
+// English and finally to the key itself.
+func T(locale, key string) string {
+	if msg, ok := catalogs[locale][key]; ok {
+		return msg
+	}
+	if msg, ok := catalogs[DefaultLocale][key]; ok {
+		return msg
+	}
+	return key
+}
+
+// normalize reduces a language tag to a catalog locale, reporting whether
+// a catalog exists for it.
+func normalize(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" || tag == "*" {
+		return "", false
+	}
+	base := strings.Split(tag, "-")[0]
+	if _, ok := catalogs[base]; ok {
+		return base, true
+	}
+	return "", false
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -26,6 +27,41 @@ type logger struct {
 	zlog zerolog.Logger
 }
 
+// contextKey is a custom type for context keys
+type contextKey string
+
+// requestIDKey stores the ID assigned by the HTTP middleware so logs
+// deeper in the stack can correlate to the incoming request
+const requestIDKey contextKey = "requestID"
+
+// WithRequestID stores a request ID in the context.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID stored in the context, or "" when the
+// work didn't originate from an HTTP request.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// remoteIPKey stores the client address resolved by the HTTP middleware
+// so the audit log can record where an action came from
+const remoteIPKey contextKey = "remoteIP"
+
+// WithRemoteIP stores the client address in the context.
+func WithRemoteIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, remoteIPKey, ip)
+}
+
+// RemoteIP returns the client address stored in the context, or "" when
+// the work didn't originate from an HTTP request.
+func RemoteIP(ctx context.Context) string {
+	ip, _ := ctx.Value(remoteIPKey).(string)
+	return ip
+}
+
 func NewLogger(cfg *config.Config) (Logger, error) {
 	logDir, err := getLogDirectory()
 	if err != nil {
@@ -58,6 +94,12 @@ func NewLogger(cfg *config.Config) (Logger, error) {
 	return &logger{zlog: zlog}, nil
 }
 
+// NewNop returns a Logger that discards everything, for tests and
+// benchmarks that don't care about output.
+func NewNop() Logger {
+	return &logger{zlog: zerolog.Nop()}
+}
+
 func getLogDirectory() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
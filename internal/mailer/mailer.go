@@ -0,0 +1,68 @@
+// Package mailer sends outgoing email through the configured SMTP server.
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/logger"
+)
+
+// Service sends plain-text email. When no SMTP server is configured the
+// service reports itself disabled and Send returns an error, so callers can
+// fall back to logging instead.
+type Service interface {
+	Enabled() bool
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+type service struct {
+	config    *config.Config
+	appLogger logger.Logger
+}
+
+func NewService(cfg *config.Config, appLogger logger.Logger) Service {
+	return &service{
+		config:    cfg,
+		appLogger: appLogger,
+	}
+}
+
+func (s *service) Enabled() bool {
+	return s.config.MailEnabled()
+}
+
+// Send delivers a plain-text message via SMTP.
+func (s *service) Send(ctx context.Context, to, subject, body string) error {
+	if !s.Enabled() {
+		return fmt.Errorf("outgoing mail is not configured")
+	}
+
+	smtpCfg := s.config.Mail.SMTP
+	addr := fmt.Sprintf("%s:%d", smtpCfg.Host, s.config.SMTPPort())
+
+	var auth smtp.Auth
+	if smtpCfg.Username != "" {
+		auth = smtp.PlainAuth("", smtpCfg.Username, smtpCfg.Password, smtpCfg.Host)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", smtpCfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(body)
+
+	if err := smtp.SendMail(addr, auth, smtpCfg.From, []string{to}, []byte(msg.String())); err != nil {
+		s.appLogger.Error().Err(err).Str("to", to).Msg("Failed to send mail")
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+
+	s.appLogger.Info().Str("to", to).Str("subject", subject).Msg("Mail sent")
+	return nil
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Channel delivers a notification to one external target (Discord,
+// Telegram, Gotify, ...). Implementations are built from the YAML config
+// at startup; a target with no configuration is simply not constructed.
+type Channel interface {
+	Name() string
+	Notify(ctx context.Context, event Event) error
+}
+
+// channelClient is shared by all channel implementations; external
+// notification targets get one timeout and no retries — the webhook
+// subsystem covers delivery guarantees for integrations that need them.
+var channelClient = &http.Client{Timeout: 10 * time.Second}
+
+// postJSON sends a JSON payload and treats any non-2xx response as an
+// error.
+func postJSON(ctx context.Context, url string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := channelClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint returned %d", resp.StatusCode)
+	}
+	return nil
+}
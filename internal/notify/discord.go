@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// discordChannel posts notifications to a Discord webhook URL.
+type discordChannel struct {
+	webhookURL string
+}
+
+func newDiscordChannel(webhookURL string) Channel {
+	return &discordChannel{webhookURL: webhookURL}
+}
+
+func (c *discordChannel) Name() string {
+	return "discord"
+}
+
+func (c *discordChannel) Notify(ctx context.Context, event Event) error {
+	content := fmt.Sprintf("**%s**\n%s", event.Title, event.Message)
+	return postJSON(ctx, c.webhookURL, map[string]string{"content": content})
+}
@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// gotifyChannel pushes notifications to a Gotify server.
+type gotifyChannel struct {
+	serverURL string
+	token     string
+}
+
+func newGotifyChannel(serverURL, token string) Channel {
+	return &gotifyChannel{serverURL: strings.TrimRight(serverURL, "/"), token: token}
+}
+
+func (c *gotifyChannel) Name() string {
+	return "gotify"
+}
+
+func (c *gotifyChannel) Notify(ctx context.Context, event Event) error {
+	endpoint := fmt.Sprintf("%s/message?token=%s", c.serverURL, url.QueryEscape(c.token))
+	return postJSON(ctx, endpoint, map[string]any{
+		"title":    event.Title,
+		"message":  event.Message,
+		"priority": 5,
+	})
+}
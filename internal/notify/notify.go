@@ -0,0 +1,251 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/mailer"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// Event is a user-facing notification, e.g. a scan report. Link points at
+// the part of the app the event is about.
+type Event struct {
+	Title   string
+	Message string
+	Link    string
+}
+
+// Service dispatches events to the configured notification channels.
+// Delivery failures must never fail the operation that raised the event.
+type Service interface {
+	Send(ctx context.Context, event Event)
+	// Channels returns the names of the configured external channels.
+	Channels() []string
+}
+
+type service struct {
+	appLogger     logger.Logger
+	mailer        mailer.Service
+	userRepo      repository.UserRepository
+	watchlistRepo repository.WatchlistRepository
+	episodeRepo   repository.EpisodeRepository
+	seriesRepo    repository.SeriesRepository
+	channels      []Channel
+}
+
+// NewService returns the notification dispatcher and subscribes it to the
+// bus events users should hear about. Admin-facing events are written to
+// the application log and fanned out to the external channels configured
+// in YAML (Discord, Telegram, Gotify); users who opted in additionally
+// receive email for new episodes of followed shows, failed tasks and
+// account sign-ins, provided an SMTP server is configured.
+func NewService(
+	cfg *config.Config,
+	appLogger logger.Logger,
+	mailSvc mailer.Service,
+	userRepo repository.UserRepository,
+	watchlistRepo repository.WatchlistRepository,
+	episodeRepo repository.EpisodeRepository,
+	seriesRepo repository.SeriesRepository,
+	bus events.Bus,
+) Service {
+	s := &service{
+		appLogger:     appLogger,
+		mailer:        mailSvc,
+		userRepo:      userRepo,
+		watchlistRepo: watchlistRepo,
+		episodeRepo:   episodeRepo,
+		seriesRepo:    seriesRepo,
+		channels:      buildChannels(cfg),
+	}
+	bus.Subscribe(events.ScanCompleted, s.onScanCompleted)
+	bus.Subscribe(events.TranscoderStatus, s.onTranscoderStatus)
+	bus.Subscribe(events.ItemCreated, s.onItemCreated)
+	bus.Subscribe(events.TaskFailed, s.onTaskFailed)
+	bus.Subscribe(events.UserLogin, s.onUserLogin)
+	return s
+}
+
+// onTranscoderStatus alerts admins when ffmpeg drops out or comes back, so
+// a server quietly running in degraded mode does not go unnoticed.
+func (s *service) onTranscoderStatus(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.TranscoderPayload)
+	if !ok {
+		return
+	}
+
+	if payload.Available {
+		s.Send(ctx, Event{
+			Title:   "Media engine restored",
+			Message: "FFmpeg is available again; metadata extraction and transcoding are enabled.",
+		})
+		return
+	}
+	s.Send(ctx, Event{
+		Title:   "Media engine unavailable",
+		Message: fmt.Sprintf("FFmpeg could not be acquired (%s). The server is running in degraded mode: browsing and direct play only.", payload.Reason),
+	})
+}
+
+// onScanCompleted turns a scan.completed bus event into a user-facing
+// notification. Unmatched items link to the manual identify queue.
+func (s *service) onScanCompleted(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.ScanPayload)
+	if !ok {
+		return
+	}
+
+	s.Send(ctx, Event{
+		Title:   fmt.Sprintf("Library scan finished: %s", payload.Scope),
+		Message: payload.Summary,
+		Link:    "/api/identify/unmatched",
+	})
+}
+
+// onItemCreated emails users who follow a series when one of its episodes
+// is imported. Bus handlers must not block, so the lookups and delivery
+// run on their own goroutine.
+func (s *service) onItemCreated(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.ItemPayload)
+	if !ok || payload.MediaType != "episode" || !s.mailer.Enabled() {
+		return
+	}
+
+	go s.mailNewEpisode(context.WithoutCancel(ctx), payload.ItemID)
+}
+
+func (s *service) mailNewEpisode(ctx context.Context, episodeID uint) {
+	episode, err := s.episodeRepo.FindEpisodeByID(ctx, episodeID)
+	if err != nil || episode == nil {
+		return
+	}
+	series, err := s.seriesRepo.FindByID(ctx, episode.SeriesID)
+	if err != nil || series == nil {
+		return
+	}
+
+	followers, err := s.watchlistRepo.ListByMedia(ctx, episode.SeriesID, "series")
+	if err != nil {
+		return
+	}
+
+	subject := fmt.Sprintf("New episode of %s", series.Title)
+	body := fmt.Sprintf("A new episode of %s was just added to the library: %s.", series.Title, episode.Title)
+
+	notified := make(map[uint]bool)
+	for _, entry := range followers {
+		if notified[entry.UserID] {
+			continue
+		}
+		notified[entry.UserID] = true
+
+		user, err := s.userRepo.FindByID(ctx, entry.UserID)
+		if err != nil || user == nil || !user.NotifyNewEpisodes || user.Email == "" {
+			continue
+		}
+		if err := s.mailer.Send(ctx, user.Email, subject, body); err != nil {
+			s.appLogger.Warn().Err(err).Str("username", user.Username).Msg("Failed to send new episode mail")
+		}
+	}
+}
+
+// onTaskFailed emails administrators who opted into task failure mail.
+func (s *service) onTaskFailed(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.TaskPayload)
+	if !ok || !s.mailer.Enabled() {
+		return
+	}
+
+	go s.mailTaskFailure(context.WithoutCancel(ctx), payload)
+}
+
+func (s *service) mailTaskFailure(ctx context.Context, payload events.TaskPayload) {
+	users, err := s.userRepo.List(ctx)
+	if err != nil {
+		return
+	}
+
+	subject := fmt.Sprintf("Scheduled task failed: %s", payload.Task)
+	body := fmt.Sprintf("The scheduled task '%s' failed with: %s", payload.Task, payload.Error)
+
+	for _, user := range users {
+		if !user.IsAdmin() || !user.NotifyTaskFailures || user.Email == "" {
+			continue
+		}
+		if err := s.mailer.Send(ctx, user.Email, subject, body); err != nil {
+			s.appLogger.Warn().Err(err).Str("username", user.Username).Msg("Failed to send task failure mail")
+		}
+	}
+}
+
+// onUserLogin emails the account owner about a sign-in when they opted in,
+// so an unexpected login does not go unnoticed.
+func (s *service) onUserLogin(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.LoginPayload)
+	if !ok || payload.UserID == 0 || !s.mailer.Enabled() {
+		return
+	}
+
+	go s.mailNewLogin(context.WithoutCancel(ctx), payload.UserID)
+}
+
+func (s *service) mailNewLogin(ctx context.Context, userID uint) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil || !user.NotifyNewLogin || user.Email == "" {
+		return
+	}
+
+	body := fmt.Sprintf("Your account '%s' just signed in. If this wasn't you, change your password and revoke your sessions.", user.Username)
+	if err := s.mailer.Send(ctx, user.Email, "New sign-in to your account", body); err != nil {
+		s.appLogger.Warn().Err(err).Str("username", user.Username).Msg("Failed to send login mail")
+	}
+}
+
+// buildChannels constructs the external channels the config enables.
+func buildChannels(cfg *config.Config) []Channel {
+	var channels []Channel
+	if cfg.Notifications.Discord.WebhookURL != "" {
+		channels = append(channels, newDiscordChannel(cfg.Notifications.Discord.WebhookURL))
+	}
+	if cfg.Notifications.Telegram.BotToken != "" && cfg.Notifications.Telegram.ChatID != "" {
+		channels = append(channels, newTelegramChannel(cfg.Notifications.Telegram.BotToken, cfg.Notifications.Telegram.ChatID))
+	}
+	if cfg.Notifications.Gotify.URL != "" && cfg.Notifications.Gotify.Token != "" {
+		channels = append(channels, newGotifyChannel(cfg.Notifications.Gotify.URL, cfg.Notifications.Gotify.Token))
+	}
+	return channels
+}
+
+func (s *service) Channels() []string {
+	names := make([]string, len(s.channels))
+	for i, channel := range s.channels {
+		names[i] = channel.Name()
+	}
+	return names
+}
+
+// Send logs the notification and fans it out to the external channels.
+// Channel delivery runs off the caller's goroutine; a failing target is
+// logged and never affects the operation that raised the event.
+func (s *service) Send(ctx context.Context, event Event) {
+	s.appLogger.Info().
+		Str("title", event.Title).
+		Str("link", event.Link).
+		Msg(event.Message)
+
+	for _, channel := range s.channels {
+		go func(channel Channel) {
+			if err := channel.Notify(context.WithoutCancel(ctx), event); err != nil {
+				s.appLogger.Warn().
+					Err(err).
+					Str("channel", channel.Name()).
+					Str("title", event.Title).
+					Msg("Failed to deliver notification")
+			}
+		}(channel)
+	}
+}
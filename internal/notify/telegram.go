@@ -0,0 +1,28 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// telegramChannel sends notifications through a Telegram bot to one chat.
+type telegramChannel struct {
+	botToken string
+	chatID   string
+}
+
+func newTelegramChannel(botToken, chatID string) Channel {
+	return &telegramChannel{botToken: botToken, chatID: chatID}
+}
+
+func (c *telegramChannel) Name() string {
+	return "telegram"
+}
+
+func (c *telegramChannel) Notify(ctx context.Context, event Event) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", c.botToken)
+	return postJSON(ctx, url, map[string]string{
+		"chat_id": c.chatID,
+		"text":    fmt.Sprintf("%s\n%s", event.Title, event.Message),
+	})
+}
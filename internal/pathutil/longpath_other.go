@@ -0,0 +1,9 @@
+//go:build !windows
+
+package pathutil
+
+// Long is a no-op outside Windows; extended-length prefixes only exist
+// there.
+func Long(path string) string {
+	return path
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package pathutil
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// windowsLongPathThreshold is just under MAX_PATH to leave room for the
+// 8.3 suffix the OS may append internally.
+const windowsLongPathThreshold = 248
+
+// Long returns the path in extended-length form (\\?\ prefixed) when it
+// would otherwise exceed the Windows MAX_PATH limit. UNC paths become
+// \\?\UNC\server\share\... form. Paths below the limit and paths that are
+// already extended are returned unchanged.
+func Long(path string) string {
+	if len(path) < windowsLongPathThreshold || strings.HasPrefix(path, `\\?\`) {
+		return path
+	}
+
+	if strings.HasPrefix(path, `\\`) {
+		// UNC path: \\server\share\... -> \\?\UNC\server\share\...
+		return `\\?\UNC` + path[1:]
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return `\\?\` + abs
+}
@@ -0,0 +1,26 @@
+// Package pathutil canonicalizes media file paths so lookups behave the
+// same across filesystems with different case and Unicode normalization
+// rules (e.g. NFD paths written by macOS).
+package pathutil
+
+import (
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Canonical returns the canonical form of a path: cleaned and
+// Unicode NFC-normalized. This is the form stored in the database.
+func Canonical(path string) string {
+	if path == "" {
+		return ""
+	}
+	return norm.NFC.String(filepath.Clean(path))
+}
+
+// Fold returns the canonical path lowercased, for case-insensitive
+// comparison against stored paths.
+func Fold(path string) string {
+	return strings.ToLower(Canonical(path))
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type ActionTokenRepository interface {
+	Store(ctx context.Context, token *entity.ActionToken) error
+	FindByHash(ctx context.Context, hash string, purpose entity.ActionTokenPurpose) (*entity.ActionToken, error)
+	MarkUsed(ctx context.Context, id uint) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type actionTokenRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewActionTokenRepository(db *gorm.DB, appLogger logger.Logger) ActionTokenRepository {
+	return &actionTokenRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *actionTokenRepository) Store(ctx context.Context, token *entity.ActionToken) error {
+	result := r.db.WithContext(ctx).Create(token)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("userID", token.UserID).
+			Str("purpose", string(token.Purpose)).
+			Msg("Failed to store action token")
+		return fmt.Errorf("failed to store action token: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *actionTokenRepository) FindByHash(ctx context.Context, hash string, purpose entity.ActionTokenPurpose) (*entity.ActionToken, error) {
+	var token entity.ActionToken
+	result := r.db.WithContext(ctx).Where("token_hash = ? AND purpose = ?", hash, purpose).First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("purpose", string(purpose)).
+			Msg("Failed to find action token by hash")
+		return nil, fmt.Errorf("failed to find action token by hash: %w", result.Error)
+	}
+	return &token, nil
+}
+
+func (r *actionTokenRepository) MarkUsed(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.ActionToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("tokenID", id).
+			Msg("Failed to mark action token as used")
+		return fmt.Errorf("failed to mark action token as used: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *actionTokenRepository) DeleteExpired(ctx context.Context) error {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&entity.ActionToken{})
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to delete expired action tokens")
+		return fmt.Errorf("failed to delete expired action tokens: %w", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type AlbumRepository interface {
+	Store(ctx context.Context, album *entity.Album) error
+	FindByID(ctx context.Context, id uint) (*entity.Album, error)
+	FindByArtistAndTitle(ctx context.Context, artistID uint, title string) (*entity.Album, error)
+	ListByArtist(ctx context.Context, artistID uint) ([]*entity.Album, error)
+}
+
+type albumRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewAlbumRepository(db *gorm.DB, appLogger logger.Logger) AlbumRepository {
+	return &albumRepository{db: db, appLogger: appLogger}
+}
+
+func (r *albumRepository) Store(ctx context.Context, album *entity.Album) error {
+	result := r.db.WithContext(ctx).Create(album)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store album: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *albumRepository) FindByID(ctx context.Context, id uint) (*entity.Album, error) {
+	var album entity.Album
+	result := r.db.WithContext(ctx).Preload("Tracks").First(&album, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find album by id: %w", result.Error)
+	}
+	return &album, nil
+}
+
+func (r *albumRepository) FindByArtistAndTitle(ctx context.Context, artistID uint, title string) (*entity.Album, error) {
+	var album entity.Album
+	result := r.db.WithContext(ctx).Where("artist_id = ? AND title = ?", artistID, title).First(&album)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find album: %w", result.Error)
+	}
+	return &album, nil
+}
+
+func (r *albumRepository) ListByArtist(ctx context.Context, artistID uint) ([]*entity.Album, error) {
+	var albums []*entity.Album
+	result := r.db.WithContext(ctx).Where("artist_id = ?", artistID).Order("year, title").Find(&albums)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list albums: %w", result.Error)
+	}
+	return albums, nil
+}
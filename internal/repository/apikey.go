@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Store(ctx context.Context, key *entity.APIKey) error
+	FindByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	FindByUser(ctx context.Context, userID uint) ([]*entity.APIKey, error)
+	Delete(ctx context.Context, userID, id uint) error
+	Touch(ctx context.Context, id uint) error
+}
+
+type apiKeyRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewAPIKeyRepository(db *gorm.DB, appLogger logger.Logger) APIKeyRepository {
+	return &apiKeyRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *apiKeyRepository) Store(ctx context.Context, key *entity.APIKey) error {
+	result := r.db.WithContext(ctx).Create(key)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("userID", key.UserID).
+			Msg("Failed to store API key")
+		return fmt.Errorf("failed to store API key: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	result := r.db.WithContext(ctx).Where("key_hash = ?", hash).First(&key)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to find API key by hash")
+		return nil, fmt.Errorf("failed to find API key by hash: %w", result.Error)
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) FindByUser(ctx context.Context, userID uint) ([]*entity.APIKey, error) {
+	var keys []*entity.APIKey
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&keys)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("userID", userID).
+			Msg("Failed to list API keys")
+		return nil, fmt.Errorf("failed to list API keys: %w", result.Error)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, userID, id uint) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Delete(&entity.APIKey{}, id)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("keyID", id).
+			Msg("Failed to delete API key")
+		return fmt.Errorf("failed to delete API key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("API key not found")
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) Touch(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		Update("last_used_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("failed to update API key last use: %w", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type ArtistRepository interface {
+	Store(ctx context.Context, artist *entity.Artist) error
+	FindByID(ctx context.Context, id uint) (*entity.Artist, error)
+	FindByName(ctx context.Context, name string) (*entity.Artist, error)
+	List(ctx context.Context) ([]*entity.Artist, error)
+}
+
+type artistRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewArtistRepository(db *gorm.DB, appLogger logger.Logger) ArtistRepository {
+	return &artistRepository{db: db, appLogger: appLogger}
+}
+
+func (r *artistRepository) Store(ctx context.Context, artist *entity.Artist) error {
+	result := r.db.WithContext(ctx).Create(artist)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store artist: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *artistRepository) FindByID(ctx context.Context, id uint) (*entity.Artist, error) {
+	var artist entity.Artist
+	result := r.db.WithContext(ctx).Preload("Albums").First(&artist, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find artist by id: %w", result.Error)
+	}
+	return &artist, nil
+}
+
+func (r *artistRepository) FindByName(ctx context.Context, name string) (*entity.Artist, error) {
+	var artist entity.Artist
+	result := r.db.WithContext(ctx).Where("name = ?", name).First(&artist)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find artist by name: %w", result.Error)
+	}
+	return &artist, nil
+}
+
+func (r *artistRepository) List(ctx context.Context) ([]*entity.Artist, error) {
+	var artists []*entity.Artist
+	result := r.db.WithContext(ctx).Order("name").Find(&artists)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list artists: %w", result.Error)
+	}
+	return artists, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
+	"gorm.io/gorm"
+)
+
+type AudiobookRepository interface {
+	StoreBook(ctx context.Context, book *entity.Audiobook) error
+	UpdateBook(ctx context.Context, book *entity.Audiobook) error
+	FindBookByID(ctx context.Context, id uint) (*entity.Audiobook, error)
+	FindBookByLibraryAndTitle(ctx context.Context, libraryID uint, title string) (*entity.Audiobook, error)
+	ListBooks(ctx context.Context) ([]*entity.Audiobook, error)
+
+	StoreFile(ctx context.Context, file *entity.AudiobookFile) error
+	FindFileByPath(ctx context.Context, filePath string) (*entity.AudiobookFile, error)
+	CountFiles(ctx context.Context, audiobookID uint) (int64, error)
+
+	StoreChapter(ctx context.Context, chapter *entity.AudiobookChapter) error
+	CountChapters(ctx context.Context, audiobookID uint) (int64, error)
+
+	GetProgress(ctx context.Context, userID, audiobookID uint) (*entity.AudiobookProgress, error)
+	UpsertProgress(ctx context.Context, progress *entity.AudiobookProgress) error
+}
+
+type audiobookRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewAudiobookRepository(db *gorm.DB, appLogger logger.Logger) AudiobookRepository {
+	return &audiobookRepository{db: db, appLogger: appLogger}
+}
+
+func (r *audiobookRepository) StoreBook(ctx context.Context, book *entity.Audiobook) error {
+	result := r.db.WithContext(ctx).Create(book)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store audiobook: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *audiobookRepository) UpdateBook(ctx context.Context, book *entity.Audiobook) error {
+	result := r.db.WithContext(ctx).Save(book)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update audiobook: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *audiobookRepository) FindBookByID(ctx context.Context, id uint) (*entity.Audiobook, error) {
+	var book entity.Audiobook
+	result := r.db.WithContext(ctx).
+		Preload("Files", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Preload("Chapters", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		First(&book, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find audiobook by id: %w", result.Error)
+	}
+	return &book, nil
+}
+
+func (r *audiobookRepository) FindBookByLibraryAndTitle(ctx context.Context, libraryID uint, title string) (*entity.Audiobook, error) {
+	var book entity.Audiobook
+	result := r.db.WithContext(ctx).Where("library_id = ? AND title = ?", libraryID, title).First(&book)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find audiobook by title: %w", result.Error)
+	}
+	return &book, nil
+}
+
+func (r *audiobookRepository) ListBooks(ctx context.Context) ([]*entity.Audiobook, error) {
+	var books []*entity.Audiobook
+	result := r.db.WithContext(ctx).Order("author, title").Find(&books)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list audiobooks: %w", result.Error)
+	}
+	return books, nil
+}
+
+func (r *audiobookRepository) StoreFile(ctx context.Context, file *entity.AudiobookFile) error {
+	file.FilePath = pathutil.Canonical(file.FilePath)
+	result := r.db.WithContext(ctx).Create(file)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store audiobook file: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *audiobookRepository) FindFileByPath(ctx context.Context, filePath string) (*entity.AudiobookFile, error) {
+	var file entity.AudiobookFile
+	result := r.db.WithContext(ctx).Where("LOWER(file_path) = ?", pathutil.Fold(filePath)).First(&file)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find audiobook file by path: %w", result.Error)
+	}
+	return &file, nil
+}
+
+func (r *audiobookRepository) CountFiles(ctx context.Context, audiobookID uint) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&entity.AudiobookFile{}).Where("audiobook_id = ?", audiobookID).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count audiobook files: %w", result.Error)
+	}
+	return count, nil
+}
+
+func (r *audiobookRepository) StoreChapter(ctx context.Context, chapter *entity.AudiobookChapter) error {
+	result := r.db.WithContext(ctx).Create(chapter)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store audiobook chapter: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *audiobookRepository) CountChapters(ctx context.Context, audiobookID uint) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&entity.AudiobookChapter{}).Where("audiobook_id = ?", audiobookID).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to count audiobook chapters: %w", result.Error)
+	}
+	return count, nil
+}
+
+func (r *audiobookRepository) GetProgress(ctx context.Context, userID, audiobookID uint) (*entity.AudiobookProgress, error) {
+	var progress entity.AudiobookProgress
+	result := r.db.WithContext(ctx).Where("user_id = ? AND audiobook_id = ?", userID, audiobookID).First(&progress)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get audiobook progress: %w", result.Error)
+	}
+	return &progress, nil
+}
+
+func (r *audiobookRepository) UpsertProgress(ctx context.Context, progress *entity.AudiobookProgress) error {
+	existing, err := r.GetProgress(ctx, progress.UserID, progress.AudiobookID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		existing.FileID = progress.FileID
+		existing.PositionSec = progress.PositionSec
+		if result := r.db.WithContext(ctx).Save(existing); result.Error != nil {
+			return fmt.Errorf("failed to update audiobook progress: %w", result.Error)
+		}
+		*progress = *existing
+		return nil
+	}
+
+	if result := r.db.WithContext(ctx).Create(progress); result.Error != nil {
+		return fmt.Errorf("failed to store audiobook progress: %w", result.Error)
+	}
+	return nil
+}
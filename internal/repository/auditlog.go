@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+// AuditLogListOptions filters an audit query; zero values leave the
+// corresponding filter off.
+type AuditLogListOptions struct {
+	Limit  int
+	Offset int
+	Action string
+	Actor  string
+	From   time.Time
+	To     time.Time
+}
+
+type AuditLogRepository interface {
+	Store(ctx context.Context, entry *entity.AuditLog) error
+	FindPage(ctx context.Context, opts AuditLogListOptions) ([]*entity.AuditLog, int64, error)
+}
+
+type auditLogRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewAuditLogRepository(db *gorm.DB, appLogger logger.Logger) AuditLogRepository {
+	return &auditLogRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *auditLogRepository) Store(ctx context.Context, entry *entity.AuditLog) error {
+	result := r.db.WithContext(ctx).Create(entry)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("action", entry.Action).
+			Msg("Failed to store audit log entry")
+		return fmt.Errorf("failed to store audit log entry: %w", result.Error)
+	}
+	return nil
+}
+
+// FindPage returns one page of entries, newest first, with the total
+// matching count for pagination.
+func (r *auditLogRepository) FindPage(ctx context.Context, opts AuditLogListOptions) ([]*entity.AuditLog, int64, error) {
+	q := r.db.WithContext(ctx).Model(&entity.AuditLog{})
+	if opts.Action != "" {
+		q = q.Where("action = ?", opts.Action)
+	}
+	if opts.Actor != "" {
+		q = q.Where("actor = ?", opts.Actor)
+	}
+	if !opts.From.IsZero() {
+		q = q.Where("created_at >= ?", opts.From)
+	}
+	if !opts.To.IsZero() {
+		q = q.Where("created_at < ?", opts.To)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit log entries: %w", err)
+	}
+
+	var entries []*entity.AuditLog
+	result := q.Order("created_at DESC").
+		Limit(opts.Limit).
+		Offset(opts.Offset).
+		Find(&entries)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to list audit log entries")
+		return nil, 0, fmt.Errorf("failed to list audit log entries: %w", result.Error)
+	}
+	return entries, total, nil
+}
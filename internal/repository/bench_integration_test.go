@@ -0,0 +1,110 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// benchLibrarySize is large enough that a full table scan is clearly
+// slower than an index lookup, without making the seed step dominate.
+const benchLibrarySize = 20000
+
+// openBenchDB opens a throwaway file-backed SQLite database seeded with
+// benchLibrarySize movies, with the hot-path indexes applied the same
+// way NewDB applies them.
+func openBenchDB(b *testing.B) *gorm.DB {
+	b.Helper()
+
+	path := filepath.Join(b.TempDir(), "bench.db")
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{
+		Logger: gormlogger.Discard,
+	})
+	if err != nil {
+		b.Fatalf("failed to open benchmark database: %v", err)
+	}
+
+	// Every table ensureIndexes touches, so it runs exactly as NewDB
+	// runs it
+	if err := db.AutoMigrate(
+		&entity.Library{},
+		&entity.LibraryItem{},
+		&entity.Movie{},
+		&entity.Series{},
+		&entity.Season{},
+		&entity.Episode{},
+		&entity.WatchHistory{},
+		&entity.Watchlist{},
+		&entity.Favorite{},
+		&entity.Rating{},
+	); err != nil {
+		b.Fatalf("failed to migrate benchmark database: %v", err)
+	}
+	if err := ensureIndexes(db, logger.NewNop()); err != nil {
+		b.Fatalf("failed to create benchmark indexes: %v", err)
+	}
+
+	movies := make([]*entity.Movie, 0, benchLibrarySize)
+	for i := 0; i < benchLibrarySize; i++ {
+		movies = append(movies, &entity.Movie{
+			LibraryItem: entity.LibraryItem{
+				LibraryID: 1,
+				FilePath:  fmt.Sprintf("/media/movies/Movie %05d (2020)/Movie %05d (2020).mkv", i, i),
+			},
+			Title: fmt.Sprintf("Movie %05d", i),
+		})
+	}
+	if err := db.CreateInBatches(movies, 500).Error; err != nil {
+		b.Fatalf("failed to seed benchmark database: %v", err)
+	}
+
+	return db
+}
+
+// BenchmarkFindByPath exercises the scanner's per-file existence check:
+// the canonical comparison uses the file_path index, while the folded
+// fallback it replaced on the hot path scans the whole table.
+func BenchmarkFindByPath(b *testing.B) {
+	db := openBenchDB(b)
+	repo := NewMovieRepository(db, logger.NewNop())
+	ctx := context.Background()
+	target := fmt.Sprintf("/media/movies/Movie %05d (2020)/Movie %05d (2020).mkv",
+		benchLibrarySize/2, benchLibrarySize/2)
+
+	b.Run("canonical-indexed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			movie, err := repo.FindByPath(ctx, target)
+			if err != nil {
+				b.Fatalf("FindByPath failed: %v", err)
+			}
+			if movie == nil {
+				b.Fatal("FindByPath returned no movie for a seeded path")
+			}
+		}
+	})
+
+	// The stored path differs in case, so the exact match misses and the
+	// LOWER(file_path) scan runs — the pre-index behaviour for every file
+	b.Run("folded-scan", func(b *testing.B) {
+		upper := fmt.Sprintf("/MEDIA/MOVIES/MOVIE %05d (2020)/MOVIE %05d (2020).MKV",
+			benchLibrarySize/2, benchLibrarySize/2)
+		for i := 0; i < b.N; i++ {
+			movie, err := repo.FindByPath(ctx, upper)
+			if err != nil {
+				b.Fatalf("FindByPath failed: %v", err)
+			}
+			if movie == nil {
+				b.Fatal("FindByPath returned no movie for a seeded path")
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type BrandingRepository interface {
+	Get(ctx context.Context) (*entity.Branding, error)
+	Save(ctx context.Context, branding *entity.Branding) error
+}
+
+type brandingRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewBrandingRepository(db *gorm.DB, appLogger logger.Logger) BrandingRepository {
+	return &brandingRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+// Get returns the branding row, or nil when no branding has been
+// configured yet.
+func (r *brandingRepository) Get(ctx context.Context) (*entity.Branding, error) {
+	var branding entity.Branding
+	result := r.db.WithContext(ctx).First(&branding)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get branding: %w", result.Error)
+	}
+	return &branding, nil
+}
+
+func (r *brandingRepository) Save(ctx context.Context, branding *entity.Branding) error {
+	result := r.db.WithContext(ctx).Save(branding)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to save branding")
+		return fmt.Errorf("failed to save branding: %w", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/cache"
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// cachedMovieRepository is a read-through cache in front of the movie
+// repository. Single-row and full-list lookups are served from the cache;
+// any write drops every movie entry, which is cheap and can never serve a
+// stale row. Reads inside a transaction bypass the cache so they see the
+// transaction's own view.
+type cachedMovieRepository struct {
+	MovieRepository
+	store cache.Store
+}
+
+// NewCachedMovieRepository wraps a movie repository with a read-through
+// cache backed by the given store.
+func NewCachedMovieRepository(inner MovieRepository, store cache.Store) MovieRepository {
+	return &cachedMovieRepository{MovieRepository: inner, store: store}
+}
+
+func (r *cachedMovieRepository) FindByID(ctx context.Context, id uint) (*entity.Movie, error) {
+	if txFromContext(ctx) != nil {
+		return r.MovieRepository.FindByID(ctx, id)
+	}
+
+	key := fmt.Sprintf("movie:id:%d", id)
+	if cached, ok := r.store.Get(key); ok {
+		return cached.(*entity.Movie), nil
+	}
+
+	movie, err := r.MovieRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.store.Set(key, movie)
+	return movie, nil
+}
+
+func (r *cachedMovieRepository) FindAll(ctx context.Context) ([]*entity.Movie, error) {
+	if txFromContext(ctx) != nil {
+		return r.MovieRepository.FindAll(ctx)
+	}
+
+	if cached, ok := r.store.Get("movie:all"); ok {
+		return cached.([]*entity.Movie), nil
+	}
+
+	movies, err := r.MovieRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.store.Set("movie:all", movies)
+	return movies, nil
+}
+
+func (r *cachedMovieRepository) Store(ctx context.Context, movie *entity.Movie) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.Store(ctx, movie)
+}
+
+func (r *cachedMovieRepository) StoreBatch(ctx context.Context, movies []*entity.Movie) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.StoreBatch(ctx, movies)
+}
+
+func (r *cachedMovieRepository) Update(ctx context.Context, movie *entity.Movie) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.Update(ctx, movie)
+}
+
+func (r *cachedMovieRepository) Delete(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.Delete(ctx, id)
+}
+
+func (r *cachedMovieRepository) HardDelete(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.HardDelete(ctx, id)
+}
+
+func (r *cachedMovieRepository) Restore(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.Restore(ctx, id)
+}
+
+func (r *cachedMovieRepository) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.PurgeDeleted(ctx, olderThan)
+}
+
+func (r *cachedMovieRepository) UpdateScannedStatus(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.UpdateScannedStatus(ctx, id)
+}
+
+func (r *cachedMovieRepository) UpdateCommunityRating(ctx context.Context, id uint, average float64, count int) error {
+	defer r.store.DeletePrefix("movie:")
+	return r.MovieRepository.UpdateCommunityRating(ctx, id, average, count)
+}
+
+// cachedSeriesRepository is the series counterpart. Only shallow lookups
+// are cached; a query with eager-load options goes straight through,
+// since the options can't be folded into a cache key.
+type cachedSeriesRepository struct {
+	SeriesRepository
+	store cache.Store
+}
+
+// NewCachedSeriesRepository wraps a series repository with a read-through
+// cache backed by the given store.
+func NewCachedSeriesRepository(inner SeriesRepository, store cache.Store) SeriesRepository {
+	return &cachedSeriesRepository{SeriesRepository: inner, store: store}
+}
+
+func (r *cachedSeriesRepository) FindByID(ctx context.Context, id uint, loads ...SeriesLoadOption) (*entity.Series, error) {
+	if len(loads) > 0 || txFromContext(ctx) != nil {
+		return r.SeriesRepository.FindByID(ctx, id, loads...)
+	}
+
+	key := fmt.Sprintf("series:id:%d", id)
+	if cached, ok := r.store.Get(key); ok {
+		return cached.(*entity.Series), nil
+	}
+
+	series, err := r.SeriesRepository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.store.Set(key, series)
+	return series, nil
+}
+
+func (r *cachedSeriesRepository) FindAll(ctx context.Context, loads ...SeriesLoadOption) ([]*entity.Series, error) {
+	if len(loads) > 0 || txFromContext(ctx) != nil {
+		return r.SeriesRepository.FindAll(ctx, loads...)
+	}
+
+	if cached, ok := r.store.Get("series:all"); ok {
+		return cached.([]*entity.Series), nil
+	}
+
+	shows, err := r.SeriesRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.store.Set("series:all", shows)
+	return shows, nil
+}
+
+func (r *cachedSeriesRepository) Store(ctx context.Context, show *entity.Series) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.Store(ctx, show)
+}
+
+func (r *cachedSeriesRepository) Update(ctx context.Context, show *entity.Series) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.Update(ctx, show)
+}
+
+func (r *cachedSeriesRepository) Delete(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.Delete(ctx, id)
+}
+
+func (r *cachedSeriesRepository) HardDelete(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.HardDelete(ctx, id)
+}
+
+func (r *cachedSeriesRepository) Restore(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.Restore(ctx, id)
+}
+
+func (r *cachedSeriesRepository) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.PurgeDeleted(ctx, olderThan)
+}
+
+func (r *cachedSeriesRepository) CleanupDeletedShows(ctx context.Context, olderThan time.Duration) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.CleanupDeletedShows(ctx, olderThan)
+}
+
+func (r *cachedSeriesRepository) UpdateScannedStatus(ctx context.Context, id uint) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.UpdateScannedStatus(ctx, id)
+}
+
+func (r *cachedSeriesRepository) UpdateCommunityRating(ctx context.Context, id uint, average float64, count int) error {
+	defer r.store.DeletePrefix("series:")
+	return r.SeriesRepository.UpdateCommunityRating(ctx, id, average, count)
+}
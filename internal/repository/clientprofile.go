@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type ClientProfileRepository interface {
+	Store(ctx context.Context, profile *entity.ClientProfile) error
+	FindByID(ctx context.Context, id uint) (*entity.ClientProfile, error)
+	FindByName(ctx context.Context, name string) (*entity.ClientProfile, error)
+	List(ctx context.Context) ([]*entity.ClientProfile, error)
+	Update(ctx context.Context, profile *entity.ClientProfile) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type clientProfileRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewClientProfileRepository(db *gorm.DB, appLogger logger.Logger) ClientProfileRepository {
+	return &clientProfileRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *clientProfileRepository) Store(ctx context.Context, profile *entity.ClientProfile) error {
+	result := r.db.WithContext(ctx).Create(profile)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("name", profile.Name).
+			Msg("Failed to store client profile")
+		return fmt.Errorf("failed to store client profile: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *clientProfileRepository) FindByID(ctx context.Context, id uint) (*entity.ClientProfile, error) {
+	var profile entity.ClientProfile
+	result := r.db.WithContext(ctx).First(&profile, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("profileID", id).
+			Msg("Failed to find client profile by ID")
+		return nil, fmt.Errorf("failed to find client profile by ID: %w", result.Error)
+	}
+	return &profile, nil
+}
+
+func (r *clientProfileRepository) FindByName(ctx context.Context, name string) (*entity.ClientProfile, error) {
+	var profile entity.ClientProfile
+	result := r.db.WithContext(ctx).Where("name = ?", name).First(&profile)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("name", name).
+			Msg("Failed to find client profile by name")
+		return nil, fmt.Errorf("failed to find client profile by name: %w", result.Error)
+	}
+	return &profile, nil
+}
+
+func (r *clientProfileRepository) List(ctx context.Context) ([]*entity.ClientProfile, error) {
+	var profiles []*entity.ClientProfile
+	result := r.db.WithContext(ctx).Order("name").Find(&profiles)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to list client profiles")
+		return nil, fmt.Errorf("failed to list client profiles: %w", result.Error)
+	}
+	return profiles, nil
+}
+
+func (r *clientProfileRepository) Update(ctx context.Context, profile *entity.ClientProfile) error {
+	result := r.db.WithContext(ctx).Save(profile)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("profileID", profile.ID).
+			Msg("Failed to update client profile")
+		return fmt.Errorf("failed to update client profile: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *clientProfileRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&entity.ClientProfile{}, id)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("profileID", id).
+			Msg("Failed to delete client profile")
+		return fmt.Errorf("failed to delete client profile: %w", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type CollectionRepository interface {
+	FindOrCreate(ctx context.Context, collection *entity.Collection) (*entity.Collection, error)
+	FindByID(ctx context.Context, id uint) (*entity.Collection, error)
+	FindAll(ctx context.Context) ([]*entity.Collection, error)
+	FindMovies(ctx context.Context, collectionID uint) ([]*entity.Movie, error)
+}
+
+type collectionRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewCollectionRepository(db *gorm.DB, appLogger logger.Logger) CollectionRepository {
+	return &collectionRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *collectionRepository) FindOrCreate(ctx context.Context, collection *entity.Collection) (*entity.Collection, error) {
+	var existing entity.Collection
+	result := r.db.WithContext(ctx).Where("tmdb_id = ?", collection.TMDbID).First(&existing)
+	if result.Error == nil {
+		return &existing, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find collection: %w", result.Error)
+	}
+
+	if err := r.db.WithContext(ctx).Create(collection).Error; err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+	return collection, nil
+}
+
+func (r *collectionRepository) FindByID(ctx context.Context, id uint) (*entity.Collection, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid collection ID: %w", errors.ErrBadRequest)
+	}
+
+	var collection entity.Collection
+	result := r.db.WithContext(ctx).First(&collection, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("collection with ID %d not found: %w", id, errors.ErrNotFound)
+		}
+		return nil, fmt.Errorf("database error finding collection %d: %w", id, result.Error)
+	}
+
+	return &collection, nil
+}
+
+func (r *collectionRepository) FindAll(ctx context.Context) ([]*entity.Collection, error) {
+	var collections []*entity.Collection
+	result := r.db.WithContext(ctx).Order("name").Find(&collections)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find all collections: %w", result.Error)
+	}
+	return collections, nil
+}
+
+func (r *collectionRepository) FindMovies(ctx context.Context, collectionID uint) ([]*entity.Movie, error) {
+	var movies []*entity.Movie
+	result := r.db.WithContext(ctx).
+		Where("collection_id = ?", collectionID).
+		Order("release_date").
+		Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find collection movies: %w", result.Error)
+	}
+	return movies, nil
+}
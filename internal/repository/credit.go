@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type CreditRepository interface {
+	Store(ctx context.Context, credit *entity.Credit) error
+	FindByMedia(ctx context.Context, mediaType string, mediaID uint) ([]entity.Credit, error)
+	FindByPerson(ctx context.Context, personID uint) ([]entity.Credit, error)
+	DeleteByMedia(ctx context.Context, mediaType string, mediaID uint) error
+}
+
+type creditRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewCreditRepository(db *gorm.DB, appLogger logger.Logger) CreditRepository {
+	return &creditRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *creditRepository) Store(ctx context.Context, credit *entity.Credit) error {
+	result := r.db.WithContext(ctx).Create(credit)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store credit: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *creditRepository) FindByMedia(ctx context.Context, mediaType string, mediaID uint) ([]entity.Credit, error) {
+	var credits []entity.Credit
+	result := r.db.WithContext(ctx).
+		Preload("Person").
+		Where("media_type = ? AND media_id = ?", mediaType, mediaID).
+		Order("cast_order ASC").
+		Find(&credits)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find credits for media: %w", result.Error)
+	}
+	return credits, nil
+}
+
+func (r *creditRepository) FindByPerson(ctx context.Context, personID uint) ([]entity.Credit, error) {
+	var credits []entity.Credit
+	result := r.db.WithContext(ctx).Where("person_id = ?", personID).Find(&credits)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find credits for person: %w", result.Error)
+	}
+	return credits, nil
+}
+
+func (r *creditRepository) DeleteByMedia(ctx context.Context, mediaType string, mediaID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("media_type = ? AND media_id = ?", mediaType, mediaID).
+		Delete(&entity.Credit{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete credits for media: %w", result.Error)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/samcharles93/cinea/config"
 	"github.com/samcharles93/cinea/internal/entity"
@@ -10,6 +11,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
 func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
@@ -18,7 +20,26 @@ func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
 
 	switch cfg.DB.Driver {
 	case "sqlite":
-		db, err = gorm.Open(sqlite.Open(cfg.DB.SQLite.Path), &gorm.Config{})
+		// A restore staged by the backup service replaces the live
+		// database here, before any connection opens
+		pending := cfg.DB.SQLite.Path + ".restore-pending"
+		if _, statErr := os.Stat(pending); statErr == nil {
+			appLogger.Info().Str("path", pending).Msg("Applying staged database restore")
+			if err := os.Rename(pending, cfg.DB.SQLite.Path); err != nil {
+				return nil, fmt.Errorf("failed to apply staged database restore: %w", err)
+			}
+		}
+
+		// Pragmas are per-connection, so they ride on the DSN and reach
+		// every pooled connection: WAL lets readers proceed during a
+		// writing scan, and the busy timeout makes writers wait out short
+		// locks instead of failing with "database is locked"
+		dsn := fmt.Sprintf("%s?_foreign_keys=ON&_journal_mode=%s&_busy_timeout=%d&_synchronous=NORMAL",
+			cfg.DB.SQLite.Path,
+			cfg.SQLiteJournalMode(),
+			cfg.SQLiteBusyTimeout().Milliseconds(),
+		)
+		db, err = gorm.Open(sqlite.Open(dsn), &gorm.Config{TranslateError: true})
 		if err != nil {
 			appLogger.Error().
 				Err(err).
@@ -26,14 +47,6 @@ func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
 				Msg("Failed to connect to SQLite Database")
 			return nil, fmt.Errorf("failed to connect to SQLite: %w", err)
 		}
-
-		if err := db.Exec("PRAGMA foreign_keys = ON").Error; err != nil {
-			appLogger.Error().
-				Err(err).
-				Str("database_driver", "sqlite").
-				Msg("Failed to enable foreign keys for SQLite")
-			return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
-		}
 	case "postgres":
 		dsn := fmt.Sprintf("postgres://%s:%s@%s:%d/%s?SSLMode=%s",
 			cfg.DB.Postgres.User,
@@ -43,7 +56,7 @@ func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
 			cfg.DB.Postgres.DBName,
 			cfg.DB.Postgres.SSLMode,
 		)
-		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 		if err != nil {
 			appLogger.Error().
 				Err(err).
@@ -59,7 +72,7 @@ func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
 			cfg.DB.MariaDB.Port,
 			cfg.DB.MariaDB.DBName,
 		)
-		db, err = gorm.Open(mysql.Open(dsn), &gorm.Config{})
+		db, err = gorm.Open(mysql.Open(dsn), &gorm.Config{TranslateError: true})
 		if err != nil {
 			appLogger.Error().
 				Err(err).
@@ -76,6 +89,22 @@ func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
 		return nil, fmt.Errorf("unsupported database driver: %s", cfg.DB.Driver)
 	}
 
+	// Bound the connection pool so a scan burst can't exhaust the
+	// database's connection limit
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access the connection pool: %w", err)
+	}
+	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns())
+	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns())
+	sqlDB.SetConnMaxLifetime(cfg.DBConnMaxLifetime())
+
+	// Trace queries through the global tracer provider; a no-op unless
+	// tracing is enabled in the config
+	if err := db.Use(tracing.NewPlugin(tracing.WithoutMetrics())); err != nil {
+		return nil, fmt.Errorf("failed to install query tracing plugin: %w", err)
+	}
+
 	// Perform database auto-migration
 	if err := db.AutoMigrate(
 		&entity.User{},
@@ -84,14 +113,48 @@ func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
 		&entity.LibraryPath{},
 		&entity.LibraryAccess{},
 		&entity.Movie{},
+		&entity.MovieVersion{},
 		&entity.Series{},
 		&entity.Season{},
 		&entity.Episode{},
+		&entity.Person{},
+		&entity.Credit{},
+		&entity.Genre{},
+		&entity.Studio{},
+		&entity.Tag{},
+		&entity.MediaGenre{},
+		&entity.MediaStudio{},
+		&entity.MediaTag{},
+		&entity.Collection{},
+		&entity.MediaTranslation{},
 		&entity.ScheduledTask{},
+		&entity.TaskRun{},
+		&entity.TaskLease{},
 		&entity.WatchHistory{},
 		&entity.Watchlist{},
 		&entity.Favorite{},
 		&entity.Rating{},
+		&entity.SearchHistory{},
+		&entity.RefreshToken{},
+		&entity.ActionToken{},
+		&entity.APIKey{},
+		&entity.AuditLog{},
+		&entity.Branding{},
+		&entity.Recommendation{},
+		&entity.SubtitleTrack{},
+		&entity.ClientProfile{},
+		&entity.Artist{},
+		&entity.Album{},
+		&entity.Track{},
+		&entity.Photo{},
+		&entity.Audiobook{},
+		&entity.AudiobookFile{},
+		&entity.AudiobookChapter{},
+		&entity.AudiobookProgress{},
+		&entity.MetadataCacheEntry{},
+		&entity.Profile{},
+		&entity.Webhook{},
+		&entity.WebhookDelivery{},
 	); err != nil {
 		appLogger.Error().
 			Err(err).
@@ -100,6 +163,34 @@ func NewDB(cfg *config.Config, appLogger logger.Logger) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	// Composite indexes for the hot query paths; auto-migration only
+	// creates the single-column ones declared in struct tags
+	if err := ensureIndexes(db, appLogger); err != nil {
+		appLogger.Error().
+			Err(err).
+			Str("step", "ensure-indexes").
+			Msg("Failed to create hot-path indexes")
+		return nil, fmt.Errorf("failed to create hot-path indexes: %w", err)
+	}
+
+	// Merge entries that only differ by path case or Unicode normalization
+	if err := mergeDuplicatePaths(db, appLogger); err != nil {
+		appLogger.Error().
+			Err(err).
+			Str("step", "merge-duplicate-paths").
+			Msg("Failed to merge duplicate path entries")
+		return nil, fmt.Errorf("failed to merge duplicate path entries: %w", err)
+	}
+
+	// Bound queries after migration so long-running migrations aren't cut off
+	if err := registerQueryTimeouts(db, cfg.DBQueryTimeout()); err != nil {
+		appLogger.Error().
+			Err(err).
+			Str("step", "register-query-timeouts").
+			Msg("Failed to register query timeout callbacks")
+		return nil, fmt.Errorf("failed to register query timeout callbacks: %w", err)
+	}
+
 	appLogger.Info().
 		Msgf("Successfully connected to and migrated %s database", cfg.DB.Driver)
 	return db, nil
@@ -4,20 +4,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/samcharles93/cinea/internal/entity"
 	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type EpisodeRepository interface {
 	AddEpisode(ctx context.Context, episode *entity.Episode) error
+	AddEpisodeBatch(ctx context.Context, episodes []*entity.Episode) error
 	FindEpisodeByNumber(ctx context.Context, showID uint, seasonNumber, episodeNumber int) (*entity.Episode, error)
 	FindEpisodeByID(ctx context.Context, episodeID uint) (*entity.Episode, error)
 	UpdateEpisode(ctx context.Context, episode *entity.Episode) error
 	DeleteEpisode(ctx context.Context, id uint) error
+	DeleteBySeries(ctx context.Context, seriesID uint) error
 	FindByPath(ctx context.Context, filePath string) (*entity.Episode, error)
+	FindByFileStem(ctx context.Context, stem string) (*entity.Episode, error)
+	ListBySeries(ctx context.Context, seriesID uint) ([]*entity.Episode, error)
+	FindByContentHash(ctx context.Context, hash string) ([]*entity.Episode, error)
+	FindByLibrary(ctx context.Context, libraryID uint) ([]*entity.Episode, error)
+	FindDuplicates(ctx context.Context) ([]*entity.Episode, error)
+
+	HardDelete(ctx context.Context, id uint) error
+	FindAllDeleted(ctx context.Context) ([]*entity.Episode, error)
+	Restore(ctx context.Context, id uint) error
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error)
 }
 
 type episodeRepository struct {
@@ -30,18 +46,35 @@ func NewEpisodeRepository(db *gorm.DB, appLogger logger.Logger) EpisodeRepositor
 }
 
 func (r *episodeRepository) AddEpisode(ctx context.Context, episode *entity.Episode) error {
-	result := r.db.WithContext(ctx).Clauses(clause.Returning{}).Create(episode)
+	episode.FilePath = pathutil.Canonical(episode.FilePath)
+	result := dbConn(ctx, r.db).Clauses(clause.Returning{}).Create(episode)
 	if result.Error != nil {
 		return fmt.Errorf("failed to add episode: %w", result.Error)
 	}
 	return nil
 }
 
+// AddEpisodeBatch inserts episodes in chunks inside one transaction;
+// primary keys are backfilled on the entities.
+func (r *episodeRepository) AddEpisodeBatch(ctx context.Context, episodes []*entity.Episode) error {
+	if len(episodes) == 0 {
+		return nil
+	}
+	for _, episode := range episodes {
+		episode.FilePath = pathutil.Canonical(episode.FilePath)
+	}
+	result := dbConn(ctx, r.db).Clauses(clause.Returning{}).CreateInBatches(episodes, 100)
+	if result.Error != nil {
+		return fmt.Errorf("failed to batch add episodes: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *episodeRepository) FindEpisodeByNumber(ctx context.Context, showID uint, seasonNumber, episodeNumber int) (*entity.Episode, error) {
 	var episode entity.Episode
 	var season entity.Season
 
-	seasonResult := r.db.WithContext(ctx).Select("id").Where("series_id = ? AND season_number = ?", showID, seasonNumber).First(&season)
+	seasonResult := dbConn(ctx, r.db).Select("id").Where("series_id = ? AND season_number = ?", showID, seasonNumber).First(&season)
 	if seasonResult.Error != nil {
 		if errors.Is(seasonResult.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -49,7 +82,7 @@ func (r *episodeRepository) FindEpisodeByNumber(ctx context.Context, showID uint
 		return nil, fmt.Errorf("failed to find season id: %w", seasonResult.Error)
 	}
 
-	result := r.db.WithContext(ctx).
+	result := dbConn(ctx, r.db).
 		Where("season_id = ? AND episode_number = ?", season.ID, episodeNumber).
 		First(&episode)
 	if result.Error != nil {
@@ -63,7 +96,7 @@ func (r *episodeRepository) FindEpisodeByNumber(ctx context.Context, showID uint
 
 func (r *episodeRepository) FindEpisodeByID(ctx context.Context, episodeID uint) (*entity.Episode, error) {
 	var episode entity.Episode
-	result := r.db.WithContext(ctx).First(&episode, episodeID)
+	result := dbConn(ctx, r.db).First(&episode, episodeID)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -74,7 +107,7 @@ func (r *episodeRepository) FindEpisodeByID(ctx context.Context, episodeID uint)
 }
 
 func (r *episodeRepository) UpdateEpisode(ctx context.Context, episode *entity.Episode) error {
-	result := r.db.WithContext(ctx).Save(episode)
+	result := dbConn(ctx, r.db).Save(episode)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update episode: %w", result.Error)
 	}
@@ -82,16 +115,35 @@ func (r *episodeRepository) UpdateEpisode(ctx context.Context, episode *entity.E
 }
 
 func (r *episodeRepository) DeleteEpisode(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Episode{}, id)
+	result := dbConn(ctx, r.db).Delete(&entity.Episode{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete episode: %w", result.Error)
 	}
 	return nil
 }
 
+func (r *episodeRepository) DeleteBySeries(ctx context.Context, seriesID uint) error {
+	result := dbConn(ctx, r.db).Where("series_id = ?", seriesID).Delete(&entity.Episode{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete episodes for series: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *episodeRepository) FindByPath(ctx context.Context, filePath string) (*entity.Episode, error) {
+	// Stored paths are canonical, so the exact comparison hits the
+	// file_path index; the case-folded scan only runs for paths that
+	// arrive in a different case than they were stored with
 	var episode entity.Episode
-	result := r.db.WithContext(ctx).Where("file_path = ?", filePath).First(&episode)
+	result := dbConn(ctx, r.db).Where("file_path = ?", pathutil.Canonical(filePath)).First(&episode)
+	if result.Error == nil {
+		return &episode, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find episode with path '%s': %w", filePath, result.Error)
+	}
+
+	result = dbConn(ctx, r.db).Where("LOWER(file_path) = ?", pathutil.Fold(filePath)).First(&episode)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -101,3 +153,114 @@ func (r *episodeRepository) FindByPath(ctx context.Context, filePath string) (*e
 	}
 	return &episode, nil
 }
+
+// FindByFileStem finds an episode whose file name (without extension)
+// matches the given stem, used to match dropped subtitle files to their
+// media.
+func (r *episodeRepository) FindByFileStem(ctx context.Context, stem string) (*entity.Episode, error) {
+	folded := pathutil.Fold(stem)
+
+	var episodes []*entity.Episode
+	result := dbConn(ctx, r.db).Where("LOWER(file_path) LIKE ?", "%"+folded+".%").Find(&episodes)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find episode by file stem: %w", result.Error)
+	}
+
+	for _, episode := range episodes {
+		base := filepath.Base(episode.FilePath)
+		if pathutil.Fold(strings.TrimSuffix(base, filepath.Ext(base))) == folded {
+			return episode, nil
+		}
+	}
+	return nil, nil
+}
+
+// FindByContentHash returns the episodes fingerprinted with the given
+// hash, used by the scanner to recognise a moved file.
+func (r *episodeRepository) FindByContentHash(ctx context.Context, hash string) ([]*entity.Episode, error) {
+	var episodes []*entity.Episode
+	result := dbConn(ctx, r.db).Where("content_hash = ?", hash).Find(&episodes)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find episodes by content hash: %w", result.Error)
+	}
+	return episodes, nil
+}
+
+// FindByLibrary returns every episode imported into one library, used by
+// the cleanup job to verify files still exist.
+func (r *episodeRepository) FindByLibrary(ctx context.Context, libraryID uint) ([]*entity.Episode, error) {
+	var episodes []*entity.Episode
+	result := dbConn(ctx, r.db).Where("library_id = ?", libraryID).Find(&episodes)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find episodes by library: %w", result.Error)
+	}
+	return episodes, nil
+}
+
+func (r *episodeRepository) HardDelete(ctx context.Context, id uint) error {
+	result := dbConn(ctx, r.db).Unscoped().Delete(&entity.Episode{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to hard delete episode: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *episodeRepository) FindAllDeleted(ctx context.Context) ([]*entity.Episode, error) {
+	var episodes []*entity.Episode
+	result := dbConn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Find(&episodes)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find all deleted episodes: %w", result.Error)
+	}
+	return episodes, nil
+}
+
+func (r *episodeRepository) Restore(ctx context.Context, id uint) error {
+	result := dbConn(ctx, r.db).Unscoped().Model(&entity.Episode{}).Where("id = ?", id).Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore episode: %w", result.Error)
+	}
+	return nil
+}
+
+// PurgeDeleted hard-deletes episodes that have sat in the recycle bin for
+// longer than the retention window, returning how many were purged.
+func (r *episodeRepository) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result := dbConn(ctx, r.db).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", time.Now().Add(-olderThan)).
+		Delete(&entity.Episode{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge deleted episodes: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// FindDuplicates returns every episode whose content hash is shared with
+// at least one other episode, ordered so rows of the same hash sit
+// together.
+func (r *episodeRepository) FindDuplicates(ctx context.Context) ([]*entity.Episode, error) {
+	dupes := r.db.Model(&entity.Episode{}).Select("content_hash").
+		Where("content_hash <> ''").
+		Group("content_hash").
+		Having("COUNT(*) > 1")
+
+	var episodes []*entity.Episode
+	result := dbConn(ctx, r.db).
+		Where("content_hash IN (?)", dupes).
+		Order("content_hash, id").
+		Find(&episodes)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find duplicate episodes: %w", result.Error)
+	}
+	return episodes, nil
+}
+
+// ListBySeries returns every episode of a series with its season loaded,
+// so callers can walk the series in airing order.
+func (r *episodeRepository) ListBySeries(ctx context.Context, seriesID uint) ([]*entity.Episode, error) {
+	var episodes []*entity.Episode
+	result := dbConn(ctx, r.db).Preload("Season").Where("series_id = ?", seriesID).Find(&episodes)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list episodes for series %d: %w", seriesID, result.Error)
+	}
+	return episodes, nil
+}
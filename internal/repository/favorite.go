@@ -11,10 +11,12 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// FavoriteRepository stores favorites. Reads and deletes are scoped to a
+// household profile; profile zero is the account owner.
 type FavoriteRepository interface {
-	GetFavorites(ctx context.Context, userID uint) ([]entity.Favorite, error)
+	GetFavorites(ctx context.Context, userID, profileID uint) ([]entity.Favorite, error)
 	AddToFavorites(ctx context.Context, favorite *entity.Favorite) error
-	RemoveFromFavorites(ctx context.Context, userID uint, mediaID uint, mediaType string) error
+	RemoveFromFavorites(ctx context.Context, userID, profileID uint, mediaID uint, mediaType string) error
 }
 
 type favoriteRepository struct {
@@ -29,14 +31,15 @@ func NewFavoriteRepository(db *gorm.DB, appLogger logger.Logger) FavoriteReposit
 	}
 }
 
-func (r *favoriteRepository) GetFavorites(ctx context.Context, userID uint) ([]entity.Favorite, error) {
+func (r *favoriteRepository) GetFavorites(ctx context.Context, userID, profileID uint) ([]entity.Favorite, error) {
 	r.appLogger.Debug().
 		Uint("userID", userID).
+		Uint("profileID", profileID).
 		Msg("Getting favorites for user")
 
 	var favorites []entity.Favorite
 	start := time.Now()
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&favorites)
+	result := r.db.WithContext(ctx).Where("user_id = ? AND profile_id = ?", userID, profileID).Find(&favorites)
 	duration := time.Since(start)
 
 	if result.Error != nil {
@@ -87,7 +90,7 @@ func (r *favoriteRepository) AddToFavorites(ctx context.Context, favorite *entit
 	return nil
 }
 
-func (r *favoriteRepository) RemoveFromFavorites(ctx context.Context, userID uint, mediaID uint, mediaType string) error {
+func (r *favoriteRepository) RemoveFromFavorites(ctx context.Context, userID, profileID uint, mediaID uint, mediaType string) error {
 	r.appLogger.Debug().
 		Uint("userID", userID).
 		Str("mediaType", mediaType).
@@ -95,7 +98,7 @@ func (r *favoriteRepository) RemoveFromFavorites(ctx context.Context, userID uin
 		Msg("Removing from favorites")
 
 	start := time.Now()
-	result := r.db.WithContext(ctx).Where("user_id = ? AND media_id = ? AND media_type = ?", userID, mediaID, mediaType).Delete(&entity.Favorite{})
+	result := r.db.WithContext(ctx).Where("user_id = ? AND profile_id = ? AND media_id = ? AND media_type = ?", userID, profileID, mediaID, mediaType).Delete(&entity.Favorite{})
 	duration := time.Since(start)
 
 	if result.Error != nil {
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+// hotPathIndexes lists the composite indexes the hot query paths rely on:
+// path lookups during scans, library-scoped listings that always filter
+// soft-deleted rows, season resolution by show, and the per-user media
+// state lookups (watch history, watchlist, favorites, ratings). They are
+// created here rather than through struct tags because the embedded
+// LibraryItem would generate colliding index names across tables.
+var hotPathIndexes = []struct {
+	name    string
+	table   string
+	columns string
+}{
+	{"idx_movies_file_path", "movies", "file_path"},
+	{"idx_episodes_file_path", "episodes", "file_path"},
+	{"idx_library_items_file_path", "library_items", "file_path"},
+	{"idx_movies_library_scope", "movies", "library_id, deleted_at"},
+	{"idx_series_library_scope", "series", "library_id, deleted_at"},
+	{"idx_episodes_library_scope", "episodes", "library_id, deleted_at"},
+	{"idx_seasons_series_number", "seasons", "series_id, season_number"},
+	{"idx_watch_histories_user_media", "watch_histories", "user_id, media_type, media_id"},
+	{"idx_watchlists_user_media", "watchlists", "user_id, media_type, media_id"},
+	{"idx_favorites_user_media", "favorites", "user_id, media_type, media_id"},
+	{"idx_ratings_user_media", "ratings", "user_id, media_type, media_id"},
+}
+
+// ensureIndexes creates the hot-path indexes if they are missing. Runs
+// once at startup after auto-migration; IF NOT EXISTS is supported by
+// SQLite, Postgres and MariaDB alike.
+func ensureIndexes(db *gorm.DB, appLogger logger.Logger) error {
+	for _, idx := range hotPathIndexes {
+		stmt := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)", idx.name, idx.table, idx.columns)
+		if err := db.Exec(stmt).Error; err != nil {
+			return fmt.Errorf("failed to create index %s: %w", idx.name, err)
+		}
+	}
+
+	appLogger.Debug().Int("indexes", len(hotPathIndexes)).Msg("Hot-path indexes ensured")
+	return nil
+}
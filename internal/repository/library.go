@@ -8,6 +8,7 @@ import (
 
 	"github.com/samcharles93/cinea/internal/entity"
 	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
 	"gorm.io/gorm"
 )
 
@@ -19,6 +20,10 @@ type LibraryRepository interface {
 	UpdateLibrary(ctx context.Context, lib *entity.Library) error
 	DeleteLibrary(ctx context.Context, id uint) error
 
+	// Library Paths
+	AddPath(ctx context.Context, path *entity.LibraryPath) error
+	DeletePath(ctx context.Context, libraryID, pathID uint) error
+
 	// Library Items
 	AddItem(ctx context.Context, item *entity.LibraryItem) error
 	GetItem(ctx context.Context, id uint) (*entity.LibraryItem, error)
@@ -26,6 +31,7 @@ type LibraryRepository interface {
 	ListItems(ctx context.Context, libraryID uint) ([]*entity.LibraryItem, error)
 	UpdateItem(ctx context.Context, item *entity.LibraryItem) error
 	DeleteItem(ctx context.Context, id uint) error
+	DeleteItemsByLibrary(ctx context.Context, libraryID uint) error
 
 	GetStaleItems(ctx context.Context, threshold time.Duration) ([]*entity.LibraryItem, error)
 	FindMissingItems(ctx context.Context, lib *entity.Library) ([]*entity.LibraryItem, error)
@@ -95,12 +101,34 @@ func (r *libraryRepository) UpdateLibrary(ctx context.Context, lib *entity.Libra
 	return nil
 }
 
+// AddPath implements repository.LibraryRepository.
+func (r *libraryRepository) AddPath(ctx context.Context, path *entity.LibraryPath) error {
+	result := r.db.WithContext(ctx).Create(path)
+	if result.Error != nil {
+		return fmt.Errorf("failed to add library path: %w", result.Error)
+	}
+	return nil
+}
+
+// DeletePath implements repository.LibraryRepository. The library ID is part
+// of the condition so a path can only be removed through its own library.
+func (r *libraryRepository) DeletePath(ctx context.Context, libraryID, pathID uint) error {
+	result := r.db.WithContext(ctx).Where("library_id = ?", libraryID).Delete(&entity.LibraryPath{}, pathID)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete library path: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("library path not found")
+	}
+	return nil
+}
+
 // Library Item Management
 
 // FindItemByPath implements repository.LibraryRepository.
 func (r *libraryRepository) FindItemByPath(ctx context.Context, path string) (*entity.LibraryItem, error) {
 	var item entity.LibraryItem
-	result := r.db.WithContext(ctx).Where("file_path = ?", path).First(&item)
+	result := r.db.WithContext(ctx).Where("LOWER(file_path) = ?", pathutil.Fold(path)).First(&item)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil // Not found is not an error
@@ -140,6 +168,7 @@ func (r *libraryRepository) GetItem(ctx context.Context, id uint) (*entity.Libra
 }
 
 func (r *libraryRepository) AddItem(ctx context.Context, item *entity.LibraryItem) error {
+	item.FilePath = pathutil.Canonical(item.FilePath)
 	result := r.db.WithContext(ctx).Create(item)
 	if result.Error != nil {
 		return fmt.Errorf("failed to add library item: %w", result.Error)
@@ -155,6 +184,15 @@ func (r *libraryRepository) DeleteItem(ctx context.Context, id uint) error {
 	return nil
 }
 
+// DeleteItemsByLibrary implements repository.LibraryRepository.
+func (r *libraryRepository) DeleteItemsByLibrary(ctx context.Context, libraryID uint) error {
+	result := r.db.WithContext(ctx).Where("library_id = ?", libraryID).Delete(&entity.LibraryItem{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete library items: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *libraryRepository) GetStaleItems(ctx context.Context, threshold time.Duration) ([]*entity.LibraryItem, error) {
 	var items []*entity.LibraryItem
 	result := r.db.WithContext(ctx).Where("last_scanned &lt; ?", time.Now().Add(-threshold)).Find(&items)
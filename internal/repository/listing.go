@@ -0,0 +1,64 @@
+package repository
+
+// Sort keys accepted by the paged movie and series listings. The repository
+// maps them onto the concrete columns so callers never hand us raw SQL.
+const (
+	SortTitle   = "title"
+	SortAdded   = "added"
+	SortRelease = "release"
+	SortRating  = "rating"
+	// SortRuntime is only accepted by the movie listing.
+	SortRuntime = "runtime"
+)
+
+// MediaListOptions narrows and pages the movie and series listing queries.
+// A zero Limit means no limit.
+type MediaListOptions struct {
+	Limit    int
+	Offset   int
+	SortBy   string // one of the Sort* constants; defaults to SortTitle
+	SortDesc bool
+
+	// Query matches titles and original titles as a substring.
+	Query string
+
+	Genre string
+	// Year filters a single release year; a YearTo greater than Year
+	// widens it to the inclusive range Year..YearTo.
+	Year   int
+	YearTo int
+	// Language matches the original language (ISO 639-1); Country matches
+	// one of the production countries (ISO 3166-1).
+	Language string
+	Country  string
+	// Runtime bounds in minutes; MinRuntime is inclusive, MaxRuntime
+	// exclusive (so max=90 reads "shorter than 90 minutes"). Zero means
+	// unbounded. Movies only.
+	MinRuntime int
+	MaxRuntime int
+	// Watched filters on whether UserID has watch history for the item;
+	// nil leaves both watched and unwatched items in.
+	Watched   *bool
+	UserID    uint
+	ProfileID uint
+}
+
+// orderClause resolves the sort key against the given release-date column
+// ("release_date" for movies, "first_air_date" for series).
+func (o MediaListOptions) orderClause(releaseColumn string) string {
+	column := "title"
+	switch o.SortBy {
+	case SortAdded:
+		column = "created_at"
+	case SortRelease:
+		column = releaseColumn
+	case SortRating:
+		column = "vote_average"
+	case SortRuntime:
+		column = "runtime"
+	}
+	if o.SortDesc {
+		return column + " DESC"
+	}
+	return column
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type MetadataCacheRepository interface {
+	// Get returns the cached payload for the key, or nil when there is no
+	// live entry.
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, payload []byte, ttl time.Duration) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type metadataCacheRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewMetadataCacheRepository(db *gorm.DB, appLogger logger.Logger) MetadataCacheRepository {
+	return &metadataCacheRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *metadataCacheRepository) Get(ctx context.Context, key string) ([]byte, error) {
+	var entry entity.MetadataCacheEntry
+	result := r.db.WithContext(ctx).Where("key = ?", key).First(&entry)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read metadata cache: %w", result.Error)
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		return nil, nil
+	}
+	return entry.Payload, nil
+}
+
+func (r *metadataCacheRepository) Put(ctx context.Context, key string, payload []byte, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl)
+
+	var existing entity.MetadataCacheEntry
+	result := r.db.WithContext(ctx).Where("key = ?", key).First(&existing)
+	if result.Error == nil {
+		existing.Payload = payload
+		existing.ExpiresAt = expiresAt
+		if err := r.db.WithContext(ctx).Save(&existing).Error; err != nil {
+			return fmt.Errorf("failed to update metadata cache entry: %w", err)
+		}
+		return nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to find metadata cache entry: %w", result.Error)
+	}
+
+	entry := &entity.MetadataCacheEntry{Key: key, Payload: payload, ExpiresAt: expiresAt}
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create metadata cache entry: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired hard-deletes entries past their TTL so the cache table
+// doesn't grow with stale provider payloads.
+func (r *metadataCacheRepository) DeleteExpired(ctx context.Context) error {
+	if err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("expires_at < ?", time.Now()).
+		Delete(&entity.MetadataCacheEntry{}).Error; err != nil {
+		return fmt.Errorf("failed to delete expired metadata cache entries: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
+	"gorm.io/gorm"
+)
+
+// mergeDuplicatePaths rewrites stored file paths to their canonical form
+// and removes rows that only differ by case or Unicode normalization,
+// keeping the oldest row in each group. Runs once at startup after
+// auto-migration.
+func mergeDuplicatePaths(db *gorm.DB, appLogger logger.Logger) error {
+	for _, table := range []string{"movies", "episodes", "library_items"} {
+		if err := mergeTableDuplicates(db, appLogger, table); err != nil {
+			return fmt.Errorf("failed to merge duplicate paths in %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func mergeTableDuplicates(db *gorm.DB, appLogger logger.Logger, table string) error {
+	type row struct {
+		ID       uint
+		FilePath string
+	}
+
+	var rows []row
+	if err := db.Table(table).
+		Select("id, file_path").
+		Where("file_path != ''").
+		Order("id").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	keepers := make(map[string]uint)
+	for _, r := range rows {
+		canonical := pathutil.Canonical(r.FilePath)
+		folded := pathutil.Fold(r.FilePath)
+
+		if keeperID, ok := keepers[folded]; ok {
+			appLogger.Info().
+				Str("table", table).
+				Str("path", r.FilePath).
+				Uint("duplicate_of", keeperID).
+				Msg("Removing duplicate path entry")
+			if err := db.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", table), r.ID).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		keepers[folded] = r.ID
+
+		if canonical != r.FilePath {
+			if err := db.Table(table).
+				Where("id = ?", r.ID).
+				Update("file_path", canonical).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
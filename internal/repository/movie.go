@@ -3,27 +3,45 @@ package repository
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/samcharles93/cinea/internal/entity"
 	"github.com/samcharles93/cinea/internal/errors"
 	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 type MovieRepository interface {
 	Store(ctx context.Context, movie *entity.Movie) error
+	StoreBatch(ctx context.Context, movies []*entity.Movie) error
 	FindByID(ctx context.Context, id uint) (*entity.Movie, error)
 	FindByPath(ctx context.Context, path string) (*entity.Movie, error)
 	FindAll(ctx context.Context) ([]*entity.Movie, error)
+	FindPage(ctx context.Context, opts MediaListOptions) ([]*entity.Movie, int64, error)
+	FindTitleCandidates(ctx context.Context) ([]*entity.Movie, error)
 	Update(ctx context.Context, movie *entity.Movie) error
 	Delete(ctx context.Context, id uint) error
 
 	HardDelete(ctx context.Context, id uint) error
 	FindAllDeleted(ctx context.Context) ([]*entity.Movie, error)
 	Restore(ctx context.Context, id uint) error
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error)
 	UpdateScannedStatus(ctx context.Context, id uint) error
+	UpdateCommunityRating(ctx context.Context, id uint, average float64, count int) error
+	FindMetadataPending(ctx context.Context, limit int) ([]*entity.Movie, error)
+	FindByFileStem(ctx context.Context, stem string) (*entity.Movie, error)
+	FindByContentHash(ctx context.Context, hash string) ([]*entity.Movie, error)
+	FindDuplicates(ctx context.Context) ([]*entity.Movie, error)
+	FindByTMDbID(ctx context.Context, tmdbID int) (*entity.Movie, error)
+	FindByLibrary(ctx context.Context, libraryID uint) ([]*entity.Movie, error)
+
+	AddVersion(ctx context.Context, version *entity.MovieVersion) error
+	FindVersions(ctx context.Context, movieID uint) ([]*entity.MovieVersion, error)
+	FindVersionByPath(ctx context.Context, path string) (*entity.MovieVersion, error)
 }
 
 type movieRepository struct {
@@ -39,20 +57,37 @@ func NewMovieRepository(db *gorm.DB, appLogger logger.Logger) MovieRepository {
 }
 
 func (r *movieRepository) Store(ctx context.Context, movie *entity.Movie) error {
-	result := r.db.WithContext(ctx).Clauses(clause.Returning{}).Create(movie)
+	movie.FilePath = pathutil.Canonical(movie.FilePath)
+	result := dbConn(ctx, r.db).Clauses(clause.Returning{}).Create(movie)
 	if result.Error != nil {
 		return fmt.Errorf("failed to store movie: %w", result.Error)
 	}
 	return nil
 }
 
+// StoreBatch inserts movies in chunks inside one transaction; primary
+// keys are backfilled on the entities.
+func (r *movieRepository) StoreBatch(ctx context.Context, movies []*entity.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+	for _, movie := range movies {
+		movie.FilePath = pathutil.Canonical(movie.FilePath)
+	}
+	result := dbConn(ctx, r.db).Clauses(clause.Returning{}).CreateInBatches(movies, 100)
+	if result.Error != nil {
+		return fmt.Errorf("failed to batch store movies: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *movieRepository) FindByID(ctx context.Context, id uint) (*entity.Movie, error) {
 	if id == 0 {
 		return nil, fmt.Errorf("invalid movie ID: %w", errors.ErrBadRequest)
 	}
 
 	var movie entity.Movie
-	result := r.db.WithContext(ctx).First(&movie, id)
+	result := dbConn(ctx, r.db).First(&movie, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("movie with ID %d not found: %w", id, errors.ErrNotFound)
@@ -64,8 +99,19 @@ func (r *movieRepository) FindByID(ctx context.Context, id uint) (*entity.Movie,
 }
 
 func (r *movieRepository) FindByPath(ctx context.Context, path string) (*entity.Movie, error) {
+	// Stored paths are canonical, so the exact comparison hits the
+	// file_path index; the case-folded scan only runs for paths that
+	// arrive in a different case than they were stored with
 	var movie entity.Movie
-	result := r.db.WithContext(ctx).Where("file_path = ?", path).First(&movie)
+	result := dbConn(ctx, r.db).Where("file_path = ?", pathutil.Canonical(path)).First(&movie)
+	if result.Error == nil {
+		return &movie, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find movie by path: %w", result.Error)
+	}
+
+	result = dbConn(ctx, r.db).Where("LOWER(file_path) = ?", pathutil.Fold(path)).First(&movie)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -74,17 +120,118 @@ func (r *movieRepository) FindByPath(ctx context.Context, path string) (*entity.
 	}
 	return &movie, nil
 }
+
+// FindByFileStem finds a movie whose file name (without extension) matches
+// the given stem, used to match dropped subtitle files to their media.
+func (r *movieRepository) FindByFileStem(ctx context.Context, stem string) (*entity.Movie, error) {
+	folded := pathutil.Fold(stem)
+
+	var movies []*entity.Movie
+	result := dbConn(ctx, r.db).Where("LOWER(file_path) LIKE ?", "%"+folded+".%").Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find movie by file stem: %w", result.Error)
+	}
+
+	for _, movie := range movies {
+		base := filepath.Base(movie.FilePath)
+		if pathutil.Fold(strings.TrimSuffix(base, filepath.Ext(base))) == folded {
+			return movie, nil
+		}
+	}
+	return nil, nil
+}
+
 func (r *movieRepository) FindAll(ctx context.Context) ([]*entity.Movie, error) {
 	var movies []*entity.Movie
-	result := r.db.WithContext(ctx).Find(&movies)
+	result := dbConn(ctx, r.db).Find(&movies)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to find all movies: %w", result.Error)
 	}
 	return movies, nil
 }
 
+// FindPage returns one page of movies plus the total count matching the
+// filters, so callers can build a paging envelope.
+func (r *movieRepository) FindPage(ctx context.Context, opts MediaListOptions) ([]*entity.Movie, int64, error) {
+	q := dbConn(ctx, r.db).Model(&entity.Movie{})
+
+	if opts.Genre != "" {
+		q = q.Joins("JOIN media_genres ON media_genres.media_id = movies.id AND media_genres.media_type = ?", "movie").
+			Joins("JOIN genres ON genres.id = media_genres.genre_id").
+			Where("genres.name = ?", opts.Genre)
+	}
+	if opts.Query != "" {
+		pattern := "%" + opts.Query + "%"
+		q = q.Where("(title LIKE ? OR original_title LIKE ?)", pattern, pattern)
+	}
+	if opts.Year > 0 {
+		start := time.Date(opts.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(1, 0, 0)
+		if opts.YearTo > opts.Year {
+			end = time.Date(opts.YearTo+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+		q = q.Where("release_date >= ? AND release_date < ?", start, end)
+	}
+	if opts.Language != "" {
+		q = q.Where("original_language = ?", opts.Language)
+	}
+	if opts.Country != "" {
+		// production_countries is a comma-separated list of ISO codes;
+		// pad with commas so "US" can't match "AUS"
+		q = q.Where("(',' || production_countries || ',') LIKE ?", "%,"+opts.Country+",%")
+	}
+	if opts.MinRuntime > 0 {
+		q = q.Where("runtime >= ?", opts.MinRuntime)
+	}
+	if opts.MaxRuntime > 0 {
+		q = q.Where("runtime > 0 AND runtime < ?", opts.MaxRuntime)
+	}
+	if opts.Watched != nil {
+		sub := r.db.Table("watch_histories").Select("1").
+			Where("watch_histories.media_type = ? AND watch_histories.media_id = movies.id AND watch_histories.user_id = ? AND watch_histories.profile_id = ? AND watch_histories.deleted_at IS NULL", "movie", opts.UserID, opts.ProfileID)
+		if *opts.Watched {
+			q = q.Where("EXISTS (?)", sub)
+		} else {
+			q = q.Where("NOT EXISTS (?)", sub)
+		}
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count movies: %w", err)
+	}
+
+	q = q.Order(opts.orderClause("release_date"))
+	if opts.Limit > 0 {
+		q = q.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		q = q.Offset(opts.Offset)
+	}
+
+	var movies []*entity.Movie
+	result := q.Find(&movies)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to find movies page: %w", result.Error)
+	}
+	return movies, total, nil
+}
+
+// FindTitleCandidates returns every movie with just the columns the
+// search suggestion scorer needs.
+func (r *movieRepository) FindTitleCandidates(ctx context.Context) ([]*entity.Movie, error) {
+	var movies []*entity.Movie
+	result := dbConn(ctx, r.db).
+		Select("id", "title", "original_title", "poster_path", "release_date").
+		Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find movie title candidates: %w", result.Error)
+	}
+	return movies, nil
+}
+
 func (r *movieRepository) Update(ctx context.Context, movie *entity.Movie) error {
-	result := r.db.WithContext(ctx).Save(movie)
+	result := dbConn(ctx, r.db).Save(movie)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update movie: %w", result.Error)
 	}
@@ -92,7 +239,7 @@ func (r *movieRepository) Update(ctx context.Context, movie *entity.Movie) error
 }
 
 func (r *movieRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Movie{}, id)
+	result := dbConn(ctx, r.db).Delete(&entity.Movie{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete movie: %w", result.Error)
 	}
@@ -100,7 +247,7 @@ func (r *movieRepository) Delete(ctx context.Context, id uint) error {
 }
 
 func (r *movieRepository) HardDelete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Unscoped().Delete(&entity.Movie{}, id)
+	result := dbConn(ctx, r.db).Unscoped().Delete(&entity.Movie{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to hard delete movie: %w", result.Error)
 	}
@@ -109,7 +256,7 @@ func (r *movieRepository) HardDelete(ctx context.Context, id uint) error {
 
 func (r *movieRepository) FindAllDeleted(ctx context.Context) ([]*entity.Movie, error) {
 	var movies []*entity.Movie
-	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Find(&movies)
+	result := dbConn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Find(&movies)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to find all deleted movies: %w", result.Error)
 	}
@@ -117,17 +264,149 @@ func (r *movieRepository) FindAllDeleted(ctx context.Context) ([]*entity.Movie,
 }
 
 func (r *movieRepository) Restore(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Unscoped().Model(&entity.Movie{}).Where("id = ?", id).Update("deleted_at", nil)
+	result := dbConn(ctx, r.db).Unscoped().Model(&entity.Movie{}).Where("id = ?", id).Update("deleted_at", nil)
 	if result.Error != nil {
 		return fmt.Errorf("failed to restore movie: %w", result.Error)
 	}
 	return nil
 }
 
+// PurgeDeleted hard-deletes movies that have sat in the recycle bin for
+// longer than the retention window, returning how many were purged.
+func (r *movieRepository) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result := dbConn(ctx, r.db).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", time.Now().Add(-olderThan)).
+		Delete(&entity.Movie{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge deleted movies: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// UpdateCommunityRating writes the aggregated local rating columns
+// without touching the rest of the row.
+func (r *movieRepository) UpdateCommunityRating(ctx context.Context, id uint, average float64, count int) error {
+	result := dbConn(ctx, r.db).Model(&entity.Movie{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"community_rating": average, "community_rating_count": count})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update community rating: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *movieRepository) UpdateScannedStatus(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Model(&entity.Movie{}).Where("id = ?", id).Update("last_scanned", time.Now())
+	result := dbConn(ctx, r.db).Model(&entity.Movie{}).Where("id = ?", id).Update("last_scanned", time.Now())
 	if result.Error != nil {
 		return fmt.Errorf("failed to update scanned status: %w", result.Error)
 	}
 	return nil
 }
+
+// FindByContentHash returns the movies fingerprinted with the given hash,
+// used by the scanner to recognise a moved file.
+func (r *movieRepository) FindByContentHash(ctx context.Context, hash string) ([]*entity.Movie, error) {
+	var movies []*entity.Movie
+	result := dbConn(ctx, r.db).Where("content_hash = ?", hash).Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find movies by content hash: %w", result.Error)
+	}
+	return movies, nil
+}
+
+// FindDuplicates returns every movie whose content hash is shared with at
+// least one other movie, ordered so rows of the same hash sit together.
+func (r *movieRepository) FindDuplicates(ctx context.Context) ([]*entity.Movie, error) {
+	dupes := r.db.Model(&entity.Movie{}).Select("content_hash").
+		Where("content_hash <> ''").
+		Group("content_hash").
+		Having("COUNT(*) > 1")
+
+	var movies []*entity.Movie
+	result := dbConn(ctx, r.db).
+		Where("content_hash IN (?)", dupes).
+		Order("content_hash, id").
+		Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find duplicate movies: %w", result.Error)
+	}
+	return movies, nil
+}
+
+// FindByTMDbID finds the movie already imported for a TMDb identity, used
+// by the scanner to group alternate files under one movie row.
+func (r *movieRepository) FindByTMDbID(ctx context.Context, tmdbID int) (*entity.Movie, error) {
+	if tmdbID == 0 {
+		return nil, nil
+	}
+
+	var movie entity.Movie
+	result := dbConn(ctx, r.db).Where("tmdb_id = ?", tmdbID).First(&movie)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find movie by TMDb ID: %w", result.Error)
+	}
+	return &movie, nil
+}
+
+// FindByLibrary returns every movie imported into one library, used by the
+// cleanup job to verify files still exist.
+func (r *movieRepository) FindByLibrary(ctx context.Context, libraryID uint) ([]*entity.Movie, error) {
+	var movies []*entity.Movie
+	result := dbConn(ctx, r.db).Where("library_id = ?", libraryID).Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find movies by library: %w", result.Error)
+	}
+	return movies, nil
+}
+
+// AddVersion stores an alternate file of a movie.
+func (r *movieRepository) AddVersion(ctx context.Context, version *entity.MovieVersion) error {
+	version.FilePath = pathutil.Canonical(version.FilePath)
+	result := dbConn(ctx, r.db).Create(version)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store movie version: %w", result.Error)
+	}
+	return nil
+}
+
+// FindVersions returns a movie's alternate files, oldest first so the
+// version selector stays stable across scans.
+func (r *movieRepository) FindVersions(ctx context.Context, movieID uint) ([]*entity.MovieVersion, error) {
+	var versions []*entity.MovieVersion
+	result := dbConn(ctx, r.db).Where("movie_id = ?", movieID).Order("id").Find(&versions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find movie versions: %w", result.Error)
+	}
+	return versions, nil
+}
+
+func (r *movieRepository) FindVersionByPath(ctx context.Context, path string) (*entity.MovieVersion, error) {
+	var version entity.MovieVersion
+	result := dbConn(ctx, r.db).Where("file_path = ?", pathutil.Canonical(path)).First(&version)
+	if result.Error == nil {
+		return &version, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find movie version by path: %w", result.Error)
+	}
+
+	result = dbConn(ctx, r.db).Where("LOWER(file_path) = ?", pathutil.Fold(path)).First(&version)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find movie version by path: %w", result.Error)
+	}
+	return &version, nil
+}
+
+func (r *movieRepository) FindMetadataPending(ctx context.Context, limit int) ([]*entity.Movie, error) {
+	var movies []*entity.Movie
+	result := dbConn(ctx, r.db).Where("metadata_pending = ?", true).Limit(limit).Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find movies pending metadata: %w", result.Error)
+	}
+	return movies, nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type PersonRepository interface {
+	Store(ctx context.Context, person *entity.Person) error
+	FindByID(ctx context.Context, id uint) (*entity.Person, error)
+	FindByTMDbID(ctx context.Context, tmdbID int) (*entity.Person, error)
+}
+
+type personRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewPersonRepository(db *gorm.DB, appLogger logger.Logger) PersonRepository {
+	return &personRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *personRepository) Store(ctx context.Context, person *entity.Person) error {
+	result := r.db.WithContext(ctx).Create(person)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store person: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *personRepository) FindByID(ctx context.Context, id uint) (*entity.Person, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid person ID: %w", errors.ErrBadRequest)
+	}
+
+	var person entity.Person
+	result := r.db.WithContext(ctx).First(&person, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("database error finding person %d: %w", id, result.Error)
+	}
+
+	return &person, nil
+}
+
+func (r *personRepository) FindByTMDbID(ctx context.Context, tmdbID int) (*entity.Person, error) {
+	var person entity.Person
+	result := r.db.WithContext(ctx).Where("tmdb_id = ?", tmdbID).First(&person)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find person by TMDb ID: %w", result.Error)
+	}
+	return &person, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
+	"gorm.io/gorm"
+)
+
+type PhotoRepository interface {
+	Store(ctx context.Context, photo *entity.Photo) error
+	FindByID(ctx context.Context, id uint) (*entity.Photo, error)
+	FindByPath(ctx context.Context, filePath string) (*entity.Photo, error)
+	ListTimeline(ctx context.Context, limit int) ([]*entity.Photo, error)
+	ListAlbums(ctx context.Context) ([]string, error)
+	ListByAlbum(ctx context.Context, album string) ([]*entity.Photo, error)
+}
+
+type photoRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewPhotoRepository(db *gorm.DB, appLogger logger.Logger) PhotoRepository {
+	return &photoRepository{db: db, appLogger: appLogger}
+}
+
+func (r *photoRepository) Store(ctx context.Context, photo *entity.Photo) error {
+	photo.FilePath = pathutil.Canonical(photo.FilePath)
+	result := r.db.WithContext(ctx).Create(photo)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store photo: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *photoRepository) FindByID(ctx context.Context, id uint) (*entity.Photo, error) {
+	var photo entity.Photo
+	result := r.db.WithContext(ctx).First(&photo, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find photo by id: %w", result.Error)
+	}
+	return &photo, nil
+}
+
+func (r *photoRepository) FindByPath(ctx context.Context, filePath string) (*entity.Photo, error) {
+	var photo entity.Photo
+	result := r.db.WithContext(ctx).Where("LOWER(file_path) = ?", pathutil.Fold(filePath)).First(&photo)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find photo by path: %w", result.Error)
+	}
+	return &photo, nil
+}
+
+func (r *photoRepository) ListTimeline(ctx context.Context, limit int) ([]*entity.Photo, error) {
+	var photos []*entity.Photo
+	result := r.db.WithContext(ctx).Order("taken_at DESC").Limit(limit).Find(&photos)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list photo timeline: %w", result.Error)
+	}
+	return photos, nil
+}
+
+func (r *photoRepository) ListAlbums(ctx context.Context) ([]string, error) {
+	var albums []string
+	result := r.db.WithContext(ctx).Model(&entity.Photo{}).
+		Distinct("album").Where("album <> ''").Order("album").Pluck("album", &albums)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list photo albums: %w", result.Error)
+	}
+	return albums, nil
+}
+
+func (r *photoRepository) ListByAlbum(ctx context.Context, album string) ([]*entity.Photo, error) {
+	var photos []*entity.Photo
+	result := r.db.WithContext(ctx).Where("album = ?", album).Order("taken_at").Find(&photos)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list photos by album: %w", result.Error)
+	}
+	return photos, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type ProfileRepository interface {
+	ListByUser(ctx context.Context, userID uint) ([]*entity.Profile, error)
+	FindByID(ctx context.Context, id uint) (*entity.Profile, error)
+	Store(ctx context.Context, profile *entity.Profile) error
+	Update(ctx context.Context, profile *entity.Profile) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type profileRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewProfileRepository(db *gorm.DB, appLogger logger.Logger) ProfileRepository {
+	return &profileRepository{db: db, appLogger: appLogger}
+}
+
+func (r *profileRepository) ListByUser(ctx context.Context, userID uint) ([]*entity.Profile, error) {
+	var profiles []*entity.Profile
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id").Find(&profiles).Error; err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+func (r *profileRepository) FindByID(ctx context.Context, id uint) (*entity.Profile, error) {
+	var profile entity.Profile
+	result := r.db.WithContext(ctx).First(&profile, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find profile: %w", result.Error)
+	}
+	return &profile, nil
+}
+
+func (r *profileRepository) Store(ctx context.Context, profile *entity.Profile) error {
+	if err := r.db.WithContext(ctx).Create(profile).Error; err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	return nil
+}
+
+func (r *profileRepository) Update(ctx context.Context, profile *entity.Profile) error {
+	if err := r.db.WithContext(ctx).Save(profile).Error; err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+	return nil
+}
+
+func (r *profileRepository) Delete(ctx context.Context, id uint) error {
+	if err := r.db.WithContext(ctx).Delete(&entity.Profile{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
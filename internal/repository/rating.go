@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
 	"github.com/samcharles93/cinea/internal/logger"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -13,9 +14,12 @@ import (
 
 type RatingRepository interface {
 	GetRatings(ctx context.Context, userID uint) ([]entity.Rating, error)
+	FindByUserAndMedia(ctx context.Context, userID uint, mediaID uint, mediaType string) (*entity.Rating, error)
 	AddRating(ctx context.Context, rating *entity.Rating) error
 	UpdateRating(ctx context.Context, rating *entity.Rating) error
 	RemoveRating(ctx context.Context, userID uint, mediaID uint, mediaType string) error
+	ListRecent(ctx context.Context, limit int) ([]entity.Rating, error)
+	Aggregate(ctx context.Context, mediaType string, mediaID uint) (float64, int64, error)
 }
 
 type ratingRepository struct {
@@ -55,6 +59,22 @@ func (r *ratingRepository) GetRatings(ctx context.Context, userID uint) ([]entit
 	return ratings, nil
 }
 
+// FindByUserAndMedia returns the user's rating for the item, or nil when
+// they have not rated it.
+func (r *ratingRepository) FindByUserAndMedia(ctx context.Context, userID uint, mediaID uint, mediaType string) (*entity.Rating, error) {
+	var rating entity.Rating
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND media_id = ? AND media_type = ?", userID, mediaID, mediaType).
+		First(&rating)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find rating: %w", result.Error)
+	}
+	return &rating, nil
+}
+
 func (r *ratingRepository) AddRating(ctx context.Context, rating *entity.Rating) error {
 	r.appLogger.Debug().
 		Uint("userID", rating.UserID).
@@ -145,3 +165,45 @@ func (r *ratingRepository) RemoveRating(ctx context.Context, userID uint, mediaI
 		Msg("Rating removed successfully")
 	return nil
 }
+
+// Aggregate returns the average score and rating count across all users
+// for one media item, zero values when nobody has rated it.
+func (r *ratingRepository) Aggregate(ctx context.Context, mediaType string, mediaID uint) (float64, int64, error) {
+	var agg struct {
+		Average float64
+		Count   int64
+	}
+	result := r.db.WithContext(ctx).Model(&entity.Rating{}).
+		Select("COALESCE(AVG(score), 0) AS average, COUNT(*) AS count").
+		Where("media_id = ? AND media_type = ?", mediaID, mediaType).
+		Scan(&agg)
+	if result.Error != nil {
+		return 0, 0, fmt.Errorf("failed to aggregate ratings: %w", result.Error)
+	}
+	return agg.Average, agg.Count, nil
+}
+
+// ListRecent returns the most recent ratings across all users, newest
+// first, for the household activity feed.
+func (r *ratingRepository) ListRecent(ctx context.Context, limit int) ([]entity.Rating, error) {
+	r.appLogger.Debug().
+		Int("limit", limit).
+		Msg("Getting recent ratings")
+
+	var ratings []entity.Rating
+	start := time.Now()
+	result := r.db.WithContext(ctx).Order("created_at DESC").Limit(limit).Find(&ratings)
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("sql", result.Statement.SQL.String()).
+			Any("args", result.Statement.Vars).
+			Dur("duration", duration).
+			Msg("Failed to get recent ratings")
+		return nil, fmt.Errorf("failed to get recent ratings: %w", result.Error)
+	}
+
+	return ratings, nil
+}
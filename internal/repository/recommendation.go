@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type RecommendationRepository interface {
+	ReplaceForUser(ctx context.Context, userID uint, recommendations []*entity.Recommendation) error
+	FindByUser(ctx context.Context, userID uint) ([]*entity.Recommendation, error)
+}
+
+type recommendationRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewRecommendationRepository(db *gorm.DB, appLogger logger.Logger) RecommendationRepository {
+	return &recommendationRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+// ReplaceForUser atomically swaps the user's cached recommendations for a
+// freshly generated batch.
+func (r *recommendationRepository) ReplaceForUser(ctx context.Context, userID uint, recommendations []*entity.Recommendation) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&entity.Recommendation{}).Error; err != nil {
+			return err
+		}
+		if len(recommendations) == 0 {
+			return nil
+		}
+		return tx.Create(recommendations).Error
+	})
+	if err != nil {
+		r.appLogger.Error().
+			Err(err).
+			Uint("userID", userID).
+			Msg("Failed to replace recommendations")
+		return fmt.Errorf("failed to replace recommendations: %w", err)
+	}
+	return nil
+}
+
+// FindByUser returns the user's cached recommendations grouped by seed,
+// best score first within each group.
+func (r *recommendationRepository) FindByUser(ctx context.Context, userID uint) ([]*entity.Recommendation, error) {
+	var recommendations []*entity.Recommendation
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("seed_media_type, seed_media_id, score DESC").
+		Find(&recommendations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get recommendations: %w", result.Error)
+	}
+	return recommendations, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	Store(ctx context.Context, token *entity.RefreshToken) error
+	FindByID(ctx context.Context, id uint) (*entity.RefreshToken, error)
+	FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error)
+	Revoke(ctx context.Context, id uint) error
+	RevokeAllForUser(ctx context.Context, userID uint) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type refreshTokenRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewRefreshTokenRepository(db *gorm.DB, appLogger logger.Logger) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *refreshTokenRepository) Store(ctx context.Context, token *entity.RefreshToken) error {
+	result := r.db.WithContext(ctx).Create(token)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("userID", token.UserID).
+			Msg("Failed to store refresh token")
+		return fmt.Errorf("failed to store refresh token: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) FindByID(ctx context.Context, id uint) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	result := r.db.WithContext(ctx).First(&token, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("tokenID", id).
+			Msg("Failed to find refresh token by ID")
+		return nil, fmt.Errorf("failed to find refresh token by ID: %w", result.Error)
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, hash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	result := r.db.WithContext(ctx).Where("token_hash = ?", hash).First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to find refresh token by hash")
+		return nil, fmt.Errorf("failed to find refresh token by hash: %w", result.Error)
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) Revoke(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("tokenID", id).
+			Msg("Failed to revoke refresh token")
+		return fmt.Errorf("failed to revoke refresh token: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) RevokeAllForUser(ctx context.Context, userID uint) error {
+	result := r.db.WithContext(ctx).
+		Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("userID", userID).
+			Msg("Failed to revoke user's refresh tokens")
+		return fmt.Errorf("failed to revoke user's refresh tokens: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *refreshTokenRepository) DeleteExpired(ctx context.Context) error {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", time.Now()).
+		Delete(&entity.RefreshToken{})
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to delete expired refresh tokens")
+		return fmt.Errorf("failed to delete expired refresh tokens: %w", result.Error)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/samcharles93/cinea/internal/entity"
 	"gorm.io/gorm"
@@ -19,6 +20,12 @@ type SchedulerRepository interface {
 
 	ToggleTaskStatus(ctx context.Context, id uint) error
 	// RestoreDefaultTasks(ctx context.Context) error // old method, to be removed
+
+	AddRun(ctx context.Context, run *entity.TaskRun) error
+	ListRunsByTask(ctx context.Context, taskID uint, limit int) ([]*entity.TaskRun, error)
+
+	AcquireLease(ctx context.Context, taskName, holder string, ttl time.Duration) (bool, error)
+	ReleaseLease(ctx context.Context, taskName, holder string) error
 }
 
 type schedulerRepository struct {
@@ -76,6 +83,84 @@ func (r *schedulerRepository) RemoveTask(ctx context.Context, id uint) error {
 	return nil
 }
 
+func (r *schedulerRepository) AddRun(ctx context.Context, run *entity.TaskRun) error {
+	result := r.db.WithContext(ctx).Create(run)
+	if result.Error != nil {
+		return fmt.Errorf("failed to add task run: %w", result.Error)
+	}
+	return nil
+}
+
+// ListRunsByTask returns the most recent runs of a task, newest first.
+func (r *schedulerRepository) ListRunsByTask(ctx context.Context, taskID uint, limit int) ([]*entity.TaskRun, error) {
+	var runs []*entity.TaskRun
+	result := r.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Order("started_at DESC").
+		Limit(limit).
+		Find(&runs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list task runs: %w", result.Error)
+	}
+	return runs, nil
+}
+
+// AcquireLease attempts to claim the task for a node. It succeeds when no
+// lease exists, the node already holds it, or the previous lease expired;
+// it reports false when another live node holds the task. Losing a create
+// race to another node also reports false.
+func (r *schedulerRepository) AcquireLease(ctx context.Context, taskName, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	acquired := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var lease entity.TaskLease
+		result := tx.Where("task_name = ?", taskName).First(&lease)
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			lease = entity.TaskLease{TaskName: taskName, Holder: holder, ExpiresAt: now.Add(ttl)}
+			if err := tx.Create(&lease).Error; err != nil {
+				// Losing the create race to another node is expected;
+				// anything else (lost connection, full disk) is a real
+				// failure the caller must see
+				if errors.Is(err, gorm.ErrDuplicatedKey) {
+					return nil
+				}
+				return err
+			}
+			acquired = true
+			return nil
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+
+		updated := tx.Model(&entity.TaskLease{}).
+			Where("task_name = ? AND (holder = ? OR expires_at <= ?)", taskName, holder, now).
+			Updates(map[string]any{"holder": holder, "expires_at": now.Add(ttl)})
+		if updated.Error != nil {
+			return updated.Error
+		}
+		acquired = updated.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire task lease: %w", err)
+	}
+	return acquired, nil
+}
+
+// ReleaseLease expires the node's own lease immediately so other nodes can
+// pick the task up on its next fire. A lease held by someone else is left
+// alone.
+func (r *schedulerRepository) ReleaseLease(ctx context.Context, taskName, holder string) error {
+	result := r.db.WithContext(ctx).Model(&entity.TaskLease{}).
+		Where("task_name = ? AND holder = ?", taskName, holder).
+		Update("expires_at", time.Now())
+	if result.Error != nil {
+		return fmt.Errorf("failed to release task lease: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *schedulerRepository) ToggleTaskStatus(ctx context.Context, id uint) error {
 	task, err := r.GetTaskByID(ctx, id)
 	if err != nil {
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type SearchHistoryRepository interface {
+	Record(ctx context.Context, userID uint, query string) error
+	FindRecent(ctx context.Context, userID uint, limit int) ([]*entity.SearchHistory, error)
+	Clear(ctx context.Context, userID uint) error
+}
+
+type searchHistoryRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewSearchHistoryRepository(db *gorm.DB, appLogger logger.Logger) SearchHistoryRepository {
+	return &searchHistoryRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+// Record stores a search query for a user. Repeating a query moves it to
+// the top of the recent list instead of duplicating it.
+func (r *searchHistoryRepository) Record(ctx context.Context, userID uint, query string) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND query = ?", userID, query).
+		Delete(&entity.SearchHistory{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to dedupe search history: %w", result.Error)
+	}
+
+	entry := &entity.SearchHistory{UserID: userID, Query: query}
+	if err := r.db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to record search: %w", err)
+	}
+	return nil
+}
+
+func (r *searchHistoryRepository) FindRecent(ctx context.Context, userID uint, limit int) ([]*entity.SearchHistory, error) {
+	var entries []*entity.SearchHistory
+	result := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&entries)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find recent searches: %w", result.Error)
+	}
+	return entries, nil
+}
+
+func (r *searchHistoryRepository) Clear(ctx context.Context, userID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ?", userID).
+		Delete(&entity.SearchHistory{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to clear search history: %w", result.Error)
+	}
+	return nil
+}
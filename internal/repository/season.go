@@ -16,6 +16,7 @@ type SeasonRepository interface {
 	FindBySeriesID(ctx context.Context, seriesID uint) (*entity.Season, error)
 	UpdateSeason(ctx context.Context, season *entity.Season) error
 	DeleteSeason(ctx context.Context, id uint) error
+	DeleteBySeries(ctx context.Context, seriesID uint) error
 }
 
 type seasonRepository struct {
@@ -31,7 +32,7 @@ func NewSeasonRepository(db *gorm.DB, appLogger logger.Logger) SeasonRepository
 }
 
 func (r *seasonRepository) AddSeason(ctx context.Context, season *entity.Season) error {
-	result := r.db.WithContext(ctx).Clauses(clause.Returning{}).Create(season)
+	result := dbConn(ctx, r.db).Clauses(clause.Returning{}).Create(season)
 	if result.Error != nil {
 		return fmt.Errorf("failed to add season: %w", result.Error)
 	}
@@ -40,7 +41,7 @@ func (r *seasonRepository) AddSeason(ctx context.Context, season *entity.Season)
 
 func (r *seasonRepository) FindBySeriesID(ctx context.Context, seriesID uint) (*entity.Season, error) {
 	var season entity.Season
-	result := r.db.WithContext(ctx).Preload("Episodes").First(&season, seriesID)
+	result := dbConn(ctx, r.db).Preload("Episodes").First(&season, seriesID)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -51,7 +52,7 @@ func (r *seasonRepository) FindBySeriesID(ctx context.Context, seriesID uint) (*
 }
 
 func (r *seasonRepository) UpdateSeason(ctx context.Context, season *entity.Season) error {
-	result := r.db.WithContext(ctx).Save(season)
+	result := dbConn(ctx, r.db).Save(season)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update season: %w", result.Error)
 	}
@@ -59,9 +60,17 @@ func (r *seasonRepository) UpdateSeason(ctx context.Context, season *entity.Seas
 }
 
 func (r *seasonRepository) DeleteSeason(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Season{}, id)
+	result := dbConn(ctx, r.db).Delete(&entity.Season{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete season: %w", result.Error)
 	}
 	return nil
 }
+
+func (r *seasonRepository) DeleteBySeries(ctx context.Context, seriesID uint) error {
+	result := dbConn(ctx, r.db).Where("series_id = ?", seriesID).Delete(&entity.Season{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete seasons for series: %w", result.Error)
+	}
+	return nil
+}
@@ -12,11 +12,39 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// SeriesLoadOption opts a series query into eager-loading associations.
+// Queries are shallow by default, so callers that only need the series
+// row (titles, artwork, parental checks) don't drag every episode of
+// every show out of the database.
+type SeriesLoadOption func(*gorm.DB) *gorm.DB
+
+// WithSeasons eager-loads the seasons without their episodes, enough for
+// list views that show season counts.
+func WithSeasons() SeriesLoadOption {
+	return func(q *gorm.DB) *gorm.DB { return q.Preload("Seasons") }
+}
+
+// WithEpisodes eager-loads the seasons together with their episodes, for
+// detail views that render the full season/episode tree.
+func WithEpisodes() SeriesLoadOption {
+	return func(q *gorm.DB) *gorm.DB { return q.Preload("Seasons.Episodes") }
+}
+
+// applySeriesLoads chains the requested eager loads onto a query.
+func applySeriesLoads(q *gorm.DB, loads []SeriesLoadOption) *gorm.DB {
+	for _, load := range loads {
+		q = load(q)
+	}
+	return q
+}
+
 type SeriesRepository interface {
 	// Basic CRUD
 	Store(ctx context.Context, show *entity.Series) error
-	FindByID(ctx context.Context, id uint) (*entity.Series, error)
-	FindAll(ctx context.Context) ([]*entity.Series, error)
+	FindByID(ctx context.Context, id uint, loads ...SeriesLoadOption) (*entity.Series, error)
+	FindAll(ctx context.Context, loads ...SeriesLoadOption) ([]*entity.Series, error)
+	FindPage(ctx context.Context, opts MediaListOptions, loads ...SeriesLoadOption) ([]*entity.Series, int64, error)
+	FindTitleCandidates(ctx context.Context) ([]*entity.Series, error)
 	Update(ctx context.Context, show *entity.Series) error
 
 	// Soft Delete Management
@@ -25,10 +53,14 @@ type SeriesRepository interface {
 	FindAllDeleted(ctx context.Context) ([]*entity.Series, error)
 	Restore(ctx context.Context, id uint) error
 	CleanupDeletedShows(ctx context.Context, olderThan time.Duration) error
+	PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error)
 
 	// Scanning Management
 	UpdateScannedStatus(ctx context.Context, id uint) error
+	UpdateCommunityRating(ctx context.Context, id uint, average float64, count int) error
 	FindStaleShows(ctx context.Context, threshold time.Duration) ([]*entity.Series, error)
+	FindByTitle(ctx context.Context, title string) (*entity.Series, error)
+	FindMetadataPending(ctx context.Context, limit int) ([]*entity.Series, error)
 }
 
 type seriesRepository struct {
@@ -46,16 +78,16 @@ func NewSeriesRepository(db *gorm.DB, appLogger logger.Logger) SeriesRepository
 // TV Series Management
 // Basic CRUD
 func (r *seriesRepository) Store(ctx context.Context, show *entity.Series) error {
-	result := r.db.WithContext(ctx).Clauses(clause.Returning{}).Create(show)
+	result := dbConn(ctx, r.db).Clauses(clause.Returning{}).Create(show)
 	if result.Error != nil {
 		return fmt.Errorf("failed to create show: %w", result.Error)
 	}
 	return nil
 }
 
-func (r *seriesRepository) FindByID(ctx context.Context, id uint) (*entity.Series, error) {
+func (r *seriesRepository) FindByID(ctx context.Context, id uint, loads ...SeriesLoadOption) (*entity.Series, error) {
 	var show entity.Series
-	result := r.db.WithContext(ctx).Preload("Seasons.Episodes").First(&show, id)
+	result := applySeriesLoads(dbConn(ctx, r.db), loads).First(&show, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, nil
@@ -65,17 +97,93 @@ func (r *seriesRepository) FindByID(ctx context.Context, id uint) (*entity.Serie
 	return &show, nil
 }
 
-func (r *seriesRepository) FindAll(ctx context.Context) ([]*entity.Series, error) {
+func (r *seriesRepository) FindAll(ctx context.Context, loads ...SeriesLoadOption) ([]*entity.Series, error) {
 	var shows []*entity.Series
-	result := r.db.WithContext(ctx).Preload("Seasons.Episodes").Find(&shows)
+	result := applySeriesLoads(dbConn(ctx, r.db), loads).Find(&shows)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to list shows: %w", result.Error)
 	}
 	return shows, nil
 }
 
+// FindPage returns one page of series plus the total count matching the
+// filters, so callers can build a paging envelope. A series counts as
+// watched once any of its episodes has watch history.
+func (r *seriesRepository) FindPage(ctx context.Context, opts MediaListOptions, loads ...SeriesLoadOption) ([]*entity.Series, int64, error) {
+	q := dbConn(ctx, r.db).Model(&entity.Series{})
+
+	if opts.Genre != "" {
+		q = q.Joins("JOIN media_genres ON media_genres.media_id = series.id AND media_genres.media_type = ?", "series").
+			Joins("JOIN genres ON genres.id = media_genres.genre_id").
+			Where("genres.name = ?", opts.Genre)
+	}
+	if opts.Query != "" {
+		pattern := "%" + opts.Query + "%"
+		q = q.Where("(title LIKE ? OR original_title LIKE ?)", pattern, pattern)
+	}
+	if opts.Year > 0 {
+		start := time.Date(opts.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(1, 0, 0)
+		if opts.YearTo > opts.Year {
+			end = time.Date(opts.YearTo+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+		q = q.Where("first_air_date >= ? AND first_air_date < ?", start, end)
+	}
+	if opts.Language != "" {
+		q = q.Where("original_language = ?", opts.Language)
+	}
+	if opts.Country != "" {
+		// production_countries is a comma-separated list of ISO codes;
+		// pad with commas so "US" can't match "AUS"
+		q = q.Where("(',' || production_countries || ',') LIKE ?", "%,"+opts.Country+",%")
+	}
+	if opts.Watched != nil {
+		sub := r.db.Table("watch_histories").Select("1").
+			Joins("JOIN episodes ON episodes.id = watch_histories.media_id AND watch_histories.media_type = ?", "episode").
+			Where("episodes.series_id = series.id AND watch_histories.user_id = ? AND watch_histories.profile_id = ? AND watch_histories.deleted_at IS NULL", opts.UserID, opts.ProfileID)
+		if *opts.Watched {
+			q = q.Where("EXISTS (?)", sub)
+		} else {
+			q = q.Where("NOT EXISTS (?)", sub)
+		}
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count series: %w", err)
+	}
+
+	q = q.Order(opts.orderClause("first_air_date"))
+	if opts.Limit > 0 {
+		q = q.Limit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		q = q.Offset(opts.Offset)
+	}
+
+	var shows []*entity.Series
+	result := applySeriesLoads(q, loads).Find(&shows)
+	if result.Error != nil {
+		return nil, 0, fmt.Errorf("failed to find series page: %w", result.Error)
+	}
+	return shows, total, nil
+}
+
+// FindTitleCandidates returns every series with just the columns the
+// search suggestion scorer needs.
+func (r *seriesRepository) FindTitleCandidates(ctx context.Context) ([]*entity.Series, error) {
+	var shows []*entity.Series
+	result := dbConn(ctx, r.db).
+		Select("id", "title", "original_title", "poster_path", "first_air_date").
+		Find(&shows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find series title candidates: %w", result.Error)
+	}
+	return shows, nil
+}
+
 func (r *seriesRepository) Update(ctx context.Context, show *entity.Series) error {
-	result := r.db.WithContext(ctx).Save(show)
+	result := dbConn(ctx, r.db).Save(show)
 	if result.Error != nil {
 		return fmt.Errorf("failed to update show: %w", result.Error)
 	}
@@ -85,7 +193,7 @@ func (r *seriesRepository) Update(ctx context.Context, show *entity.Series) erro
 // Soft Delete Management
 // Delete will delete a series and cascade down to seasons and episodes.
 func (r *seriesRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Series{}, id)
+	result := dbConn(ctx, r.db).Delete(&entity.Series{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete series: %w", result.Error)
 	}
@@ -93,7 +201,7 @@ func (r *seriesRepository) Delete(ctx context.Context, id uint) error {
 }
 
 func (r *seriesRepository) HardDelete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Unscoped().Delete(&entity.Series{}, id)
+	result := dbConn(ctx, r.db).Unscoped().Delete(&entity.Series{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to hard delete series: %w", result.Error)
 	}
@@ -101,7 +209,7 @@ func (r *seriesRepository) HardDelete(ctx context.Context, id uint) error {
 }
 func (r *seriesRepository) FindAllDeleted(ctx context.Context) ([]*entity.Series, error) {
 	var shows []*entity.Series
-	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Find(&shows)
+	result := dbConn(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").Find(&shows)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to find all deleted shows: %w", result.Error)
 	}
@@ -109,7 +217,7 @@ func (r *seriesRepository) FindAllDeleted(ctx context.Context) ([]*entity.Series
 }
 
 func (r *seriesRepository) Restore(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Unscoped().Model(&entity.Series{}).Where("id = ?", id).Update("deleted_at", nil)
+	result := dbConn(ctx, r.db).Unscoped().Model(&entity.Series{}).Where("id = ?", id).Update("deleted_at", nil)
 	if result.Error != nil {
 		return fmt.Errorf("failed to restore series: %w", result.Error)
 	}
@@ -117,16 +225,39 @@ func (r *seriesRepository) Restore(ctx context.Context, id uint) error {
 }
 
 func (r *seriesRepository) CleanupDeletedShows(ctx context.Context, olderThan time.Duration) error {
-	result := r.db.WithContext(ctx).Unscoped().Where("deleted_at < ?", time.Now().Add(-olderThan)).Delete(&entity.Series{})
+	result := dbConn(ctx, r.db).Unscoped().Where("deleted_at < ?", time.Now().Add(-olderThan)).Delete(&entity.Series{})
 	if result.Error != nil {
 		return fmt.Errorf("failed to cleanup deleted shows: %w", result.Error)
 	}
 	return nil
 }
 
+// PurgeDeleted hard-deletes shows that have sat in the recycle bin for
+// longer than the retention window, returning how many were purged.
+func (r *seriesRepository) PurgeDeleted(ctx context.Context, olderThan time.Duration) (int64, error) {
+	result := dbConn(ctx, r.db).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", time.Now().Add(-olderThan)).
+		Delete(&entity.Series{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge deleted shows: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // Scanning Management
+// UpdateCommunityRating writes the aggregated local rating columns
+// without touching the rest of the row.
+func (r *seriesRepository) UpdateCommunityRating(ctx context.Context, id uint, average float64, count int) error {
+	result := dbConn(ctx, r.db).Model(&entity.Series{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"community_rating": average, "community_rating_count": count})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update community rating: %w", result.Error)
+	}
+	return nil
+}
+
 func (r *seriesRepository) UpdateScannedStatus(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Model(&entity.Series{}).Where("id=?", id).Update("last_scanned", time.Now())
+	result := dbConn(ctx, r.db).Model(&entity.Series{}).Where("id=?", id).Update("last_scanned", time.Now())
 	if result.Error != nil {
 		return fmt.Errorf("failed to update scanned status")
 	}
@@ -135,9 +266,30 @@ func (r *seriesRepository) UpdateScannedStatus(ctx context.Context, id uint) err
 
 func (r *seriesRepository) FindStaleShows(ctx context.Context, threshold time.Duration) ([]*entity.Series, error) {
 	var shows []*entity.Series
-	result := r.db.WithContext(ctx).Where("last_scanned < ?", time.Now().Add(-threshold)).Find(&shows)
+	result := dbConn(ctx, r.db).Where("last_scanned < ?", time.Now().Add(-threshold)).Find(&shows)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to find stale shows: %w", result.Error)
 	}
 	return shows, nil
 }
+
+func (r *seriesRepository) FindByTitle(ctx context.Context, title string) (*entity.Series, error) {
+	var show entity.Series
+	result := dbConn(ctx, r.db).Where("LOWER(title) = LOWER(?)", title).First(&show)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find show by title: %w", result.Error)
+	}
+	return &show, nil
+}
+
+func (r *seriesRepository) FindMetadataPending(ctx context.Context, limit int) ([]*entity.Series, error) {
+	var shows []*entity.Series
+	result := dbConn(ctx, r.db).Where("metadata_pending = ?", true).Limit(limit).Find(&shows)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find shows pending metadata: %w", result.Error)
+	}
+	return shows, nil
+}
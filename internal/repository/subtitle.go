@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
+	"gorm.io/gorm"
+)
+
+type SubtitleRepository interface {
+	Store(ctx context.Context, track *entity.SubtitleTrack) error
+	FindByPath(ctx context.Context, path string) (*entity.SubtitleTrack, error)
+	FindByMedia(ctx context.Context, mediaType string, mediaID uint) ([]*entity.SubtitleTrack, error)
+}
+
+type subtitleRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewSubtitleRepository(db *gorm.DB, appLogger logger.Logger) SubtitleRepository {
+	return &subtitleRepository{db: db, appLogger: appLogger}
+}
+
+func (r *subtitleRepository) Store(ctx context.Context, track *entity.SubtitleTrack) error {
+	track.FilePath = pathutil.Canonical(track.FilePath)
+	result := r.db.WithContext(ctx).Create(track)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store subtitle track: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *subtitleRepository) FindByPath(ctx context.Context, path string) (*entity.SubtitleTrack, error) {
+	var track entity.SubtitleTrack
+	result := r.db.WithContext(ctx).Where("LOWER(file_path) = ?", pathutil.Fold(path)).First(&track)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find subtitle track by path: %w", result.Error)
+	}
+	return &track, nil
+}
+
+func (r *subtitleRepository) FindByMedia(ctx context.Context, mediaType string, mediaID uint) ([]*entity.SubtitleTrack, error) {
+	var tracks []*entity.SubtitleTrack
+	result := r.db.WithContext(ctx).
+		Where("media_type = ? AND media_id = ?", mediaType, mediaID).
+		Find(&tracks)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find subtitle tracks: %w", result.Error)
+	}
+	return tracks, nil
+}
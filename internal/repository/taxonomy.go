@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type TaxonomyRepository interface {
+	ListGenres(ctx context.Context) ([]*entity.Genre, error)
+	ListStudios(ctx context.Context) ([]*entity.Studio, error)
+	ListTags(ctx context.Context) ([]*entity.Tag, error)
+
+	FindOrCreateGenre(ctx context.Context, tmdbID int, name string) (*entity.Genre, error)
+	FindOrCreateStudio(ctx context.Context, tmdbID int, name string) (*entity.Studio, error)
+	FindOrCreateTag(ctx context.Context, name string, createdBy uint) (*entity.Tag, error)
+
+	SetMediaGenres(ctx context.Context, mediaType string, mediaID uint, genres []*entity.Genre) error
+	SetMediaStudios(ctx context.Context, mediaType string, mediaID uint, studios []*entity.Studio) error
+	AddMediaTag(ctx context.Context, mediaType string, mediaID uint, tagID uint) error
+	RemoveMediaTag(ctx context.Context, mediaType string, mediaID uint, tagID uint) error
+
+	FindMoviesByGenre(ctx context.Context, genre string) ([]*entity.Movie, error)
+	FindSeriesByGenre(ctx context.Context, genre string) ([]*entity.Series, error)
+
+	FindGenreNames(ctx context.Context, mediaType string, mediaID uint) ([]string, error)
+	FindAllMediaGenres(ctx context.Context) ([]*entity.MediaGenre, error)
+	FindUserGenreCounts(ctx context.Context, userID uint) (map[uint]int, error)
+}
+
+type taxonomyRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewTaxonomyRepository(db *gorm.DB, appLogger logger.Logger) TaxonomyRepository {
+	return &taxonomyRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *taxonomyRepository) ListGenres(ctx context.Context) ([]*entity.Genre, error) {
+	var genres []*entity.Genre
+	result := r.db.WithContext(ctx).Order("name").Find(&genres)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list genres: %w", result.Error)
+	}
+	return genres, nil
+}
+
+func (r *taxonomyRepository) ListStudios(ctx context.Context) ([]*entity.Studio, error) {
+	var studios []*entity.Studio
+	result := r.db.WithContext(ctx).Order("name").Find(&studios)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list studios: %w", result.Error)
+	}
+	return studios, nil
+}
+
+func (r *taxonomyRepository) ListTags(ctx context.Context) ([]*entity.Tag, error) {
+	var tags []*entity.Tag
+	result := r.db.WithContext(ctx).Order("name").Find(&tags)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", result.Error)
+	}
+	return tags, nil
+}
+
+func (r *taxonomyRepository) FindOrCreateGenre(ctx context.Context, tmdbID int, name string) (*entity.Genre, error) {
+	if name == "" {
+		return nil, fmt.Errorf("genre name is required: %w", errors.ErrBadRequest)
+	}
+
+	var genre entity.Genre
+	result := r.db.WithContext(ctx).Where("name = ?", name).First(&genre)
+	if result.Error == nil {
+		return &genre, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find genre: %w", result.Error)
+	}
+
+	genre = entity.Genre{TMDbID: tmdbID, Name: name}
+	if err := r.db.WithContext(ctx).Create(&genre).Error; err != nil {
+		return nil, fmt.Errorf("failed to create genre: %w", err)
+	}
+	return &genre, nil
+}
+
+func (r *taxonomyRepository) FindOrCreateStudio(ctx context.Context, tmdbID int, name string) (*entity.Studio, error) {
+	if name == "" {
+		return nil, fmt.Errorf("studio name is required: %w", errors.ErrBadRequest)
+	}
+
+	var studio entity.Studio
+	result := r.db.WithContext(ctx).Where("name = ?", name).First(&studio)
+	if result.Error == nil {
+		return &studio, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find studio: %w", result.Error)
+	}
+
+	studio = entity.Studio{TMDbID: tmdbID, Name: name}
+	if err := r.db.WithContext(ctx).Create(&studio).Error; err != nil {
+		return nil, fmt.Errorf("failed to create studio: %w", err)
+	}
+	return &studio, nil
+}
+
+func (r *taxonomyRepository) FindOrCreateTag(ctx context.Context, name string, createdBy uint) (*entity.Tag, error) {
+	if name == "" {
+		return nil, fmt.Errorf("tag name is required: %w", errors.ErrBadRequest)
+	}
+
+	var tag entity.Tag
+	result := r.db.WithContext(ctx).Where("name = ?", name).First(&tag)
+	if result.Error == nil {
+		return &tag, nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find tag: %w", result.Error)
+	}
+
+	tag = entity.Tag{Name: name, CreatedBy: createdBy}
+	if err := r.db.WithContext(ctx).Create(&tag).Error; err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+	return &tag, nil
+}
+
+func (r *taxonomyRepository) SetMediaGenres(ctx context.Context, mediaType string, mediaID uint, genres []*entity.Genre) error {
+	result := r.db.WithContext(ctx).
+		Where("media_type = ? AND media_id = ?", mediaType, mediaID).
+		Unscoped().
+		Delete(&entity.MediaGenre{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to clear media genres: %w", result.Error)
+	}
+
+	for _, genre := range genres {
+		link := &entity.MediaGenre{
+			GenreID:   genre.ID,
+			MediaType: mediaType,
+			MediaID:   mediaID,
+		}
+		if err := r.db.WithContext(ctx).Create(link).Error; err != nil {
+			return fmt.Errorf("failed to link genre: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *taxonomyRepository) SetMediaStudios(ctx context.Context, mediaType string, mediaID uint, studios []*entity.Studio) error {
+	result := r.db.WithContext(ctx).
+		Where("media_type = ? AND media_id = ?", mediaType, mediaID).
+		Unscoped().
+		Delete(&entity.MediaStudio{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to clear media studios: %w", result.Error)
+	}
+
+	for _, studio := range studios {
+		link := &entity.MediaStudio{
+			StudioID:  studio.ID,
+			MediaType: mediaType,
+			MediaID:   mediaID,
+		}
+		if err := r.db.WithContext(ctx).Create(link).Error; err != nil {
+			return fmt.Errorf("failed to link studio: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *taxonomyRepository) AddMediaTag(ctx context.Context, mediaType string, mediaID uint, tagID uint) error {
+	var existing entity.MediaTag
+	result := r.db.WithContext(ctx).
+		Where("tag_id = ? AND media_type = ? AND media_id = ?", tagID, mediaType, mediaID).
+		First(&existing)
+	if result.Error == nil {
+		return nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check media tag: %w", result.Error)
+	}
+
+	link := &entity.MediaTag{
+		TagID:     tagID,
+		MediaType: mediaType,
+		MediaID:   mediaID,
+	}
+	if err := r.db.WithContext(ctx).Create(link).Error; err != nil {
+		return fmt.Errorf("failed to tag media: %w", err)
+	}
+	return nil
+}
+
+func (r *taxonomyRepository) RemoveMediaTag(ctx context.Context, mediaType string, mediaID uint, tagID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("tag_id = ? AND media_type = ? AND media_id = ?", tagID, mediaType, mediaID).
+		Unscoped().
+		Delete(&entity.MediaTag{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to untag media: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *taxonomyRepository) FindMoviesByGenre(ctx context.Context, genre string) ([]*entity.Movie, error) {
+	var movies []*entity.Movie
+	result := r.db.WithContext(ctx).
+		Joins("JOIN media_genres ON media_genres.media_id = movies.id AND media_genres.media_type = ?", "movie").
+		Joins("JOIN genres ON genres.id = media_genres.genre_id").
+		Where("genres.name = ?", genre).
+		Find(&movies)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find movies by genre: %w", result.Error)
+	}
+	return movies, nil
+}
+
+func (r *taxonomyRepository) FindSeriesByGenre(ctx context.Context, genre string) ([]*entity.Series, error) {
+	var series []*entity.Series
+	result := r.db.WithContext(ctx).
+		Joins("JOIN media_genres ON media_genres.media_id = series.id AND media_genres.media_type = ?", "series").
+		Joins("JOIN genres ON genres.id = media_genres.genre_id").
+		Where("genres.name = ?", genre).
+		Find(&series)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find series by genre: %w", result.Error)
+	}
+	return series, nil
+}
+
+// FindGenreNames returns the genre names linked to a single item, used
+// by the parental-control tag blocklist.
+func (r *taxonomyRepository) FindGenreNames(ctx context.Context, mediaType string, mediaID uint) ([]string, error) {
+	var names []string
+	result := r.db.WithContext(ctx).Model(&entity.MediaGenre{}).
+		Joins("JOIN genres ON genres.id = media_genres.genre_id").
+		Where("media_genres.media_type = ? AND media_genres.media_id = ?", mediaType, mediaID).
+		Pluck("genres.name", &names)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find genre names: %w", result.Error)
+	}
+	return names, nil
+}
+
+// FindAllMediaGenres returns every genre link, used by the search
+// suggestion scorer to look up an item's genres without per-item queries.
+func (r *taxonomyRepository) FindAllMediaGenres(ctx context.Context) ([]*entity.MediaGenre, error) {
+	var links []*entity.MediaGenre
+	result := r.db.WithContext(ctx).
+		Select("media_type", "media_id", "genre_id").
+		Find(&links)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find media genres: %w", result.Error)
+	}
+	return links, nil
+}
+
+// FindUserGenreCounts returns how often each genre appears in the user's
+// watch history. An episode counts toward its series' genres.
+func (r *taxonomyRepository) FindUserGenreCounts(ctx context.Context, userID uint) (map[uint]int, error) {
+	type genreCount struct {
+		GenreID uint
+		Total   int
+	}
+
+	counts := make(map[uint]int)
+
+	var movieCounts []genreCount
+	result := r.db.WithContext(ctx).Table("watch_histories").
+		Select("media_genres.genre_id AS genre_id, COUNT(*) AS total").
+		Joins("JOIN media_genres ON media_genres.media_id = watch_histories.media_id AND media_genres.media_type = ?", "movie").
+		Where("watch_histories.media_type = ? AND watch_histories.user_id = ? AND watch_histories.deleted_at IS NULL", "movie", userID).
+		Group("media_genres.genre_id").
+		Scan(&movieCounts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to count watched movie genres: %w", result.Error)
+	}
+	for _, c := range movieCounts {
+		counts[c.GenreID] += c.Total
+	}
+
+	var episodeCounts []genreCount
+	result = r.db.WithContext(ctx).Table("watch_histories").
+		Select("media_genres.genre_id AS genre_id, COUNT(*) AS total").
+		Joins("JOIN episodes ON episodes.id = watch_histories.media_id").
+		Joins("JOIN media_genres ON media_genres.media_id = episodes.series_id AND media_genres.media_type = ?", "series").
+		Where("watch_histories.media_type = ? AND watch_histories.user_id = ? AND watch_histories.deleted_at IS NULL", "episode", userID).
+		Group("media_genres.genre_id").
+		Scan(&episodeCounts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to count watched episode genres: %w", result.Error)
+	}
+	for _, c := range episodeCounts {
+		counts[c.GenreID] += c.Total
+	}
+
+	return counts, nil
+}
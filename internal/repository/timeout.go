@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// queryTimeoutCancelKey stores the cancel func for the per-query deadline
+// between the before and after callbacks.
+const queryTimeoutCancelKey = "cinea:query_timeout_cancel"
+
+// registerQueryTimeouts installs GORM callbacks that bound every query with
+// the configured db_query timeout so a stalled database can't hang a scan
+// or request indefinitely.
+func registerQueryTimeouts(db *gorm.DB, timeout time.Duration) error {
+	before := func(tx *gorm.DB) {
+		if tx.Statement == nil || tx.Statement.Context == nil {
+			return
+		}
+		ctx, cancel := context.WithTimeout(tx.Statement.Context, timeout)
+		tx.Statement.Context = ctx
+		tx.InstanceSet(queryTimeoutCancelKey, cancel)
+	}
+	after := func(tx *gorm.DB) {
+		if value, ok := tx.InstanceGet(queryTimeoutCancelKey); ok {
+			if cancel, ok := value.(context.CancelFunc); ok {
+				cancel()
+			}
+		}
+	}
+
+	if err := db.Callback().Create().Before("gorm:create").Register("cinea:timeout_before", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().Register("cinea:timeout_after", after); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("cinea:timeout_before", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Register("cinea:timeout_after", after); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("cinea:timeout_before", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Register("cinea:timeout_after", after); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("cinea:timeout_before", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Register("cinea:timeout_after", after); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Before("gorm:raw").Register("cinea:timeout_before", before); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Register("cinea:timeout_after", after); err != nil {
+		return err
+	}
+
+	return nil
+}
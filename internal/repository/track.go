@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
+	"gorm.io/gorm"
+)
+
+type TrackRepository interface {
+	Store(ctx context.Context, track *entity.Track) error
+	FindByID(ctx context.Context, id uint) (*entity.Track, error)
+	FindByPath(ctx context.Context, filePath string) (*entity.Track, error)
+	ListByAlbum(ctx context.Context, albumID uint) ([]*entity.Track, error)
+	Update(ctx context.Context, track *entity.Track) error
+}
+
+type trackRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewTrackRepository(db *gorm.DB, appLogger logger.Logger) TrackRepository {
+	return &trackRepository{db: db, appLogger: appLogger}
+}
+
+func (r *trackRepository) Store(ctx context.Context, track *entity.Track) error {
+	track.FilePath = pathutil.Canonical(track.FilePath)
+	result := r.db.WithContext(ctx).Create(track)
+	if result.Error != nil {
+		return fmt.Errorf("failed to store track: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *trackRepository) FindByID(ctx context.Context, id uint) (*entity.Track, error) {
+	var track entity.Track
+	result := r.db.WithContext(ctx).First(&track, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find track by id: %w", result.Error)
+	}
+	return &track, nil
+}
+
+func (r *trackRepository) FindByPath(ctx context.Context, filePath string) (*entity.Track, error) {
+	var track entity.Track
+	result := r.db.WithContext(ctx).Where("LOWER(file_path) = ?", pathutil.Fold(filePath)).First(&track)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find track by path: %w", result.Error)
+	}
+	return &track, nil
+}
+
+func (r *trackRepository) ListByAlbum(ctx context.Context, albumID uint) ([]*entity.Track, error) {
+	var tracks []*entity.Track
+	result := r.db.WithContext(ctx).Where("album_id = ?", albumID).Order("disc_number, track_number").Find(&tracks)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to list tracks: %w", result.Error)
+	}
+	return tracks, nil
+}
+
+func (r *trackRepository) Update(ctx context.Context, track *entity.Track) error {
+	result := r.db.WithContext(ctx).Save(track)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update track: %w", result.Error)
+	}
+	return nil
+}
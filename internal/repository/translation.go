@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type TranslationRepository interface {
+	Upsert(ctx context.Context, translation *entity.MediaTranslation) error
+	Find(ctx context.Context, mediaType string, mediaID uint, language string) (*entity.MediaTranslation, error)
+	FindAllTitles(ctx context.Context) ([]*entity.MediaTranslation, error)
+}
+
+type translationRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewTranslationRepository(db *gorm.DB, appLogger logger.Logger) TranslationRepository {
+	return &translationRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *translationRepository) Upsert(ctx context.Context, translation *entity.MediaTranslation) error {
+	var existing entity.MediaTranslation
+	result := r.db.WithContext(ctx).
+		Where("media_type = ? AND media_id = ? AND language = ?", translation.MediaType, translation.MediaID, translation.Language).
+		First(&existing)
+	if result.Error == nil {
+		existing.Title = translation.Title
+		existing.Overview = translation.Overview
+		if err := r.db.WithContext(ctx).Save(&existing).Error; err != nil {
+			return fmt.Errorf("failed to update translation: %w", err)
+		}
+		return nil
+	}
+	if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to find translation: %w", result.Error)
+	}
+
+	if err := r.db.WithContext(ctx).Create(translation).Error; err != nil {
+		return fmt.Errorf("failed to create translation: %w", err)
+	}
+	return nil
+}
+
+func (r *translationRepository) Find(ctx context.Context, mediaType string, mediaID uint, language string) (*entity.MediaTranslation, error) {
+	var translation entity.MediaTranslation
+	result := r.db.WithContext(ctx).
+		Where("media_type = ? AND media_id = ? AND language = ?", mediaType, mediaID, language).
+		First(&translation)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find translation: %w", result.Error)
+	}
+	return &translation, nil
+}
+
+// FindAllTitles returns every translated title, used as alias candidates
+// by the search suggestion scorer.
+func (r *translationRepository) FindAllTitles(ctx context.Context) ([]*entity.MediaTranslation, error) {
+	var translations []*entity.MediaTranslation
+	result := r.db.WithContext(ctx).
+		Select("media_type", "media_id", "title").
+		Where("title <> ''").
+		Find(&translations)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find translated titles: %w", result.Error)
+	}
+	return translations, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txContextKey carries an open transaction through a context so
+// repository methods join it transparently.
+type txContextKey struct{}
+
+// TxManager is the unit-of-work boundary: WithTx runs fn inside one
+// database transaction, committing when fn returns nil and rolling back
+// otherwise. Repository methods called with the context fn receives
+// automatically run on the transaction.
+type TxManager interface {
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) TxManager {
+	return &txManager{db: db}
+}
+
+func (m *txManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Nested WithTx calls join the outer transaction rather than opening
+	// a second one
+	if tx := txFromContext(ctx); tx != nil {
+		return fn(ctx)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// txFromContext returns the transaction carried by ctx, or nil outside
+// WithTx.
+func txFromContext(ctx context.Context) *gorm.DB {
+	tx, _ := ctx.Value(txContextKey{}).(*gorm.DB)
+	return tx
+}
+
+// dbConn returns the handle a repository method should run on: the
+// transaction carried by ctx inside WithTx, the shared handle otherwise.
+func dbConn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
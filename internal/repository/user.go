@@ -20,7 +20,9 @@ type UserRepository interface {
 	Update(ctx context.Context, user *entity.User) error
 	Delete(ctx context.Context, id uint) error
 	List(ctx context.Context) ([]*entity.User, error)
+	Count(ctx context.Context) (int64, error)
 	UpdateLastLogin(ctx context.Context, id uint) error
+	FindByOAuth(ctx context.Context, provider, oauthID string) (*entity.User, error)
 }
 
 type userRepository struct {
@@ -165,6 +167,41 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 	return &user, nil
 }
 
+func (r *userRepository) FindByOAuth(ctx context.Context, provider, oauthID string) (*entity.User, error) {
+	r.appLogger.Debug().
+		Str("provider", provider).
+		Msg("Finding user by OAuth identity")
+
+	var user entity.User
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("o_auth_provider = ? AND o_auth_id = ?", provider, oauthID).First(&user)
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			r.appLogger.Debug().
+				Str("provider", provider).
+				Dur("duration", duration).
+				Msg("User not found")
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("sql", result.Statement.SQL.String()).
+			Any("args", result.Statement.Vars).
+			Dur("duration", duration).
+			Msg("Failed to find user by OAuth identity")
+		return nil, fmt.Errorf("failed to find user by OAuth identity: %w", result.Error)
+	}
+
+	r.appLogger.Info().
+		Str("provider", provider).
+		Uint("userID", user.ID).
+		Dur("duration", duration).
+		Msg("User found by OAuth identity")
+	return &user, nil
+}
+
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 	r.appLogger.Debug().
 		Uint("userID", user.ID).
@@ -268,3 +305,15 @@ func (r *userRepository) UpdateLastLogin(ctx context.Context, id uint) error {
 		Msg("Last login updated successfully")
 	return nil
 }
+
+func (r *userRepository) Count(ctx context.Context) (int64, error) {
+	var count int64
+	result := r.db.WithContext(ctx).Model(&entity.User{}).Count(&count)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to count users")
+		return 0, fmt.Errorf("failed to count users: %w", result.Error)
+	}
+	return count, nil
+}
@@ -11,11 +11,17 @@ import (
 	"gorm.io/gorm/clause"
 )
 
+// WatchHistoryRepository stores watch history entries. Reads and deletes
+// are scoped to a household profile; profile zero is the account owner.
 type WatchHistoryRepository interface {
-	GetWatchHistory(ctx context.Context, userID uint) ([]entity.WatchHistory, error)
+	GetWatchHistory(ctx context.Context, userID, profileID uint) ([]entity.WatchHistory, error)
 	AddToWatchHistory(ctx context.Context, history *entity.WatchHistory) error
 	UpdateWatchProgress(ctx context.Context, historyID uint, progress float64) error
-	ClearHistory(ctx context.Context, userId uint) ([]entity.WatchHistory, error)
+	ClearHistory(ctx context.Context, userId, profileID uint) ([]entity.WatchHistory, error)
+	ListRecent(ctx context.Context, limit int) ([]entity.WatchHistory, error)
+	AddBatch(ctx context.Context, entries []entity.WatchHistory) error
+	DeleteForMedia(ctx context.Context, userID, profileID uint, mediaType string, mediaIDs []uint) error
+	FindWatchedSet(ctx context.Context, userID, profileID uint, mediaType string, mediaIDs []uint) (map[uint]bool, error)
 }
 
 type watchHistoryRepository struct {
@@ -27,14 +33,16 @@ func NewWatchHistoryRepository(db *gorm.DB, appLogger logger.Logger) WatchHistor
 	return &watchHistoryRepository{db: db, appLogger: appLogger}
 }
 
-func (r *watchHistoryRepository) GetWatchHistory(ctx context.Context, userID uint) ([]entity.WatchHistory, error) {
+func (r *watchHistoryRepository) GetWatchHistory(ctx context.Context, userID, profileID uint) ([]entity.WatchHistory, error) {
 	r.appLogger.Debug().
+		Str("request_id", logger.RequestID(ctx)).
 		Uint("userID", userID).
+		Uint("profileID", profileID).
 		Msg("Getting watch history for user")
 
 	var history []entity.WatchHistory
 	start := time.Now()
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&history)
+	result := r.db.WithContext(ctx).Where("user_id = ? AND profile_id = ?", userID, profileID).Find(&history)
 	duration := time.Since(start)
 
 	if result.Error != nil {
@@ -57,6 +65,7 @@ func (r *watchHistoryRepository) GetWatchHistory(ctx context.Context, userID uin
 
 func (r *watchHistoryRepository) AddToWatchHistory(ctx context.Context, history *entity.WatchHistory) error {
 	r.appLogger.Debug().
+		Str("request_id", logger.RequestID(ctx)).
 		Uint("userID", history.UserID).
 		Str("mediaType", history.MediaType).
 		Uint("mediaID", history.MediaID).
@@ -88,6 +97,7 @@ func (r *watchHistoryRepository) AddToWatchHistory(ctx context.Context, history
 
 func (r *watchHistoryRepository) UpdateWatchProgress(ctx context.Context, historyID uint, progress float64) error {
 	r.appLogger.Debug().
+		Str("request_id", logger.RequestID(ctx)).
 		Uint("historyID", historyID).
 		Float64("progress", progress).
 		Msg("Updating watch progress")
@@ -114,17 +124,19 @@ func (r *watchHistoryRepository) UpdateWatchProgress(ctx context.Context, histor
 	return nil
 }
 
-func (r *watchHistoryRepository) ClearHistory(ctx context.Context, userId uint) ([]entity.WatchHistory, error) {
+func (r *watchHistoryRepository) ClearHistory(ctx context.Context, userId, profileID uint) ([]entity.WatchHistory, error) {
 	r.appLogger.Debug().
+		Str("request_id", logger.RequestID(ctx)).
 		Uint("userID", userId).
+		Uint("profileID", profileID).
 		Msg("Clearing watch history for user")
 
 	start := time.Now()
 	// First, get the history to return it
 	var history []entity.WatchHistory
-	r.db.Unscoped().Where("user_id = ?", userId).Find(&history)
+	r.db.Unscoped().Where("user_id = ? AND profile_id = ?", userId, profileID).Find(&history)
 
-	result := r.db.WithContext(ctx).Where("user_id = ?", userId).Delete(&entity.WatchHistory{})
+	result := r.db.WithContext(ctx).Where("user_id = ? AND profile_id = ?", userId, profileID).Delete(&entity.WatchHistory{})
 	duration := time.Since(start)
 
 	if result.Error != nil {
@@ -143,3 +155,90 @@ func (r *watchHistoryRepository) ClearHistory(ctx context.Context, userId uint)
 		Msg("Watch history cleared successfully")
 	return history, nil
 }
+
+// ListRecent returns the most recent watch history entries across all
+// users, newest first, for the household activity feed.
+func (r *watchHistoryRepository) ListRecent(ctx context.Context, limit int) ([]entity.WatchHistory, error) {
+	r.appLogger.Debug().
+		Int("limit", limit).
+		Msg("Getting recent watch history")
+
+	var history []entity.WatchHistory
+	start := time.Now()
+	result := r.db.WithContext(ctx).Order("watched_at DESC").Limit(limit).Find(&history)
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("sql", result.Statement.SQL.String()).
+			Any("args", result.Statement.Vars).
+			Dur("duration", duration).
+			Msg("Failed to get recent watch history")
+		return nil, fmt.Errorf("failed to get recent watch history: %w", result.Error)
+	}
+
+	return history, nil
+}
+
+// AddBatch creates many history entries in one insert, used by the bulk
+// mark-watched endpoints.
+func (r *watchHistoryRepository) AddBatch(ctx context.Context, entries []entity.WatchHistory) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).Create(&entries)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Int("count", len(entries)).
+			Msg("Failed to add watch history batch")
+		return fmt.Errorf("failed to add watch history batch: %w", result.Error)
+	}
+	return nil
+}
+
+// DeleteForMedia removes the user's history entries for the given items,
+// used by the mark-unwatched endpoints.
+func (r *watchHistoryRepository) DeleteForMedia(ctx context.Context, userID, profileID uint, mediaType string, mediaIDs []uint) error {
+	if len(mediaIDs) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND profile_id = ? AND media_type = ? AND media_id IN ?", userID, profileID, mediaType, mediaIDs).
+		Delete(&entity.WatchHistory{})
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("userID", userID).
+			Str("mediaType", mediaType).
+			Msg("Failed to delete watch history entries")
+		return fmt.Errorf("failed to delete watch history entries: %w", result.Error)
+	}
+	return nil
+}
+
+// FindWatchedSet reports which of the given items appear in the user's
+// watch history, keyed by media ID.
+func (r *watchHistoryRepository) FindWatchedSet(ctx context.Context, userID, profileID uint, mediaType string, mediaIDs []uint) (map[uint]bool, error) {
+	set := make(map[uint]bool, len(mediaIDs))
+	if len(mediaIDs) == 0 {
+		return set, nil
+	}
+
+	var ids []uint
+	result := r.db.WithContext(ctx).Model(&entity.WatchHistory{}).
+		Distinct("media_id").
+		Where("user_id = ? AND profile_id = ? AND media_type = ? AND media_id IN ?", userID, profileID, mediaType, mediaIDs).
+		Pluck("media_id", &ids)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get watched set: %w", result.Error)
+	}
+
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set, nil
+}
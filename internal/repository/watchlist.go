@@ -10,10 +10,14 @@ import (
 	"gorm.io/gorm"
 )
 
+// WatchlistRepository stores watchlist items. Reads and deletes are
+// scoped to a household profile; profile zero is the account owner.
 type WatchlistRepository interface {
-	GetWatchlist(ctx context.Context, userID uint) ([]entity.Watchlist, error)
+	GetWatchlist(ctx context.Context, userID, profileID uint) ([]entity.Watchlist, error)
 	AddToWatchlist(ctx context.Context, item *entity.Watchlist) error
-	RemoveFromWatchlist(ctx context.Context, userID uint, mediaID uint, mediaType string) error
+	RemoveFromWatchlist(ctx context.Context, userID, profileID uint, mediaID uint, mediaType string) error
+	ListRecent(ctx context.Context, limit int) ([]entity.Watchlist, error)
+	ListByMedia(ctx context.Context, mediaID uint, mediaType string) ([]entity.Watchlist, error)
 }
 
 type watchlistRepository struct {
@@ -25,14 +29,15 @@ func NewWatchlistRepository(db *gorm.DB, appLogger logger.Logger) WatchlistRepos
 	return &watchlistRepository{db: db, appLogger: appLogger}
 }
 
-func (r *watchlistRepository) GetWatchlist(ctx context.Context, userID uint) ([]entity.Watchlist, error) {
+func (r *watchlistRepository) GetWatchlist(ctx context.Context, userID, profileID uint) ([]entity.Watchlist, error) {
 	r.appLogger.Debug().
 		Uint("userID", userID).
+		Uint("profileID", profileID).
 		Msg("Getting watchlist for user")
 
 	var watchlist []entity.Watchlist
 	start := time.Now()
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&watchlist)
+	result := r.db.WithContext(ctx).Where("user_id = ? AND profile_id = ?", userID, profileID).Find(&watchlist)
 	duration := time.Since(start)
 
 	if result.Error != nil {
@@ -83,7 +88,7 @@ func (r *watchlistRepository) AddToWatchlist(ctx context.Context, item *entity.W
 	return nil
 }
 
-func (r *watchlistRepository) RemoveFromWatchlist(ctx context.Context, userID uint, mediaID uint, mediaType string) error {
+func (r *watchlistRepository) RemoveFromWatchlist(ctx context.Context, userID, profileID uint, mediaID uint, mediaType string) error {
 	r.appLogger.Debug().
 		Uint("userID", userID).
 		Str("mediaType", mediaType).
@@ -91,7 +96,7 @@ func (r *watchlistRepository) RemoveFromWatchlist(ctx context.Context, userID ui
 		Msg("Removing from watchlist")
 
 	start := time.Now()
-	result := r.db.WithContext(ctx).Where("user_id = ? AND media_id = ? AND media_type = ?", userID, mediaID, mediaType).Delete(&entity.Watchlist{})
+	result := r.db.WithContext(ctx).Where("user_id = ? AND profile_id = ? AND media_id = ? AND media_type = ?", userID, profileID, mediaID, mediaType).Delete(&entity.Watchlist{})
 	duration := time.Since(start)
 
 	if result.Error != nil {
@@ -112,3 +117,54 @@ func (r *watchlistRepository) RemoveFromWatchlist(ctx context.Context, userID ui
 		Msg("Removed from watchlist successfully")
 	return nil
 }
+
+// ListByMedia returns every watchlist entry for one media item, across
+// all users, so notifications can reach everyone following it.
+func (r *watchlistRepository) ListByMedia(ctx context.Context, mediaID uint, mediaType string) ([]entity.Watchlist, error) {
+	r.appLogger.Debug().
+		Uint("mediaID", mediaID).
+		Str("mediaType", mediaType).
+		Msg("Getting watchlist entries for media")
+
+	var items []entity.Watchlist
+	start := time.Now()
+	result := r.db.WithContext(ctx).Where("media_id = ? AND media_type = ?", mediaID, mediaType).Find(&items)
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("sql", result.Statement.SQL.String()).
+			Any("args", result.Statement.Vars).
+			Dur("duration", duration).
+			Msg("Failed to get watchlist entries for media")
+		return nil, fmt.Errorf("failed to get watchlist entries for media: %w", result.Error)
+	}
+
+	return items, nil
+}
+
+// ListRecent returns the most recent watchlist additions across all users,
+// newest first, for the household activity feed.
+func (r *watchlistRepository) ListRecent(ctx context.Context, limit int) ([]entity.Watchlist, error) {
+	r.appLogger.Debug().
+		Int("limit", limit).
+		Msg("Getting recent watchlist additions")
+
+	var items []entity.Watchlist
+	start := time.Now()
+	result := r.db.WithContext(ctx).Order("created_at DESC").Limit(limit).Find(&items)
+	duration := time.Since(start)
+
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("sql", result.Statement.SQL.String()).
+			Any("args", result.Statement.Vars).
+			Dur("duration", duration).
+			Msg("Failed to get recent watchlist additions")
+		return nil, fmt.Errorf("failed to get recent watchlist additions: %w", result.Error)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	List(ctx context.Context) ([]*entity.Webhook, error)
+	FindByID(ctx context.Context, id uint) (*entity.Webhook, error)
+	FindEnabled(ctx context.Context) ([]*entity.Webhook, error)
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	Delete(ctx context.Context, id uint) error
+	AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, webhookID uint, limit int) ([]*entity.WebhookDelivery, error)
+}
+
+type webhookRepository struct {
+	db        *gorm.DB
+	appLogger logger.Logger
+}
+
+func NewWebhookRepository(db *gorm.DB, appLogger logger.Logger) WebhookRepository {
+	return &webhookRepository{
+		db:        db,
+		appLogger: appLogger,
+	}
+}
+
+func (r *webhookRepository) List(ctx context.Context) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	result := r.db.WithContext(ctx).Order("created_at ASC").Find(&webhooks)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to list webhooks")
+		return nil, fmt.Errorf("failed to list webhooks: %w", result.Error)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) FindByID(ctx context.Context, id uint) (*entity.Webhook, error) {
+	var webhook entity.Webhook
+	result := r.db.WithContext(ctx).First(&webhook, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("webhookID", id).
+			Msg("Failed to find webhook")
+		return nil, fmt.Errorf("failed to find webhook: %w", result.Error)
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) FindEnabled(ctx context.Context) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	result := r.db.WithContext(ctx).Where("enabled = ?", true).Find(&webhooks)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Msg("Failed to list enabled webhooks")
+		return nil, fmt.Errorf("failed to list enabled webhooks: %w", result.Error)
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	result := r.db.WithContext(ctx).Create(webhook)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Str("name", webhook.Name).
+			Msg("Failed to create webhook")
+		return fmt.Errorf("failed to create webhook: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	result := r.db.WithContext(ctx).Save(webhook)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("webhookID", webhook.ID).
+			Msg("Failed to update webhook")
+		return fmt.Errorf("failed to update webhook: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&entity.Webhook{}, id)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("webhookID", id).
+			Msg("Failed to delete webhook")
+		return fmt.Errorf("failed to delete webhook: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *webhookRepository) AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	result := r.db.WithContext(ctx).Create(delivery)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("webhookID", delivery.WebhookID).
+			Msg("Failed to record webhook delivery")
+		return fmt.Errorf("failed to record webhook delivery: %w", result.Error)
+	}
+	return nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, webhookID uint, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	result := r.db.WithContext(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries)
+	if result.Error != nil {
+		r.appLogger.Error().
+			Err(result.Error).
+			Uint("webhookID", webhookID).
+			Msg("Failed to list webhook deliveries")
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", result.Error)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,45 @@
+package router
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/andybalholm/brotli"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// compressibleTypes lists the content types worth compressing; media
+// streams and images are already compressed and pass through untouched.
+var compressibleTypes = []string{
+	"application/json",
+	"application/javascript",
+	"text/html",
+	"text/css",
+	"text/javascript",
+	"text/plain",
+	"image/svg+xml",
+}
+
+// compressResponses negotiates a content encoding with the client:
+// brotli when offered, falling back to chi's built-in gzip/deflate.
+// Compression runs outside the ETag middleware, so tags are computed
+// over the uncompressed body and stay stable across encodings.
+func compressResponses() func(http.Handler) http.Handler {
+	compressor := middleware.NewCompressor(5, compressibleTypes...)
+	compressor.SetEncoder("br", func(w io.Writer, level int) io.Writer {
+		return brotli.NewWriterLevel(w, level)
+	})
+	return compressor.Handler
+}
+
+// cacheControl sets a default Cache-Control directive for a route group.
+// Handlers that set their own directive (the image cache's immutable
+// header, for instance) override it, since they write later.
+func cacheControl(directive string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", directive)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package router
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// conditionalGet adds ETag support to JSON GET endpoints. Successful JSON
+// responses are buffered and tagged with a strong ETag over the rendered
+// body; since listings and detail payloads embed updated_at timestamps,
+// the tag changes exactly when the underlying rows do. A request whose
+// If-None-Match matches is answered with 304 and no body, so polling
+// clients and the HTMX frontend stop re-downloading unchanged JSON.
+// Non-JSON responses (images, streams, playlists) pass through untouched.
+func conditionalGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &conditionalWriter{ResponseWriter: w, request: r}
+		next.ServeHTTP(cw, r)
+		cw.finish()
+	})
+}
+
+// conditionalWriter defers the decision to buffer until the handler
+// commits a status: only 200 responses with a JSON content type are held
+// back for tagging, everything else streams straight through.
+type conditionalWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	buffer      bytes.Buffer
+	buffering   bool
+	wroteHeader bool
+	status      int
+}
+
+func (cw *conditionalWriter) WriteHeader(status int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = status
+
+	contentType := cw.Header().Get("Content-Type")
+	if status == http.StatusOK && strings.HasPrefix(contentType, "application/json") {
+		cw.buffering = true
+		return
+	}
+	cw.ResponseWriter.WriteHeader(status)
+}
+
+func (cw *conditionalWriter) Write(b []byte) (int, error) {
+	if !cw.wroteHeader {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.buffering {
+		return cw.buffer.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Flush abandons buffering so streamed responses (server-sent events)
+// keep their incremental delivery.
+func (cw *conditionalWriter) Flush() {
+	cw.passthrough()
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack hands the connection over untouched for upgraded protocols.
+func (cw *conditionalWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := cw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("underlying ResponseWriter does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+// passthrough flushes anything buffered so far and disables tagging for
+// the rest of the response.
+func (cw *conditionalWriter) passthrough() {
+	if !cw.buffering {
+		return
+	}
+	cw.buffering = false
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if cw.buffer.Len() > 0 {
+		_, _ = cw.ResponseWriter.Write(cw.buffer.Bytes())
+		cw.buffer.Reset()
+	}
+}
+
+// finish computes the ETag over the buffered body and either answers 304
+// or releases the response with the tag attached.
+func (cw *conditionalWriter) finish() {
+	if !cw.buffering {
+		return
+	}
+	cw.buffering = false
+
+	sum := sha256.Sum256(cw.buffer.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	cw.Header().Set("ETag", etag)
+
+	if etagMatches(cw.request.Header.Get("If-None-Match"), etag) {
+		cw.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	_, _ = cw.ResponseWriter.Write(cw.buffer.Bytes())
+}
+
+// etagMatches reports whether an If-None-Match header covers the tag;
+// weak comparison is fine for cache revalidation.
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
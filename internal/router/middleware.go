@@ -0,0 +1,71 @@
+package router
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/logger"
+)
+
+// requestLogger logs one line per request via the application logger and
+// stores the request ID in the context so repository logs can correlate
+// to the incoming request. It expects chi's RequestID middleware to have
+// run first.
+func requestLogger(appLogger logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := middleware.GetReqID(r.Context())
+			ctx := logger.WithRequestID(r.Context(), requestID)
+			ctx = logger.WithRemoteIP(ctx, r.RemoteAddr)
+			ctx, requestUser := auth.WithRequestUser(ctx)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			start := time.Now()
+			defer func() {
+				event := appLogger.Info().
+					Str("request_id", requestID).
+					Str("method", r.Method).
+					Str("path", r.URL.Path).
+					Int("status", ww.Status()).
+					Dur("latency", time.Since(start))
+				if requestUser.Username != "" {
+					event = event.Str("user", requestUser.Username)
+				}
+				event.Msg("Request completed")
+			}()
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		})
+	}
+}
+
+// traceRequests opens a server span per request and honours incoming
+// trace context headers. Spans resolve against the global provider, so
+// this is a no-op when tracing is disabled.
+func traceRequests() func(http.Handler) http.Handler {
+	tracer := otel.Tracer("cinea")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method+" "+r.URL.Path,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.method", r.Method),
+					attribute.String("http.target", r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+			span.SetAttributes(attribute.Int("http.status_code", ww.Status()))
+		})
+	}
+}
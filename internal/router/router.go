@@ -5,45 +5,125 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/auth"
 	"github.com/samcharles93/cinea/internal/handler"
+	"github.com/samcharles93/cinea/internal/logger"
 )
 
 func NewRouter(
 	cfg *config.Config,
-	movieHandler *handler.MovieHandler,
-	seriesHandler *handler.SeriesHandler,
-	userHandler *handler.UserHandler,
-	authHandler *handler.AuthHandler,
-	webHandler *handler.WebHandler,
+	appLogger logger.Logger,
+	movieHandler handler.MovieHandler,
+	seriesHandler handler.SeriesHandler,
+	searchHandler handler.SearchHandler,
+	personHandler handler.PersonHandler,
+	taxonomyHandler handler.TaxonomyHandler,
+	collectionHandler handler.CollectionHandler,
+	identifyHandler handler.IdentifyHandler,
+	deepLinkHandler handler.DeepLinkHandler,
+	imageHandler handler.ImageHandler,
+	userHandler handler.UserHandler,
+	libraryHandler handler.LibraryHandler,
+	musicHandler handler.MusicHandler,
+	photoHandler handler.PhotoHandler,
+	audiobookHandler handler.AudiobookHandler,
+	authHandler handler.AuthHandler,
+	apiKeyHandler handler.APIKeyHandler,
+	playbackHandler handler.PlaybackHandler,
+	castHandler handler.CastHandler,
+	activityHandler handler.ActivityHandler,
+	dashboardHandler handler.DashboardHandler,
+	schedulerHandler handler.SchedulerHandler,
+	adminHandler handler.AdminHandler,
+	webhookHandler handler.WebhookHandler,
+	integrationHandler handler.IntegrationHandler,
+	systemHandler handler.SystemHandler,
+	brandingHandler handler.BrandingHandler,
+	recommendationHandler handler.RecommendationHandler,
+	docsHandler handler.DocsHandler,
+	wsHandler handler.WSHandler,
+	webHandler handler.WebHandler,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Base middleware
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.Use(traceRequests())
+	r.Use(requestLogger(appLogger))
+	r.Use(middleware.Recoverer)
+	r.Use(compressResponses())
+	r.Use(auth.ExtractParentalPIN)
 
-	// Configure Cors
+	// Configure CORS from the config, falling back to defaults that cover
+	// the bundled web client
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"http://localhost:8937"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "HX-Request", "HX-Target"},
-		ExposedHeaders:   []string{"Link"},
-		AllowCredentials: true,
-		MaxAge:           300,
+		AllowedOrigins:   cfg.CORSAllowedOrigins(),
+		AllowedMethods:   cfg.CORSAllowedMethods(),
+		AllowedHeaders:   cfg.CORSAllowedHeaders(),
+		ExposedHeaders:   cfg.CORSExposedHeaders(),
+		AllowCredentials: cfg.CORSAllowCredentials(),
+		MaxAge:           cfg.CORSMaxAge(),
 	}))
 
-	// API routes
-	r.Route("/api", func(r chi.Router) {
+	// registerAPI mounts every API handler; the same set is served under
+	// the versioned prefix and, for now, the legacy unversioned one
+	registerAPI := func(r chi.Router) {
 		authHandler.RegisterRoutes(r)
+		apiKeyHandler.RegisterRoutes(r)
 		movieHandler.RegisterRoutes(r)
 		seriesHandler.RegisterRoutes(r)
+		searchHandler.RegisterRoutes(r)
+		personHandler.RegisterRoutes(r)
+		taxonomyHandler.RegisterRoutes(r)
+		collectionHandler.RegisterRoutes(r)
+		identifyHandler.RegisterRoutes(r)
+		deepLinkHandler.RegisterRoutes(r)
+		imageHandler.RegisterRoutes(r)
 		userHandler.RegisterRoutes(r)
+		libraryHandler.RegisterRoutes(r)
+		musicHandler.RegisterRoutes(r)
+		photoHandler.RegisterRoutes(r)
+		audiobookHandler.RegisterRoutes(r)
+		playbackHandler.RegisterRoutes(r)
+		castHandler.RegisterRoutes(r)
+		activityHandler.RegisterRoutes(r)
+		dashboardHandler.RegisterRoutes(r)
+		schedulerHandler.RegisterRoutes(r)
+		adminHandler.RegisterRoutes(r)
+		webhookHandler.RegisterRoutes(r)
+		integrationHandler.RegisterRoutes(r)
+		systemHandler.RegisterRoutes(r)
+		brandingHandler.RegisterRoutes(r)
+		recommendationHandler.RegisterRoutes(r)
+		docsHandler.RegisterRoutes(r)
+	}
+
+	// API routes: /api/v1 is the current surface; the bare /api prefix is
+	// kept for existing clients and answers with Deprecation/Sunset
+	// headers until they move over
+	r.Route("/api", func(r chi.Router) {
+		r.Use(negotiateAPIVersion)
+		// API responses revalidate against their ETag rather than being
+		// cached blind
+		r.Use(cacheControl("no-cache, private"))
+		r.Use(conditionalGet)
+
+		r.Route("/v1", registerAPI)
+
+		r.Group(func(r chi.Router) {
+			r.Use(deprecateLegacyAPI)
+			registerAPI(r)
+		})
 	})
 
+	// Real-time event stream
+	wsHandler.RegisterRoutes(r)
+
 	// Web routes
 	webHandler.RegisterRoutes(r)
+	// Shareable deep links land on the web client's detail view
+	deepLinkHandler.RegisterWebRoutes(r)
 	//r.Get("/", webHandler.DashboardHandler)
 	//r.Get("/login", webHandler.LoginHandler)
 	//r.Post("/login", webHandler.LoginHandler)
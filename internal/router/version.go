@@ -0,0 +1,44 @@
+package router
+
+import (
+	"net/http"
+	"time"
+)
+
+// currentAPIVersion is the only version the server currently serves; it
+// is reported on every API response so clients can discover what they
+// are talking to.
+const currentAPIVersion = "v1"
+
+// legacyAPISunset is the date after which the unversioned /api prefix may
+// be removed; it is advertised to clients in the Sunset header.
+var legacyAPISunset = time.Date(2027, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+// negotiateAPIVersion lets clients pin the API version with an
+// X-API-Version request header. Requests without the header pass through
+// unchanged; requests naming a version the server does not serve are
+// rejected up front instead of failing on a missing route later.
+func negotiateAPIVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-API-Version", currentAPIVersion)
+
+		if requested := r.Header.Get("X-API-Version"); requested != "" && requested != currentAPIVersion {
+			http.Error(w, "Unsupported API version: "+requested, http.StatusNotAcceptable)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// deprecateLegacyAPI marks responses on the unversioned /api prefix so
+// clients can migrate to /api/v1 before the legacy paths are removed.
+// The headers follow RFC 8594 (Sunset) and the Deprecation header draft.
+func deprecateLegacyAPI(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", legacyAPISunset.Format(http.TimeFormat))
+		w.Header().Set("Link", `</api/v1>; rel="successor-version"`)
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,187 @@
+// Package searchquery parses the advanced search grammar shared by the
+// search endpoint and smart filters. A query mixes free text with
+// field:value filters, e.g.:
+//
+//	genre:horror year:1980..1989 unwatched:true "john carpenter"
+//
+// Quoted phrases and bare words become the free-text term; known fields
+// become structured filters. Unknown fields are an error so typos don't
+// silently search for nothing.
+package searchquery
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Query is the parsed form of a search expression. Zero values mean the
+// filter was not given.
+type Query struct {
+	// Text is the free-text portion, matched against titles.
+	Text string
+
+	Genre    string
+	Language string
+	Country  string
+
+	// YearFrom/YearTo bound the release year, both inclusive; a single
+	// year:YYYY filter sets both to the same value.
+	YearFrom int
+	YearTo   int
+
+	// RuntimeMin is inclusive, RuntimeMax exclusive, both in minutes,
+	// matching the list endpoints' min_runtime/max_runtime.
+	RuntimeMin int
+	RuntimeMax int
+
+	// Watched filters on the requesting user's watch history; nil leaves
+	// both watched and unwatched items in.
+	Watched *bool
+
+	Sort  string
+	Order string
+}
+
+// Parse turns a raw search expression into a Query.
+func Parse(raw string) (*Query, error) {
+	query := &Query{}
+	var text []string
+
+	for _, token := range tokenize(raw) {
+		if token.quoted {
+			text = append(text, token.value)
+			continue
+		}
+
+		key, value, found := strings.Cut(token.value, ":")
+		if !found {
+			text = append(text, token.value)
+			continue
+		}
+
+		if err := query.apply(strings.ToLower(key), value); err != nil {
+			return nil, err
+		}
+	}
+
+	query.Text = strings.Join(text, " ")
+	return query, nil
+}
+
+// apply sets one field:value filter on the query.
+func (q *Query) apply(key, value string) error {
+	if value == "" {
+		return fmt.Errorf("search field '%s' has no value", key)
+	}
+
+	switch key {
+	case "genre":
+		q.Genre = value
+	case "language":
+		q.Language = value
+	case "country":
+		q.Country = value
+	case "year":
+		from, to, err := parseRange(key, value)
+		if err != nil {
+			return err
+		}
+		q.YearFrom, q.YearTo = from, to
+	case "runtime":
+		from, to, err := parseRange(key, value)
+		if err != nil {
+			return err
+		}
+		q.RuntimeMin, q.RuntimeMax = from, to
+	case "watched":
+		watched, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid watched value '%s'", value)
+		}
+		q.Watched = &watched
+	case "unwatched":
+		unwatched, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid unwatched value '%s'", value)
+		}
+		watched := !unwatched
+		q.Watched = &watched
+	case "sort":
+		q.Sort = value
+	case "order":
+		q.Order = value
+	default:
+		return fmt.Errorf("unknown search field '%s'", key)
+	}
+	return nil
+}
+
+// parseRange reads "N", "N..M", "N.." or "..M" into a from/to pair, with
+// zero standing in for an open end.
+func parseRange(key, value string) (int, int, error) {
+	low, high, isRange := strings.Cut(value, "..")
+
+	from := 0
+	if low != "" {
+		n, err := strconv.Atoi(low)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("invalid %s value '%s'", key, value)
+		}
+		from = n
+	}
+	if !isRange {
+		return from, from, nil
+	}
+
+	to := 0
+	if high != "" {
+		n, err := strconv.Atoi(high)
+		if err != nil || n < 0 || (from > 0 && n < from) {
+			return 0, 0, fmt.Errorf("invalid %s range '%s'", key, value)
+		}
+		to = n
+	}
+	return from, to, nil
+}
+
+type token struct {
+	value  string
+	quoted bool
+}
+
+// tokenize splits the expression on whitespace, keeping double-quoted
+// phrases together. A quote may also open mid-token (genre:"film noir").
+func tokenize(raw string) []token {
+	var tokens []token
+	var current strings.Builder
+	inQuotes := false
+	sawQuote := false
+
+	flush := func() {
+		if current.Len() > 0 || sawQuote {
+			tokens = append(tokens, token{value: current.String(), quoted: sawQuote})
+		}
+		current.Reset()
+		sawQuote = false
+	}
+
+	for _, r := range raw {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			// A token that is entirely quoted is free text; a quote after
+			// "key:" only groups the value
+			if current.Len() == 0 {
+				sawQuote = true
+			}
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/mailer"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+const (
+	passwordResetLifetime     = time.Hour
+	emailVerificationLifetime = 24 * time.Hour
+)
+
+// AccountService handles email-driven account actions: password resets and
+// email verification. Tokens are one-time use, expire, and only a hash is
+// stored server-side.
+type AccountService interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	RequestEmailVerification(ctx context.Context, user *entity.User) error
+	VerifyEmail(ctx context.Context, token string) error
+}
+
+type accountService struct {
+	config       *config.Config
+	appLogger    logger.Logger
+	userRepo     repository.UserRepository
+	tokenRepo    repository.ActionTokenRepository
+	mailer       mailer.Service
+	tokenRevoker repository.RefreshTokenRepository
+}
+
+func NewAccountService(cfg *config.Config, appLogger logger.Logger, userRepo repository.UserRepository, tokenRepo repository.ActionTokenRepository, refreshTokenRepo repository.RefreshTokenRepository, mailSvc mailer.Service) AccountService {
+	return &accountService{
+		config:       cfg,
+		appLogger:    appLogger,
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		mailer:       mailSvc,
+		tokenRevoker: refreshTokenRepo,
+	}
+}
+
+// RequestPasswordReset issues a reset token for the account with the given
+// email and sends the reset link. It succeeds silently when no account
+// matches, so the endpoint cannot be used to probe for registered emails.
+func (s *accountService) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		s.appLogger.Debug().Str("email", email).Msg("Password reset requested for unknown email")
+		return nil
+	}
+
+	token, err := s.issueToken(ctx, user.ID, entity.ActionPasswordReset, passwordResetLifetime)
+	if err != nil {
+		return err
+	}
+
+	link := s.actionLink("/reset-password", token)
+	body := fmt.Sprintf(
+		"Hi %s,\n\nA password reset was requested for your Cinea account. Open the link below to choose a new password. The link expires in %s and can be used once.\n\n%s\n\nIf you did not request this, you can ignore this email.\n",
+		user.Username, passwordResetLifetime, link)
+
+	return s.deliver(ctx, user, "Reset your Cinea password", body, link)
+}
+
+// ResetPassword sets a new password for the user identified by a valid
+// reset token. All of the user's sessions are revoked so a stolen refresh
+// token cannot outlive the reset.
+func (s *accountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	stored, err := s.consumeToken(ctx, token, entity.ActionPasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return fmt.Errorf("invalid token")
+	}
+
+	user.Password = string(hashed)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if err := s.tokenRevoker.RevokeAllForUser(ctx, user.ID); err != nil {
+		s.appLogger.Error().Err(err).Uint("userID", user.ID).Msg("Failed to revoke sessions after password reset")
+	}
+
+	s.appLogger.Info().Uint("userID", user.ID).Msg("Password reset completed")
+	return nil
+}
+
+// RequestEmailVerification sends a verification link to the user's email
+// address.
+func (s *accountService) RequestEmailVerification(ctx context.Context, user *entity.User) error {
+	if user.EmailVerified {
+		return fmt.Errorf("email is already verified")
+	}
+
+	token, err := s.issueToken(ctx, user.ID, entity.ActionEmailVerification, emailVerificationLifetime)
+	if err != nil {
+		return err
+	}
+
+	link := s.actionLink("/verify-email", token)
+	body := fmt.Sprintf(
+		"Hi %s,\n\nOpen the link below to verify the email address on your Cinea account. The link expires in %s and can be used once.\n\n%s\n",
+		user.Username, emailVerificationLifetime, link)
+
+	return s.deliver(ctx, user, "Verify your Cinea email address", body, link)
+}
+
+// VerifyEmail marks the token's user as having a verified email address.
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	stored, err := s.consumeToken(ctx, token, entity.ActionEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return fmt.Errorf("invalid token")
+	}
+
+	user.EmailVerified = true
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to mark email as verified: %w", err)
+	}
+
+	s.appLogger.Info().Uint("userID", user.ID).Msg("Email address verified")
+	return nil
+}
+
+// issueToken creates and stores a one-time token, returning the raw value
+// for the email link.
+func (s *accountService) issueToken(ctx context.Context, userID uint, purpose entity.ActionTokenPurpose, lifetime time.Duration) (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	stored := &entity.ActionToken{
+		UserID:    userID,
+		TokenHash: hashActionToken(token),
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(lifetime),
+	}
+	if err := s.tokenRepo.Store(ctx, stored); err != nil {
+		return "", fmt.Errorf("failed to store token: %w", err)
+	}
+
+	return token, nil
+}
+
+// consumeToken validates a raw token for the given purpose and marks it
+// used, enforcing one-time semantics.
+func (s *accountService) consumeToken(ctx context.Context, token string, purpose entity.ActionTokenPurpose) (*entity.ActionToken, error) {
+	stored, err := s.tokenRepo.FindByHash(ctx, hashActionToken(token), purpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up token: %w", err)
+	}
+	if stored == nil || !stored.IsValid() {
+		return nil, fmt.Errorf("invalid or expired token")
+	}
+
+	if err := s.tokenRepo.MarkUsed(ctx, stored.ID); err != nil {
+		return nil, fmt.Errorf("failed to consume token: %w", err)
+	}
+
+	return stored, nil
+}
+
+// deliver sends the email when a mailer is configured; otherwise the link
+// is written to the log so a local admin can still complete the flow.
+func (s *accountService) deliver(ctx context.Context, user *entity.User, subject, body, link string) error {
+	if !s.mailer.Enabled() {
+		s.appLogger.Warn().
+			Uint("userID", user.ID).
+			Str("link", link).
+			Msg("Outgoing mail is not configured; action link written to log")
+		return nil
+	}
+
+	if err := s.mailer.Send(ctx, user.Email, subject, body); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// actionLink builds the link embedded in an email from the configured base
+// URL.
+func (s *accountService) actionLink(path, token string) string {
+	base := strings.TrimRight(s.config.Server.BaseURL, "/")
+	return fmt.Sprintf("%s%s?token=%s", base, path, token)
+}
+
+// hashActionToken returns the hex SHA-256 of a raw action token; only the
+// hash is ever stored server-side.
+func hashActionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// activityFeedLimit caps how many entries the household feed returns.
+const activityFeedLimit = 50
+
+// ActivityService builds the household activity feed: recent watches,
+// ratings and watchlist additions across all users. Users who opted out of
+// activity sharing are excluded.
+type ActivityService interface {
+	GetFeed(ctx context.Context) ([]*dto.ActivityItemDTO, error)
+}
+
+type activityService struct {
+	appLogger        logger.Logger
+	userRepo         repository.UserRepository
+	watchHistoryRepo repository.WatchHistoryRepository
+	ratingRepo       repository.RatingRepository
+	watchlistRepo    repository.WatchlistRepository
+	movieRepo        repository.MovieRepository
+	seriesRepo       repository.SeriesRepository
+	episodeRepo      repository.EpisodeRepository
+}
+
+func NewActivityService(
+	appLogger logger.Logger,
+	userRepo repository.UserRepository,
+	watchHistoryRepo repository.WatchHistoryRepository,
+	ratingRepo repository.RatingRepository,
+	watchlistRepo repository.WatchlistRepository,
+	movieRepo repository.MovieRepository,
+	seriesRepo repository.SeriesRepository,
+	episodeRepo repository.EpisodeRepository,
+) ActivityService {
+	return &activityService{
+		appLogger:        appLogger,
+		userRepo:         userRepo,
+		watchHistoryRepo: watchHistoryRepo,
+		ratingRepo:       ratingRepo,
+		watchlistRepo:    watchlistRepo,
+		movieRepo:        movieRepo,
+		seriesRepo:       seriesRepo,
+		episodeRepo:      episodeRepo,
+	}
+}
+
+// GetFeed merges recent activity from all sources, newest first, skipping
+// users who opted out of sharing.
+func (s *activityService) GetFeed(ctx context.Context) ([]*dto.ActivityItemDTO, error) {
+	sharers, err := s.sharingUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*dto.ActivityItemDTO, 0, activityFeedLimit)
+
+	history, err := s.watchHistoryRepo.ListRecent(ctx, activityFeedLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range history {
+		user, ok := sharers[h.UserID]
+		if !ok {
+			continue
+		}
+		items = append(items, &dto.ActivityItemDTO{
+			UserID:    h.UserID,
+			Username:  user.Username,
+			Action:    "watched",
+			MediaType: h.MediaType,
+			MediaID:   h.MediaID,
+			Title:     s.mediaTitle(ctx, h.MediaType, h.MediaID),
+			Timestamp: h.WatchedAt,
+		})
+	}
+
+	ratings, err := s.ratingRepo.ListRecent(ctx, activityFeedLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, rating := range ratings {
+		user, ok := sharers[rating.UserID]
+		if !ok {
+			continue
+		}
+		items = append(items, &dto.ActivityItemDTO{
+			UserID:    rating.UserID,
+			Username:  user.Username,
+			Action:    "rated",
+			MediaType: rating.MediaType,
+			MediaID:   rating.MediaID,
+			Title:     s.mediaTitle(ctx, rating.MediaType, rating.MediaID),
+			Score:     rating.Score,
+			Timestamp: rating.CreatedAt,
+		})
+	}
+
+	listed, err := s.watchlistRepo.ListRecent(ctx, activityFeedLimit)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range listed {
+		user, ok := sharers[entry.UserID]
+		if !ok {
+			continue
+		}
+		items = append(items, &dto.ActivityItemDTO{
+			UserID:    entry.UserID,
+			Username:  user.Username,
+			Action:    "watchlisted",
+			MediaType: entry.MediaType,
+			MediaID:   entry.MediaID,
+			Title:     s.mediaTitle(ctx, entry.MediaType, entry.MediaID),
+			Timestamp: entry.CreatedAt,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Timestamp.After(items[j].Timestamp)
+	})
+	if len(items) > activityFeedLimit {
+		items = items[:activityFeedLimit]
+	}
+
+	return items, nil
+}
+
+// sharingUsers returns the users who have activity sharing enabled, keyed
+// by ID.
+func (s *activityService) sharingUsers(ctx context.Context) (map[uint]*entity.User, error) {
+	users, err := s.userRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sharers := make(map[uint]*entity.User, len(users))
+	for _, user := range users {
+		if user.ShareActivity {
+			sharers[user.ID] = user
+		}
+	}
+	return sharers, nil
+}
+
+// mediaTitle best-effort resolves a display title; a lookup failure leaves
+// the title empty rather than failing the whole feed.
+func (s *activityService) mediaTitle(ctx context.Context, mediaType string, id uint) string {
+	switch mediaType {
+	case "movie":
+		if movie, err := s.movieRepo.FindByID(ctx, id); err == nil && movie != nil {
+			return movie.Title
+		}
+	case "series", "tv":
+		if series, err := s.seriesRepo.FindByID(ctx, id); err == nil && series != nil {
+			return series.Title
+		}
+	case "episode":
+		if episode, err := s.episodeRepo.FindEpisodeByID(ctx, id); err == nil && episode != nil {
+			return episode.Title
+		}
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// apiKeyPrefix marks raw keys so they are recognisable in client configs
+// and can never be confused with a JWT.
+const apiKeyPrefix = "cinea_"
+
+type APIKeyService interface {
+	Create(ctx context.Context, userID uint, req *dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedDTO, error)
+	List(ctx context.Context, userID uint) ([]*dto.APIKeyDTO, error)
+	Revoke(ctx context.Context, userID, id uint) error
+}
+
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// Create mints a new key for the user. The raw key is returned once in the
+// response and only its hash is stored.
+func (s *apiKeyService) Create(ctx context.Context, userID uint, req *dto.CreateAPIKeyRequest) (*dto.APIKeyCreatedDTO, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("a key name is required: %w", errors.ErrBadRequest)
+	}
+	scopes, err := auth.ParseScopes(req.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), errors.ErrBadRequest)
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required: %w", errors.ErrBadRequest)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	rawKey := apiKeyPrefix + hex.EncodeToString(raw)
+
+	scopeNames := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scopeNames = append(scopeNames, string(scope))
+	}
+
+	key := &entity.APIKey{
+		UserID: userID,
+		Name:   name,
+		// The prefix lets users tell keys apart in the list view without
+		// ever exposing enough of the key to use
+		Prefix:  rawKey[:len(apiKeyPrefix)+6],
+		KeyHash: auth.HashAPIKey(rawKey),
+		Scopes:  strings.Join(scopeNames, ","),
+	}
+	if err := s.apiKeyRepo.Store(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &dto.APIKeyCreatedDTO{
+		APIKeyDTO: *dto.APIKeyToDTO(key),
+		Key:       rawKey,
+	}, nil
+}
+
+func (s *apiKeyService) List(ctx context.Context, userID uint) ([]*dto.APIKeyDTO, error) {
+	keys, err := s.apiKeyRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*dto.APIKeyDTO, 0, len(keys))
+	for _, key := range keys {
+		out = append(out, dto.APIKeyToDTO(key))
+	}
+	return out, nil
+}
+
+// Revoke deletes one of the user's keys; the user ID is part of the lookup
+// so nobody can revoke another account's keys.
+func (s *apiKeyService) Revoke(ctx context.Context, userID, id uint) error {
+	return s.apiKeyRepo.Delete(ctx, userID, id)
+}
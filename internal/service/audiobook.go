@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// AudiobookService exposes the books built by the scanner and the per-user
+// playback position sync used by long-form audio clients.
+type AudiobookService interface {
+	List(ctx context.Context) ([]*dto.AudiobookDTO, error)
+	Get(ctx context.Context, id uint) (*dto.AudiobookDTO, error)
+	GetProgress(ctx context.Context, userID, id uint) (*dto.AudiobookProgressDTO, error)
+	UpdateProgress(ctx context.Context, userID, id uint, req *dto.UpdateAudiobookProgressRequest) (*dto.AudiobookProgressDTO, error)
+}
+
+type audiobookService struct {
+	audiobookRepo repository.AudiobookRepository
+}
+
+func NewAudiobookService(audiobookRepo repository.AudiobookRepository) AudiobookService {
+	return &audiobookService{audiobookRepo: audiobookRepo}
+}
+
+func (s *audiobookService) List(ctx context.Context) ([]*dto.AudiobookDTO, error) {
+	books, err := s.audiobookRepo.ListBooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dto.AudiobooksToDTO(books), nil
+}
+
+func (s *audiobookService) Get(ctx context.Context, id uint) (*dto.AudiobookDTO, error) {
+	book, err := s.getBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.AudiobookToDTO(book), nil
+}
+
+func (s *audiobookService) GetProgress(ctx context.Context, userID, id uint) (*dto.AudiobookProgressDTO, error) {
+	if _, err := s.getBook(ctx, id); err != nil {
+		return nil, err
+	}
+
+	progress, err := s.audiobookRepo.GetProgress(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if progress == nil {
+		// No saved position yet; the client starts from the beginning
+		return &dto.AudiobookProgressDTO{AudiobookID: id}, nil
+	}
+	return dto.AudiobookProgressToDTO(progress), nil
+}
+
+func (s *audiobookService) UpdateProgress(ctx context.Context, userID, id uint, req *dto.UpdateAudiobookProgressRequest) (*dto.AudiobookProgressDTO, error) {
+	book, err := s.getBook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The synced position must reference one of the book's own files
+	validFile := false
+	for _, file := range book.Files {
+		if file.ID == req.FileID {
+			validFile = true
+			break
+		}
+	}
+	if !validFile {
+		return nil, fmt.Errorf("file %d does not belong to audiobook %d", req.FileID, id)
+	}
+	if req.PositionSec < 0 {
+		return nil, fmt.Errorf("position must not be negative")
+	}
+
+	progress := &entity.AudiobookProgress{
+		UserID:      userID,
+		AudiobookID: id,
+		FileID:      req.FileID,
+		PositionSec: req.PositionSec,
+	}
+	if err := s.audiobookRepo.UpsertProgress(ctx, progress); err != nil {
+		return nil, err
+	}
+	return dto.AudiobookProgressToDTO(progress), nil
+}
+
+func (s *audiobookService) getBook(ctx context.Context, id uint) (*entity.Audiobook, error) {
+	book, err := s.audiobookRepo.FindBookByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if book == nil {
+		return nil, fmt.Errorf("audiobook %d does not exist", id)
+	}
+	return book, nil
+}
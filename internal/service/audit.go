@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+const (
+	auditDefaultLimit = 50
+	auditMaxLimit     = 200
+)
+
+// AuditService persists security-relevant events from the bus and serves
+// the admin query API over them. Publishers stay unaware of auditing; any
+// new security event only needs a subscription here.
+type AuditService interface {
+	List(ctx context.Context, params *dto.AuditListParams) (*dto.AuditPageDTO, error)
+}
+
+type auditService struct {
+	appLogger logger.Logger
+	auditRepo repository.AuditLogRepository
+}
+
+func NewAuditService(appLogger logger.Logger, auditRepo repository.AuditLogRepository, bus events.Bus) AuditService {
+	s := &auditService{
+		appLogger: appLogger,
+		auditRepo: auditRepo,
+	}
+	bus.Subscribe(events.UserLogin, s.onLogin)
+	bus.Subscribe(events.UserLoginFailed, s.onLoginFailed)
+	bus.Subscribe(events.UserImpersonated, s.onImpersonated)
+	bus.Subscribe(events.UserRegistered, s.onUserRegistered)
+	bus.Subscribe(events.UserDeleted, s.onUserDeleted)
+	bus.Subscribe(events.LibraryDeleted, s.onLibraryDeleted)
+	bus.Subscribe(events.SettingsChanged, s.onSettingsChanged)
+	return s
+}
+
+func (s *auditService) List(ctx context.Context, params *dto.AuditListParams) (*dto.AuditPageDTO, error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = auditDefaultLimit
+	}
+	if limit > auditMaxLimit {
+		limit = auditMaxLimit
+	}
+
+	entries, total, err := s.auditRepo.FindPage(ctx, repository.AuditLogListOptions{
+		Limit:  limit,
+		Offset: params.Offset,
+		Action: params.Action,
+		Actor:  params.Actor,
+		From:   params.From,
+		To:     params.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dto.AuditPageDTO{
+		Entries: make([]dto.AuditLogDTO, 0, len(entries)),
+		Total:   total,
+		Limit:   limit,
+		Offset:  params.Offset,
+	}
+	for _, entry := range entries {
+		page.Entries = append(page.Entries, dto.AuditLogToDTO(entry))
+	}
+	return page, nil
+}
+
+func (s *auditService) onLogin(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.LoginPayload)
+	if !ok {
+		return
+	}
+	s.record(ctx, &entity.AuditLog{
+		Action:  string(event.Type),
+		ActorID: &payload.UserID,
+		Actor:   payload.Username,
+	})
+}
+
+func (s *auditService) onLoginFailed(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.LoginPayload)
+	if !ok {
+		return
+	}
+	entry := &entity.AuditLog{
+		Action: string(event.Type),
+		Actor:  payload.Username,
+	}
+	if payload.UserID != 0 {
+		entry.ActorID = &payload.UserID
+	}
+	s.record(ctx, entry)
+}
+
+func (s *auditService) onImpersonated(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.ImpersonationPayload)
+	if !ok {
+		return
+	}
+	adminID := payload.AdminID
+	s.record(ctx, &entity.AuditLog{
+		Action:  string(event.Type),
+		ActorID: &adminID,
+		Actor:   payload.AdminUsername,
+		Detail:  fmt.Sprintf("impersonated user %q (id %d)", payload.TargetUsername, payload.TargetID),
+	})
+}
+
+func (s *auditService) onUserRegistered(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.UserPayload)
+	if !ok {
+		return
+	}
+	s.recordActed(ctx, string(event.Type), fmt.Sprintf("created user %q (id %d)", payload.Username, payload.UserID))
+}
+
+func (s *auditService) onUserDeleted(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.UserPayload)
+	if !ok {
+		return
+	}
+	s.recordActed(ctx, string(event.Type), fmt.Sprintf("deleted user %q (id %d)", payload.Username, payload.UserID))
+}
+
+func (s *auditService) onLibraryDeleted(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.LibraryPayload)
+	if !ok {
+		return
+	}
+	s.recordActed(ctx, string(event.Type), fmt.Sprintf("deleted library %q (id %d)", payload.Name, payload.LibraryID))
+}
+
+func (s *auditService) onSettingsChanged(ctx context.Context, event events.Event) {
+	payload, ok := event.Payload.(events.SettingsPayload)
+	if !ok {
+		return
+	}
+	s.recordActed(ctx, string(event.Type), fmt.Sprintf("%s: %s", payload.Setting, payload.Detail))
+}
+
+// recordActed writes an entry attributed to whoever is authenticated on
+// the request the event came from.
+func (s *auditService) recordActed(ctx context.Context, action, detail string) {
+	entry := &entity.AuditLog{
+		Action: action,
+		Detail: detail,
+	}
+	if user, err := auth.GetUserFromContext(ctx); err == nil {
+		id := user.ID
+		entry.ActorID = &id
+		entry.Actor = user.Username
+	}
+	s.record(ctx, entry)
+}
+
+func (s *auditService) record(ctx context.Context, entry *entity.AuditLog) {
+	entry.RemoteIP = logger.RemoteIP(ctx)
+	if err := s.auditRepo.Store(ctx, entry); err != nil {
+		s.appLogger.Warn().Err(err).Str("action", entry.Action).Msg("Failed to record audit entry")
+	}
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -9,34 +12,46 @@ import (
 	"github.com/samcharles93/cinea/config"
 	"github.com/samcharles93/cinea/internal/dto"
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/events"
 	"github.com/samcharles93/cinea/internal/logger"
 	"github.com/samcharles93/cinea/internal/repository"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService interface {
-	GenerateToken(user *entity.User) (string, error)
 	GetUserFromContext(ctx context.Context) (*entity.User, error)
 	IsAdmin(ctx context.Context) bool
 	IsAuthenticated(ctx context.Context) bool
 	Authenticate(ctx context.Context, username, password string) (*dto.AuthResponse, error)
 	CreateUser(ctx context.Context, username, email, password string) (*dto.AuthResponse, error)
+	Refresh(ctx context.Context, refreshToken string) (*dto.AuthResponse, error)
+	Revoke(ctx context.Context, refreshToken string) error
+	Impersonate(ctx context.Context, targetID uint) (*dto.ImpersonationResponse, error)
+	SelectProfile(ctx context.Context, profileID uint) (*dto.SelectProfileResponse, error)
+	IssueTokens(ctx context.Context, user *entity.User) (*dto.AuthResponse, error)
 	ListUsers(ctx context.Context) ([]*entity.User, error)
 }
 
 type authService struct {
-	config    *config.Config
-	appLogger logger.Logger
-	tokenAuth *jwtauth.JWTAuth
-	userRepo  repository.UserRepository
+	config      *config.Config
+	appLogger   logger.Logger
+	tokenAuth   *jwtauth.JWTAuth
+	userRepo    repository.UserRepository
+	tokenRepo   repository.RefreshTokenRepository
+	profileRepo repository.ProfileRepository
+	bus         events.Bus
 }
 
-func NewAuthService(userRepo repository.UserRepository, cfg *config.Config, appLogger logger.Logger, tokenAuth *jwtauth.JWTAuth) AuthService {
+func NewAuthService(userRepo repository.UserRepository, tokenRepo repository.RefreshTokenRepository, profileRepo repository.ProfileRepository, cfg *config.Config, appLogger logger.Logger, tokenAuth *jwtauth.JWTAuth, bus events.Bus) AuthService {
 	return &authService{
-		tokenAuth: tokenAuth,
-		userRepo:  userRepo,
-		appLogger: appLogger,
-		config:    cfg,
+		tokenAuth:   tokenAuth,
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		profileRepo: profileRepo,
+		appLogger:   appLogger,
+		config:      cfg,
+		bus:         bus,
 	}
 }
 
@@ -47,41 +62,22 @@ func (s *authService) Authenticate(ctx context.Context, username, password strin
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 	if user == nil {
-		return nil, fmt.Errorf("username or password is incorrect")
+		s.bus.Publish(ctx, events.UserLoginFailed, events.LoginPayload{Username: username})
+		return nil, errors.ErrInvalidCredentials
 	}
 
 	// Compare hash and password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return nil, fmt.Errorf("username or password is incorrect")
-	}
-
-	// Generate user token
-	tokenString, err := s.GenerateToken(user)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate token: %w", err)
+		s.bus.Publish(ctx, events.UserLoginFailed, events.LoginPayload{UserID: user.ID, Username: username})
+		return nil, errors.ErrInvalidCredentials
 	}
 
 	if err := s.userRepo.UpdateLastLogin(ctx, user.ID); err != nil {
 		s.appLogger.Warn().Err(err).Msg("failed to update last login")
 	}
 
-	// Prepare response
-	resp := dto.AuthResponse{
-		Token: tokenString,
-		User: struct {
-			ID       uint   `json:"id"`
-			Username string `json:"username"`
-			Email    string `json:"email"`
-			Role     string `json:"role"`
-		}{
-			ID:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			Role:     string(user.Role),
-		},
-	}
-
-	return &resp, nil
+	s.bus.Publish(ctx, events.UserLogin, events.LoginPayload{UserID: user.ID, Username: user.Username})
+	return s.IssueTokens(ctx, user)
 }
 
 func (s *authService) CreateUser(ctx context.Context, username, email, password string) (*dto.AuthResponse, error) {
@@ -114,41 +110,232 @@ func (s *authService) CreateUser(ctx context.Context, username, email, password
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
-	// Generate Token
-	tokenString, err := s.GenerateToken(newUser)
+	s.bus.Publish(ctx, events.UserRegistered, events.UserPayload{
+		UserID:   newUser.ID,
+		Username: newUser.Username,
+	})
+
+	return s.IssueTokens(ctx, newUser)
+}
+
+// Refresh exchanges a valid refresh token for a new access/refresh token
+// pair. The presented token is revoked as part of the exchange (rotation);
+// presenting an already-revoked token is treated as theft and revokes every
+// session the user has.
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*dto.AuthResponse, error) {
+	stored, err := s.tokenRepo.FindByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+	if stored == nil {
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+
+	if stored.RevokedAt != nil {
+		s.appLogger.Warn().Uint("userID", stored.UserID).Msg("Revoked refresh token presented, revoking all sessions for user")
+		if err := s.tokenRepo.RevokeAllForUser(ctx, stored.UserID); err != nil {
+			s.appLogger.Error().Err(err).Uint("userID", stored.UserID).Msg("Failed to revoke user sessions")
+		}
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+	if !stored.IsValid() {
+		return nil, fmt.Errorf("refresh token expired")
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil || !user.IsActive {
+		return nil, fmt.Errorf("invalid refresh token")
+	}
+
+	if err := s.tokenRepo.Revoke(ctx, stored.ID); err != nil {
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+
+	return s.IssueTokens(ctx, user)
+}
+
+// Revoke invalidates a refresh token and the access tokens issued against
+// it. The refresh token itself is the credential, so no JWT is required.
+func (s *authService) Revoke(ctx context.Context, refreshToken string) error {
+	stored, err := s.tokenRepo.FindByHash(ctx, hashRefreshToken(refreshToken))
+	if err != nil {
+		return fmt.Errorf("failed to look up refresh token: %w", err)
+	}
+	if stored == nil {
+		return fmt.Errorf("invalid refresh token")
+	}
+
+	if err := s.tokenRepo.Revoke(ctx, stored.ID); err != nil {
+		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	return nil
+}
+
+// impersonationTokenLifetime bounds how long an impersonation token
+// stays valid; there is no refresh token behind it, so expiry is the
+// only way it ends.
+const impersonationTokenLifetime = time.Hour
+
+// profileTokenLifetime bounds a profile-scoped token; clients pick the
+// profile again at the next login.
+const profileTokenLifetime = 24 * time.Hour
+
+// Impersonate issues a short-lived access token acting as the target
+// user on behalf of the admin authenticated on ctx. The token carries an
+// "act" (actor) claim identifying the admin so clients can show an
+// impersonation banner, and the grant is recorded in the audit log.
+// Reverting is simply discarding the token; the admin's own session is
+// untouched.
+func (s *authService) Impersonate(ctx context.Context, targetID uint) (*dto.ImpersonationResponse, error) {
+	admin, err := s.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user from context: %w", err)
+	}
+
+	target, err := s.userRepo.FindByID(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if target == nil {
+		return nil, errors.NotFoundError("user")
+	}
+	if target.ID == admin.ID {
+		return nil, fmt.Errorf("cannot impersonate yourself: %w", errors.ErrBadRequest)
+	}
+	if target.Role == entity.RoleAdmin {
+		return nil, errors.ForbiddenError("cannot impersonate another administrator")
+	}
+
+	expiresAt := time.Now().Add(impersonationTokenLifetime)
+	_, tokenString, err := s.tokenAuth.Encode(map[string]interface{}{
+		"user": map[string]interface{}{
+			"id":       target.ID,
+			"username": target.Username,
+			"email":    target.Email,
+			"role":     target.Role,
+		},
+		"act": map[string]interface{}{
+			"id":       admin.ID,
+			"username": admin.Username,
+		},
+		"exp": expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	s.bus.Publish(ctx, events.UserImpersonated, events.ImpersonationPayload{
+		AdminID:        admin.ID,
+		AdminUsername:  admin.Username,
+		TargetID:       target.ID,
+		TargetUsername: target.Username,
+	})
+
+	return &dto.ImpersonationResponse{
+		Token:     tokenString,
+		ExpiresAt: expiresAt,
+		UserID:    target.ID,
+		Username:  target.Username,
+	}, nil
+}
+
+// SelectProfile issues a token scoped to one of the caller's household
+// profiles via the "pid" claim; personal lists are filtered by it until
+// the token expires or the client selects another profile.
+func (s *authService) SelectProfile(ctx context.Context, profileID uint) (*dto.SelectProfileResponse, error) {
+	user, err := s.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user from context: %w", err)
+	}
+
+	profile, err := s.profileRepo.FindByID(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find profile: %w", err)
+	}
+	if profile == nil || profile.UserID != user.ID {
+		return nil, errors.NotFoundError("profile")
+	}
+
+	expiresAt := time.Now().Add(profileTokenLifetime)
+	_, tokenString, err := s.tokenAuth.Encode(map[string]interface{}{
+		"user": map[string]interface{}{
+			"id":       user.ID,
+			"username": user.Username,
+			"email":    user.Email,
+			"role":     user.Role,
+		},
+		"pid": profile.ID,
+		"exp": expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	return &dto.SelectProfileResponse{
+		Token:     tokenString,
+		ExpiresAt: expiresAt,
+		Profile:   dto.ProfileToDTO(profile),
+	}, nil
+}
+
+// IssueTokens creates a server-side session row and returns a short-lived
+// access token plus the raw refresh token backing it. It is also used by
+// alternative login flows (e.g. OIDC) once the user is established.
+func (s *authService) IssueTokens(ctx context.Context, user *entity.User) (*dto.AuthResponse, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := hex.EncodeToString(raw)
+
+	session := &entity.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.config.RefreshTokenLifetime()),
+	}
+	if err := s.tokenRepo.Store(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	accessToken, err := s.generateAccessToken(user, session.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	// Prepare response
 	resp := dto.AuthResponse{
-		Token: tokenString,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
 		User: struct {
 			ID       uint   `json:"id"`
 			Username string `json:"username"`
 			Email    string `json:"email"`
 			Role     string `json:"role"`
 		}{
-			ID:       newUser.ID,
-			Username: newUser.Username,
-			Email:    newUser.Email,
-			Role:     string(newUser.Role),
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     string(user.Role),
 		},
 	}
 
 	return &resp, nil
 }
 
-func (s *authService) GenerateToken(user *entity.User) (string, error) {
-	tokenAuth := jwtauth.New("HS256", []byte(s.config.Auth.JWTSecret), nil)
-	_, tokenString, err := tokenAuth.Encode(map[string]interface{}{
+// generateAccessToken encodes a short-lived JWT carrying the session ID so
+// the verifier can reject tokens whose session has been revoked.
+func (s *authService) generateAccessToken(user *entity.User, sessionID uint) (string, error) {
+	_, tokenString, err := s.tokenAuth.Encode(map[string]interface{}{
 		"user": map[string]interface{}{
 			"id":       user.ID,
 			"username": user.Username,
 			"email":    user.Email,
 			"role":     user.Role,
 		},
-		"exp": time.Now().Add(time.Hour * 24).Unix(),
+		"sid": sessionID,
+		"exp": time.Now().Add(s.config.AccessTokenLifetime()).Unix(),
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %w", err)
@@ -157,7 +344,14 @@ func (s *authService) GenerateToken(user *entity.User) (string, error) {
 	return tokenString, nil
 }
 
-func (s *authService) GetUserFromContext(ctx context.Context) (*dto.UserDTO, error) {
+// hashRefreshToken returns the hex SHA-256 of a raw refresh token; only the
+// hash is ever stored server-side.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *authService) GetUserFromContext(ctx context.Context) (*entity.User, error) {
 	_, claims, err := jwtauth.FromContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user from context: %w", err)
@@ -168,7 +362,7 @@ func (s *authService) GetUserFromContext(ctx context.Context) (*dto.UserDTO, err
 		return nil, fmt.Errorf("failed to find user: %w", err)
 	}
 
-	return dto.UserToDTO(user), nil
+	return user, nil
 }
 
 func (s *authService) IsAdmin(ctx context.Context) bool {
@@ -177,7 +371,7 @@ func (s *authService) IsAdmin(ctx context.Context) bool {
 		return false
 	}
 
-	return user.Role == string(entity.RoleAdmin)
+	return user.Role == entity.RoleAdmin
 }
 
 func (s *authService) IsAuthenticated(ctx context.Context) bool {
@@ -191,7 +385,7 @@ func (s *authService) ListUsers(ctx context.Context) ([]*entity.User, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user from context: %w", err)
 	}
-	if user.Role != string(entity.RoleAdmin) {
+	if user.Role != entity.RoleAdmin {
 		return nil, fmt.Errorf("only admins can list users")
 	}
 
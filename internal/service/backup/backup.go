@@ -1,83 +1,426 @@
+// Package backup dumps the configured database (and optionally the
+// artwork cache) into timestamped tar.gz archives under the configured
+// backup directory, prunes archives past the retention count, and
+// restores from an archive on request. It doubles as a scheduled task so
+// automatic backups run on the configured interval.
 package backup
 
 import (
 	"archive/tar"
 	"compress/gzip"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
 )
 
-type Service struct {
-	config *config.Config
+// archive entry names; the database entry's extension identifies which
+// driver produced the dump
+const (
+	sqliteEntry   = "database/cinea.sqlite"
+	postgresEntry = "database/cinea.pgdump"
+	mariadbEntry  = "database/cinea.sql"
+	artworkPrefix = "artwork/"
+)
+
+type Service interface {
+	CreateBackup(ctx context.Context) (*dto.BackupDTO, error)
+	ListBackups(ctx context.Context) ([]dto.BackupDTO, error)
+	Restore(ctx context.Context, file string) error
+
+	// Task scheduler methods
+	Execute(ctx context.Context, config string) error
+	Description() string
+}
+
+type service struct {
+	config    *config.Config
+	appLogger logger.Logger
+	db        *gorm.DB
+}
+
+func NewBackupService(cfg *config.Config, appLogger logger.Logger, db *gorm.DB) Service {
+	return &service{
+		config:    cfg,
+		appLogger: appLogger,
+		db:        db,
+	}
+}
+
+// CreateBackup writes a new archive into the backup directory and prunes
+// archives beyond the retention count.
+func (s *service) CreateBackup(ctx context.Context) (*dto.BackupDTO, error) {
+	dir := s.config.Backup.BackupDir
+	if dir == "" {
+		return nil, fmt.Errorf("backup directory is not configured")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	name := fmt.Sprintf("cinea-backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+	backupFile := filepath.Join(dir, name)
+
+	if err := s.writeArchive(ctx, backupFile); err != nil {
+		// A partial archive would restore a partial database; remove it
+		_ = os.Remove(backupFile)
+		return nil, err
+	}
+
+	s.pruneOldBackups(dir)
+
+	info, err := os.Stat(backupFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat backup archive: %w", err)
+	}
+
+	s.appLogger.Info().Str("file", name).Int64("size", info.Size()).Msg("Backup archive created")
+	return &dto.BackupDTO{File: name, Size: info.Size(), CreatedAt: info.ModTime()}, nil
+}
+
+// ListBackups returns the archives in the backup directory, newest first.
+func (s *service) ListBackups(ctx context.Context) ([]dto.BackupDTO, error) {
+	dir := s.config.Backup.BackupDir
+	if dir == "" {
+		return nil, fmt.Errorf("backup directory is not configured")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []dto.BackupDTO{}, nil
+		}
+		return nil, fmt.Errorf("failed to read backup directory: %w", err)
+	}
+
+	backups := make([]dto.BackupDTO, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !isBackupArchive(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, dto.BackupDTO{
+			File:      entry.Name(),
+			Size:      info.Size(),
+			CreatedAt: info.ModTime(),
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].File > backups[j].File
+	})
+	return backups, nil
+}
+
+// Restore applies an archive from the backup directory. SQLite restores
+// are staged next to the live database and swapped in on the next
+// startup, before any connection opens; Postgres and MariaDB restores are
+// applied immediately through the driver's own restore tool.
+func (s *service) Restore(ctx context.Context, file string) error {
+	dir := s.config.Backup.BackupDir
+	if dir == "" {
+		return fmt.Errorf("backup directory is not configured")
+	}
+
+	// The file name comes from the API; never let it escape the backup dir
+	name := filepath.Base(file)
+	if !isBackupArchive(name) {
+		return fmt.Errorf("'%s' is not a backup archive", name)
+	}
+
+	archive, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	defer archive.Close()
+
+	gr, err := gzip.NewReader(archive)
+	if err != nil {
+		return fmt.Errorf("failed to read backup archive: %w", err)
+	}
+	defer gr.Close()
+
+	restoredDatabase := false
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read backup archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch {
+		case header.Name == sqliteEntry, header.Name == postgresEntry, header.Name == mariadbEntry:
+			if err := s.restoreDatabase(ctx, header.Name, tr); err != nil {
+				return err
+			}
+			restoredDatabase = true
+		case strings.HasPrefix(header.Name, artworkPrefix):
+			if err := s.restoreArtworkFile(header.Name, tr); err != nil {
+				s.appLogger.Warn().Err(err).Str("entry", header.Name).Msg("Failed to restore artwork file")
+			}
+		}
+	}
+
+	if !restoredDatabase {
+		return fmt.Errorf("archive '%s' contains no database dump", name)
+	}
+
+	s.appLogger.Info().Str("file", name).Msg("Backup restored")
+	return nil
 }
 
-func New(cfg *config.Config) *Service {
-	return &Service{config: cfg}
+func (s *service) Execute(ctx context.Context, config string) error {
+	_, err := s.CreateBackup(ctx)
+	return err
 }
 
-func (s *Service) CreateBackup() (string, error) {
-	timestamp := time.Now().Format(time.RFC3339)
-	backupFile := filepath.Join(s.config.Backup.BackupDir, fmt.Sprintf("cinea-backup-%s.tar.gz", timestamp))
+func (s *service) Description() string {
+	return "Creates a database backup archive and prunes old ones."
+}
 
-	// Create the backup file
+// writeArchive dumps the database (and optionally the artwork cache) into
+// a tar.gz at the given path.
+func (s *service) writeArchive(ctx context.Context, backupFile string) error {
 	file, err := os.Create(backupFile)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to create backup archive: %w", err)
 	}
 	defer file.Close()
 
-	// Create the gzip writer
 	gw := gzip.NewWriter(file)
 	defer gw.Close()
 
-	// Create the tar writer
 	tw := tar.NewWriter(gw)
 	defer tw.Close()
 
-	// Backup the database
-	if err := s.backupDatabase(tw); err != nil {
-		return "", err
+	if err := s.dumpDatabase(ctx, tw); err != nil {
+		return err
 	}
 
-	// Backup the config
-	if err := s.backupConfig(tw); err != nil {
-		return "", err
+	if s.config.Backup.IncludeArtwork && s.config.Jobs.Artwork.CacheDir != "" {
+		if err := addDirToTar(tw, s.config.Jobs.Artwork.CacheDir, artworkPrefix); err != nil {
+			return fmt.Errorf("failed to add artwork cache to backup: %w", err)
+		}
 	}
 
-	// Backup the metadata
-	if err := s.backupMetadata(tw); err != nil {
-		return "", err
+	return nil
+}
+
+// dumpDatabase produces a consistent dump for the configured driver and
+// adds it to the archive.
+func (s *service) dumpDatabase(ctx context.Context, tw *tar.Writer) error {
+	tmp := filepath.Join(os.TempDir(), fmt.Sprintf("cinea-dump-%d", time.Now().UnixNano()))
+	defer os.Remove(tmp)
+
+	switch s.config.DB.Driver {
+	case "sqlite":
+		// VACUUM INTO writes a consistent snapshot without locking out
+		// concurrent readers
+		if err := s.db.WithContext(ctx).Exec("VACUUM INTO ?", tmp).Error; err != nil {
+			return fmt.Errorf("failed to snapshot SQLite database: %w", err)
+		}
+		return addFileToTar(tw, tmp, sqliteEntry)
+	case "postgres":
+		pg := s.config.DB.Postgres
+		cmd := exec.CommandContext(ctx, "pg_dump",
+			"--format=custom",
+			"--file="+tmp,
+			"--host="+pg.Host,
+			"--port="+strconv.Itoa(pg.Port),
+			"--username="+pg.User,
+			pg.DBName,
+		)
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+pg.Password)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to run pg_dump: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return addFileToTar(tw, tmp, postgresEntry)
+	case "mariadb", "mysql":
+		mdb := s.config.DB.MariaDB
+		cmd := exec.CommandContext(ctx, "mysqldump",
+			"--result-file="+tmp,
+			"--host="+mdb.Host,
+			"--port="+strconv.Itoa(mdb.Port),
+			"--user="+mdb.User,
+			"--password="+mdb.Password,
+			mdb.DBName,
+		)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to run mysqldump: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return addFileToTar(tw, tmp, mariadbEntry)
+	default:
+		return fmt.Errorf("unsupported database driver: %s", s.config.DB.Driver)
 	}
+}
 
-	return backupFile, nil
+// restoreDatabase applies a database dump from the archive; the entry
+// name must match the configured driver.
+func (s *service) restoreDatabase(ctx context.Context, entry string, r io.Reader) error {
+	switch {
+	case entry == sqliteEntry && s.config.DB.Driver == "sqlite":
+		// Stage next to the live file; the swap happens on the next
+		// startup before any connection opens
+		pending := s.config.DB.SQLite.Path + ".restore-pending"
+		if err := writeFileFrom(pending, r); err != nil {
+			return fmt.Errorf("failed to stage SQLite restore: %w", err)
+		}
+		s.appLogger.Info().Str("path", pending).Msg("SQLite restore staged; restart the server to apply it")
+		return nil
+	case entry == postgresEntry && s.config.DB.Driver == "postgres":
+		tmp := filepath.Join(os.TempDir(), fmt.Sprintf("cinea-restore-%d", time.Now().UnixNano()))
+		defer os.Remove(tmp)
+		if err := writeFileFrom(tmp, r); err != nil {
+			return fmt.Errorf("failed to extract database dump: %w", err)
+		}
+		pg := s.config.DB.Postgres
+		cmd := exec.CommandContext(ctx, "pg_restore",
+			"--clean",
+			"--if-exists",
+			"--host="+pg.Host,
+			"--port="+strconv.Itoa(pg.Port),
+			"--username="+pg.User,
+			"--dbname="+pg.DBName,
+			tmp,
+		)
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+pg.Password)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to run pg_restore: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	case entry == mariadbEntry && (s.config.DB.Driver == "mariadb" || s.config.DB.Driver == "mysql"):
+		mdb := s.config.DB.MariaDB
+		cmd := exec.CommandContext(ctx, "mysql",
+			"--host="+mdb.Host,
+			"--port="+strconv.Itoa(mdb.Port),
+			"--user="+mdb.User,
+			"--password="+mdb.Password,
+			mdb.DBName,
+		)
+		cmd.Stdin = r
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to run mysql restore: %w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	default:
+		return fmt.Errorf("archive was taken with a different database driver than '%s'", s.config.DB.Driver)
+	}
 }
 
-func (s *Service) Restore(backupPath string) error {
-	/*
-		Implement Restore Fucntionality
+// restoreArtworkFile writes an artwork entry back into the cache dir.
+func (s *service) restoreArtworkFile(entry string, r io.Reader) error {
+	cacheDir := s.config.Jobs.Artwork.CacheDir
+	if cacheDir == "" {
+		return nil
+	}
 
-		1. Verify the backup integrity
-		2. Stop Services
-		3. Restore the database
-		4. Restore the config
-		5. Restore the metadata
-		6. Start the services
-	*/
-	return nil
+	rel := strings.TrimPrefix(entry, artworkPrefix)
+	target := filepath.Join(cacheDir, filepath.FromSlash(rel))
+	// Reject entries that would escape the cache dir
+	if !strings.HasPrefix(filepath.Clean(target), filepath.Clean(cacheDir)+string(filepath.Separator)) {
+		return fmt.Errorf("artwork entry '%s' escapes the cache directory", entry)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	return writeFileFrom(target, r)
 }
 
-func (s *Service) backupDatabase(tw *tar.Writer) error {
-	return nil
+// pruneOldBackups removes archives beyond the retention count, oldest
+// first. Failures only cost disk space, so they are logged and ignored.
+func (s *service) pruneOldBackups(dir string) {
+	backups, err := s.ListBackups(context.Background())
+	if err != nil {
+		s.appLogger.Warn().Err(err).Msg("Failed to list backups for pruning")
+		return
+	}
+
+	for _, old := range backups[min(len(backups), s.config.BackupRetention()):] {
+		if err := os.Remove(filepath.Join(dir, old.File)); err != nil {
+			s.appLogger.Warn().Err(err).Str("file", old.File).Msg("Failed to prune old backup")
+			continue
+		}
+		s.appLogger.Info().Str("file", old.File).Msg("Pruned old backup")
+	}
 }
 
-func (s *Service) backupConfig(tw *tar.Writer) error {
-	return nil
+// isBackupArchive reports whether a file name looks like one of our
+// archives.
+func isBackupArchive(name string) bool {
+	return strings.HasPrefix(name, "cinea-backup-") && strings.HasSuffix(name, ".tar.gz")
 }
 
-func (s *Service) backupMetadata(tw *tar.Writer) error {
+// addFileToTar copies one file into the archive under the given name.
+func addFileToTar(tw *tar.Writer, path, name string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open '%s': %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat '%s': %w", path, err)
+	}
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    info.Size(),
+		ModTime: info.ModTime(),
+	}); err != nil {
+		return fmt.Errorf("failed to write archive header for '%s': %w", name, err)
+	}
+	if _, err := io.Copy(tw, file); err != nil {
+		return fmt.Errorf("failed to write '%s' into archive: %w", name, err)
+	}
 	return nil
 }
+
+// addDirToTar copies every regular file under root into the archive,
+// prefixing its root-relative path.
+func addDirToTar(tw *tar.Writer, root, prefix string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		return addFileToTar(tw, path, prefix+filepath.ToSlash(rel))
+	})
+}
+
+// writeFileFrom streams a reader into a new file at path.
+func writeFileFrom(path string, r io.Reader) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/upload"
+)
+
+// accentColorPattern accepts CSS hex colors like "#fff" or "#1db954".
+var accentColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// BrandingService manages the server's configurable look: display name,
+// accent color and the login artwork. Uploaded images run through the
+// shared upload pipeline and are stored in the branding asset directory.
+type BrandingService interface {
+	Get(ctx context.Context) (*dto.BrandingDTO, error)
+	ServerName(ctx context.Context) string
+	Update(ctx context.Context, req *dto.UpdateBrandingRequest) (*dto.BrandingDTO, error)
+	SetImage(ctx context.Context, kind string, r io.Reader) error
+	ImagePath(ctx context.Context, kind string) (string, error)
+}
+
+// Image kinds accepted by SetImage and ImagePath.
+const (
+	BrandingImageLogo       = "logo"
+	BrandingImageBackground = "background"
+)
+
+type brandingService struct {
+	config       *config.Config
+	appLogger    logger.Logger
+	brandingRepo repository.BrandingRepository
+	bus          events.Bus
+}
+
+func NewBrandingService(cfg *config.Config, appLogger logger.Logger, brandingRepo repository.BrandingRepository, bus events.Bus) BrandingService {
+	return &brandingService{
+		config:       cfg,
+		appLogger:    appLogger,
+		brandingRepo: brandingRepo,
+		bus:          bus,
+	}
+}
+
+// Get returns the branding clients should render; unset fields fall back
+// to the configured server name and client defaults.
+func (s *brandingService) Get(ctx context.Context) (*dto.BrandingDTO, error) {
+	branding, err := s.brandingRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if branding == nil {
+		branding = &entity.Branding{}
+	}
+
+	result := &dto.BrandingDTO{
+		ServerName:  branding.ServerName,
+		AccentColor: branding.AccentColor,
+	}
+	if result.ServerName == "" {
+		result.ServerName = s.config.ServerName()
+	}
+	if branding.LogoPath != "" {
+		result.LogoURL = "/api/branding/logo"
+	}
+	if branding.LoginBackgroundPath != "" {
+		result.LoginBackgroundURL = "/api/branding/background"
+	}
+	return result, nil
+}
+
+// ServerName returns the effective display name: the branded name when one
+// is set, otherwise the configured one. Lookup failures fall back to the
+// config so callers never lose the name over a database hiccup.
+func (s *brandingService) ServerName(ctx context.Context) string {
+	branding, err := s.brandingRepo.Get(ctx)
+	if err == nil && branding != nil && branding.ServerName != "" {
+		return branding.ServerName
+	}
+	return s.config.ServerName()
+}
+
+// Update applies name and accent color edits; nil fields are left
+// unchanged and empty strings reset to the default.
+func (s *brandingService) Update(ctx context.Context, req *dto.UpdateBrandingRequest) (*dto.BrandingDTO, error) {
+	branding, err := s.getOrInit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ServerName != nil {
+		branding.ServerName = *req.ServerName
+	}
+	if req.AccentColor != nil {
+		if *req.AccentColor != "" && !accentColorPattern.MatchString(*req.AccentColor) {
+			return nil, fmt.Errorf("invalid accent color %q", *req.AccentColor)
+		}
+		branding.AccentColor = *req.AccentColor
+	}
+
+	if err := s.brandingRepo.Save(ctx, branding); err != nil {
+		return nil, err
+	}
+
+	s.bus.Publish(ctx, events.SettingsChanged, events.SettingsPayload{
+		Setting: "branding",
+		Detail:  "updated server name and accent color",
+	})
+	return s.Get(ctx)
+}
+
+// SetImage validates, re-encodes and stores a branding image, replacing
+// any previous one of the same kind.
+func (s *brandingService) SetImage(ctx context.Context, kind string, r io.Reader) error {
+	if kind != BrandingImageLogo && kind != BrandingImageBackground {
+		return fmt.Errorf("invalid branding image kind %q", kind)
+	}
+
+	img, err := upload.ReadImage(r, upload.MaxImageSize)
+	if err != nil {
+		return err
+	}
+
+	dir := s.config.BrandingAssetDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create branding asset directory: %w", err)
+	}
+	path := filepath.Join(dir, kind+img.Extension)
+	if err := os.WriteFile(path, img.Data, 0o644); err != nil {
+		return fmt.Errorf("failed to write branding image: %w", err)
+	}
+
+	branding, err := s.getOrInit(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The extension can change between uploads; drop the replaced file
+	old := branding.LogoPath
+	if kind == BrandingImageBackground {
+		old = branding.LoginBackgroundPath
+	}
+	if old != "" && old != path {
+		if err := os.Remove(old); err != nil && !os.IsNotExist(err) {
+			s.appLogger.Warn().Err(err).Str("path", old).Msg("Failed to remove replaced branding image")
+		}
+	}
+
+	if kind == BrandingImageLogo {
+		branding.LogoPath = path
+	} else {
+		branding.LoginBackgroundPath = path
+	}
+	if err := s.brandingRepo.Save(ctx, branding); err != nil {
+		return err
+	}
+
+	s.bus.Publish(ctx, events.SettingsChanged, events.SettingsPayload{
+		Setting: "branding",
+		Detail:  fmt.Sprintf("uploaded %s image", kind),
+	})
+	return nil
+}
+
+// ImagePath returns the on-disk path of a branding image, or empty when
+// none is set.
+func (s *brandingService) ImagePath(ctx context.Context, kind string) (string, error) {
+	branding, err := s.brandingRepo.Get(ctx)
+	if err != nil {
+		return "", err
+	}
+	if branding == nil {
+		return "", nil
+	}
+	switch kind {
+	case BrandingImageLogo:
+		return branding.LogoPath, nil
+	case BrandingImageBackground:
+		return branding.LoginBackgroundPath, nil
+	default:
+		return "", fmt.Errorf("invalid branding image kind %q", kind)
+	}
+}
+
+// getOrInit returns the branding row, creating the in-memory zero value on
+// first edit.
+func (s *brandingService) getOrInit(ctx context.Context) (*entity.Branding, error) {
+	branding, err := s.brandingRepo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if branding == nil {
+		branding = &entity.Branding{}
+	}
+	return branding, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// tmdbImageBase is the TMDb image CDN; sizes are fixed variants the CDN
+// scales to, so the artwork dimensions are known without probing.
+const tmdbImageBase = "https://image.tmdb.org/t/p/"
+
+// castImageVariant pairs a TMDb size name with the dimensions it produces
+// for the standard poster (2:3) and backdrop (16:9) aspect ratios.
+type castImageVariant struct {
+	size   string
+	width  int
+	height int
+}
+
+var (
+	castPosterVariants = []castImageVariant{
+		{size: "w342", width: 342, height: 513},
+		{size: "w500", width: 500, height: 750},
+		{size: "w780", width: 780, height: 1170},
+	}
+	castBackdropVariants = []castImageVariant{
+		{size: "w780", width: 780, height: 439},
+		{size: "w1280", width: 1280, height: 720},
+	}
+)
+
+// CastService assembles the metadata cast receivers need to display what
+// is playing, including artwork at the resolutions receivers require.
+type CastService interface {
+	GetCastMetadata(ctx context.Context, mediaType string, id uint) (*dto.CastMetadataDTO, error)
+}
+
+type castService struct {
+	movieRepo   repository.MovieRepository
+	seriesRepo  repository.SeriesRepository
+	episodeRepo repository.EpisodeRepository
+}
+
+func NewCastService(movieRepo repository.MovieRepository, seriesRepo repository.SeriesRepository, episodeRepo repository.EpisodeRepository) CastService {
+	return &castService{
+		movieRepo:   movieRepo,
+		seriesRepo:  seriesRepo,
+		episodeRepo: episodeRepo,
+	}
+}
+
+func (s *castService) GetCastMetadata(ctx context.Context, mediaType string, id uint) (*dto.CastMetadataDTO, error) {
+	switch mediaType {
+	case "movie":
+		return s.movieMetadata(ctx, id)
+	case "episode":
+		return s.episodeMetadata(ctx, id)
+	default:
+		return nil, fmt.Errorf("unsupported media type '%s': %w", mediaType, errors.ErrBadRequest)
+	}
+}
+
+func (s *castService) movieMetadata(ctx context.Context, id uint) (*dto.CastMetadataDTO, error) {
+	movie, err := s.movieRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if movie == nil {
+		return nil, fmt.Errorf("movie with ID %d not found: %w", id, errors.ErrNotFound)
+	}
+
+	meta := &dto.CastMetadataDTO{
+		MediaType:   "movie",
+		ID:          movie.ID,
+		Title:       movie.Title,
+		ContentType: containerMIME(movie.LibraryItem),
+		Duration:    movie.Runtime * 60,
+		Images:      castImages(movie.PosterPath, movie.BackdropPath),
+	}
+	return meta, nil
+}
+
+func (s *castService) episodeMetadata(ctx context.Context, id uint) (*dto.CastMetadataDTO, error) {
+	episode, err := s.episodeRepo.FindEpisodeByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if episode == nil {
+		return nil, fmt.Errorf("episode with ID %d not found: %w", id, errors.ErrNotFound)
+	}
+
+	meta := &dto.CastMetadataDTO{
+		MediaType:   "episode",
+		ID:          episode.ID,
+		Title:       episode.Title,
+		ContentType: containerMIME(episode.LibraryItem),
+		Images:      castImages("", episode.StillPath),
+	}
+
+	series, err := s.seriesRepo.FindByID(ctx, episode.SeriesID, repository.WithSeasons())
+	if err != nil {
+		return nil, err
+	}
+	if series != nil {
+		meta.Subtitle = series.Title
+		for _, season := range series.Seasons {
+			if season.ID == episode.SeasonID {
+				meta.Subtitle = fmt.Sprintf("%s - S%02dE%02d", series.Title, season.SeasonNumber, episode.EpisodeNumber)
+				break
+			}
+		}
+		// Episodes without a still fall back to the series artwork so the
+		// receiver never shows a blank
+		if len(meta.Images) == 0 {
+			meta.Images = castImages(series.PosterPath, series.BackdropPath)
+		}
+	}
+	return meta, nil
+}
+
+// castImages builds the artwork variant list from the stored TMDb poster
+// and backdrop paths, skipping whichever is missing.
+func castImages(posterPath, backdropPath string) []*dto.CastImageDTO {
+	var images []*dto.CastImageDTO
+	if posterPath != "" {
+		for _, variant := range castPosterVariants {
+			images = append(images, &dto.CastImageDTO{
+				URL:    tmdbImageBase + variant.size + posterPath,
+				Width:  variant.width,
+				Height: variant.height,
+			})
+		}
+	}
+	if backdropPath != "" {
+		for _, variant := range castBackdropVariants {
+			images = append(images, &dto.CastImageDTO{
+				URL:    tmdbImageBase + variant.size + backdropPath,
+				Width:  variant.width,
+				Height: variant.height,
+			})
+		}
+	}
+	return images
+}
+
+// containerMIME maps the item's container to the MIME type receivers use
+// to pick a decoder.
+func containerMIME(item entity.LibraryItem) string {
+	container := item.Container
+	if container == "" {
+		container = strings.TrimPrefix(filepath.Ext(item.FilePath), ".")
+	}
+
+	switch strings.ToLower(container) {
+	case "mp4", "m4v":
+		return "video/mp4"
+	case "mkv", "matroska", "matroska,webm":
+		return "video/x-matroska"
+	case "webm":
+		return "video/webm"
+	case "avi":
+		return "video/x-msvideo"
+	case "mov":
+		return "video/quicktime"
+	case "ts", "mpegts":
+		return "video/mp2t"
+	default:
+		return "application/octet-stream"
+	}
+}
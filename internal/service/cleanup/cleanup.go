@@ -2,11 +2,20 @@ package cleanup
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/samcharles93/cinea/config"
 	"github.com/samcharles93/cinea/internal/entity"
 	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
 	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/service/scanner"
 )
 
 type Service interface {
@@ -21,26 +30,91 @@ type service struct {
 	config      *config.Config
 	appLogger   logger.Logger
 	libraryRepo repository.LibraryRepository
+	movieRepo   repository.MovieRepository
+	seriesRepo  repository.SeriesRepository
+	episodeRepo repository.EpisodeRepository
+	mediaFiles  *scanner.MediaFileChecker
+
+	// lastSummary holds the report of the most recent run so the scheduler
+	// can persist it with the task run.
+	lastSummary string
+
+	// progress is the scheduler's callback for live per-library progress;
+	// nil outside scheduled runs.
+	progress func(current, total int, message string)
 }
 
-func NewCleanupService(config *config.Config, appLogger logger.Logger, libraryRepo repository.LibraryRepository) Service {
+func NewCleanupService(config *config.Config, appLogger logger.Logger, libraryRepo repository.LibraryRepository, movieRepo repository.MovieRepository, seriesRepo repository.SeriesRepository, episodeRepo repository.EpisodeRepository) Service {
 	return &service{
 		config:      config,
 		appLogger:   appLogger,
 		libraryRepo: libraryRepo,
+		movieRepo:   movieRepo,
+		seriesRepo:  seriesRepo,
+		episodeRepo: episodeRepo,
+		mediaFiles:  scanner.NewMediaFileChecker(config),
+	}
+}
+
+// runSettings are the effective options for one cleanup run, resolved from
+// the config file and any task config overrides.
+type runSettings struct {
+	deleteMissing  bool
+	deleteOrphaned bool
+	dryRun         bool
+	gracePeriod    time.Duration
+}
+
+func (s *service) defaultSettings() runSettings {
+	return runSettings{
+		deleteMissing:  s.config.Jobs.Cleanup.DeleteMissing,
+		deleteOrphaned: s.config.Jobs.Cleanup.DeleteOrphaned,
+		dryRun:         s.config.Jobs.Cleanup.DryRun,
+		gracePeriod:    s.config.CleanupGracePeriod(),
 	}
 }
 
+// cleanupReport accumulates what happened during one run so a summary can
+// be persisted with the task run.
+type cleanupReport struct {
+	started   time.Time
+	marked    int // files newly found missing, grace period started
+	recovered int // previously missing files found again
+	removed   int // entries soft-deleted after the grace period
+	orphaned  int // on-disk media files with no database entry
+	purged    int // recycle-bin entries hard-deleted after retention
+	errors    int
+}
+
+func newCleanupReport() *cleanupReport {
+	return &cleanupReport{started: time.Now()}
+}
+
+// Summary renders the report as a single human-readable line.
+func (r *cleanupReport) Summary() string {
+	return fmt.Sprintf("%d marked missing, %d recovered, %d removed, %d orphaned, %d purged, %d errors in %s",
+		r.marked, r.recovered, r.removed, r.orphaned, r.purged, r.errors, time.Since(r.started).Round(time.Second))
+}
+
 // Cleanup movies that have been soft-deleted for more than cfg.Cleanup.MaxAge days
 func (s *service) Run(ctx context.Context) error {
+	return s.run(ctx, s.defaultSettings())
+}
+
+func (s *service) run(ctx context.Context, settings runSettings) error {
 	// Get all libraries
 	libraries, err := s.libraryRepo.ListLibraries(ctx)
 	if err != nil {
 		return err
 	}
 
-	for _, lib := range libraries {
-		if err := s.cleanupLibrary(ctx, lib); err != nil {
+	report := newCleanupReport()
+	// The recycle-bin purge counts as one extra step after the libraries
+	total := len(libraries) + 1
+	for i, lib := range libraries {
+		s.reportProgress(i+1, total, "Cleaning "+lib.Name)
+		if err := s.cleanupLibrary(ctx, lib, settings, report); err != nil {
+			report.errors++
 			s.appLogger.Error().
 				Err(err).
 				Str("library", lib.Name).
@@ -48,20 +122,83 @@ func (s *service) Run(ctx context.Context) error {
 		}
 	}
 
+	s.reportProgress(total, total, "Purging the recycle bin")
+	s.purgeTrash(ctx, settings, report)
+
+	summary := report.Summary()
+	s.lastSummary = summary
+	s.appLogger.Info().
+		Bool("dry_run", settings.dryRun).
+		Int("marked", report.marked).
+		Int("recovered", report.recovered).
+		Int("removed", report.removed).
+		Int("orphaned", report.orphaned).
+		Int("purged", report.purged).
+		Int("errors", report.errors).
+		Msg("Cleanup finished: " + summary)
 	return nil
 }
 
-func (s *service) cleanupLibrary(ctx context.Context, lib *entity.Library) error {
+// purgeTrash hard-deletes recycle-bin entries older than the retention
+// window, so soft-deleted items stay restorable for a while but don't pile
+// up forever.
+func (s *service) purgeTrash(ctx context.Context, settings runSettings, report *cleanupReport) {
+	if settings.dryRun {
+		s.appLogger.Debug().Msg("Dry run: skipping recycle-bin purge")
+		return
+	}
+
+	retention := s.config.TrashRetention()
+	if purged, err := s.movieRepo.PurgeDeleted(ctx, retention); err != nil {
+		report.errors++
+		s.appLogger.Error().Err(err).Msg("Failed to purge deleted movies")
+	} else {
+		report.purged += int(purged)
+	}
+	if purged, err := s.seriesRepo.PurgeDeleted(ctx, retention); err != nil {
+		report.errors++
+		s.appLogger.Error().Err(err).Msg("Failed to purge deleted shows")
+	} else {
+		report.purged += int(purged)
+	}
+	if purged, err := s.episodeRepo.PurgeDeleted(ctx, retention); err != nil {
+		report.errors++
+		s.appLogger.Error().Err(err).Msg("Failed to purge deleted episodes")
+	} else {
+		report.purged += int(purged)
+	}
+}
+
+// Summary implements the scheduler's Summarizer interface so the last run's
+// report is persisted with the task run.
+func (s *service) Summary() string {
+	return s.lastSummary
+}
+
+// SetProgressFunc implements the scheduler's ProgressReporter interface;
+// scheduled runs report per-library progress through fn.
+func (s *service) SetProgressFunc(fn func(current, total int, message string)) {
+	s.progress = fn
+}
+
+// reportProgress invokes the progress callback when one is registered.
+func (s *service) reportProgress(current, total int, message string) {
+	if s.progress != nil {
+		s.progress(current, total, message)
+	}
+}
+
+func (s *service) cleanupLibrary(ctx context.Context, lib *entity.Library, settings runSettings, report *cleanupReport) error {
 	// Find items with missing files
-	if s.config.Jobs.Cleanup.DeleteMissing {
-		if err := s.cleanupMissingFiles(ctx, lib); err != nil {
+	if settings.deleteMissing {
+		if err := s.cleanupMissingFiles(ctx, lib, settings, report); err != nil {
 			return err
 		}
 	}
 
 	// Find orphaned files
-	if s.config.Jobs.Cleanup.DeleteOrphaned {
-		if err := s.cleanupOrphanedFiles(ctx, lib); err != nil {
+	if settings.deleteOrphaned {
+		if err := s.cleanupOrphanedFiles(ctx, lib, settings, report); err != nil {
 			return err
 		}
 	}
@@ -69,20 +206,239 @@ func (s *service) cleanupLibrary(ctx context.Context, lib *entity.Library) error
 	return nil
 }
 
-func (s *service) cleanupOrphanedFiles(ctx context.Context, lib *entity.Library) error {
-	// Find and cleanup media files which don't have database entries
+// missingAction is what cleanupMissingFiles decided for one item.
+type missingAction int
+
+const (
+	missingNone    missingAction = iota
+	missingMark                  // file gone, start the grace period
+	missingRecover               // file back, clear the mark
+	missingRemove                // grace period over, soft-delete the entry
+)
+
+// checkMissing decides what to do with an item based on whether its file
+// exists and how long it has been gone.
+func checkMissing(item *entity.LibraryItem, now time.Time, grace time.Duration) missingAction {
+	if _, err := os.Stat(pathutil.Long(item.FilePath)); err == nil || !os.IsNotExist(err) {
+		// Present, or unreadable for another reason (permissions, flaky
+		// mount); neither justifies removing the entry
+		if item.MissingSince != nil {
+			return missingRecover
+		}
+		return missingNone
+	}
+
+	if item.MissingSince == nil {
+		return missingMark
+	}
+	if now.Sub(*item.MissingSince) >= grace {
+		return missingRemove
+	}
+	return missingNone
+}
+
+// cleanupMissingFiles verifies every imported file in the library still
+// exists. A file found gone is first marked and only soft-deleted once the
+// grace period elapses, so an unmounted share doesn't purge the library;
+// soft deletion keeps watch history recoverable via restore.
+func (s *service) cleanupMissingFiles(ctx context.Context, lib *entity.Library, settings runSettings, report *cleanupReport) error {
+	now := time.Now()
+
+	movies, err := s.movieRepo.FindByLibrary(ctx, lib.ID)
+	if err != nil {
+		return err
+	}
+	for _, movie := range movies {
+		switch checkMissing(&movie.LibraryItem, now, settings.gracePeriod) {
+		case missingMark:
+			report.marked++
+			s.appLogger.Warn().Str("title", movie.Title).Str("path", movie.FilePath).Msg("Movie file is missing; grace period started")
+			if !settings.dryRun {
+				movie.MissingSince = &now
+				if err := s.movieRepo.Update(ctx, movie); err != nil {
+					return err
+				}
+			}
+		case missingRecover:
+			report.recovered++
+			s.appLogger.Info().Str("title", movie.Title).Str("path", movie.FilePath).Msg("Missing movie file reappeared")
+			if !settings.dryRun {
+				movie.MissingSince = nil
+				if err := s.movieRepo.Update(ctx, movie); err != nil {
+					return err
+				}
+			}
+		case missingRemove:
+			report.removed++
+			s.appLogger.Info().Str("title", movie.Title).Str("path", movie.FilePath).Bool("dry_run", settings.dryRun).Msg("Removing movie whose file stayed missing past the grace period")
+			if !settings.dryRun {
+				if err := s.movieRepo.Delete(ctx, movie.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	episodes, err := s.episodeRepo.FindByLibrary(ctx, lib.ID)
+	if err != nil {
+		return err
+	}
+	for _, episode := range episodes {
+		switch checkMissing(&episode.LibraryItem, now, settings.gracePeriod) {
+		case missingMark:
+			report.marked++
+			s.appLogger.Warn().Str("title", episode.Title).Str("path", episode.FilePath).Msg("Episode file is missing; grace period started")
+			if !settings.dryRun {
+				episode.MissingSince = &now
+				if err := s.episodeRepo.UpdateEpisode(ctx, episode); err != nil {
+					return err
+				}
+			}
+		case missingRecover:
+			report.recovered++
+			s.appLogger.Info().Str("title", episode.Title).Str("path", episode.FilePath).Msg("Missing episode file reappeared")
+			if !settings.dryRun {
+				episode.MissingSince = nil
+				if err := s.episodeRepo.UpdateEpisode(ctx, episode); err != nil {
+					return err
+				}
+			}
+		case missingRemove:
+			report.removed++
+			s.appLogger.Info().Str("title", episode.Title).Str("path", episode.FilePath).Bool("dry_run", settings.dryRun).Msg("Removing episode whose file stayed missing past the grace period")
+			if !settings.dryRun {
+				if err := s.episodeRepo.DeleteEpisode(ctx, episode.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
 	return nil
 }
 
-func (s *service) cleanupMissingFiles(ctx context.Context, lib *entity.Library) error {
-	// Find and cleanup database entries where media files don't exist
+// cleanupOrphanedFiles flags on-disk video files with no database entry.
+// Orphans are only reported, never deleted: removing user files over a
+// metadata mismatch is not a call a background job should make.
+func (s *service) cleanupOrphanedFiles(ctx context.Context, lib *entity.Library, settings runSettings, report *cleanupReport) error {
+	// Only movie and tv files can be checked against the media tables
+	if lib.Type != entity.LibraryTypeMovie && lib.Type != entity.LibraryTypeTV {
+		return nil
+	}
+
+	for _, libPath := range lib.Paths {
+		if !libPath.Enabled {
+			continue
+		}
+
+		err := filepath.WalkDir(pathutil.Long(libPath.Path), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || !s.mediaFiles.IsVideo(path) {
+				return nil
+			}
+
+			known, err := s.pathKnown(ctx, path)
+			if err != nil {
+				return err
+			}
+			if !known {
+				report.orphaned++
+				s.appLogger.Warn().Str("library", lib.Name).Str("path", path).Msg("Found media file with no database entry")
+			}
+			return nil
+		})
+		if err != nil {
+			report.errors++
+			s.appLogger.Error().Err(err).Str("library", lib.Name).Str("path", libPath.Path).Msg("Failed to walk library path for orphans")
+		}
+	}
+
 	return nil
 }
 
+// pathKnown reports whether any media table has an entry for the file.
+func (s *service) pathKnown(ctx context.Context, path string) (bool, error) {
+	movie, err := s.movieRepo.FindByPath(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	if movie != nil {
+		return true, nil
+	}
+
+	version, err := s.movieRepo.FindVersionByPath(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	if version != nil {
+		return true, nil
+	}
+
+	episode, err := s.episodeRepo.FindByPath(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	return episode != nil, nil
+}
+
+// taskConfig is the typed schema for a cleanup task's Config field; nil
+// fields fall back to the Jobs.Cleanup settings from the config file.
+type taskConfig struct {
+	DeleteMissing  *bool `json:"delete_missing,omitempty"`
+	DeleteOrphaned *bool `json:"delete_orphaned,omitempty"`
+	DryRun         *bool `json:"dry_run,omitempty"`
+}
+
+// parseTaskConfig parses a task's Config JSON; an empty string yields the
+// zero config, which uses the config file settings.
+func parseTaskConfig(configStr string) (taskConfig, error) {
+	var cfg taskConfig
+	if configStr == "" {
+		return cfg, nil
+	}
+
+	// Unknown fields are rejected so typos surface when the config is saved
+	decoder := json.NewDecoder(strings.NewReader(configStr))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid cleanup task config: %w", err)
+	}
+	return cfg, nil
+}
+
+// ValidateConfig implements the scheduler.ConfigValidator interface so bad
+// task configs are rejected when saved rather than at run time.
+func (s *service) ValidateConfig(config string) error {
+	_, err := parseTaskConfig(config)
+	return err
+}
+
 func (s *service) Execute(ctx context.Context, config string) error {
-	// Process the config file to determine what settings are being used in the runner.
+	taskCfg, err := parseTaskConfig(config)
+	if err != nil {
+		return err
+	}
+
+	settings := s.defaultSettings()
+	if taskCfg.DeleteMissing != nil {
+		settings.deleteMissing = *taskCfg.DeleteMissing
+	}
+	if taskCfg.DeleteOrphaned != nil {
+		settings.deleteOrphaned = *taskCfg.DeleteOrphaned
+	}
+	if taskCfg.DryRun != nil {
+		settings.dryRun = *taskCfg.DryRun
+	}
 
-	return s.Run(ctx)
+	return s.run(ctx, settings)
 }
 
 func (s *service) Description() string {
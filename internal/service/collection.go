@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+type CollectionService interface {
+	ListCollections(ctx context.Context) ([]*dto.CollectionDTO, error)
+	GetCollectionByID(ctx context.Context, id uint) (*dto.CollectionDTO, error)
+}
+
+type collectionService struct {
+	collectionRepo repository.CollectionRepository
+}
+
+func NewCollectionService(collectionRepo repository.CollectionRepository) CollectionService {
+	return &collectionService{
+		collectionRepo: collectionRepo,
+	}
+}
+
+func (s *collectionService) ListCollections(ctx context.Context) ([]*dto.CollectionDTO, error) {
+	collections, err := s.collectionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list collections: %w", err)
+	}
+	return dto.CollectionsToDTO(collections), nil
+}
+
+func (s *collectionService) GetCollectionByID(ctx context.Context, id uint) (*dto.CollectionDTO, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid collection ID: %w", errors.ErrBadRequest)
+	}
+
+	collection, err := s.collectionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errors.ErrNotFound) || errors.Is(err, errors.ErrBadRequest) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to get collection by ID: %w", err)
+	}
+
+	collectionDTO := dto.CollectionToDTO(collection)
+
+	movies, err := s.collectionRepo.FindMovies(ctx, collection.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection movies: %w", err)
+	}
+	collectionDTO.Movies = dto.MoviesToDTO(movies)
+
+	return collectionDTO, nil
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+const (
+	// dashboardRowLimit caps how many items each dashboard row returns.
+	dashboardRowLimit = 20
+
+	// watchedThreshold is the progress fraction at which an item counts as
+	// finished rather than in progress. A history entry with zero progress
+	// is a plain "watched" record and also counts as finished.
+	watchedThreshold = 0.9
+)
+
+// DashboardService builds the personal dashboard rows that combine watch
+// history with the library: in-progress items and the next unwatched
+// episode per series.
+type DashboardService interface {
+	ContinueWatching(ctx context.Context, userID uint) ([]*dto.ContinueWatchingItemDTO, error)
+	NextUp(ctx context.Context, userID uint) ([]*dto.NextUpItemDTO, error)
+}
+
+type dashboardService struct {
+	appLogger        logger.Logger
+	watchHistoryRepo repository.WatchHistoryRepository
+	movieRepo        repository.MovieRepository
+	seriesRepo       repository.SeriesRepository
+	episodeRepo      repository.EpisodeRepository
+}
+
+func NewDashboardService(
+	appLogger logger.Logger,
+	watchHistoryRepo repository.WatchHistoryRepository,
+	movieRepo repository.MovieRepository,
+	seriesRepo repository.SeriesRepository,
+	episodeRepo repository.EpisodeRepository,
+) DashboardService {
+	return &dashboardService{
+		appLogger:        appLogger,
+		watchHistoryRepo: watchHistoryRepo,
+		movieRepo:        movieRepo,
+		seriesRepo:       seriesRepo,
+		episodeRepo:      episodeRepo,
+	}
+}
+
+// ContinueWatching returns the user's in-progress items, most recently
+// watched first. Only the latest history entry per item counts, so an item
+// finished on a rewatch drops off the row.
+func (s *dashboardService) ContinueWatching(ctx context.Context, userID uint) ([]*dto.ContinueWatchingItemDTO, error) {
+	history, err := s.watchHistoryRepo.GetWatchHistory(ctx, userID, auth.ActiveProfileID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	latest := latestPerItem(history)
+
+	items := make([]*dto.ContinueWatchingItemDTO, 0, len(latest))
+	for _, h := range latest {
+		if !inProgress(h.Progress) {
+			continue
+		}
+
+		item := &dto.ContinueWatchingItemDTO{
+			MediaType: h.MediaType,
+			MediaID:   h.MediaID,
+			Progress:  h.Progress,
+			WatchedAt: h.WatchedAt,
+		}
+		if !s.resolveItem(ctx, item) {
+			// The item was removed from the library since it was watched
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].WatchedAt.After(items[j].WatchedAt)
+	})
+	if len(items) > dashboardRowLimit {
+		items = items[:dashboardRowLimit]
+	}
+	return items, nil
+}
+
+// NextUp returns, for each series the user has watched episodes of, the
+// first episode in airing order they have not finished. Series the user is
+// fully caught up on are skipped; rows are ordered by when the user last
+// watched the series.
+func (s *dashboardService) NextUp(ctx context.Context, userID uint) ([]*dto.NextUpItemDTO, error) {
+	history, err := s.watchHistoryRepo.GetWatchHistory(ctx, userID, auth.ActiveProfileID(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// Group finished episodes and the latest watch time by series
+	watched := make(map[uint]map[uint]bool)
+	lastWatched := make(map[uint]time.Time)
+	for _, h := range history {
+		if h.MediaType != "episode" {
+			continue
+		}
+		episode, err := s.episodeRepo.FindEpisodeByID(ctx, h.MediaID)
+		if err != nil || episode == nil {
+			continue
+		}
+		if h.WatchedAt.After(lastWatched[episode.SeriesID]) {
+			lastWatched[episode.SeriesID] = h.WatchedAt
+		}
+		if !finished(h.Progress) {
+			continue
+		}
+		if watched[episode.SeriesID] == nil {
+			watched[episode.SeriesID] = make(map[uint]bool)
+		}
+		watched[episode.SeriesID][episode.ID] = true
+	}
+
+	items := make([]*dto.NextUpItemDTO, 0, len(lastWatched))
+	for seriesID, watchedAt := range lastWatched {
+		next, err := s.nextEpisode(ctx, seriesID, watched[seriesID])
+		if err != nil {
+			s.appLogger.Warn().Err(err).Uint("series", seriesID).Msg("Failed to resolve next episode")
+			continue
+		}
+		if next == nil {
+			// Fully caught up
+			continue
+		}
+
+		series, err := s.seriesRepo.FindByID(ctx, seriesID)
+		if err != nil || series == nil {
+			continue
+		}
+		items = append(items, &dto.NextUpItemDTO{
+			SeriesID:      seriesID,
+			SeriesTitle:   series.Title,
+			EpisodeID:     next.ID,
+			SeasonNumber:  next.Season.SeasonNumber,
+			EpisodeNumber: next.EpisodeNumber,
+			Title:         next.Title,
+			StillPath:     next.StillPath,
+			LastWatchedAt: watchedAt,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].LastWatchedAt.After(items[j].LastWatchedAt)
+	})
+	if len(items) > dashboardRowLimit {
+		items = items[:dashboardRowLimit]
+	}
+	return items, nil
+}
+
+// nextEpisode walks the series in airing order and returns the first
+// episode not in the watched set, or nil when every episode is watched.
+// Specials (season 0) are skipped.
+func (s *dashboardService) nextEpisode(ctx context.Context, seriesID uint, watchedEpisodes map[uint]bool) (*entity.Episode, error) {
+	episodes, err := s.episodeRepo.ListBySeries(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(episodes, func(i, j int) bool {
+		if episodes[i].Season.SeasonNumber != episodes[j].Season.SeasonNumber {
+			return episodes[i].Season.SeasonNumber < episodes[j].Season.SeasonNumber
+		}
+		return episodes[i].EpisodeNumber < episodes[j].EpisodeNumber
+	})
+
+	for _, episode := range episodes {
+		if episode.Season.SeasonNumber == 0 {
+			continue
+		}
+		if !watchedEpisodes[episode.ID] {
+			return episode, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveItem fills the title and artwork for a history entry, reporting
+// whether the underlying item still exists.
+func (s *dashboardService) resolveItem(ctx context.Context, item *dto.ContinueWatchingItemDTO) bool {
+	switch item.MediaType {
+	case "movie":
+		movie, err := s.movieRepo.FindByID(ctx, item.MediaID)
+		if err != nil || movie == nil {
+			return false
+		}
+		item.Title = movie.Title
+		item.PosterPath = movie.PosterPath
+		return true
+	case "episode":
+		episode, err := s.episodeRepo.FindEpisodeByID(ctx, item.MediaID)
+		if err != nil || episode == nil {
+			return false
+		}
+		item.Title = episode.Title
+		item.SeriesID = episode.SeriesID
+		if series, err := s.seriesRepo.FindByID(ctx, episode.SeriesID); err == nil && series != nil {
+			item.SeriesTitle = series.Title
+			item.PosterPath = series.PosterPath
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// latestPerItem reduces the history to the most recent entry per item.
+func latestPerItem(history []entity.WatchHistory) map[[2]any]entity.WatchHistory {
+	latest := make(map[[2]any]entity.WatchHistory)
+	for _, h := range history {
+		key := [2]any{h.MediaType, h.MediaID}
+		if existing, ok := latest[key]; !ok || h.WatchedAt.After(existing.WatchedAt) {
+			latest[key] = h
+		}
+	}
+	return latest
+}
+
+// inProgress reports whether a progress fraction counts as partway through.
+func inProgress(progress float64) bool {
+	return progress > 0 && progress < watchedThreshold
+}
+
+// finished reports whether a progress fraction counts as watched to the
+// end. Zero means the entry was logged without progress tracking, which is
+// treated as a completed watch.
+func finished(progress float64) bool {
+	return progress == 0 || progress >= watchedThreshold
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// DedupeService reports files that share a content hash across libraries
+// and can resolve each group by keeping the best-quality copy and
+// soft-deleting the rest. Soft deletion means a wrong call is
+// recoverable through the deleted-item restore endpoints.
+type DedupeService interface {
+	Report(ctx context.Context) ([]*dto.DuplicateGroupDTO, error)
+	Resolve(ctx context.Context, dryRun bool) (*dto.DedupeResolveResultDTO, error)
+}
+
+type dedupeService struct {
+	appLogger   logger.Logger
+	movieRepo   repository.MovieRepository
+	episodeRepo repository.EpisodeRepository
+}
+
+func NewDedupeService(appLogger logger.Logger, movieRepo repository.MovieRepository, episodeRepo repository.EpisodeRepository) DedupeService {
+	return &dedupeService{
+		appLogger:   appLogger,
+		movieRepo:   movieRepo,
+		episodeRepo: episodeRepo,
+	}
+}
+
+// Report groups movies and episodes by shared content hash. Within each
+// group the files are ordered best quality first and the best copy is
+// flagged as the keeper.
+func (s *dedupeService) Report(ctx context.Context) ([]*dto.DuplicateGroupDTO, error) {
+	byHash := make(map[string][]dto.DuplicateFileDTO)
+
+	movies, err := s.movieRepo.FindDuplicates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate movies: %w", err)
+	}
+	for _, movie := range movies {
+		byHash[movie.ContentHash] = append(byHash[movie.ContentHash],
+			duplicateFile("movie", movie.ID, movie.Title, movie.LibraryItem))
+	}
+
+	episodes, err := s.episodeRepo.FindDuplicates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate episodes: %w", err)
+	}
+	for _, episode := range episodes {
+		byHash[episode.ContentHash] = append(byHash[episode.ContentHash],
+			duplicateFile("episode", episode.ID, episode.Title, episode.LibraryItem))
+	}
+
+	groups := make([]*dto.DuplicateGroupDTO, 0, len(byHash))
+	for hash, files := range byHash {
+		sortByQuality(files)
+		files[0].Keep = true
+		groups = append(groups, &dto.DuplicateGroupDTO{ContentHash: hash, Files: files})
+	}
+
+	// Map iteration order is random; keep the report stable for clients
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ContentHash < groups[j].ContentHash })
+	return groups, nil
+}
+
+// Resolve soft-deletes every copy except the best-quality one in each
+// duplicate group. With dryRun the result lists what would be removed
+// without touching the database.
+func (s *dedupeService) Resolve(ctx context.Context, dryRun bool) (*dto.DedupeResolveResultDTO, error) {
+	groups, err := s.Report(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.DedupeResolveResultDTO{
+		DryRun:  dryRun,
+		Groups:  len(groups),
+		Removed: []dto.DuplicateFileDTO{},
+	}
+
+	for _, group := range groups {
+		for _, file := range group.Files {
+			if file.Keep {
+				continue
+			}
+			if !dryRun {
+				if err := s.softDelete(ctx, file); err != nil {
+					s.appLogger.Error().Err(err).
+						Str("media_type", file.MediaType).
+						Uint("id", file.ID).
+						Msg("Failed to remove duplicate")
+					continue
+				}
+				s.appLogger.Info().
+					Str("media_type", file.MediaType).
+					Uint("id", file.ID).
+					Str("filepath", file.FilePath).
+					Msg("Removed duplicate copy")
+			}
+			result.Removed = append(result.Removed, file)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *dedupeService) softDelete(ctx context.Context, file dto.DuplicateFileDTO) error {
+	switch file.MediaType {
+	case "movie":
+		return s.movieRepo.Delete(ctx, file.ID)
+	case "episode":
+		return s.episodeRepo.DeleteEpisode(ctx, file.ID)
+	default:
+		return fmt.Errorf("unsupported media type %q", file.MediaType)
+	}
+}
+
+func duplicateFile(mediaType string, id uint, title string, item entity.LibraryItem) dto.DuplicateFileDTO {
+	return dto.DuplicateFileDTO{
+		ID:               id,
+		MediaType:        mediaType,
+		Title:            title,
+		LibraryID:        item.LibraryID,
+		FilePath:         item.FilePath,
+		FileSize:         item.FileSize,
+		Codec:            item.Codec,
+		ResolutionWidth:  item.ResolutionWidth,
+		ResolutionHeight: item.ResolutionHeight,
+	}
+}
+
+// sortByQuality orders copies best first: higher resolution wins, then
+// the larger file (a proxy for bitrate), then the lower ID so ties are
+// deterministic.
+func sortByQuality(files []dto.DuplicateFileDTO) {
+	sort.Slice(files, func(i, j int) bool {
+		areaI := files[i].ResolutionWidth * files[i].ResolutionHeight
+		areaJ := files[j].ResolutionWidth * files[j].ResolutionHeight
+		if areaI != areaJ {
+			return areaI > areaJ
+		}
+		if files[i].FileSize != files[j].FileSize {
+			return files[i].FileSize > files[j].FileSize
+		}
+		return files[i].ID < files[j].ID
+	})
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+type FavoriteService interface {
+	GetFavorites(ctx context.Context, userID uint) ([]*dto.FavoriteDTO, error)
+	AddToFavorites(ctx context.Context, favorite *dto.FavoriteDTO) error
+	RemoveFromFavorites(ctx context.Context, userID uint, mediaID uint, mediaType string) error
+}
+
+type favoriteService struct {
+	favoriteRepo repository.FavoriteRepository
+}
+
+func NewFavoriteService(favoriteRepo repository.FavoriteRepository) FavoriteService {
+	return &favoriteService{
+		favoriteRepo: favoriteRepo,
+	}
+}
+
+func (s *favoriteService) GetFavorites(ctx context.Context, userID uint) ([]*dto.FavoriteDTO, error) {
+	favorites, err := s.favoriteRepo.GetFavorites(ctx, userID, auth.ActiveProfileID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get favorites: %w", err)
+	}
+	return dto.FavoritesToDTO(favorites), nil
+}
+
+func (s *favoriteService) AddToFavorites(ctx context.Context, favorite *dto.FavoriteDTO) error {
+	newFavorite := &entity.Favorite{
+		UserID:    favorite.UserID,
+		ProfileID: auth.ActiveProfileID(ctx),
+		MediaType: favorite.MediaType,
+		MediaID:   favorite.MediaID,
+	}
+
+	if err := s.favoriteRepo.AddToFavorites(ctx, newFavorite); err != nil {
+		return fmt.Errorf("failed to add to favorites: %w", err)
+	}
+	return nil
+}
+
+func (s *favoriteService) RemoveFromFavorites(ctx context.Context, userID uint, mediaID uint, mediaType string) error {
+	if err := s.favoriteRepo.RemoveFromFavorites(ctx, userID, auth.ActiveProfileID(ctx), mediaID, mediaType); err != nil {
+		return fmt.Errorf("failed to remove from favorites: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/service/metadata"
+)
+
+// IdentifyService lets an operator search TMDb and fix a misidentified
+// movie or series. Fixed items are flagged as manually matched so scans
+// don't overwrite them.
+type IdentifyService interface {
+	SearchCandidates(ctx context.Context, mediaType, query, year string) ([]*dto.IdentifyCandidateDTO, error)
+	ListUnmatched(ctx context.Context) ([]*dto.UnmatchedItemDTO, error)
+	IdentifyMovie(ctx context.Context, movieID uint, tmdbID int) (*dto.MovieDTO, error)
+	IdentifySeries(ctx context.Context, seriesID uint, tmdbID uint) (*dto.SeriesDTO, error)
+}
+
+type identifyService struct {
+	movieRepo      repository.MovieRepository
+	seriesRepo     repository.SeriesRepository
+	taxonomyRepo   repository.TaxonomyRepository
+	collectionRepo repository.CollectionRepository
+	tmdb           *metadata.TMDbService
+}
+
+func NewIdentifyService(
+	movieRepo repository.MovieRepository,
+	seriesRepo repository.SeriesRepository,
+	taxonomyRepo repository.TaxonomyRepository,
+	collectionRepo repository.CollectionRepository,
+	tmdb *metadata.TMDbService,
+) IdentifyService {
+	return &identifyService{
+		movieRepo:      movieRepo,
+		seriesRepo:     seriesRepo,
+		taxonomyRepo:   taxonomyRepo,
+		collectionRepo: collectionRepo,
+		tmdb:           tmdb,
+	}
+}
+
+func (s *identifyService) SearchCandidates(ctx context.Context, mediaType, query, year string) ([]*dto.IdentifyCandidateDTO, error) {
+	if query == "" {
+		return nil, fmt.Errorf("search query is required: %w", errors.ErrBadRequest)
+	}
+
+	switch mediaType {
+	case "movie":
+		result, err := s.tmdb.SearchMovie(ctx, query, metadata.WithMovieYear(year))
+		if err != nil {
+			return nil, fmt.Errorf("failed to search movies: %w", err)
+		}
+
+		candidates := make([]*dto.IdentifyCandidateDTO, 0, len(result.Results))
+		for _, movie := range result.Results {
+			candidate := &dto.IdentifyCandidateDTO{
+				TMDbID:   movie.ID,
+				Title:    movie.Title,
+				Overview: movie.Overview,
+			}
+			if len(movie.ReleaseDate) >= 4 {
+				candidate.Year = movie.ReleaseDate[:4]
+			}
+			if movie.PosterPath != nil {
+				candidate.PosterPath = *movie.PosterPath
+			}
+			candidates = append(candidates, candidate)
+		}
+		return candidates, nil
+
+	case "series":
+		result, err := s.tmdb.SearchTV(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search series: %w", err)
+		}
+
+		candidates := make([]*dto.IdentifyCandidateDTO, 0, len(result.Results))
+		for _, show := range result.Results {
+			candidate := &dto.IdentifyCandidateDTO{
+				TMDbID:   int(show.ID),
+				Title:    show.Name,
+				Overview: show.Overview,
+			}
+			if len(show.FirstAirDate) >= 4 {
+				candidate.Year = show.FirstAirDate[:4]
+			}
+			if show.PosterPath != nil {
+				candidate.PosterPath = *show.PosterPath
+			}
+			candidates = append(candidates, candidate)
+		}
+		return candidates, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported media type '%s': %w", mediaType, errors.ErrBadRequest)
+	}
+}
+
+// unmatchedListLimit caps how many queued items are returned per media type.
+const unmatchedListLimit = 200
+
+// ListUnmatched returns the queue of items imported without a TMDb match,
+// waiting for a manual identify or the metadata task.
+func (s *identifyService) ListUnmatched(ctx context.Context) ([]*dto.UnmatchedItemDTO, error) {
+	movies, err := s.movieRepo.FindMetadataPending(ctx, unmatchedListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unmatched movies: %w", err)
+	}
+	shows, err := s.seriesRepo.FindMetadataPending(ctx, unmatchedListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unmatched series: %w", err)
+	}
+
+	items := make([]*dto.UnmatchedItemDTO, 0, len(movies)+len(shows))
+	for _, movie := range movies {
+		items = append(items, &dto.UnmatchedItemDTO{
+			ID:        movie.ID,
+			MediaType: "movie",
+			Title:     movie.Title,
+			FilePath:  movie.FilePath,
+		})
+	}
+	for _, series := range shows {
+		items = append(items, &dto.UnmatchedItemDTO{
+			ID:        series.ID,
+			MediaType: "series",
+			Title:     series.Title,
+		})
+	}
+	return items, nil
+}
+
+func (s *identifyService) IdentifyMovie(ctx context.Context, movieID uint, tmdbID int) (*dto.MovieDTO, error) {
+	movie, err := s.movieRepo.FindByID(ctx, movieID)
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := s.tmdb.GetMovieDetails(ctx, tmdbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch movie details: %w", err)
+	}
+
+	movie.TMDbID = details.ID
+	movie.Title = details.Title
+	movie.OriginalTitle = details.OriginalTitle
+	movie.Overview = details.Overview
+	if details.ReleaseDate != "" {
+		if releaseDate, err := time.Parse("2006-01-02", details.ReleaseDate); err == nil {
+			movie.ReleaseDate = releaseDate
+		}
+	}
+	if details.PosterPath != nil {
+		movie.PosterPath = *details.PosterPath
+	}
+	if details.BackdropPath != nil {
+		movie.BackdropPath = *details.BackdropPath
+	}
+	movie.VoteAverage = details.VoteAverage
+	movie.VoteCount = details.VoteCount
+	movie.Runtime = details.Runtime
+	movie.OriginalLanguage = details.OriginalLanguage
+	movie.ProductionCountries = strings.Join(metadata.CountryCodes(details.ProductionCountries), ",")
+	movie.ManualMatch = true
+	movie.MetadataPending = false
+
+	// Relink the collection from the corrected match
+	movie.CollectionID = 0
+	if details.BelongsToCollection != nil {
+		collection := &entity.Collection{
+			TMDbID: details.BelongsToCollection.ID,
+			Name:   details.BelongsToCollection.Name,
+		}
+		if details.BelongsToCollection.PosterPath != nil {
+			collection.PosterPath = *details.BelongsToCollection.PosterPath
+		}
+		if details.BelongsToCollection.BackdropPath != nil {
+			collection.BackdropPath = *details.BelongsToCollection.BackdropPath
+		}
+		stored, err := s.collectionRepo.FindOrCreate(ctx, collection)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store collection: %w", err)
+		}
+		movie.CollectionID = stored.ID
+	}
+
+	if err := s.movieRepo.Update(ctx, movie); err != nil {
+		return nil, fmt.Errorf("failed to update movie: %w", err)
+	}
+
+	if err := s.replaceGenres(ctx, "movie", movie.ID, details.Genres); err != nil {
+		return nil, err
+	}
+
+	return dto.MovieToDTO(movie), nil
+}
+
+func (s *identifyService) IdentifySeries(ctx context.Context, seriesID uint, tmdbID uint) (*dto.SeriesDTO, error) {
+	series, err := s.seriesRepo.FindByID(ctx, seriesID, repository.WithSeasons())
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, fmt.Errorf("series with ID %d not found: %w", seriesID, errors.ErrNotFound)
+	}
+
+	details, err := s.tmdb.GetTVDetails(ctx, tmdbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch series details: %w", err)
+	}
+
+	series.TMDbID = details.ID
+	series.Title = details.Name
+	series.OriginalTitle = details.OriginalName
+	series.Overview = details.Overview
+	if details.FirstAirDate != "" {
+		if firstAirDate, err := time.Parse("2006-01-02", details.FirstAirDate); err == nil {
+			series.FirstAirDate = firstAirDate
+		}
+	}
+	if details.PosterPath != nil {
+		series.PosterPath = *details.PosterPath
+	}
+	if details.BackdropPath != nil {
+		series.BackdropPath = *details.BackdropPath
+	}
+	series.VoteAverage = details.VoteAverage
+	series.VoteCount = details.VoteCount
+	series.OriginalLanguage = details.OriginalLanguage
+	series.ProductionCountries = strings.Join(metadata.CountryCodes(details.ProductionCountries), ",")
+	series.ManualMatch = true
+	series.MetadataPending = false
+
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
+		return nil, fmt.Errorf("failed to update series: %w", err)
+	}
+
+	if err := s.replaceGenres(ctx, "series", series.ID, details.Genres); err != nil {
+		return nil, err
+	}
+
+	return dto.SeriesToDTO(series), nil
+}
+
+func (s *identifyService) replaceGenres(ctx context.Context, mediaType string, mediaID uint, genres []metadata.Genre) error {
+	entities := make([]*entity.Genre, 0, len(genres))
+	for _, genre := range genres {
+		stored, err := s.taxonomyRepo.FindOrCreateGenre(ctx, genre.ID, genre.Name)
+		if err != nil {
+			return fmt.Errorf("failed to store genre '%s': %w", genre.Name, err)
+		}
+		entities = append(entities, stored)
+	}
+	return s.taxonomyRepo.SetMediaGenres(ctx, mediaType, mediaID, entities)
+}
@@ -0,0 +1,356 @@
+// Package images proxies TMDb artwork through a local disk cache and
+// serves it in the most compact format the client accepts, transcoding
+// to WebP or AVIF through ffmpeg when the local build can encode them.
+package images
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+const tmdbImageBase = "https://image.tmdb.org/t/p/"
+
+// artworkSizes are the TMDb size buckets the proxy will fetch; anything
+// else is rejected before it can become an arbitrary upstream request.
+var artworkSizes = map[string]bool{
+	"w92":      true,
+	"w154":     true,
+	"w185":     true,
+	"w342":     true,
+	"w500":     true,
+	"w780":     true,
+	"w1280":    true,
+	"original": true,
+}
+
+// artworkFile matches the bare TMDb artwork file names ("abc123.jpg"),
+// keeping path separators and dotfiles out of the cache directory.
+var artworkFile = regexp.MustCompile(`^[A-Za-z0-9]+\.(?:jpg|png)$`)
+
+type Service interface {
+	// NegotiateFormat picks the best target format ("avif", "webp" or ""
+	// for the original) that the Accept header allows and the installed
+	// ffmpeg build can encode.
+	NegotiateFormat(ctx context.Context, accept string) string
+
+	// Artwork returns the path of the cached artwork file for the TMDb
+	// file name at the given size, transcoded to format when not empty.
+	// The original is downloaded on first request; a failed transcode
+	// falls back to the original rather than failing the request.
+	Artwork(ctx context.Context, size, file, format string) (string, error)
+
+	// Pregenerate warms the cache for all library artwork at the
+	// configured sizes, in every format the ffmpeg build supports.
+	Pregenerate(ctx context.Context) error
+
+	// Task scheduler methods
+	Execute(ctx context.Context, config string) error
+	Description() string
+	Summary() string
+}
+
+type service struct {
+	config     *config.Config
+	appLogger  logger.Logger
+	movieRepo  repository.MovieRepository
+	seriesRepo repository.SeriesRepository
+	ffmpeg     ffmpeg.Service
+	client     *http.Client
+
+	mu          sync.Mutex
+	lastSummary string
+}
+
+func NewService(
+	cfg *config.Config,
+	appLogger logger.Logger,
+	movieRepo repository.MovieRepository,
+	seriesRepo repository.SeriesRepository,
+	ffmpegService ffmpeg.Service,
+) Service {
+	return &service{
+		config:     cfg,
+		appLogger:  appLogger,
+		movieRepo:  movieRepo,
+		seriesRepo: seriesRepo,
+		ffmpeg:     ffmpegService,
+		client:     &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// NegotiateFormat consults the Accept header and the ffmpeg capability
+// probe; clients that accept both get AVIF, the smaller of the two.
+func (s *service) NegotiateFormat(ctx context.Context, accept string) string {
+	if !s.ffmpeg.Available() {
+		return ""
+	}
+	caps, err := s.ffmpeg.Capabilities(ctx)
+	if err != nil {
+		return ""
+	}
+
+	if acceptsImage(accept, "image/avif") && caps.HasEncoder("libaom-av1") {
+		return "avif"
+	}
+	if acceptsImage(accept, "image/webp") && caps.HasEncoder("libwebp") {
+		return "webp"
+	}
+	return ""
+}
+
+// acceptsImage reports whether the Accept header lists the given image
+// MIME type. A simple substring test is enough: image types are never
+// implied by wildcards browsers actually send alongside them.
+func acceptsImage(accept, mimeType string) bool {
+	for _, part := range splitAccept(accept) {
+		if part == mimeType {
+			return true
+		}
+	}
+	return false
+}
+
+// splitAccept breaks an Accept header into bare media types, dropping
+// quality parameters.
+func splitAccept(accept string) []string {
+	var types []string
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(part)
+		if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+			mediaType = strings.TrimSpace(mediaType[:i])
+		}
+		if mediaType != "" {
+			types = append(types, mediaType)
+		}
+	}
+	return types
+}
+
+func (s *service) Artwork(ctx context.Context, size, file, format string) (string, error) {
+	if !artworkSizes[size] {
+		return "", fmt.Errorf("invalid artwork size '%s': %w", size, errors.ErrBadRequest)
+	}
+	if !artworkFile.MatchString(file) {
+		return "", fmt.Errorf("invalid artwork file name: %w", errors.ErrBadRequest)
+	}
+
+	original, err := s.ensureOriginal(ctx, size, file)
+	if err != nil {
+		return "", err
+	}
+	if format == "" {
+		return original, nil
+	}
+
+	variant, err := s.ensureVariant(ctx, original, format)
+	if err != nil {
+		s.appLogger.Warn().Err(err).
+			Str("file", file).
+			Str("format", format).
+			Msg("Artwork transcode failed, serving the original")
+		return original, nil
+	}
+	return variant, nil
+}
+
+// ensureOriginal downloads the artwork from TMDb into the cache unless it
+// is already present, writing through a temp file so a failed download
+// never leaves a partial entry behind.
+func (s *service) ensureOriginal(ctx context.Context, size, file string) (string, error) {
+	dir := filepath.Join(s.config.ArtworkCacheDir(), size)
+	path := filepath.Join(dir, file)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create artwork cache directory: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, tmdbImageBase+size+"/"+file, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build artwork request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download artwork: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", errors.NotFoundError("artwork")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("artwork download returned status %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp(dir, file+".download-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create artwork temp file: %w", err)
+	}
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to write artwork: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to close artwork temp file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to move artwork into cache: %w", err)
+	}
+
+	return path, nil
+}
+
+// ensureVariant transcodes the cached original to the target format
+// unless the variant already exists. Variants live next to the original
+// with the format appended ("abc.jpg.webp"), so a cache sweep of the
+// directory removes them together.
+func (s *service) ensureVariant(ctx context.Context, original, format string) (string, error) {
+	variant := original + "." + format
+	if _, err := os.Stat(variant); err == nil {
+		return variant, nil
+	}
+
+	var args []string
+	switch format {
+	case "webp":
+		args = []string{"-y", "-i", original, "-c:v", "libwebp", "-quality", "80", variant}
+	case "avif":
+		args = []string{"-y", "-i", original, "-c:v", "libaom-av1", "-still-picture", "1", "-crf", "32", "-b:v", "0", variant}
+	default:
+		return "", fmt.Errorf("unsupported artwork format '%s': %w", format, errors.ErrBadRequest)
+	}
+
+	if _, err := s.ffmpeg.RunFFmpeg(ctx, args); err != nil {
+		// A failed run can leave a truncated output file behind
+		os.Remove(variant)
+		return "", fmt.Errorf("failed to transcode artwork to %s: %w", format, err)
+	}
+
+	return variant, nil
+}
+
+// Execute implements the scheduler.TaskExecutor interface
+func (s *service) Execute(ctx context.Context, config string) error {
+	return s.Pregenerate(ctx)
+}
+
+func (s *service) Description() string {
+	return "Downloads library artwork and pre-generates WebP/AVIF variants at common sizes."
+}
+
+// Summary implements scheduler.Summarizer, reporting the last run.
+func (s *service) Summary() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastSummary
+}
+
+// Pregenerate walks every movie and series and warms the cache for their
+// posters and backdrops at the configured sizes, so grid pages are served
+// from disk instead of transcoding on first view.
+func (s *service) Pregenerate(ctx context.Context) error {
+	formats := s.availableFormats(ctx)
+
+	var files []string
+	movies, err := s.movieRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list movies for artwork pregeneration: %w", err)
+	}
+	for _, movie := range movies {
+		files = appendArtwork(files, movie.PosterPath, movie.BackdropPath)
+	}
+
+	shows, err := s.seriesRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list series for artwork pregeneration: %w", err)
+	}
+	for _, show := range shows {
+		files = appendArtwork(files, show.PosterPath, show.BackdropPath)
+	}
+
+	var warmed, failed int
+	for _, file := range files {
+		for _, size := range s.config.ArtworkPregenSizes() {
+			if !artworkSizes[size] {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if _, err := s.Artwork(ctx, size, file, ""); err != nil {
+				s.appLogger.Warn().Err(err).Str("file", file).Str("size", size).Msg("Failed to warm artwork")
+				failed++
+				continue
+			}
+			warmed++
+
+			for _, format := range formats {
+				original := filepath.Join(s.config.ArtworkCacheDir(), size, file)
+				if _, err := s.ensureVariant(ctx, original, format); err != nil {
+					s.appLogger.Warn().Err(err).Str("file", file).Str("format", format).Msg("Failed to pre-generate artwork variant")
+					failed++
+				}
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.lastSummary = fmt.Sprintf("Warmed %d artwork files in %d format(s), %d failures.", warmed, len(formats)+1, failed)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// availableFormats lists the transcode targets the ffmpeg build supports;
+// empty when ffmpeg is unavailable, in which case only originals are warmed.
+func (s *service) availableFormats(ctx context.Context) []string {
+	if !s.ffmpeg.Available() {
+		return nil
+	}
+	caps, err := s.ffmpeg.Capabilities(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var formats []string
+	if caps.HasEncoder("libwebp") {
+		formats = append(formats, "webp")
+	}
+	if caps.HasEncoder("libaom-av1") {
+		formats = append(formats, "avif")
+	}
+	return formats
+}
+
+// appendArtwork adds the valid TMDb file names among paths, trimming the
+// leading slash the API stores them with.
+func appendArtwork(files []string, paths ...string) []string {
+	for _, path := range paths {
+		file := strings.TrimPrefix(path, "/")
+		if artworkFile.MatchString(file) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/service/scanner"
+)
+
+// LibraryService manages media libraries and their scan paths.
+type LibraryService interface {
+	List(ctx context.Context) ([]*dto.LibraryDTO, error)
+	Get(ctx context.Context, id uint) (*dto.LibraryDTO, error)
+	Create(ctx context.Context, req *dto.CreateLibraryRequest) (*dto.LibraryDTO, error)
+	Update(ctx context.Context, id uint, req *dto.UpdateLibraryRequest) (*dto.LibraryDTO, error)
+	AddPath(ctx context.Context, id uint, path, contentType string) (*dto.LibraryDTO, error)
+	RemovePath(ctx context.Context, libraryID, pathID uint) error
+	Delete(ctx context.Context, id uint, cascadeItems bool) error
+	Scan(ctx context.Context, id uint) error
+}
+
+type libraryService struct {
+	appLogger   logger.Logger
+	libraryRepo repository.LibraryRepository
+	scannerSvc  scanner.Service
+	bus         events.Bus
+}
+
+func NewLibraryService(appLogger logger.Logger, libraryRepo repository.LibraryRepository, scannerSvc scanner.Service, bus events.Bus) LibraryService {
+	return &libraryService{
+		appLogger:   appLogger,
+		libraryRepo: libraryRepo,
+		scannerSvc:  scannerSvc,
+		bus:         bus,
+	}
+}
+
+// Scan kicks off a scan of one library in the background; the request only
+// confirms the scan was accepted, it does not wait for it to finish.
+func (s *libraryService) Scan(ctx context.Context, id uint) error {
+	lib, err := s.getLibrary(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Detach from the request context so the scan survives the response
+	go func() {
+		if err := s.scannerSvc.ScanLibrary(context.Background(), lib); err != nil {
+			s.appLogger.Warn().Err(err).Str("library", lib.Name).Msg("Triggered library scan failed")
+		}
+	}()
+	return nil
+}
+
+func (s *libraryService) List(ctx context.Context) ([]*dto.LibraryDTO, error) {
+	libraries, err := s.libraryRepo.ListLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dto.LibrariesToDTO(libraries), nil
+}
+
+func (s *libraryService) Get(ctx context.Context, id uint) (*dto.LibraryDTO, error) {
+	lib, err := s.getLibrary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.LibraryToDTO(lib), nil
+}
+
+func (s *libraryService) Create(ctx context.Context, req *dto.CreateLibraryRequest) (*dto.LibraryDTO, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("library name is required")
+	}
+	libType, err := parseLibraryType(req.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	lib := &entity.Library{
+		Name:        req.Name,
+		Type:        libType,
+		Description: req.Description,
+		AutoScan:    true,
+	}
+	for _, path := range req.Paths {
+		if path == "" {
+			return nil, fmt.Errorf("library path must not be empty")
+		}
+		lib.Paths = append(lib.Paths, entity.LibraryPath{Path: path, Enabled: true})
+	}
+
+	if err := s.libraryRepo.CreateLibrary(ctx, lib); err != nil {
+		return nil, err
+	}
+
+	s.appLogger.Info().Str("library", lib.Name).Str("type", string(lib.Type)).Msg("Library created")
+	return dto.LibraryToDTO(lib), nil
+}
+
+func (s *libraryService) Update(ctx context.Context, id uint, req *dto.UpdateLibraryRequest) (*dto.LibraryDTO, error) {
+	lib, err := s.getLibrary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		if *req.Name == "" {
+			return nil, fmt.Errorf("library name is required")
+		}
+		lib.Name = *req.Name
+	}
+	if req.Type != nil {
+		libType, err := parseLibraryType(*req.Type)
+		if err != nil {
+			return nil, err
+		}
+		lib.Type = libType
+	}
+	if req.Description != nil {
+		lib.Description = *req.Description
+	}
+	if req.AutoScan != nil {
+		lib.AutoScan = *req.AutoScan
+	}
+	if req.AnimeNaming != nil {
+		lib.AnimeNaming = *req.AnimeNaming
+	}
+	if req.ExtraExtensions != nil {
+		lib.ExtraExtensions = *req.ExtraExtensions
+	}
+	if req.AudioLanguagePriority != nil {
+		lib.AudioLanguagePriority = *req.AudioLanguagePriority
+	}
+
+	if err := s.libraryRepo.UpdateLibrary(ctx, lib); err != nil {
+		return nil, err
+	}
+	return dto.LibraryToDTO(lib), nil
+}
+
+func (s *libraryService) AddPath(ctx context.Context, id uint, path, contentType string) (*dto.LibraryDTO, error) {
+	if path == "" {
+		return nil, fmt.Errorf("library path must not be empty")
+	}
+
+	// An empty content type inherits the library's type
+	var pathType entity.LibraryType
+	if contentType != "" {
+		parsed, err := parseLibraryType(contentType)
+		if err != nil {
+			return nil, err
+		}
+		pathType = parsed
+	}
+
+	lib, err := s.getLibrary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range lib.Paths {
+		if existing.Path == path {
+			return nil, fmt.Errorf("path is already part of the library")
+		}
+	}
+
+	if err := s.libraryRepo.AddPath(ctx, &entity.LibraryPath{
+		LibraryID:   lib.ID,
+		Path:        path,
+		Enabled:     true,
+		ContentType: pathType,
+	}); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+func (s *libraryService) RemovePath(ctx context.Context, libraryID, pathID uint) error {
+	return s.libraryRepo.DeletePath(ctx, libraryID, pathID)
+}
+
+// Delete removes a library; with cascadeItems the imported items are removed
+// as well, otherwise they are left behind for the cleanup task.
+func (s *libraryService) Delete(ctx context.Context, id uint, cascadeItems bool) error {
+	lib, err := s.getLibrary(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if cascadeItems {
+		if err := s.libraryRepo.DeleteItemsByLibrary(ctx, lib.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.libraryRepo.DeleteLibrary(ctx, lib.ID); err != nil {
+		return err
+	}
+
+	s.appLogger.Info().Str("library", lib.Name).Bool("cascade", cascadeItems).Msg("Library deleted")
+	s.bus.Publish(ctx, events.LibraryDeleted, events.LibraryPayload{LibraryID: lib.ID, Name: lib.Name})
+	return nil
+}
+
+func (s *libraryService) getLibrary(ctx context.Context, id uint) (*entity.Library, error) {
+	lib, err := s.libraryRepo.GetLibrary(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lib == nil {
+		return nil, fmt.Errorf("library %d does not exist", id)
+	}
+	return lib, nil
+}
+
+func parseLibraryType(value string) (entity.LibraryType, error) {
+	switch entity.LibraryType(value) {
+	case entity.LibraryTypeMovie, entity.LibraryTypeTV, entity.LibraryTypeMusic, entity.LibraryTypePhotos, entity.LibraryTypeAudiobook:
+		return entity.LibraryType(value), nil
+	default:
+		return "", fmt.Errorf("invalid library type %q", value)
+	}
+}
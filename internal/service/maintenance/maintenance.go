@@ -0,0 +1,79 @@
+// Package maintenance runs heavy database housekeeping (vacuum, optimise,
+// analyse) as a scheduled task. The scheduler confines it to the configured
+// maintenance window when one is set.
+package maintenance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/logger"
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Run(ctx context.Context) error
+
+	// Task scheduler methods
+	Execute(ctx context.Context, config string) error
+	Description() string
+}
+
+type service struct {
+	config    *config.Config
+	appLogger logger.Logger
+	db        *gorm.DB
+}
+
+func NewMaintenanceService(config *config.Config, appLogger logger.Logger, db *gorm.DB) Service {
+	return &service{
+		config:    config,
+		appLogger: appLogger,
+		db:        db,
+	}
+}
+
+// Run performs the housekeeping statements for the configured driver. Each
+// statement is attempted even when an earlier one fails; the first error is
+// returned.
+func (s *service) Run(ctx context.Context) error {
+	var statements []string
+	switch s.config.DB.Driver {
+	case "sqlite":
+		statements = []string{"VACUUM", "ANALYZE", "PRAGMA optimize"}
+	case "postgres":
+		statements = []string{"VACUUM (ANALYZE)"}
+	case "mariadb":
+		statements = []string{"ANALYZE TABLE library_items"}
+	default:
+		statements = []string{"ANALYZE"}
+	}
+
+	var firstErr error
+	for _, stmt := range statements {
+		s.appLogger.Info().Str("statement", stmt).Msg("Running database maintenance")
+		if err := s.db.WithContext(ctx).Exec(stmt).Error; err != nil {
+			s.appLogger.Error().Err(err).Str("statement", stmt).Msg("Database maintenance statement failed")
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to run %q: %w", stmt, err)
+			}
+		}
+	}
+
+	return firstErr
+}
+
+func (s *service) Execute(ctx context.Context, config string) error {
+	return s.Run(ctx)
+}
+
+func (s *service) Description() string {
+	return "Runs database maintenance (vacuum, optimise, analyse)."
+}
+
+// Heavy marks the task so the scheduler defers it outside the maintenance
+// window.
+func (s *service) Heavy() bool {
+	return true
+}
@@ -0,0 +1,70 @@
+package metadata
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrUnavailable is returned without contacting TMDb while the circuit
+// breaker is open, so scans can continue metadata-less instead of waiting
+// out a timeout for every file.
+var ErrUnavailable = errors.New("TMDb is unavailable (circuit breaker open)")
+
+// breaker trips after consecutive TMDb failures and blocks further requests
+// until the cooldown elapses, after which a single probe request is allowed
+// through.
+type breaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow reports whether a request may proceed.
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	// Half-open: let one probe through; recordFailure re-opens the window
+	b.openedAt = time.Now()
+	return true
+}
+
+// recordSuccess closes the breaker.
+func (b *breaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+}
+
+// recordFailure counts a failure and reports whether this one tripped the
+// breaker open.
+func (b *breaker) recordFailure() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.failures == b.threshold {
+		b.openedAt = time.Now()
+		return true
+	}
+	if b.failures > b.threshold {
+		b.openedAt = time.Now()
+	}
+	return false
+}
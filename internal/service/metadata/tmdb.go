@@ -4,18 +4,41 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
 	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/telemetry"
 )
 
 type TMDbService struct {
 	config    *config.Config
+	appLogger logger.Logger
 	client    *http.Client
 	baseURL   string
 	sessionID string
+	breaker   *breaker
+	limiter   *limiter
+	cache     *responseCache
+	// store persists responses across scans and restarts; the in-memory
+	// cache in front of it absorbs the hot lookups of a single scan
+	store    repository.MetadataCacheRepository
+	storeTTL time.Duration
+
+	sweepMu   sync.Mutex
+	lastSweep time.Time
+
+	genreMu    sync.Mutex
+	genreCache map[string]map[int]string
 }
 
 type SessionRequest struct {
@@ -24,18 +47,103 @@ type SessionRequest struct {
 	RequestToken string `json:"request_token"`
 }
 
-func NewTMDbService(cfg *config.Config) *TMDbService {
+func NewTMDbService(cfg *config.Config, appLogger logger.Logger, store repository.MetadataCacheRepository) *TMDbService {
 	return &TMDbService{
-		config:  cfg,
-		client:  &http.Client{Timeout: 10 * time.Second},
-		baseURL: "https://api.themoviedb.org/3",
+		config:    cfg,
+		appLogger: appLogger,
+		// Per-request deadlines are applied from the configured timeout
+		client:     &http.Client{},
+		baseURL:    "https://api.themoviedb.org/3",
+		breaker:    newBreaker(cfg.TMDbBreakerThreshold(), cfg.TMDbBreakerCooldown()),
+		limiter:    newLimiter(cfg.TMDbRateLimit()),
+		cache:      newResponseCache(cfg.TMDbCacheTTL()),
+		store:      store,
+		storeTTL:   cfg.TMDbDiskCacheTTL(),
+		genreCache: make(map[string]map[int]string),
 	}
 }
 
 func (s *TMDbService) fetch(ctx context.Context, url string, v interface{}) error {
+	if data, ok := s.cache.get(url); ok {
+		return json.Unmarshal(data, v)
+	}
+	if data := s.storeGet(ctx, url); data != nil {
+		// Warm the in-memory cache so repeat lookups in the same scan
+		// skip the database as well
+		s.cache.put(url, data)
+		return json.Unmarshal(data, v)
+	}
+
+	ctx, span := telemetry.Tracer().Start(ctx, "tmdb.fetch")
+	span.SetAttributes(attribute.String("http.url", url))
+	defer span.End()
+
+	err := s.fetchRemote(ctx, url, v)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// fetchRemote performs the rate-limited, breaker-guarded retrieval behind
+// the cache.
+func (s *TMDbService) fetchRemote(ctx context.Context, url string, v interface{}) error {
+
+	if !s.breaker.allow() {
+		return ErrUnavailable
+	}
+
+	const maxAttempts = 3
+	backoff := 500 * time.Millisecond
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err := s.limiter.wait(ctx); err != nil {
+			return err
+		}
+
+		data, retryable, err := s.doGet(ctx, url)
+		if err == nil {
+			s.breaker.recordSuccess()
+			s.cache.put(url, data)
+			s.storePut(ctx, url, data)
+			return json.Unmarshal(data, v)
+		}
+		lastErr = err
+
+		if !retryable {
+			return err
+		}
+		if attempt == maxAttempts {
+			break
+		}
+
+		s.appLogger.Debug().Err(err).Str("url", url).Dur("backoff", backoff).Msg("Retrying TMDb request")
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+
+	s.noteFailure()
+	return lastErr
+}
+
+// doGet performs a single GET against the TMDb API. The second return
+// value reports whether the failure is worth retrying (transport errors,
+// 429 and 5xx responses).
+func (s *TMDbService) doGet(ctx context.Context, url string) ([]byte, bool, error) {
+	timeout := s.config.TMDbRequestTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return err
+		return nil, false, err
 	}
 
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", s.config.Meta.TMDb.BearerToken))
@@ -43,22 +151,46 @@ func (s *TMDbService) fetch(ctx context.Context, url string, v interface{}) erro
 
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return err
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, true, fmt.Errorf("TMDb request timed out after %s: %w", timeout, err)
+		}
+		return nil, true, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+		// An invalid token fails every lookup identically, so count it
+		// towards the breaker even though retrying won't help
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			s.noteFailure()
+		}
 		var tmdbError struct {
 			StatusMessage string `json:"status_message"`
 			StatusCode    int    `json:"status_code"`
 		}
 		if err := json.NewDecoder(resp.Body).Decode(&tmdbError); err != nil {
-			return fmt.Errorf("TMDb API error: %s", resp.Status)
+			return nil, retryable, fmt.Errorf("TMDb API error: %s", resp.Status)
 		}
-		return fmt.Errorf("TMDb API error: %s (code: %d)", tmdbError.StatusMessage, tmdbError.StatusCode)
+		return nil, retryable, fmt.Errorf("TMDb API error: %s (code: %d)", tmdbError.StatusMessage, tmdbError.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read TMDb response: %w", err)
 	}
+	return data, false, nil
+}
 
-	return json.NewDecoder(resp.Body).Decode(v)
+// noteFailure records a TMDb failure and raises an admin alert in the log
+// when the breaker trips open.
+func (s *TMDbService) noteFailure() {
+	if s.breaker.recordFailure() {
+		s.appLogger.Error().
+			Int("threshold", s.breaker.threshold).
+			Dur("cooldown", s.breaker.cooldown).
+			Msg("TMDb circuit breaker tripped: pausing lookups, new items will be marked for a later metadata refresh")
+	}
 }
 
 func (s *TMDbService) createUserSession(ctx context.Context, username, password string) error {
@@ -105,6 +237,14 @@ func (s *TMDbService) createUserSession(ctx context.Context, username, password
 
 // Helper method for POST requests
 func (s *TMDbService) postJSON(ctx context.Context, url string, body, response interface{}) error {
+	if !s.breaker.allow() {
+		return ErrUnavailable
+	}
+
+	timeout := s.config.TMDbRequestTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return err
@@ -121,11 +261,18 @@ func (s *TMDbService) postJSON(ctx context.Context, url string, body, response i
 
 	resp, err := s.client.Do(req)
 	if err != nil {
+		s.noteFailure()
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("TMDb request timed out after %s: %w", timeout, err)
+		}
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode != http.StatusNotFound {
+			s.noteFailure()
+		}
 		var tmdbError struct {
 			StatusMessage string `json:"status_message"`
 			StatusCode    int    `json:"status_code"`
@@ -136,5 +283,6 @@ func (s *TMDbService) postJSON(ctx context.Context, url string, body, response i
 		return fmt.Errorf("TMDb API error: %s (code: %d)", tmdbError.StatusMessage, tmdbError.StatusCode)
 	}
 
+	s.breaker.recordSuccess()
 	return json.NewDecoder(resp.Body).Decode(response)
 }
@@ -0,0 +1,136 @@
+package metadata
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// limiter spaces requests out so large scans stay under the TMDb API rate
+// limit instead of bursting and getting 429s.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	last     time.Time
+}
+
+func newLimiter(perSecond int) *limiter {
+	return &limiter{interval: time.Second / time.Duration(perSecond)}
+}
+
+// wait blocks until the caller's turn comes up or the context is done.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	next := l.last.Add(l.interval)
+	if next.Before(now) {
+		next = now
+	}
+	l.last = next
+	l.mu.Unlock()
+
+	delay := time.Until(next)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// responseCache keeps successful GET responses in memory keyed by URL so
+// repeated lookups (genre lists, shared collections) don't hit the API.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	data     []byte
+	storedAt time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *responseCache) get(url string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[url]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(entry.storedAt) > c.ttl {
+		delete(c.entries, url)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+func (c *responseCache) put(url string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Sweep expired entries occasionally so the cache doesn't grow forever
+	if len(c.entries) > 4096 {
+		for key, entry := range c.entries {
+			if time.Since(entry.storedAt) > c.ttl {
+				delete(c.entries, key)
+			}
+		}
+	}
+	c.entries[url] = cacheEntry{data: data, storedAt: time.Now()}
+}
+
+// storeGet reads the persistent cache shared between scanner, refresh
+// tasks and the discovery endpoints. Cache trouble never fails a lookup;
+// the request just goes to the API.
+func (s *TMDbService) storeGet(ctx context.Context, url string) []byte {
+	if s.store == nil {
+		return nil
+	}
+
+	data, err := s.store.Get(ctx, url)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("url", url).Msg("Failed to read persistent TMDb cache")
+		return nil
+	}
+	return data
+}
+
+// storePut persists a successful response and sweeps expired entries at
+// most once an hour so the table doesn't accumulate stale payloads.
+func (s *TMDbService) storePut(ctx context.Context, url string, data []byte) {
+	if s.store == nil {
+		return
+	}
+
+	if err := s.store.Put(ctx, url, data, s.storeTTL); err != nil {
+		s.appLogger.Warn().Err(err).Str("url", url).Msg("Failed to write persistent TMDb cache")
+		return
+	}
+
+	s.sweepMu.Lock()
+	due := time.Since(s.lastSweep) > time.Hour
+	if due {
+		s.lastSweep = time.Now()
+	}
+	s.sweepMu.Unlock()
+	if due {
+		if err := s.store.DeleteExpired(ctx); err != nil {
+			s.appLogger.Warn().Err(err).Msg("Failed to sweep expired TMDb cache entries")
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// certificationFallbackRegion is used when the configured language carries
+// no region suffix; TMDb certifications are keyed by country.
+const certificationFallbackRegion = "US"
+
+type movieReleaseDates struct {
+	Results []struct {
+		ISO      string `json:"iso_3166_1"`
+		Releases []struct {
+			Certification string `json:"certification"`
+		} `json:"release_dates"`
+	} `json:"results"`
+}
+
+type tvContentRatings struct {
+	Results []struct {
+		ISO    string `json:"iso_3166_1"`
+		Rating string `json:"rating"`
+	} `json:"results"`
+}
+
+// GetMovieCertification fetches a movie's age certification for the
+// configured region, falling back to the US rating. An empty string means
+// TMDb has no certification on record.
+func (s *TMDbService) GetMovieCertification(ctx context.Context, movieID int) (string, error) {
+	fullURL := fmt.Sprintf("%s/movie/%d/release_dates", s.baseURL, movieID)
+
+	var dates movieReleaseDates
+	if err := s.fetch(ctx, fullURL, &dates); err != nil {
+		return "", fmt.Errorf("get movie certification error: %w", err)
+	}
+
+	byRegion := make(map[string]string, len(dates.Results))
+	for _, result := range dates.Results {
+		for _, release := range result.Releases {
+			if release.Certification != "" {
+				byRegion[result.ISO] = release.Certification
+				break
+			}
+		}
+	}
+	return pickCertification(byRegion, s.certificationRegion()), nil
+}
+
+// GetTVCertification fetches a series' content rating for the configured
+// region, falling back to the US rating.
+func (s *TMDbService) GetTVCertification(ctx context.Context, seriesID uint) (string, error) {
+	fullURL := fmt.Sprintf("%s/tv/%d/content_ratings", s.baseURL, seriesID)
+
+	var ratings tvContentRatings
+	if err := s.fetch(ctx, fullURL, &ratings); err != nil {
+		return "", fmt.Errorf("get tv certification error: %w", err)
+	}
+
+	byRegion := make(map[string]string, len(ratings.Results))
+	for _, result := range ratings.Results {
+		if result.Rating != "" {
+			byRegion[result.ISO] = result.Rating
+		}
+	}
+	return pickCertification(byRegion, s.certificationRegion()), nil
+}
+
+// certificationRegion derives the certification country from the
+// configured metadata language ("en-AU" yields "AU").
+func (s *TMDbService) certificationRegion() string {
+	if _, region, ok := strings.Cut(s.config.Meta.TMDb.Language, "-"); ok && len(region) == 2 {
+		return strings.ToUpper(region)
+	}
+	return certificationFallbackRegion
+}
+
+func pickCertification(byRegion map[string]string, region string) string {
+	if cert, ok := byRegion[region]; ok {
+		return cert
+	}
+	return byRegion[certificationFallbackRegion]
+}
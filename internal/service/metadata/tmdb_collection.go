@@ -0,0 +1,29 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+)
+
+// Collection is the collection a movie belongs to on TMDb
+type Collection struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	PosterPath   *string `json:"poster_path"`
+	BackdropPath *string `json:"backdrop_path"`
+}
+
+// GetMovieCollection fetches the collection membership for a movie, or nil
+// when the movie is not part of a collection.
+func (s *TMDbService) GetMovieCollection(ctx context.Context, movieID int) (*Collection, error) {
+	fullURL := fmt.Sprintf("%s/movie/%d?language=%s", s.baseURL, movieID, s.config.Meta.TMDb.Language)
+
+	var result struct {
+		BelongsToCollection *Collection `json:"belongs_to_collection"`
+	}
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("get movie collection error: %w", err)
+	}
+
+	return result.BelongsToCollection, nil
+}
@@ -0,0 +1,59 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type CreditsResult struct {
+	ID   int          `json:"id"`
+	Cast []CastMember `json:"cast"`
+	Crew []CrewMember `json:"crew"`
+}
+
+type CastMember struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	ProfilePath *string `json:"profile_path"`
+	Character   string  `json:"character"`
+	Order       int     `json:"order"`
+}
+
+type CrewMember struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	ProfilePath *string `json:"profile_path"`
+	Department  string  `json:"department"`
+	Job         string  `json:"job"`
+}
+
+// GetMovieCredits fetches the cast and crew for a movie
+func (s *TMDbService) GetMovieCredits(ctx context.Context, movieID int) (*CreditsResult, error) {
+	params := url.Values{}
+	params.Add("language", s.config.Meta.TMDb.Language)
+
+	fullURL := fmt.Sprintf("%s/movie/%d/credits?%s", s.baseURL, movieID, params.Encode())
+
+	var result CreditsResult
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("get movie credits error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetTVCredits fetches the cast and crew for a TV show
+func (s *TMDbService) GetTVCredits(ctx context.Context, seriesID uint) (*CreditsResult, error) {
+	params := url.Values{}
+	params.Add("language", s.config.Meta.TMDb.Language)
+
+	fullURL := fmt.Sprintf("%s/tv/%d/credits?%s", s.baseURL, seriesID, params.Encode())
+
+	var result CreditsResult
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("get TV credits error: %w", err)
+	}
+
+	return &result, nil
+}
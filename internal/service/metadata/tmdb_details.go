@@ -0,0 +1,89 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+)
+
+// MovieDetails is the full TMDb movie record, used when re-identifying a
+// movie against a known TMDb ID.
+type MovieDetails struct {
+	ID                  int                 `json:"id"`
+	Title               string              `json:"title"`
+	OriginalTitle       string              `json:"original_title"`
+	Overview            string              `json:"overview"`
+	ReleaseDate         string              `json:"release_date"`
+	PosterPath          *string             `json:"poster_path"`
+	BackdropPath        *string             `json:"backdrop_path"`
+	VoteAverage         float64             `json:"vote_average"`
+	VoteCount           int                 `json:"vote_count"`
+	Runtime             int                 `json:"runtime"`
+	OriginalLanguage    string              `json:"original_language"`
+	ProductionCountries []ProductionCountry `json:"production_countries"`
+	Genres              []Genre             `json:"genres"`
+	BelongsToCollection *Collection         `json:"belongs_to_collection"`
+}
+
+// ProductionCountry is one entry of a record's production_countries list.
+type ProductionCountry struct {
+	ISO  string `json:"iso_3166_1"`
+	Name string `json:"name"`
+}
+
+// CountryCodes flattens production countries to their ISO 3166-1 codes.
+func CountryCodes(countries []ProductionCountry) []string {
+	codes := make([]string, 0, len(countries))
+	for _, country := range countries {
+		codes = append(codes, country.ISO)
+	}
+	return codes
+}
+
+// TVDetails is the full TMDb series record
+type TVDetails struct {
+	ID           uint    `json:"id"`
+	Name         string  `json:"name"`
+	OriginalName string  `json:"original_name"`
+	Overview     string  `json:"overview"`
+	FirstAirDate string  `json:"first_air_date"`
+	PosterPath   *string `json:"poster_path"`
+	BackdropPath *string `json:"backdrop_path"`
+	VoteAverage  float64 `json:"vote_average"`
+	VoteCount    int     `json:"vote_count"`
+	Genres       []Genre `json:"genres"`
+
+	OriginalLanguage    string              `json:"original_language"`
+	ProductionCountries []ProductionCountry `json:"production_countries"`
+	Seasons             []TVSeasonSummary   `json:"seasons"`
+}
+
+// TVSeasonSummary is the per-season summary embedded in a series record,
+// enough to map absolute episode numbers onto seasons.
+type TVSeasonSummary struct {
+	SeasonNumber int `json:"season_number"`
+	EpisodeCount int `json:"episode_count"`
+}
+
+// GetMovieDetails fetches the full record for a movie by TMDb ID
+func (s *TMDbService) GetMovieDetails(ctx context.Context, movieID int) (*MovieDetails, error) {
+	fullURL := fmt.Sprintf("%s/movie/%d?language=%s", s.baseURL, movieID, s.config.Meta.TMDb.Language)
+
+	var details MovieDetails
+	if err := s.fetch(ctx, fullURL, &details); err != nil {
+		return nil, fmt.Errorf("get movie details error: %w", err)
+	}
+
+	return &details, nil
+}
+
+// GetTVDetails fetches the full record for a series by TMDb ID
+func (s *TMDbService) GetTVDetails(ctx context.Context, seriesID uint) (*TVDetails, error) {
+	fullURL := fmt.Sprintf("%s/tv/%d?language=%s", s.baseURL, seriesID, s.config.Meta.TMDb.Language)
+
+	var details TVDetails
+	if err := s.fetch(ctx, fullURL, &details); err != nil {
+		return nil, fmt.Errorf("get tv details error: %w", err)
+	}
+
+	return &details, nil
+}
@@ -0,0 +1,42 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// GetSimilarMovies returns TMDb's "similar" list for a movie, used to
+// recommend titles that are not in the library yet.
+func (s *TMDbService) GetSimilarMovies(ctx context.Context, movieID int) (*MovieSearchResult, error) {
+	params := url.Values{}
+	params.Add("api_key", s.config.Meta.TMDb.BearerToken)
+	params.Add("language", s.config.Meta.TMDb.Language)
+	params.Add("page", "1")
+
+	fullURL := fmt.Sprintf("%s/movie/%d/similar?%s", s.baseURL, movieID, params.Encode())
+
+	var result MovieSearchResult
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("similar movies error: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetSimilarTV returns TMDb's "similar" list for a series.
+func (s *TMDbService) GetSimilarTV(ctx context.Context, seriesID uint) (*SeriesSearchResult, error) {
+	params := url.Values{}
+	params.Add("api_key", s.config.Meta.TMDb.BearerToken)
+	params.Add("language", s.config.Meta.TMDb.Language)
+	params.Add("page", "1")
+
+	fullURL := fmt.Sprintf("%s/tv/%d/similar?%s", s.baseURL, seriesID, params.Encode())
+
+	var result SeriesSearchResult
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("similar series error: %w", err)
+	}
+
+	return &result, nil
+}
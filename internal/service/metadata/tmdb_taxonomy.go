@@ -0,0 +1,106 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+)
+
+// Genre is a TMDb genre list entry
+type Genre struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Company is a TMDb production company or network
+type Company struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type genreListResult struct {
+	Genres []Genre `json:"genres"`
+}
+
+// genreNames fetches the TMDb genre list for the media type ("movie" or
+// "tv") and caches the id to name mapping for the life of the process.
+func (s *TMDbService) genreNames(ctx context.Context, mediaType string) (map[int]string, error) {
+	s.genreMu.Lock()
+	defer s.genreMu.Unlock()
+
+	if names, ok := s.genreCache[mediaType]; ok {
+		return names, nil
+	}
+
+	fullURL := fmt.Sprintf("%s/genre/%s/list?language=%s", s.baseURL, mediaType, s.config.Meta.TMDb.Language)
+
+	var result genreListResult
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("get %s genres error: %w", mediaType, err)
+	}
+
+	names := make(map[int]string, len(result.Genres))
+	for _, genre := range result.Genres {
+		names[genre.ID] = genre.Name
+	}
+	s.genreCache[mediaType] = names
+
+	return names, nil
+}
+
+// ResolveMovieGenres maps the genre IDs from a movie search result to genres
+func (s *TMDbService) ResolveMovieGenres(ctx context.Context, genreIDs []int) ([]Genre, error) {
+	return s.resolveGenres(ctx, "movie", genreIDs)
+}
+
+// ResolveTVGenres maps the genre IDs from a TV search result to genres
+func (s *TMDbService) ResolveTVGenres(ctx context.Context, genreIDs []int) ([]Genre, error) {
+	return s.resolveGenres(ctx, "tv", genreIDs)
+}
+
+func (s *TMDbService) resolveGenres(ctx context.Context, mediaType string, genreIDs []int) ([]Genre, error) {
+	if len(genreIDs) == 0 {
+		return nil, nil
+	}
+
+	names, err := s.genreNames(ctx, mediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	var genres []Genre
+	for _, id := range genreIDs {
+		if name, ok := names[id]; ok {
+			genres = append(genres, Genre{ID: id, Name: name})
+		}
+	}
+	return genres, nil
+}
+
+// GetMovieCompanies fetches the production companies for a movie
+func (s *TMDbService) GetMovieCompanies(ctx context.Context, movieID int) ([]Company, error) {
+	fullURL := fmt.Sprintf("%s/movie/%d?language=%s", s.baseURL, movieID, s.config.Meta.TMDb.Language)
+
+	var result struct {
+		ProductionCompanies []Company `json:"production_companies"`
+	}
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("get movie companies error: %w", err)
+	}
+
+	return result.ProductionCompanies, nil
+}
+
+// GetTVNetworks fetches the networks and production companies for a show
+func (s *TMDbService) GetTVNetworks(ctx context.Context, seriesID uint) ([]Company, error) {
+	fullURL := fmt.Sprintf("%s/tv/%d?language=%s", s.baseURL, seriesID, s.config.Meta.TMDb.Language)
+
+	var result struct {
+		Networks            []Company `json:"networks"`
+		ProductionCompanies []Company `json:"production_companies"`
+	}
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("get tv networks error: %w", err)
+	}
+
+	return append(result.Networks, result.ProductionCompanies...), nil
+}
@@ -0,0 +1,68 @@
+package metadata
+
+import (
+	"context"
+	"fmt"
+)
+
+// Translation is a localized title and overview from the TMDb translations
+// endpoint. The Language tag combines the ISO 639-1 and ISO 3166-1 codes,
+// e.g. "fr-FR".
+type Translation struct {
+	Language string
+	Title    string
+	Overview string
+}
+
+type translationData struct {
+	Title    string `json:"title"`
+	Name     string `json:"name"`
+	Overview string `json:"overview"`
+}
+
+type translationEntry struct {
+	ISO6391  string          `json:"iso_639_1"`
+	ISO31661 string          `json:"iso_3166_1"`
+	Data     translationData `json:"data"`
+}
+
+type translationsResult struct {
+	Translations []translationEntry `json:"translations"`
+}
+
+// GetMovieTranslations fetches all localized titles and overviews for a movie
+func (s *TMDbService) GetMovieTranslations(ctx context.Context, movieID int) ([]Translation, error) {
+	fullURL := fmt.Sprintf("%s/movie/%d/translations", s.baseURL, movieID)
+	return s.getTranslations(ctx, fullURL)
+}
+
+// GetTVTranslations fetches all localized names and overviews for a series
+func (s *TMDbService) GetTVTranslations(ctx context.Context, seriesID uint) ([]Translation, error) {
+	fullURL := fmt.Sprintf("%s/tv/%d/translations", s.baseURL, seriesID)
+	return s.getTranslations(ctx, fullURL)
+}
+
+func (s *TMDbService) getTranslations(ctx context.Context, fullURL string) ([]Translation, error) {
+	var result translationsResult
+	if err := s.fetch(ctx, fullURL, &result); err != nil {
+		return nil, fmt.Errorf("get translations error: %w", err)
+	}
+
+	translations := make([]Translation, 0, len(result.Translations))
+	for _, entry := range result.Translations {
+		title := entry.Data.Title
+		if title == "" {
+			title = entry.Data.Name
+		}
+		if title == "" && entry.Data.Overview == "" {
+			continue
+		}
+		translations = append(translations, Translation{
+			Language: fmt.Sprintf("%s-%s", entry.ISO6391, entry.ISO31661),
+			Title:    title,
+			Overview: entry.Data.Overview,
+		})
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// MusicService exposes the artist/album/track hierarchy built by the
+// scanner for browsing.
+type MusicService interface {
+	ListArtists(ctx context.Context) ([]*dto.ArtistDTO, error)
+	GetArtist(ctx context.Context, id uint) (*dto.ArtistDTO, error)
+	GetAlbum(ctx context.Context, id uint) (*dto.AlbumDTO, error)
+}
+
+type musicService struct {
+	artistRepo repository.ArtistRepository
+	albumRepo  repository.AlbumRepository
+	trackRepo  repository.TrackRepository
+}
+
+func NewMusicService(artistRepo repository.ArtistRepository, albumRepo repository.AlbumRepository, trackRepo repository.TrackRepository) MusicService {
+	return &musicService{
+		artistRepo: artistRepo,
+		albumRepo:  albumRepo,
+		trackRepo:  trackRepo,
+	}
+}
+
+func (s *musicService) ListArtists(ctx context.Context) ([]*dto.ArtistDTO, error) {
+	artists, err := s.artistRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ArtistsToDTO(artists), nil
+}
+
+func (s *musicService) GetArtist(ctx context.Context, id uint) (*dto.ArtistDTO, error) {
+	artist, err := s.artistRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if artist == nil {
+		return nil, fmt.Errorf("artist %d does not exist", id)
+	}
+	return dto.ArtistToDTO(artist), nil
+}
+
+func (s *musicService) GetAlbum(ctx context.Context, id uint) (*dto.AlbumDTO, error) {
+	album, err := s.albumRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if album == nil {
+		return nil, fmt.Errorf("album %d does not exist", id)
+	}
+
+	// Tracks are ordered by disc and track number for display
+	tracks, err := s.trackRepo.ListByAlbum(ctx, album.ID)
+	if err != nil {
+		return nil, err
+	}
+	album.Tracks = album.Tracks[:0]
+	for _, track := range tracks {
+		album.Tracks = append(album.Tracks, *track)
+	}
+
+	return dto.AlbumToDTO(album), nil
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+type PersonService interface {
+	GetPersonByID(ctx context.Context, id uint) (*dto.PersonDTO, error)
+	GetMediaCredits(ctx context.Context, mediaType string, mediaID uint) ([]*dto.CreditDTO, error)
+}
+
+type personService struct {
+	personRepo repository.PersonRepository
+	creditRepo repository.CreditRepository
+}
+
+func NewPersonService(personRepo repository.PersonRepository, creditRepo repository.CreditRepository) PersonService {
+	return &personService{
+		personRepo: personRepo,
+		creditRepo: creditRepo,
+	}
+}
+
+// GetPersonByID returns a person along with their credits, so clients can
+// build "more from this actor" views from a single call.
+func (s *personService) GetPersonByID(ctx context.Context, id uint) (*dto.PersonDTO, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid person ID: %w", errors.ErrBadRequest)
+	}
+
+	person, err := s.personRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get person by ID: %w", err)
+	}
+	if person == nil {
+		return nil, nil
+	}
+
+	personDTO := dto.PersonToDTO(person)
+
+	credits, err := s.creditRepo.FindByPerson(ctx, person.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credits for person: %w", err)
+	}
+	for i := range credits {
+		personDTO.Credits = append(personDTO.Credits, *dto.CreditToDTO(&credits[i]))
+	}
+
+	return personDTO, nil
+}
+
+func (s *personService) GetMediaCredits(ctx context.Context, mediaType string, mediaID uint) ([]*dto.CreditDTO, error) {
+	credits, err := s.creditRepo.FindByMedia(ctx, mediaType, mediaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media credits: %w", err)
+	}
+	return dto.CreditsToDTO(credits), nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// defaultTimelineLimit caps the photo timeline when the client does not ask
+// for a specific page size.
+const defaultTimelineLimit = 200
+
+// PhotoService exposes the photos imported by the scanner as a
+// newest-first timeline and as directory-derived albums.
+type PhotoService interface {
+	Timeline(ctx context.Context, limit int) ([]*dto.PhotoDTO, error)
+	ListAlbums(ctx context.Context) ([]string, error)
+	GetAlbum(ctx context.Context, name string) (*dto.PhotoAlbumDTO, error)
+}
+
+type photoService struct {
+	photoRepo repository.PhotoRepository
+}
+
+func NewPhotoService(photoRepo repository.PhotoRepository) PhotoService {
+	return &photoService{photoRepo: photoRepo}
+}
+
+func (s *photoService) Timeline(ctx context.Context, limit int) ([]*dto.PhotoDTO, error) {
+	if limit <= 0 {
+		limit = defaultTimelineLimit
+	}
+	photos, err := s.photoRepo.ListTimeline(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return dto.PhotosToDTO(photos), nil
+}
+
+func (s *photoService) ListAlbums(ctx context.Context) ([]string, error) {
+	return s.photoRepo.ListAlbums(ctx)
+}
+
+func (s *photoService) GetAlbum(ctx context.Context, name string) (*dto.PhotoAlbumDTO, error) {
+	photos, err := s.photoRepo.ListByAlbum(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(photos) == 0 {
+		return nil, fmt.Errorf("album %q does not exist", name)
+	}
+
+	album := &dto.PhotoAlbumDTO{Name: name, Photos: make([]dto.PhotoDTO, 0, len(photos))}
+	for _, photo := range photos {
+		album.Photos = append(album.Photos, *dto.PhotoToDTO(photo))
+	}
+	return album, nil
+}
@@ -0,0 +1,482 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/telemetry"
+)
+
+// PlaybackService resolves what a client should play: the item's source
+// media details plus transcode directives derived from the selected client
+// profile. Profiles themselves are managed by administrators.
+type PlaybackService interface {
+	GetPlaybackInfo(ctx context.Context, mediaType string, id uint, opts *dto.PlaybackOptions) (*dto.PlaybackInfoDTO, error)
+	ListProfiles(ctx context.Context) ([]*dto.ClientProfileDTO, error)
+	CreateProfile(ctx context.Context, req *dto.ClientProfileRequest) (*dto.ClientProfileDTO, error)
+	UpdateProfile(ctx context.Context, id uint, req *dto.ClientProfileRequest) (*dto.ClientProfileDTO, error)
+	DeleteProfile(ctx context.Context, id uint) error
+}
+
+type playbackService struct {
+	appLogger   logger.Logger
+	movieRepo   repository.MovieRepository
+	episodeRepo repository.EpisodeRepository
+	profileRepo repository.ClientProfileRepository
+	userRepo    repository.UserRepository
+	parental    ParentalService
+	ffService   ffmpeg.Service
+	bus         events.Bus
+}
+
+func NewPlaybackService(appLogger logger.Logger, movieRepo repository.MovieRepository, episodeRepo repository.EpisodeRepository, profileRepo repository.ClientProfileRepository, userRepo repository.UserRepository, parental ParentalService, ffService ffmpeg.Service, bus events.Bus) PlaybackService {
+	return &playbackService{
+		appLogger:   appLogger,
+		movieRepo:   movieRepo,
+		episodeRepo: episodeRepo,
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+		parental:    parental,
+		ffService:   ffService,
+		bus:         bus,
+	}
+}
+
+// GetPlaybackInfo returns the playback info for a movie or episode, with
+// the named client profile's forced settings applied and the session's
+// per-request selections layered on top. An empty profile name yields
+// direct-play info.
+func (s *playbackService) GetPlaybackInfo(ctx context.Context, mediaType string, id uint, opts *dto.PlaybackOptions) (*dto.PlaybackInfoDTO, error) {
+	ctx, span := telemetry.Tracer().Start(ctx, "playback.GetPlaybackInfo")
+	span.SetAttributes(attribute.String("media.type", mediaType), attribute.Int("media.id", int(id)))
+	defer span.End()
+
+	item, err := s.findItem(ctx, mediaType, id)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, fmt.Errorf("%s not found", mediaType)
+	}
+
+	// Restricted users must not stream what they cannot see
+	if err := s.parental.AuthorizePlayback(ctx, mediaType, id); err != nil {
+		return nil, err
+	}
+
+	// Best effort: the user is only on the context for authenticated requests
+	payload := events.PlaybackPayload{MediaType: mediaType, MediaID: id}
+	if user, err := auth.GetUserFromContext(ctx); err == nil {
+		payload.UserID = user.ID
+	}
+	s.bus.Publish(ctx, events.PlaybackStarted, payload)
+
+	// Stored playback preferences only apply to authenticated sessions;
+	// a failed lookup falls back to the defaults rather than failing play
+	var prefs *entity.User
+	if payload.UserID != 0 {
+		loaded, err := s.userRepo.FindByID(ctx, payload.UserID)
+		if err != nil {
+			s.appLogger.Warn().Err(err).Uint("userID", payload.UserID).Msg("Failed to load playback preferences")
+		} else {
+			prefs = loaded
+		}
+	}
+
+	// Movies can carry alternate versions of the same film (4K, Director's
+	// Cut); the selector swaps the chosen file in while the metadata stays
+	// on the movie itself
+	var versions []*entity.MovieVersion
+	primary := item
+	if mediaType == "movie" {
+		versions, err = s.movieRepo.FindVersions(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Version != 0 {
+			selected := selectMovieVersion(versions, opts.Version)
+			if selected == nil {
+				return nil, fmt.Errorf("unknown movie version %d", opts.Version)
+			}
+			item = &selected.LibraryItem
+		}
+	}
+
+	info := &dto.PlaybackInfoDTO{
+		MediaType:      mediaType,
+		ID:             id,
+		Container:      item.Container,
+		VideoCodec:     item.Codec,
+		Width:          item.ResolutionWidth,
+		Height:         item.ResolutionHeight,
+		AudioChannels:  item.AudioChannels,
+		AudioLanguages: item.AudioLanguageList(),
+	}
+	if len(versions) > 0 {
+		info.Versions = movieVersionOptions(primary, versions)
+	}
+
+	// The request's profile selection wins; the user's stored default
+	// quality fills in when none is named
+	profileName := opts.Profile
+	if profileName == "" && prefs != nil {
+		profileName = prefs.DefaultQuality
+	}
+	if profileName != "" {
+		profile, err := s.profileRepo.FindByName(ctx, profileName)
+		if err != nil {
+			return nil, err
+		}
+		if profile == nil {
+			if opts.Profile != "" {
+				return nil, fmt.Errorf("unknown client profile '%s'", opts.Profile)
+			}
+			// A stale stored default must not break playback
+			s.appLogger.Warn().Str("profile", profileName).Msg("Stored default quality names an unknown client profile")
+		} else {
+			info.Profile = profile.Name
+			info.TranscodeAudio = profile.ForceAudioTranscode
+			info.ToneMapHDR = profile.DisableHDR
+			info.DownmixStereo = profile.DownmixStereo
+			info.LoudnessCompensation = profile.LoudnessCompensation
+			if profile.MaxHeight > 0 && item.ResolutionHeight > profile.MaxHeight {
+				info.TranscodeVideo = true
+				info.MaxHeight = profile.MaxHeight
+			}
+		}
+	}
+
+	s.applyDownmix(info, item, opts)
+	s.applyAudioPreference(info, prefs)
+
+	if err := s.applySubtitles(ctx, info, item.FilePath, opts.SubtitleTrack, prefs); err != nil {
+		return nil, err
+	}
+
+	if prefs != nil && prefs.PlaybackSpeed > 0 {
+		info.PlaybackSpeed = prefs.PlaybackSpeed
+	}
+
+	s.applyCapabilities(ctx, info)
+	return info, nil
+}
+
+// applyDownmix layers the session's stereo fold-down selections over the
+// profile defaults. Downmixing re-encodes the audio, so it implies an audio
+// transcode; loudness compensation only makes sense during the fold-down.
+func (s *playbackService) applyDownmix(info *dto.PlaybackInfoDTO, item *entity.LibraryItem, opts *dto.PlaybackOptions) {
+	if opts.Downmix != nil {
+		info.DownmixStereo = *opts.Downmix
+	}
+	if opts.Loudnorm != nil {
+		info.LoudnessCompensation = *opts.Loudnorm
+	}
+
+	// Nothing to fold down for mono or stereo sources
+	if item.AudioChannels <= 2 {
+		info.DownmixStereo = false
+	}
+	if !info.DownmixStereo {
+		info.LoudnessCompensation = false
+		return
+	}
+	info.TranscodeAudio = true
+}
+
+// applySubtitles lists the embedded subtitle tracks and, when the caller
+// selected one, plans how to deliver it. Image-based formats (PGS, VOBSUB)
+// can't be converted to text, so the selected track is burned into the
+// video, which forces a transcode and pins the session to that track.
+// applyAudioPreference selects the user's preferred audio language when
+// the item carries a matching track; otherwise the library's priority
+// order stands.
+func (s *playbackService) applyAudioPreference(info *dto.PlaybackInfoDTO, prefs *entity.User) {
+	if prefs == nil || prefs.PreferredAudioLanguage == "" {
+		return
+	}
+	for _, lang := range info.AudioLanguages {
+		if strings.EqualFold(lang, prefs.PreferredAudioLanguage) {
+			info.SelectedAudioLanguage = lang
+			return
+		}
+	}
+}
+
+func (s *playbackService) applySubtitles(ctx context.Context, info *dto.PlaybackInfoDTO, filePath string, subtitleTrack int, prefs *entity.User) error {
+	meta, err := s.ffService.ExtractMetadata(ctx, filePath)
+	if err != nil {
+		if subtitleTrack >= 0 {
+			return fmt.Errorf("failed to probe subtitle tracks: %w", err)
+		}
+		s.appLogger.Warn().Err(err).Str("path", filePath).Msg("Failed to probe subtitle tracks")
+		return nil
+	}
+
+	var selected *dto.SubtitleTrackDTO
+	for _, track := range meta.SubtitleTracks {
+		trackDTO := &dto.SubtitleTrackDTO{
+			Index:      track.Index,
+			Codec:      track.CodecName,
+			Language:   track.Tags["language"],
+			Title:      track.Tags["title"],
+			Forced:     track.Disposition["forced"] == 1,
+			ImageBased: imageBasedSubtitle(track.CodecName),
+		}
+		info.SubtitleTracks = append(info.SubtitleTracks, trackDTO)
+		if track.Index == subtitleTrack {
+			selected = trackDTO
+		}
+	}
+
+	if subtitleTrack < 0 {
+		selected = autoSelectSubtitle(info.SubtitleTracks, prefs)
+		if selected == nil {
+			return nil
+		}
+	}
+	if selected == nil {
+		return fmt.Errorf("unknown subtitle track %d", subtitleTrack)
+	}
+
+	index := selected.Index
+	info.SelectedSubtitleIndex = &index
+
+	if selected.ImageBased {
+		info.BurnSubtitle = true
+		info.BurnSubtitleIndex = selected.Index
+		info.SubtitleRestartRequired = true
+		// Burning happens on the video frames, so direct play is off the
+		// table even when the profile didn't ask for a transcode
+		info.TranscodeVideo = true
+	}
+	return nil
+}
+
+// autoSelectSubtitle picks a subtitle track from the user's stored
+// preferences when the request named none. "always" takes the
+// preferred-language track, "forced" only considers forced tracks (any
+// language as a fallback); "off" and unauthenticated requests select
+// nothing.
+func autoSelectSubtitle(tracks []*dto.SubtitleTrackDTO, prefs *entity.User) *dto.SubtitleTrackDTO {
+	if prefs == nil || prefs.SubtitleMode == "" || prefs.SubtitleMode == entity.SubtitleModeOff {
+		return nil
+	}
+
+	var fallback *dto.SubtitleTrackDTO
+	for _, track := range tracks {
+		if prefs.SubtitleMode == entity.SubtitleModeForced {
+			if !track.Forced {
+				continue
+			}
+			if fallback == nil {
+				fallback = track
+			}
+		}
+		if prefs.PreferredSubtitleLanguage != "" && !strings.EqualFold(track.Language, prefs.PreferredSubtitleLanguage) {
+			continue
+		}
+		return track
+	}
+	return fallback
+}
+
+// imageBasedSubtitle reports whether the codec stores subtitles as bitmaps
+// rather than text.
+func imageBasedSubtitle(codec string) bool {
+	switch codec {
+	case "hdmv_pgs_subtitle", "dvd_subtitle", "dvb_subtitle", "xsub":
+		return true
+	}
+	return false
+}
+
+// applyCapabilities checks planned transcode work against what the
+// installed ffmpeg build can actually do, falling back to direct play for
+// anything it cannot. Builds vary per architecture, so encoder availability
+// is never assumed.
+func (s *playbackService) applyCapabilities(ctx context.Context, info *dto.PlaybackInfoDTO) {
+	if !info.TranscodeVideo && !info.TranscodeAudio && !info.ToneMapHDR {
+		return
+	}
+
+	caps, err := s.ffService.Capabilities(ctx)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Msg("FFmpeg capabilities unknown; falling back to direct play")
+		info.TranscodeVideo = false
+		info.TranscodeAudio = false
+		info.ToneMapHDR = false
+		info.MaxHeight = 0
+		info.DownmixStereo = false
+		info.LoudnessCompensation = false
+		s.clearBurnSubtitle(info)
+		return
+	}
+
+	if info.TranscodeVideo && !caps.HasEncoder("libx264") {
+		s.appLogger.Warn().Str("encoder", "libx264").Msg("Encoder missing from ffmpeg build; skipping video transcode")
+		info.TranscodeVideo = false
+		info.MaxHeight = 0
+		s.clearBurnSubtitle(info)
+	}
+	if info.TranscodeAudio && !caps.HasEncoder("aac") {
+		s.appLogger.Warn().Str("encoder", "aac").Msg("Encoder missing from ffmpeg build; skipping audio transcode")
+		info.TranscodeAudio = false
+		info.DownmixStereo = false
+		info.LoudnessCompensation = false
+	}
+	if info.DownmixStereo && !caps.HasFilter("pan") {
+		s.appLogger.Warn().Str("filter", "pan").Msg("Filter missing from ffmpeg build; skipping stereo downmix")
+		info.DownmixStereo = false
+		info.LoudnessCompensation = false
+	}
+	if info.LoudnessCompensation && !caps.HasFilter("loudnorm") {
+		s.appLogger.Warn().Str("filter", "loudnorm").Msg("Filter missing from ffmpeg build; skipping loudness compensation")
+		info.LoudnessCompensation = false
+	}
+	if info.ToneMapHDR && !caps.HasFilter("tonemap") {
+		s.appLogger.Warn().Str("filter", "tonemap").Msg("Filter missing from ffmpeg build; skipping HDR tone mapping")
+		info.ToneMapHDR = false
+	}
+}
+
+// clearBurnSubtitle drops the burn-in directives when the video transcode
+// they depend on can't happen.
+func (s *playbackService) clearBurnSubtitle(info *dto.PlaybackInfoDTO) {
+	if !info.BurnSubtitle {
+		return
+	}
+	s.appLogger.Warn().Int("track", info.BurnSubtitleIndex).Msg("Video transcode unavailable; cannot burn subtitle track")
+	info.BurnSubtitle = false
+	info.BurnSubtitleIndex = 0
+	info.SubtitleRestartRequired = false
+}
+
+func (s *playbackService) ListProfiles(ctx context.Context) ([]*dto.ClientProfileDTO, error) {
+	profiles, err := s.profileRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ClientProfilesToDTO(profiles), nil
+}
+
+func (s *playbackService) CreateProfile(ctx context.Context, req *dto.ClientProfileRequest) (*dto.ClientProfileDTO, error) {
+	if req.Name == "" {
+		return nil, fmt.Errorf("profile name is required")
+	}
+
+	existing, err := s.profileRepo.FindByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("profile '%s' already exists", req.Name)
+	}
+
+	profile := &entity.ClientProfile{
+		Name:                 req.Name,
+		MaxHeight:            req.MaxHeight,
+		ForceAudioTranscode:  req.ForceAudioTranscode,
+		DisableHDR:           req.DisableHDR,
+		DownmixStereo:        req.DownmixStereo,
+		LoudnessCompensation: req.LoudnessCompensation,
+	}
+	if err := s.profileRepo.Store(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return dto.ClientProfileToDTO(profile), nil
+}
+
+func (s *playbackService) UpdateProfile(ctx context.Context, id uint, req *dto.ClientProfileRequest) (*dto.ClientProfileDTO, error) {
+	profile, err := s.profileRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("profile not found")
+	}
+
+	if req.Name != "" {
+		profile.Name = req.Name
+	}
+	profile.MaxHeight = req.MaxHeight
+	profile.ForceAudioTranscode = req.ForceAudioTranscode
+	profile.DisableHDR = req.DisableHDR
+	profile.DownmixStereo = req.DownmixStereo
+	profile.LoudnessCompensation = req.LoudnessCompensation
+
+	if err := s.profileRepo.Update(ctx, profile); err != nil {
+		return nil, err
+	}
+
+	return dto.ClientProfileToDTO(profile), nil
+}
+
+func (s *playbackService) DeleteProfile(ctx context.Context, id uint) error {
+	return s.profileRepo.Delete(ctx, id)
+}
+
+// findItem loads the LibraryItem fields shared by movies and episodes.
+// selectMovieVersion resolves a requested version ID against the movie's
+// alternates.
+func selectMovieVersion(versions []*entity.MovieVersion, id uint) *entity.MovieVersion {
+	for _, version := range versions {
+		if version.ID == id {
+			return version
+		}
+	}
+	return nil
+}
+
+// movieVersionOptions builds the version selector: the movie's primary
+// file first (ID 0), then each alternate.
+func movieVersionOptions(primary *entity.LibraryItem, versions []*entity.MovieVersion) []*dto.MovieVersionDTO {
+	options := make([]*dto.MovieVersionDTO, 0, len(versions)+1)
+	options = append(options, &dto.MovieVersionDTO{
+		Label:    "Original",
+		Codec:    primary.Codec,
+		Width:    primary.ResolutionWidth,
+		Height:   primary.ResolutionHeight,
+		FileSize: primary.FileSize,
+	})
+	for _, version := range versions {
+		options = append(options, &dto.MovieVersionDTO{
+			ID:       version.ID,
+			Label:    version.Label,
+			Codec:    version.Codec,
+			Width:    version.ResolutionWidth,
+			Height:   version.ResolutionHeight,
+			FileSize: version.FileSize,
+		})
+	}
+	return options
+}
+
+func (s *playbackService) findItem(ctx context.Context, mediaType string, id uint) (*entity.LibraryItem, error) {
+	switch mediaType {
+	case "movie":
+		movie, err := s.movieRepo.FindByID(ctx, id)
+		if err != nil || movie == nil {
+			return nil, err
+		}
+		return &movie.LibraryItem, nil
+	case "episode":
+		episode, err := s.episodeRepo.FindEpisodeByID(ctx, id)
+		if err != nil || episode == nil {
+			return nil, err
+		}
+		return &episode.LibraryItem, nil
+	default:
+		return nil, fmt.Errorf("unsupported media type '%s'", mediaType)
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// maxProfilesPerUser caps how many household profiles one account can
+// hold; enough for a family without inviting abuse.
+const maxProfilesPerUser = 10
+
+// ProfileService manages the household sub-profiles under an account.
+// Every operation is scoped to the owning user; profiles are never
+// visible across accounts.
+type ProfileService interface {
+	ListProfiles(ctx context.Context, userID uint) ([]*dto.ProfileDTO, error)
+	CreateProfile(ctx context.Context, userID uint, req *dto.CreateProfileRequest) (*dto.ProfileDTO, error)
+	UpdateProfile(ctx context.Context, userID, profileID uint, req *dto.UpdateProfileRequest) (*dto.ProfileDTO, error)
+	DeleteProfile(ctx context.Context, userID, profileID uint) error
+}
+
+type profileService struct {
+	profileRepo repository.ProfileRepository
+}
+
+func NewProfileService(profileRepo repository.ProfileRepository) ProfileService {
+	return &profileService{profileRepo: profileRepo}
+}
+
+func (s *profileService) ListProfiles(ctx context.Context, userID uint) ([]*dto.ProfileDTO, error) {
+	profiles, err := s.profileRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	return dto.ProfilesToDTO(profiles), nil
+}
+
+func (s *profileService) CreateProfile(ctx context.Context, userID uint, req *dto.CreateProfileRequest) (*dto.ProfileDTO, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("profile name is required: %w", errors.ErrBadRequest)
+	}
+
+	existing, err := s.profileRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+	if len(existing) >= maxProfilesPerUser {
+		return nil, fmt.Errorf("account already has %d profiles: %w", maxProfilesPerUser, errors.ErrBadRequest)
+	}
+	for _, profile := range existing {
+		if strings.EqualFold(profile.Name, name) {
+			return nil, fmt.Errorf("profile %q already exists: %w", name, errors.ErrAlreadyExists)
+		}
+	}
+
+	profile := &entity.Profile{
+		UserID: userID,
+		Name:   name,
+		Avatar: req.Avatar,
+		Kid:    req.Kid,
+	}
+	if err := s.profileRepo.Store(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to create profile: %w", err)
+	}
+	return dto.ProfileToDTO(profile), nil
+}
+
+func (s *profileService) UpdateProfile(ctx context.Context, userID, profileID uint, req *dto.UpdateProfileRequest) (*dto.ProfileDTO, error) {
+	profile, err := s.ownedProfile(ctx, userID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" {
+			return nil, fmt.Errorf("profile name is required: %w", errors.ErrBadRequest)
+		}
+		profile.Name = name
+	}
+	if req.Avatar != nil {
+		profile.Avatar = *req.Avatar
+	}
+	if req.Kid != nil {
+		profile.Kid = *req.Kid
+	}
+
+	if err := s.profileRepo.Update(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+	return dto.ProfileToDTO(profile), nil
+}
+
+// DeleteProfile removes the profile. Its watch history, watchlist and
+// favorites stay in place, keyed to the now-unused profile ID.
+func (s *profileService) DeleteProfile(ctx context.Context, userID, profileID uint) error {
+	if _, err := s.ownedProfile(ctx, userID, profileID); err != nil {
+		return err
+	}
+	if err := s.profileRepo.Delete(ctx, profileID); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
+
+// ownedProfile loads the profile and confirms it belongs to the user; a
+// foreign profile answers the same as a missing one.
+func (s *profileService) ownedProfile(ctx context.Context, userID, profileID uint) (*entity.Profile, error) {
+	profile, err := s.profileRepo.FindByID(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find profile: %w", err)
+	}
+	if profile == nil || profile.UserID != userID {
+		return nil, errors.NotFoundError("profile")
+	}
+	return profile, nil
+}
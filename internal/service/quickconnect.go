@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+)
+
+const (
+	// quickConnectLifetime is how long a pairing code stays valid.
+	quickConnectLifetime = 5 * time.Minute
+
+	// quickConnectCodeLength is the length of the code shown on the device.
+	quickConnectCodeLength = 6
+)
+
+// quickConnectAlphabet deliberately omits easily-confused characters
+// (0/O, 1/I/L) since the code is read off a TV screen.
+const quickConnectAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// QuickConnectService implements PIN-based device linking: a device
+// displays a short code, a logged-in user approves it, and the device polls
+// until it receives the normal Cinea tokens. Sessions are short-lived and
+// held in memory only.
+type QuickConnectService interface {
+	Initiate(ctx context.Context) (*dto.QuickConnectInitiateResponse, error)
+	Approve(ctx context.Context, code string, user *entity.User) error
+	Poll(ctx context.Context, secret string) (*dto.AuthResponse, error)
+}
+
+type quickConnectSession struct {
+	code      string
+	secret    string
+	expiresAt time.Time
+
+	// tokens is set once a user approves the code
+	tokens *dto.AuthResponse
+}
+
+type quickConnectService struct {
+	appLogger logger.Logger
+	authSvc   AuthService
+
+	mu       sync.Mutex
+	sessions map[string]*quickConnectSession // keyed by code
+}
+
+func NewQuickConnectService(appLogger logger.Logger, authSvc AuthService) QuickConnectService {
+	return &quickConnectService{
+		appLogger: appLogger,
+		authSvc:   authSvc,
+		sessions:  make(map[string]*quickConnectSession),
+	}
+}
+
+// Initiate creates a pairing session and returns the code to display plus
+// the secret the device polls with.
+func (s *quickConnectService) Initiate(ctx context.Context) (*dto.QuickConnectInitiateResponse, error) {
+	code, err := generateQuickConnectCode()
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %w", err)
+	}
+	secret := hex.EncodeToString(raw)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purgeExpiredLocked()
+
+	if _, exists := s.sessions[code]; exists {
+		return nil, fmt.Errorf("code collision, please retry")
+	}
+	s.sessions[code] = &quickConnectSession{
+		code:      code,
+		secret:    secret,
+		expiresAt: time.Now().Add(quickConnectLifetime),
+	}
+
+	return &dto.QuickConnectInitiateResponse{
+		Code:      code,
+		Secret:    secret,
+		ExpiresIn: int(quickConnectLifetime.Seconds()),
+	}, nil
+}
+
+// Approve links a pending code to the approving user by issuing tokens the
+// device will collect on its next poll.
+func (s *quickConnectService) Approve(ctx context.Context, code string, user *entity.User) error {
+	s.mu.Lock()
+	session, ok := s.sessions[code]
+	if !ok || time.Now().After(session.expiresAt) {
+		s.mu.Unlock()
+		return fmt.Errorf("unknown or expired code")
+	}
+	if session.tokens != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("code has already been approved")
+	}
+	s.mu.Unlock()
+
+	// Issue tokens outside the lock; the session can only expire meanwhile,
+	// which the poll handles
+	tokens, err := s.authSvc.IssueTokens(ctx, user)
+	if err != nil {
+		return fmt.Errorf("failed to issue tokens: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.sessions[code]; ok {
+		current.tokens = tokens
+	}
+
+	s.appLogger.Info().Uint("userID", user.ID).Msg("Quick Connect code approved")
+	return nil
+}
+
+// Poll returns the issued tokens once the code has been approved, or nil
+// while the session is still pending.
+func (s *quickConnectService) Poll(ctx context.Context, secret string) (*dto.AuthResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for code, session := range s.sessions {
+		if session.secret != secret {
+			continue
+		}
+		if time.Now().After(session.expiresAt) {
+			delete(s.sessions, code)
+			return nil, fmt.Errorf("code expired")
+		}
+		if session.tokens == nil {
+			return nil, nil
+		}
+		// Hand over the tokens exactly once
+		delete(s.sessions, code)
+		return session.tokens, nil
+	}
+
+	return nil, fmt.Errorf("unknown or expired code")
+}
+
+// purgeExpiredLocked drops expired sessions; callers must hold the lock.
+func (s *quickConnectService) purgeExpiredLocked() {
+	now := time.Now()
+	for code, session := range s.sessions {
+		if now.After(session.expiresAt) {
+			delete(s.sessions, code)
+		}
+	}
+}
+
+func generateQuickConnectCode() (string, error) {
+	code := make([]byte, quickConnectCodeLength)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(quickConnectAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate code: %w", err)
+		}
+		code[i] = quickConnectAlphabet[n.Int64()]
+	}
+	return string(code), nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+type RatingService interface {
+	GetRatings(ctx context.Context, userID uint) ([]entity.Rating, error)
+	AddRating(ctx context.Context, rating *entity.Rating) error
+	RemoveRating(ctx context.Context, userID uint, mediaID uint, mediaType string) error
+}
+
+type ratingService struct {
+	ratingRepo repository.RatingRepository
+	movieRepo  repository.MovieRepository
+	seriesRepo repository.SeriesRepository
+}
+
+func NewRatingService(ratingRepo repository.RatingRepository, movieRepo repository.MovieRepository, seriesRepo repository.SeriesRepository) RatingService {
+	return &ratingService{
+		ratingRepo: ratingRepo,
+		movieRepo:  movieRepo,
+		seriesRepo: seriesRepo,
+	}
+}
+
+func (s *ratingService) GetRatings(ctx context.Context, userID uint) ([]entity.Rating, error) {
+	ratings, err := s.ratingRepo.GetRatings(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ratings: %w", err)
+	}
+	return ratings, nil
+}
+
+// AddRating stores or replaces the user's rating for an item and brings
+// the item's community aggregate up to date.
+func (s *ratingService) AddRating(ctx context.Context, rating *entity.Rating) error {
+	if rating.Score < 0 || rating.Score > 10 {
+		return fmt.Errorf("score must be between 0 and 10: %w", errors.ErrBadRequest)
+	}
+	if rating.MediaType != "movie" && rating.MediaType != "series" {
+		return fmt.Errorf("unknown media type '%s': %w", rating.MediaType, errors.ErrBadRequest)
+	}
+
+	// One rating per user and item: a second submission updates the first
+	existing, err := s.ratingRepo.FindByUserAndMedia(ctx, rating.UserID, rating.MediaID, rating.MediaType)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		existing.Score = rating.Score
+		existing.Review = rating.Review
+		if err := s.ratingRepo.UpdateRating(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update rating: %w", err)
+		}
+	} else if err := s.ratingRepo.AddRating(ctx, rating); err != nil {
+		return fmt.Errorf("failed to add rating: %w", err)
+	}
+
+	return s.recalculate(ctx, rating.MediaType, rating.MediaID)
+}
+
+// RemoveRating deletes the user's rating for an item and brings the
+// item's community aggregate up to date.
+func (s *ratingService) RemoveRating(ctx context.Context, userID uint, mediaID uint, mediaType string) error {
+	if err := s.ratingRepo.RemoveRating(ctx, userID, mediaID, mediaType); err != nil {
+		return fmt.Errorf("failed to remove rating: %w", err)
+	}
+	return s.recalculate(ctx, mediaType, mediaID)
+}
+
+// recalculate recomputes the average score and rating count for the item
+// and stores them on the media row, so list and detail endpoints serve
+// the community score without touching the ratings table.
+func (s *ratingService) recalculate(ctx context.Context, mediaType string, mediaID uint) error {
+	average, count, err := s.ratingRepo.Aggregate(ctx, mediaType, mediaID)
+	if err != nil {
+		return err
+	}
+
+	switch mediaType {
+	case "movie":
+		err = s.movieRepo.UpdateCommunityRating(ctx, mediaID, average, int(count))
+	case "series":
+		err = s.seriesRepo.UpdateCommunityRating(ctx, mediaID, average, int(count))
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store community rating: %w", err)
+	}
+	return nil
+}
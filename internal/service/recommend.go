@@ -0,0 +1,486 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/service/metadata"
+)
+
+const (
+	// recommendSeedLimit is how many recently watched items seed rows.
+	recommendSeedLimit = 5
+
+	// recommendRowLimit caps how many items each row holds.
+	recommendRowLimit = 10
+
+	// Shared people say more about taste than shared genres, so they
+	// weigh heavier in the similarity score.
+	recommendGenreWeight  = 1.0
+	recommendPersonWeight = 2.0
+)
+
+// RecommendationService generates per-user "Because you watched…" rows
+// from genre and people similarity within the library, topped up with
+// TMDb's similar titles. Rows are cached and regenerated by the
+// recommendations task; it doubles as that task's executor.
+type RecommendationService interface {
+	GetRows(ctx context.Context, userID uint) ([]*dto.RecommendationRowDTO, error)
+	RefreshUser(ctx context.Context, userID uint) error
+
+	// scheduler.TaskExecutor
+	Execute(ctx context.Context, config string) error
+	Description() string
+}
+
+type recommendationService struct {
+	appLogger          logger.Logger
+	userRepo           repository.UserRepository
+	watchHistoryRepo   repository.WatchHistoryRepository
+	movieRepo          repository.MovieRepository
+	seriesRepo         repository.SeriesRepository
+	episodeRepo        repository.EpisodeRepository
+	creditRepo         repository.CreditRepository
+	taxonomyRepo       repository.TaxonomyRepository
+	recommendationRepo repository.RecommendationRepository
+	tmdbService        *metadata.TMDbService
+}
+
+func NewRecommendationService(
+	appLogger logger.Logger,
+	userRepo repository.UserRepository,
+	watchHistoryRepo repository.WatchHistoryRepository,
+	movieRepo repository.MovieRepository,
+	seriesRepo repository.SeriesRepository,
+	episodeRepo repository.EpisodeRepository,
+	creditRepo repository.CreditRepository,
+	taxonomyRepo repository.TaxonomyRepository,
+	recommendationRepo repository.RecommendationRepository,
+	tmdbService *metadata.TMDbService,
+) RecommendationService {
+	return &recommendationService{
+		appLogger:          appLogger,
+		userRepo:           userRepo,
+		watchHistoryRepo:   watchHistoryRepo,
+		movieRepo:          movieRepo,
+		seriesRepo:         seriesRepo,
+		episodeRepo:        episodeRepo,
+		creditRepo:         creditRepo,
+		taxonomyRepo:       taxonomyRepo,
+		recommendationRepo: recommendationRepo,
+		tmdbService:        tmdbService,
+	}
+}
+
+// GetRows returns the user's cached rows, generating them on the spot for
+// users the scheduled task has not reached yet.
+func (s *recommendationService) GetRows(ctx context.Context, userID uint) ([]*dto.RecommendationRowDTO, error) {
+	cached, err := s.recommendationRepo.FindByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cached) == 0 {
+		if err := s.RefreshUser(ctx, userID); err != nil {
+			return nil, err
+		}
+		cached, err = s.recommendationRepo.FindByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groupRows(cached), nil
+}
+
+// RefreshUser regenerates and caches the user's rows from their recent
+// watch history.
+func (s *recommendationService) RefreshUser(ctx context.Context, userID uint) error {
+	history, err := s.watchHistoryRepo.GetWatchHistory(ctx, userID, auth.ActiveProfileID(ctx))
+	if err != nil {
+		return err
+	}
+
+	seeds, watched := s.resolveSeeds(ctx, history)
+	if len(seeds) == 0 {
+		return s.recommendationRepo.ReplaceForUser(ctx, userID, nil)
+	}
+
+	genres, err := s.genreIndex(ctx)
+	if err != nil {
+		return err
+	}
+	libraryTMDbIDs, err := s.libraryMovieTMDbIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	var batch []*entity.Recommendation
+	for _, seed := range seeds {
+		row := s.libraryCandidates(ctx, seed, watched, genres)
+		row = append(row, s.tmdbCandidates(ctx, seed, watched, libraryTMDbIDs)...)
+		row = dedupeRow(row)
+		if len(row) > recommendRowLimit {
+			row = row[:recommendRowLimit]
+		}
+		for _, rec := range row {
+			rec.UserID = userID
+			batch = append(batch, rec)
+		}
+	}
+
+	return s.recommendationRepo.ReplaceForUser(ctx, userID, batch)
+}
+
+// Execute regenerates the rows for every user; it is the executor behind
+// the "recommendations" scheduled task.
+func (s *recommendationService) Execute(ctx context.Context, config string) error {
+	users, err := s.userRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	for _, user := range users {
+		if err := s.RefreshUser(ctx, user.ID); err != nil {
+			s.appLogger.Warn().Err(err).Str("user", user.Username).Msg("Failed to refresh recommendations")
+		}
+	}
+	return nil
+}
+
+func (s *recommendationService) Description() string {
+	return "Regenerates personalized recommendation rows for all users."
+}
+
+// seed is a recently watched item recommendations are derived from.
+type seed struct {
+	mediaType string
+	mediaID   uint
+	title     string
+	tmdbID    int
+}
+
+// resolveSeeds picks the most recently watched distinct items (episodes
+// collapse to their series) and returns them together with the set of
+// everything the user has watched, which is never recommended back.
+func (s *recommendationService) resolveSeeds(ctx context.Context, history []entity.WatchHistory) ([]seed, map[string]bool) {
+	watched := make(map[string]bool)
+
+	type candidate struct {
+		seed      seed
+		watchedAt time.Time
+	}
+	latest := make(map[string]candidate)
+
+	for _, h := range history {
+		var sd seed
+		switch h.MediaType {
+		case "movie":
+			movie, err := s.movieRepo.FindByID(ctx, h.MediaID)
+			if err != nil || movie == nil {
+				continue
+			}
+			sd = seed{mediaType: "movie", mediaID: movie.ID, title: movie.Title, tmdbID: movie.TMDbID}
+		case "episode":
+			episode, err := s.episodeRepo.FindEpisodeByID(ctx, h.MediaID)
+			if err != nil || episode == nil {
+				continue
+			}
+			series, err := s.seriesRepo.FindByID(ctx, episode.SeriesID)
+			if err != nil || series == nil {
+				continue
+			}
+			sd = seed{mediaType: "series", mediaID: series.ID, title: series.Title, tmdbID: int(series.TMDbID)}
+		default:
+			continue
+		}
+
+		key := mediaKey(sd.mediaType, sd.mediaID)
+		watched[key] = true
+		if existing, ok := latest[key]; !ok || h.WatchedAt.After(existing.watchedAt) {
+			latest[key] = candidate{seed: sd, watchedAt: h.WatchedAt}
+		}
+	}
+
+	candidates := make([]candidate, 0, len(latest))
+	for _, c := range latest {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].watchedAt.After(candidates[j].watchedAt)
+	})
+	if len(candidates) > recommendSeedLimit {
+		candidates = candidates[:recommendSeedLimit]
+	}
+
+	seeds := make([]seed, 0, len(candidates))
+	for _, c := range candidates {
+		seeds = append(seeds, c.seed)
+	}
+	return seeds, watched
+}
+
+// libraryCandidates scores library items against one seed by shared genres
+// and shared cast/crew.
+func (s *recommendationService) libraryCandidates(ctx context.Context, sd seed, watched map[string]bool, genres *genreIndex) []*entity.Recommendation {
+	scores := make(map[string]float64)
+
+	for _, genreID := range genres.byMedia[mediaKey(sd.mediaType, sd.mediaID)] {
+		for _, key := range genres.byGenre[genreID] {
+			scores[key] += recommendGenreWeight
+		}
+	}
+
+	credits, err := s.creditRepo.FindByMedia(ctx, sd.mediaType, sd.mediaID)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("seed", sd.title).Msg("Failed to load seed credits")
+	}
+	for _, credit := range credits {
+		coCredits, err := s.creditRepo.FindByPerson(ctx, credit.PersonID)
+		if err != nil {
+			continue
+		}
+		for _, co := range coCredits {
+			scores[mediaKey(co.MediaType, co.MediaID)] += recommendPersonWeight
+		}
+	}
+
+	delete(scores, mediaKey(sd.mediaType, sd.mediaID))
+
+	type scored struct {
+		key   string
+		score float64
+	}
+	ranked := make([]scored, 0, len(scores))
+	for key, score := range scores {
+		if watched[key] {
+			continue
+		}
+		ranked = append(ranked, scored{key: key, score: score})
+	}
+	sort.Slice(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+	if len(ranked) > recommendRowLimit {
+		ranked = ranked[:recommendRowLimit]
+	}
+
+	recommendations := make([]*entity.Recommendation, 0, len(ranked))
+	for _, entry := range ranked {
+		rec := s.resolveLibraryItem(ctx, entry.key)
+		if rec == nil {
+			continue
+		}
+		rec.SeedMediaType = sd.mediaType
+		rec.SeedMediaID = sd.mediaID
+		rec.SeedTitle = sd.title
+		rec.Source = "library"
+		rec.Score = entry.score
+		recommendations = append(recommendations, rec)
+	}
+	return recommendations
+}
+
+// tmdbCandidates asks TMDb for titles similar to the seed. Provider
+// failures degrade to library-only rows rather than failing the refresh.
+func (s *recommendationService) tmdbCandidates(ctx context.Context, sd seed, watched map[string]bool, libraryTMDbIDs map[string]uint) []*entity.Recommendation {
+	if sd.tmdbID <= 0 {
+		return nil
+	}
+
+	var recommendations []*entity.Recommendation
+	add := func(mediaType string, tmdbID int, title, posterPath, overview string, score float64) {
+		rec := &entity.Recommendation{
+			SeedMediaType: sd.mediaType,
+			SeedMediaID:   sd.mediaID,
+			SeedTitle:     sd.title,
+			MediaType:     mediaType,
+			TMDbID:        tmdbID,
+			Title:         title,
+			PosterPath:    posterPath,
+			Overview:      overview,
+			Source:        "tmdb",
+			Score:         score,
+		}
+		// Matches against the library get their media ID so clients can
+		// link straight to the item
+		if id, ok := libraryTMDbIDs[fmt.Sprintf("%s:%d", mediaType, tmdbID)]; ok {
+			if watched[mediaKey(mediaType, id)] {
+				return
+			}
+			rec.MediaID = id
+		}
+		recommendations = append(recommendations, rec)
+	}
+
+	switch sd.mediaType {
+	case "movie":
+		similar, err := s.tmdbService.GetSimilarMovies(ctx, sd.tmdbID)
+		if err != nil {
+			s.appLogger.Warn().Err(err).Str("seed", sd.title).Msg("Failed to fetch similar movies")
+			return nil
+		}
+		for _, movie := range similar.Results {
+			add("movie", movie.ID, movie.Title, deref(movie.PosterPath), movie.Overview, movie.VoteAverage)
+		}
+	case "series":
+		similar, err := s.tmdbService.GetSimilarTV(ctx, uint(sd.tmdbID))
+		if err != nil {
+			s.appLogger.Warn().Err(err).Str("seed", sd.title).Msg("Failed to fetch similar series")
+			return nil
+		}
+		for _, series := range similar.Results {
+			add("series", int(series.ID), series.Name, deref(series.PosterPath), series.Overview, series.VoteAverage)
+		}
+	}
+	return recommendations
+}
+
+// resolveLibraryItem turns a media key back into a recommendation skeleton
+// with title and artwork, or nil when the item no longer exists.
+func (s *recommendationService) resolveLibraryItem(ctx context.Context, key string) *entity.Recommendation {
+	mediaType, id := splitMediaKey(key)
+	switch mediaType {
+	case "movie":
+		movie, err := s.movieRepo.FindByID(ctx, id)
+		if err != nil || movie == nil {
+			return nil
+		}
+		return &entity.Recommendation{MediaType: "movie", MediaID: movie.ID, TMDbID: movie.TMDbID, Title: movie.Title, PosterPath: movie.PosterPath, Overview: movie.Overview}
+	case "series":
+		series, err := s.seriesRepo.FindByID(ctx, id)
+		if err != nil || series == nil {
+			return nil
+		}
+		return &entity.Recommendation{MediaType: "series", MediaID: series.ID, TMDbID: int(series.TMDbID), Title: series.Title, PosterPath: series.PosterPath, Overview: series.Overview}
+	default:
+		return nil
+	}
+}
+
+// genreIndex maps media items to their genres and back.
+type genreIndex struct {
+	byMedia map[string][]uint
+	byGenre map[uint][]string
+}
+
+func (s *recommendationService) genreIndex(ctx context.Context) (*genreIndex, error) {
+	links, err := s.taxonomyRepo.FindAllMediaGenres(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := &genreIndex{
+		byMedia: make(map[string][]uint),
+		byGenre: make(map[uint][]string),
+	}
+	for _, link := range links {
+		key := mediaKey(link.MediaType, link.MediaID)
+		index.byMedia[key] = append(index.byMedia[key], link.GenreID)
+		index.byGenre[link.GenreID] = append(index.byGenre[link.GenreID], key)
+	}
+	return index, nil
+}
+
+// libraryMovieTMDbIDs maps "mediaType:tmdbID" to the library item's ID so
+// TMDb suggestions already in the library link to the local item.
+func (s *recommendationService) libraryMovieTMDbIDs(ctx context.Context) (map[string]uint, error) {
+	index := make(map[string]uint)
+
+	movies, err := s.movieRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		if movie.TMDbID > 0 {
+			index[fmt.Sprintf("movie:%d", movie.TMDbID)] = movie.ID
+		}
+	}
+
+	series, err := s.seriesRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, show := range series {
+		if show.TMDbID > 0 {
+			index[fmt.Sprintf("series:%d", show.TMDbID)] = show.ID
+		}
+	}
+	return index, nil
+}
+
+// groupRows turns the flat cache (already ordered by seed, then score)
+// into per-seed rows.
+func groupRows(recommendations []*entity.Recommendation) []*dto.RecommendationRowDTO {
+	rows := make([]*dto.RecommendationRowDTO, 0)
+	byseed := make(map[string]*dto.RecommendationRowDTO)
+
+	for _, rec := range recommendations {
+		key := mediaKey(rec.SeedMediaType, rec.SeedMediaID)
+		row, ok := byseed[key]
+		if !ok {
+			row = &dto.RecommendationRowDTO{Title: fmt.Sprintf("Because you watched %s", rec.SeedTitle)}
+			byseed[key] = row
+			rows = append(rows, row)
+		}
+		row.Items = append(row.Items, &dto.RecommendationItemDTO{
+			MediaType:  rec.MediaType,
+			MediaID:    rec.MediaID,
+			TMDbID:     rec.TMDbID,
+			Title:      rec.Title,
+			PosterPath: rec.PosterPath,
+			Overview:   rec.Overview,
+			InLibrary:  rec.MediaID != 0,
+			Score:      rec.Score,
+		})
+	}
+	return rows
+}
+
+// dedupeRow drops repeated titles within one row, keeping the first (and
+// therefore best-scored library) occurrence.
+func dedupeRow(row []*entity.Recommendation) []*entity.Recommendation {
+	seen := make(map[string]bool)
+	deduped := row[:0]
+	for _, rec := range row {
+		key := mediaKey(rec.MediaType, rec.MediaID)
+		if rec.MediaID == 0 {
+			key = fmt.Sprintf("%s:tmdb:%d", rec.MediaType, rec.TMDbID)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, rec)
+	}
+	return deduped
+}
+
+func mediaKey(mediaType string, id uint) string {
+	return fmt.Sprintf("%s:%d", mediaType, id)
+}
+
+func splitMediaKey(key string) (string, uint) {
+	sep := strings.IndexByte(key, ':')
+	if sep < 0 {
+		return key, 0
+	}
+	id, err := strconv.ParseUint(key[sep+1:], 10, 64)
+	if err != nil {
+		return key[:sep], 0
+	}
+	return key[:sep], uint(id)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,90 @@
+package scanner
+
+import (
+	"context"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Anime releases rarely follow the S01E01 convention; the common shape is
+//
+//	[Group] Show Title - 028v2 [1080p][ABCD1234].mkv
+//
+// a fansub group tag up front, an absolute episode number (no season), an
+// optional vN re-release suffix and quality/checksum tags at the end.
+var (
+	// One or more leading [Group] tags
+	animeLeadingTags = regexp.MustCompile(`^(?:\s*\[[^\]]*\])+\s*`)
+	// Trailing [1080p], (BD) etc. tags, possibly several
+	animeTrailingTags = regexp.MustCompile(`(?:\s*(?:\[[^\]]*\]|\([^)]*\)))+$`)
+	// "Title - 028", "Title 028v2", "Title EP28"
+	animeEpisodeNumber = regexp.MustCompile(`(?i)^(.+?)[\s._-]+(?:ep?\.?\s*)?(\d{1,4})(?:v\d+)?$`)
+)
+
+// extractAnimeInfo parses an anime-style filename into a title and an
+// absolute episode number. It reports false when no episode number is
+// found, so callers can fall back to the warn-and-skip path.
+func extractAnimeInfo(path string) (tvShowInfo, bool) {
+	filename := filepath.Base(path)
+	ext := filepath.Ext(filename)
+	nameOnly := strings.TrimSuffix(filename, ext)
+
+	nameOnly = animeLeadingTags.ReplaceAllString(nameOnly, "")
+	nameOnly = animeTrailingTags.ReplaceAllString(nameOnly, "")
+	nameOnly = strings.TrimSpace(nameOnly)
+
+	matches := animeEpisodeNumber.FindStringSubmatch(nameOnly)
+	if len(matches) != 3 {
+		return tvShowInfo{}, false
+	}
+
+	episode, _ := strconv.Atoi(matches[2])
+	if episode == 0 {
+		return tvShowInfo{}, false
+	}
+
+	// Season 1 is the fallback when the absolute number can't be mapped
+	// against the show's season layout later
+	return tvShowInfo{
+		Title:    cleanTitle(strings.Trim(matches[1], " -")),
+		Season:   1,
+		Episode:  episode,
+		Absolute: true,
+	}, true
+}
+
+// resolveAbsoluteEpisode maps an absolute episode number onto a season and
+// episode using TMDb's per-season episode counts, walking regular seasons
+// in order (specials in season 0 don't count towards absolute numbering).
+// When the show's layout is unknown or the number runs past it, the
+// absolute number stays in season 1.
+func (s *service) resolveAbsoluteEpisode(ctx context.Context, seriesID uint, absolute int) (int, int) {
+	details, err := s.tmdb.GetTVDetails(ctx, seriesID)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Uint("tmdb_id", seriesID).Msg("Failed to fetch season layout for absolute episode mapping")
+		return 1, absolute
+	}
+
+	seasons := make([]int, 0, len(details.Seasons))
+	counts := make(map[int]int, len(details.Seasons))
+	for _, season := range details.Seasons {
+		if season.SeasonNumber <= 0 || season.EpisodeCount <= 0 {
+			continue
+		}
+		seasons = append(seasons, season.SeasonNumber)
+		counts[season.SeasonNumber] = season.EpisodeCount
+	}
+	sort.Ints(seasons)
+
+	remaining := absolute
+	for _, seasonNumber := range seasons {
+		if remaining <= counts[seasonNumber] {
+			return seasonNumber, remaining
+		}
+		remaining -= counts[seasonNumber]
+	}
+	return 1, absolute
+}
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"strings"
+
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+)
+
+// audioLanguages returns the distinct audio track languages in stream order
+// as a comma-separated list, e.g. "jpn,eng" for a dual-audio release.
+func audioLanguages(tracks []ffmpeg.AudioTrackMetadata) string {
+	seen := make(map[string]bool)
+	languages := make([]string, 0, len(tracks))
+	for _, track := range tracks {
+		language := strings.ToLower(strings.TrimSpace(track.Language))
+		if language == "" || seen[language] {
+			continue
+		}
+		seen[language] = true
+		languages = append(languages, language)
+	}
+	return strings.Join(languages, ",")
+}
+
+// hasAudioDescription reports whether any audio track is an audio
+// description for visually impaired viewers, detected from the stream
+// disposition or a title tag.
+func hasAudioDescription(tracks []ffmpeg.AudioTrackMetadata) bool {
+	for _, track := range tracks {
+		if track.Disposition["visual_impaired"] == 1 || track.Disposition["descriptions"] == 1 {
+			return true
+		}
+		title := strings.ToLower(track.Tags["title"])
+		if strings.Contains(title, "audio description") || strings.Contains(title, "descriptive") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSDHSubtitles reports whether any subtitle track carries SDH or closed
+// captions for deaf and hard-of-hearing viewers, detected from the stream
+// disposition, a title tag or a caption codec.
+func hasSDHSubtitles(tracks []ffmpeg.SubtitleTrackMetadata) bool {
+	for _, track := range tracks {
+		if track.Disposition["hearing_impaired"] == 1 || track.Disposition["captions"] == 1 {
+			return true
+		}
+		title := strings.ToLower(track.Tags["title"])
+		if strings.Contains(title, "sdh") || strings.Contains(title, "hearing impaired") {
+			return true
+		}
+		// Broadcast closed-caption streams are SDH by definition
+		if track.CodecName == "eia_608" || track.CodecName == "cea_708" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/pathutil"
+)
+
+// processAudiobookFile imports one audiobook file. Files sharing a parent
+// directory are grouped into one book; embedded chapter markers (m4b) are
+// persisted as-is, and plain files become one chapter each so multi-file
+// books still get a chapter list.
+func (s *service) processAudiobookFile(ctx context.Context, lib *entity.Library, filePath string, report *scanReport) error {
+	// 1. Check if the file already exists (by path)
+	existingFile, err := s.audiobookRepo.FindFileByPath(ctx, filePath)
+	if err != nil {
+		return fmt.Errorf("error checking for existing audiobook file: %w", err)
+	}
+	if existingFile != nil {
+		report.updated++
+		return nil
+	}
+
+	// 2. Extract technical metadata, tags and chapters
+	fileMeta, err := s.mediaExtractor.Extract(ctx, pathutil.Long(filePath))
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to extract audiobook metadata")
+	}
+
+	title := musicTag(fileMeta.Tags, "album")
+	if title == "" {
+		title = filepath.Base(filepath.Dir(filePath))
+	}
+	author := musicTag(fileMeta.Tags, "artist", "album_artist", "author")
+	narrator := musicTag(fileMeta.Tags, "composer", "narrator", "narratedby")
+
+	// 3. Find or create the book
+	book, err := s.audiobookRepo.FindBookByLibraryAndTitle(ctx, lib.ID, title)
+	if err != nil {
+		return fmt.Errorf("error checking for existing audiobook: %w", err)
+	}
+	if book == nil {
+		book = &entity.Audiobook{
+			LibraryID: lib.ID,
+			Title:     title,
+			Author:    author,
+			Narrator:  narrator,
+		}
+		if err := s.audiobookRepo.StoreBook(ctx, book); err != nil {
+			return fmt.Errorf("failed to store audiobook: %w", err)
+		}
+	}
+
+	// 4. Store the file, ordered after the book's existing files unless the
+	// track tag says otherwise
+	position := musicTagNumber(fileMeta.Tags, "track")
+	if position == 0 {
+		existing, err := s.audiobookRepo.CountFiles(ctx, book.ID)
+		if err != nil {
+			return err
+		}
+		position = int(existing) + 1
+	}
+
+	file := &entity.AudiobookFile{
+		LibraryItem: entity.LibraryItem{
+			LibraryID: lib.ID,
+			DateAdded: time.Now(),
+			FilePath:  filePath,
+			Container: fileMeta.Container,
+		},
+		AudiobookID: book.ID,
+		Position:    position,
+		Duration:    int(fileMeta.Duration.Seconds()),
+	}
+	if len(fileMeta.AudioTracks) > 0 {
+		file.LibraryItem.Codec = fileMeta.AudioTracks[0].Codec
+		file.LibraryItem.AudioChannels = fileMeta.AudioTracks[0].Channels
+	}
+	if err := s.audiobookRepo.StoreFile(ctx, file); err != nil {
+		return fmt.Errorf("failed to store audiobook file: %w", err)
+	}
+
+	// 5. Persist chapters: embedded markers when the file has them, one
+	// synthesised chapter for the whole file otherwise
+	chapterOffset, err := s.audiobookRepo.CountChapters(ctx, book.ID)
+	if err != nil {
+		return err
+	}
+	if len(fileMeta.Chapters) > 0 {
+		for i, marker := range fileMeta.Chapters {
+			chapterTitle := marker.Title
+			if chapterTitle == "" {
+				chapterTitle = fmt.Sprintf("Chapter %d", int(chapterOffset)+i+1)
+			}
+			chapter := &entity.AudiobookChapter{
+				AudiobookID: book.ID,
+				FileID:      file.ID,
+				Title:       chapterTitle,
+				Position:    int(chapterOffset) + i + 1,
+				StartSec:    marker.Start,
+				EndSec:      marker.End,
+			}
+			if err := s.audiobookRepo.StoreChapter(ctx, chapter); err != nil {
+				return fmt.Errorf("failed to store audiobook chapter: %w", err)
+			}
+		}
+	} else {
+		chapterTitle := musicTag(fileMeta.Tags, "title")
+		if chapterTitle == "" {
+			chapterTitle = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+		}
+		chapter := &entity.AudiobookChapter{
+			AudiobookID: book.ID,
+			FileID:      file.ID,
+			Title:       chapterTitle,
+			Position:    int(chapterOffset) + 1,
+			StartSec:    0,
+			EndSec:      fileMeta.Duration.Seconds(),
+		}
+		if err := s.audiobookRepo.StoreChapter(ctx, chapter); err != nil {
+			return fmt.Errorf("failed to store audiobook chapter: %w", err)
+		}
+	}
+
+	// 6. Keep the book's total duration and metadata current
+	book.Duration += file.Duration
+	if book.Author == "" {
+		book.Author = author
+	}
+	if book.Narrator == "" {
+		book.Narrator = narrator
+	}
+	if err := s.audiobookRepo.UpdateBook(ctx, book); err != nil {
+		return err
+	}
+
+	report.added++
+	s.bus.Publish(ctx, events.ItemCreated, events.ItemPayload{
+		LibraryID: lib.ID,
+		ItemID:    file.LibraryItem.ID,
+		MediaType: "audiobook",
+		Path:      filePath,
+	})
+
+	return nil
+}
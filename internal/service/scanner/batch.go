@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"context"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+)
+
+// scanBatchSize is how many buffered items accumulate before a flush;
+// large enough to amortise the insert round trips, small enough that a
+// crash mid-scan loses little work.
+const scanBatchSize = 200
+
+// queueMovie buffers a movie for batch insert, flushing when the buffer
+// is full. Only movies without follow-up work (no TMDb extras to pull)
+// queue here; matched movies insert individually because the extras need
+// the stored row.
+func (s *service) queueMovie(ctx context.Context, state *walkState, movie *entity.Movie) error {
+	state.pendingMovies = append(state.pendingMovies, movie)
+	if len(state.pendingMovies) >= scanBatchSize {
+		return s.flushMovies(ctx, state)
+	}
+	return nil
+}
+
+// queueEpisode buffers an episode for batch insert, flushing when the
+// buffer is full.
+func (s *service) queueEpisode(ctx context.Context, state *walkState, episode *entity.Episode) error {
+	state.pendingEpisodes = append(state.pendingEpisodes, episode)
+	if len(state.pendingEpisodes) >= scanBatchSize {
+		return s.flushEpisodes(ctx, state)
+	}
+	return nil
+}
+
+// flushBatches drains both buffers; call it when a walk finishes so
+// nothing queued is left behind.
+func (s *service) flushBatches(ctx context.Context, state *walkState) {
+	if err := s.flushMovies(ctx, state); err != nil {
+		s.appLogger.Error().Err(err).Msg("Failed to flush buffered movies")
+	}
+	if err := s.flushEpisodes(ctx, state); err != nil {
+		s.appLogger.Error().Err(err).Msg("Failed to flush buffered episodes")
+	}
+}
+
+func (s *service) flushMovies(ctx context.Context, state *walkState) error {
+	batch := state.pendingMovies
+	if len(batch) == 0 {
+		return nil
+	}
+	state.pendingMovies = nil
+
+	if err := s.movieRepo.StoreBatch(ctx, batch); err != nil {
+		state.report.errors += len(batch)
+		return err
+	}
+
+	state.report.added += len(batch)
+	for _, movie := range batch {
+		s.bus.Publish(ctx, events.ItemCreated, events.ItemPayload{
+			LibraryID: movie.LibraryItem.LibraryID,
+			ItemID:    movie.LibraryItem.ID,
+			MediaType: "movie",
+			Path:      movie.LibraryItem.FilePath,
+		})
+	}
+	return nil
+}
+
+func (s *service) flushEpisodes(ctx context.Context, state *walkState) error {
+	batch := state.pendingEpisodes
+	if len(batch) == 0 {
+		return nil
+	}
+	state.pendingEpisodes = nil
+
+	if err := s.episodeRepo.AddEpisodeBatch(ctx, batch); err != nil {
+		state.report.errors += len(batch)
+		return err
+	}
+
+	state.report.added += len(batch)
+	for _, episode := range batch {
+		s.bus.Publish(ctx, events.ItemCreated, events.ItemPayload{
+			LibraryID: episode.LibraryItem.LibraryID,
+			ItemID:    episode.LibraryItem.ID,
+			MediaType: "episode",
+			Path:      episode.LibraryItem.FilePath,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// storeMovieCollection links a movie to its TMDb collection, creating the
+// collection locally the first time a member is scanned.
+func (s *service) storeMovieCollection(ctx context.Context, movie *entity.Movie) error {
+	tmdbCollection, err := s.tmdb.GetMovieCollection(ctx, movie.TMDbID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch movie collection: %w", err)
+	}
+	if tmdbCollection == nil {
+		return nil
+	}
+
+	collection := &entity.Collection{
+		TMDbID: tmdbCollection.ID,
+		Name:   tmdbCollection.Name,
+	}
+	if tmdbCollection.PosterPath != nil {
+		collection.PosterPath = *tmdbCollection.PosterPath
+	}
+	if tmdbCollection.BackdropPath != nil {
+		collection.BackdropPath = *tmdbCollection.BackdropPath
+	}
+
+	stored, err := s.collectionRepo.FindOrCreate(ctx, collection)
+	if err != nil {
+		return fmt.Errorf("failed to store collection '%s': %w", collection.Name, err)
+	}
+
+	movie.CollectionID = stored.ID
+	return s.movieRepo.Update(ctx, movie)
+}
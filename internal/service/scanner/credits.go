@@ -0,0 +1,103 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service/metadata"
+)
+
+// storeCredits replaces the stored cast and crew for a media item with the
+// credits returned by TMDb, creating person records as needed.
+func (s *service) storeCredits(ctx context.Context, mediaType string, mediaID uint, credits *metadata.CreditsResult) error {
+	if err := s.creditRepo.DeleteByMedia(ctx, mediaType, mediaID); err != nil {
+		return fmt.Errorf("failed to clear existing credits: %w", err)
+	}
+
+	for _, cast := range credits.Cast {
+		person, err := s.findOrCreatePerson(ctx, cast.ID, cast.Name, cast.ProfilePath)
+		if err != nil {
+			s.appLogger.Warn().Err(err).Str("name", cast.Name).Msg("Failed to store cast member")
+			continue
+		}
+
+		credit := &entity.Credit{
+			PersonID:   person.ID,
+			MediaType:  mediaType,
+			MediaID:    mediaID,
+			PersonType: entity.PersonTypeActor,
+			Character:  cast.Character,
+			CastOrder:  cast.Order,
+		}
+		if err := s.creditRepo.Store(ctx, credit); err != nil {
+			s.appLogger.Warn().Err(err).Str("name", cast.Name).Msg("Failed to store cast credit")
+		}
+	}
+
+	for _, crew := range credits.Crew {
+		person, err := s.findOrCreatePerson(ctx, crew.ID, crew.Name, crew.ProfilePath)
+		if err != nil {
+			s.appLogger.Warn().Err(err).Str("name", crew.Name).Msg("Failed to store crew member")
+			continue
+		}
+
+		credit := &entity.Credit{
+			PersonID:   person.ID,
+			MediaType:  mediaType,
+			MediaID:    mediaID,
+			PersonType: crewPersonType(crew),
+			Department: crew.Department,
+			Job:        crew.Job,
+		}
+		if err := s.creditRepo.Store(ctx, credit); err != nil {
+			s.appLogger.Warn().Err(err).Str("name", crew.Name).Msg("Failed to store crew credit")
+		}
+	}
+
+	return nil
+}
+
+func (s *service) findOrCreatePerson(ctx context.Context, tmdbID int, name string, profilePath *string) (*entity.Person, error) {
+	person, err := s.personRepo.FindByTMDbID(ctx, tmdbID)
+	if err != nil {
+		return nil, err
+	}
+	if person != nil {
+		return person, nil
+	}
+
+	person = &entity.Person{
+		TMDbID:      tmdbID,
+		Name:        name,
+		ProfilePath: getPtrValue(profilePath),
+	}
+	if err := s.personRepo.Store(ctx, person); err != nil {
+		return nil, err
+	}
+	return person, nil
+}
+
+func crewPersonType(crew metadata.CrewMember) entity.PersonType {
+	switch crew.Job {
+	case "Director":
+		return entity.PersonTypeDirector
+	case "Producer", "Executive Producer":
+		return entity.PersonTypeProducer
+	case "Editor":
+		return entity.PersonTypeEditor
+	}
+
+	switch crew.Department {
+	case "Writing":
+		return entity.PersonTypeWriter
+	case "Directing":
+		return entity.PersonTypeDirector
+	case "Production":
+		return entity.PersonTypeProducer
+	case "Editing":
+		return entity.PersonTypeEditor
+	}
+
+	return entity.PersonTypeUnknown
+}
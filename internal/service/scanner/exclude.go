@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samcharles93/cinea/config"
+)
+
+// Junk commonly shipped alongside real media: sample clips and trailers.
+// Patterns match the whole filename or its last separated token, so
+// "Movie.2023.Sample.mkv" is skipped while "Trailer Park Boys S01E01"
+// is not.
+var defaultIgnorePatterns = []string{"sample", "trailer"}
+
+// ignoredDirs are directory names never descended into: NAS thumbnail
+// stores, recycle bins and similar metadata trees.
+var ignoredDirs = map[string]bool{
+	"@eadir":       true,
+	"#recycle":     true,
+	".recycle":     true,
+	"$recycle.bin": true,
+	"lost+found":   true,
+}
+
+// excludeRules decides which files a scan should skip as junk, built from
+// the scanner config with built-in defaults.
+type excludeRules struct {
+	patterns []string
+	minSize  int64 // bytes; 0 disables the size filter
+}
+
+func newExcludeRules(cfg *config.Config) *excludeRules {
+	return &excludeRules{
+		patterns: withDefaults(cfg.Jobs.Scanner.IgnorePatterns, defaultIgnorePatterns),
+		minSize:  int64(cfg.Jobs.Scanner.MinFileSizeMB) * 1024 * 1024,
+	}
+}
+
+// skipDir reports whether a directory should not be walked at all:
+// dot-directories and the well-known metadata/recycle trees.
+func skipDir(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+	return ignoredDirs[strings.ToLower(name)]
+}
+
+// exclude reports whether a file should be skipped, with the matched rule
+// for the scan log. isVideo gates the size filter so small but legitimate
+// audio and image files aren't caught by it.
+func (e *excludeRules) exclude(info os.FileInfo, path string, isVideo bool) (string, bool) {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") {
+		return "dotfile", true
+	}
+
+	lower := strings.ToLower(strings.TrimSuffix(name, filepath.Ext(name)))
+	for _, pattern := range e.patterns {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		// Whole name or trailing token only; a pattern appearing mid-title
+		// ("Trailer Park Boys") must not match
+		if lower == pattern ||
+			strings.HasSuffix(lower, "-"+pattern) ||
+			strings.HasSuffix(lower, "."+pattern) ||
+			strings.HasSuffix(lower, "_"+pattern) ||
+			strings.HasSuffix(lower, " "+pattern) {
+			return "matches ignore pattern '" + pattern + "'", true
+		}
+	}
+
+	if isVideo && e.minSize > 0 && info.Size() < e.minSize {
+		return "below minimum file size", true
+	}
+	return "", false
+}
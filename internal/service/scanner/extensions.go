@@ -0,0 +1,136 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// mediaFileType is the handling strategy for a file extension.
+type mediaFileType string
+
+const (
+	fileTypeVideo  mediaFileType = "video"
+	fileTypeAudio  mediaFileType = "audio"
+	fileTypeImage  mediaFileType = "image"
+	fileTypeBook   mediaFileType = "book"
+	fileTypeIgnore mediaFileType = "ignore"
+)
+
+var defaultVideoExtensions = []string{
+	".mp4", ".mkv", ".avi", ".mov", ".m4v", ".webm",
+	".wmv", ".flv", ".ts", ".m2ts",
+}
+
+var defaultAudioExtensions = []string{
+	".mp3", ".flac", ".m4a", ".m4b", ".aac", ".ogg", ".opus", ".wav",
+}
+
+var defaultImageExtensions = []string{
+	".jpg", ".jpeg", ".png", ".webp", ".gif", ".heic",
+}
+
+var defaultBookExtensions = []string{
+	".epub", ".mobi", ".azw3", ".pdf", ".cbz", ".cbr",
+}
+
+// extensionSet resolves file extensions to a media type, built from the
+// scanner config with built-in defaults for each media type.
+type extensionSet struct {
+	types map[string]mediaFileType
+}
+
+func newExtensionSet(cfg *config.Config) *extensionSet {
+	set := &extensionSet{
+		types: make(map[string]mediaFileType),
+	}
+
+	set.add(withDefaults(cfg.Jobs.Scanner.VideoExtensions, defaultVideoExtensions), fileTypeVideo)
+	set.add(withDefaults(cfg.Jobs.Scanner.AudioExtensions, defaultAudioExtensions), fileTypeAudio)
+	set.add(withDefaults(cfg.Jobs.Scanner.ImageExtensions, defaultImageExtensions), fileTypeImage)
+	set.add(withDefaults(cfg.Jobs.Scanner.BookExtensions, defaultBookExtensions), fileTypeBook)
+
+	// Custom mappings take precedence over the extension lists
+	for ext, fileType := range cfg.Jobs.Scanner.ExtensionTypes {
+		set.types[normalizeExt(ext)] = mediaFileType(fileType)
+	}
+
+	return set
+}
+
+func withDefaults(configured, defaults []string) []string {
+	if len(configured) > 0 {
+		return configured
+	}
+	return defaults
+}
+
+func (e *extensionSet) add(extensions []string, fileType mediaFileType) {
+	for _, ext := range extensions {
+		e.types[normalizeExt(ext)] = fileType
+	}
+}
+
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// typeOf returns the media type mapped to the file's extension, or an empty
+// string when the extension is unknown or mapped to ignore.
+func (e *extensionSet) typeOf(path string) mediaFileType {
+	fileType, ok := e.types[strings.ToLower(filepath.Ext(path))]
+	if !ok || fileType == fileTypeIgnore {
+		return ""
+	}
+	return fileType
+}
+
+// MediaFileChecker reports whether a path carries a media extension,
+// built from the same config-driven extension sets the scanner walks
+// with, so other subsystems (cleanup) agree with the scanner on what
+// counts as media.
+type MediaFileChecker struct {
+	set *extensionSet
+}
+
+func NewMediaFileChecker(cfg *config.Config) *MediaFileChecker {
+	return &MediaFileChecker{set: newExtensionSet(cfg)}
+}
+
+// IsVideo reports whether the path has a video extension.
+func (c *MediaFileChecker) IsVideo(path string) bool {
+	return c.set.typeOf(path) == fileTypeVideo
+}
+
+// shouldScan reports whether the file belongs in the library, taking the
+// library's extra extensions into account before the configured sets. The
+// path's content type override wins over the library type when set.
+func (s *service) shouldScan(lib *entity.Library, pathType entity.LibraryType, path string) bool {
+	for _, ext := range strings.Split(lib.ExtraExtensions, ",") {
+		if ext = normalizeExt(ext); ext != "" && ext == strings.ToLower(filepath.Ext(path)) {
+			return true
+		}
+	}
+
+	effective := pathType
+	if effective == "" {
+		effective = lib.Type
+	}
+
+	switch effective {
+	case entity.LibraryTypeMovie, entity.LibraryTypeTV:
+		return s.extensions.typeOf(path) == fileTypeVideo
+	case entity.LibraryTypeMusic, entity.LibraryTypeAudiobook:
+		return s.extensions.typeOf(path) == fileTypeAudio
+	case entity.LibraryTypePhotos:
+		return s.extensions.typeOf(path) == fileTypeImage
+	default:
+		return false
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileID returns a stable identifier for the underlying file so hardlinked
+// copies can be detected. Returns an empty string when the platform stat
+// info is unavailable.
+func fileID(info os.FileInfo) string {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", stat.Dev, stat.Ino)
+}
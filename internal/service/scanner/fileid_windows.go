@@ -0,0 +1,14 @@
+//go:build windows
+
+package scanner
+
+import (
+	"os"
+)
+
+// fileID returns a stable identifier for the underlying file so hardlinked
+// copies can be detected. Windows file IDs aren't exposed through os.FileInfo,
+// so hardlink detection is unavailable there.
+func fileID(info os.FileInfo) string {
+	return ""
+}
@@ -0,0 +1,71 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/samcharles93/cinea/internal/pathutil"
+)
+
+// hashChunkSize is how much of each end of a file goes into the content
+// hash. Hashing the head and tail plus the size identifies duplicate
+// media without reading multi-gigabyte files end to end.
+const hashChunkSize = 64 * 1024
+
+// contentHash fingerprints a media file for duplicate detection. The
+// hash covers the file size and the first and last hashChunkSize bytes,
+// so two copies of the same release match regardless of their paths.
+func contentHash(filePath string) (string, int64, error) {
+	f, err := os.Open(pathutil.Long(filePath))
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to open file for hashing: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat file for hashing: %w", err)
+	}
+	size := info.Size()
+
+	h := sha256.New()
+	binary.Write(h, binary.LittleEndian, size)
+
+	if _, err := io.CopyN(h, f, min(size, hashChunkSize)); err != nil && err != io.EOF {
+		return "", 0, fmt.Errorf("failed to hash file head: %w", err)
+	}
+
+	if size > hashChunkSize {
+		if _, err := f.Seek(-hashChunkSize, io.SeekEnd); err != nil {
+			return "", 0, fmt.Errorf("failed to seek to file tail: %w", err)
+		}
+		if _, err := io.CopyN(h, f, hashChunkSize); err != nil && err != io.EOF {
+			return "", 0, fmt.Errorf("failed to hash file tail: %w", err)
+		}
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil)), size, nil
+}
+
+// fileGone reports whether an item's stored file no longer exists, the
+// precondition for treating a matching hash elsewhere as a rename.
+func fileGone(filePath string) bool {
+	_, err := os.Stat(pathutil.Long(filePath))
+	return os.IsNotExist(err)
+}
+
+// stampContentHash fills in an item's content hash and size, logging and
+// moving on when the file can't be read; a missing hash only excludes
+// the item from duplicate detection.
+func (s *service) stampContentHash(filePath string, hash *string, fileSize *int64) {
+	computed, size, err := contentHash(filePath)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to compute content hash")
+		return
+	}
+	*hash = computed
+	*fileSize = size
+}
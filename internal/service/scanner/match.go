@@ -0,0 +1,224 @@
+package scanner
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/samcharles93/cinea/internal/service/metadata"
+)
+
+// maxScoredCandidates caps how many search results get scored per file.
+const maxScoredCandidates = 5
+
+// pickMovieMatch scores the TMDb search results against the filename info
+// and the probed runtime, and returns the best candidate when it clears
+// the configured confidence threshold. Low-confidence matches return nil
+// so the item goes to the manual identify queue instead.
+func (s *service) pickMovieMatch(ctx context.Context, movieInfo mediaInfo, probedRuntime time.Duration, results []metadata.Movie) *metadata.Movie {
+	var best *metadata.Movie
+	var bestScore float64
+
+	for i := range results {
+		if i == maxScoredCandidates {
+			break
+		}
+		candidate := &results[i]
+		year := ""
+		if len(candidate.ReleaseDate) >= 4 {
+			year = candidate.ReleaseDate[:4]
+		}
+		score := 0.75*titleSimilarity(movieInfo.Title, candidate.Title) + 0.25*yearScore(movieInfo.Year, year)
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	// Compare the probed duration against the candidate's runtime; a large
+	// mismatch usually means a sample, extra or wrong movie
+	if probedRuntime > 0 {
+		if details, err := s.tmdb.GetMovieDetails(ctx, best.ID); err == nil && details.Runtime > 0 {
+			bestScore = 0.8*bestScore + 0.2*runtimeScore(probedRuntime, details.Runtime)
+		}
+	}
+
+	threshold := s.config.ScannerMatchConfidence()
+	if bestScore < threshold {
+		s.appLogger.Info().
+			Str("title", movieInfo.Title).
+			Str("candidate", best.Title).
+			Float64("score", bestScore).
+			Float64("threshold", threshold).
+			Msg("Best TMDb match below confidence threshold, queueing for manual identify")
+		return nil
+	}
+	return best
+}
+
+// pickSeriesMatch scores the TMDb search results against the filename-derived
+// show title and returns the best candidate above the confidence threshold.
+func (s *service) pickSeriesMatch(tvTitle string, results []metadata.Series) *metadata.Series {
+	var best *metadata.Series
+	var bestScore float64
+
+	for i := range results {
+		if i == maxScoredCandidates {
+			break
+		}
+		candidate := &results[i]
+		score := titleSimilarity(tvTitle, candidate.Name)
+		if original := titleSimilarity(tvTitle, candidate.OriginalName); original > score {
+			score = original
+		}
+		if score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	threshold := s.config.ScannerMatchConfidence()
+	if bestScore < threshold {
+		s.appLogger.Info().
+			Str("title", tvTitle).
+			Str("candidate", best.Name).
+			Float64("score", bestScore).
+			Float64("threshold", threshold).
+			Msg("Best TMDb match below confidence threshold, queueing for manual identify")
+		return nil
+	}
+	return best
+}
+
+// titleSimilarity returns a normalized Levenshtein similarity between two
+// titles, 1 being identical after normalization.
+func titleSimilarity(a, b string) float64 {
+	a, b = normalizeTitle(a), normalizeTitle(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	distance := levenshtein(a, b)
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	return 1 - float64(distance)/float64(longest)
+}
+
+// normalizeTitle lowercases, strips punctuation and collapses whitespace so
+// "Se7en." and "se7en" compare equal.
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	lastSpace := true
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			lastSpace = false
+		case !lastSpace:
+			b.WriteRune(' ')
+			lastSpace = true
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// yearScore rewards exact year matches and tolerates being one year off,
+// which is common for festival releases and region differences.
+func yearScore(wanted, candidate string) float64 {
+	if wanted == "" {
+		// No year in the filename: neither reward nor punish
+		return 0.5
+	}
+	if candidate == "" {
+		return 0.25
+	}
+
+	wantedYear, err1 := strconv.Atoi(wanted)
+	candidateYear, err2 := strconv.Atoi(candidate)
+	if err1 != nil || err2 != nil {
+		return 0.25
+	}
+
+	switch diff := absInt(wantedYear - candidateYear); diff {
+	case 0:
+		return 1
+	case 1:
+		return 0.7
+	case 2:
+		return 0.3
+	default:
+		return 0
+	}
+}
+
+// runtimeScore compares the probed duration with the TMDb runtime.
+func runtimeScore(probed time.Duration, runtimeMinutes int) float64 {
+	probedMinutes := probed.Minutes()
+	if probedMinutes <= 0 {
+		return 0.5
+	}
+
+	diff := probedMinutes - float64(runtimeMinutes)
+	if diff < 0 {
+		diff = -diff
+	}
+	ratio := diff / float64(runtimeMinutes)
+	switch {
+	case ratio <= 0.1:
+		return 1
+	case ratio <= 0.25:
+		return 0.6
+	default:
+		return 0
+	}
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(prev[j]+1, current[j-1]+1, prev[j-1]+cost)
+		}
+		prev, current = current, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -3,13 +3,20 @@ package scanner
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/ffmpeg"
+	"github.com/samcharles93/cinea/internal/pathutil"
 	"github.com/samcharles93/cinea/internal/service/metadata"
 )
 
-func (s *service) processMovieFile(ctx context.Context, lib *entity.Library, filePath string) error {
+func (s *service) processMovieFile(ctx context.Context, lib *entity.Library, filePath string, state *walkState) error {
+	report := state.report
+
 	// 1. Check if the movie already exists (by path)
 	existingMovie, err := s.movieRepo.FindByPath(ctx, filePath)
 	if err != nil {
@@ -18,33 +25,83 @@ func (s *service) processMovieFile(ctx context.Context, lib *entity.Library, fil
 
 	if existingMovie != nil {
 		existingMovie.LastScanned = time.Now()
-		return s.movieRepo.Update(ctx, existingMovie)
+		// Backfill the content hash for items imported before hashing
+		if existingMovie.ContentHash == "" {
+			s.stampContentHash(filePath, &existingMovie.ContentHash, &existingMovie.FileSize)
+		}
+		if err := s.movieRepo.Update(ctx, existingMovie); err != nil {
+			return err
+		}
+		report.updated++
+		return nil
 	}
 
-	// 2. Extract metadata
-	fileMeta, err := s.mediaExtractor.Extract(ctx, filePath)
+	// A path already imported as an alternate version needs no re-import
+	existingVersion, err := s.movieRepo.FindVersionByPath(ctx, filePath)
 	if err != nil {
-		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to extract movie metadata")
+		return fmt.Errorf("error checking for existing movie version: %w", err)
+	}
+	if existingVersion != nil {
+		report.updated++
+		return nil
 	}
 
-	// 3. Extract movie info (title, year) from the filename.
-	movieInfo := extractMovieInfo(filePath)
+	// 2. Fingerprint the file; a known hash whose old path vanished means
+	// the file was renamed or moved, so the existing entity is re-pointed
+	// rather than deleted and re-imported, keeping watch history, ratings
+	// and manual matches attached
+	var contentHash string
+	var fileSize int64
+	s.stampContentHash(filePath, &contentHash, &fileSize)
+	if moved, err := s.relocateMovie(ctx, filePath, contentHash); err != nil {
+		return err
+	} else if moved {
+		report.moved++
+		return nil
+	}
 
-	// 4. Search TMDb
-	searchResult, err := s.tmdb.SearchMovie(ctx, movieInfo.Title, metadata.WithMovieYear(movieInfo.Year))
+	// 3. Extract metadata
+	fileMeta, err := s.mediaExtractor.Extract(ctx, pathutil.Long(filePath))
 	if err != nil {
-		s.appLogger.Error().Err(err).Str("title", movieInfo.Title).Str("year", movieInfo.Year).Msg("TMDb search failed")
+		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to extract movie metadata")
 	}
+
+	// 4. Extract movie info (title, year) from the filename.
+	movieInfo := extractMovieInfo(filePath)
+
+	// 5. Search TMDb, unless metadata matching is deferred to the queue
 	var tmdbMovie *metadata.Movie
+	if !s.config.Jobs.Scanner.DeferMetadata {
+		searchResult, err := s.tmdb.SearchMovie(ctx, movieInfo.Title, metadata.WithMovieYear(movieInfo.Year))
+		if err != nil {
+			s.appLogger.Error().Err(err).Str("title", movieInfo.Title).Str("year", movieInfo.Year).Msg("TMDb search failed")
+		}
 
-	if searchResult != nil && len(searchResult.Results) > 0 {
-		tmdbMovie = &searchResult.Results[0]
-		s.appLogger.Info().Str("title", tmdbMovie.Title).Int("tmdb_id", tmdbMovie.ID).Msg("Found movie on TMDb")
-	} else {
-		s.appLogger.Warn().Str("title", movieInfo.Title).Str("year", movieInfo.Year).Msg("No results found on TMDb")
+		if searchResult != nil && len(searchResult.Results) > 0 {
+			tmdbMovie = s.pickMovieMatch(ctx, movieInfo, fileMeta.Duration, searchResult.Results)
+			if tmdbMovie != nil {
+				s.appLogger.Info().Str("title", tmdbMovie.Title).Int("tmdb_id", tmdbMovie.ID).Msg("Found movie on TMDb")
+			}
+		} else {
+			s.appLogger.Warn().Str("title", movieInfo.Title).Str("year", movieInfo.Year).Msg("No results found on TMDb")
+		}
 	}
 
-	// 5. Create and store the movie entity
+	// 6. The same film in another resolution or edition groups under the
+	// already imported movie as a selectable version rather than creating
+	// a duplicate movie row
+	if tmdbMovie != nil {
+		grouped, err := s.groupMovieVersion(ctx, lib, filePath, fileMeta, contentHash, fileSize, tmdbMovie.ID)
+		if err != nil {
+			return err
+		}
+		if grouped {
+			report.added++
+			return nil
+		}
+	}
+
+	// 7. Create and store the movie entity
 	movie := &entity.Movie{
 		LibraryItem: entity.LibraryItem{
 			LibraryID:        lib.ID,
@@ -54,42 +111,224 @@ func (s *service) processMovieFile(ctx context.Context, lib *entity.Library, fil
 			Codec:            fileMeta.Codec,
 			ResolutionWidth:  fileMeta.ResolutionWidth,
 			ResolutionHeight: fileMeta.ResolutionHeight,
+			ContentHash:      contentHash,
+			FileSize:         fileSize,
 		},
 		LastScanned: time.Now(),
 	}
 	if len(fileMeta.AudioTracks) > 0 {
 		movie.LibraryItem.AudioChannels = fileMeta.AudioTracks[0].Channels
+		movie.LibraryItem.AudioLanguages = audioLanguages(fileMeta.AudioTracks)
 	}
+	movie.LibraryItem.HasAudioDescription = hasAudioDescription(fileMeta.AudioTracks)
+	movie.LibraryItem.HasSDHSubtitles = hasSDHSubtitles(fileMeta.SubtitleTracks)
 
 	// If we found a match on TMDb, populate more fields.
-	if tmdbMovie != nil {
-		movie.Title = tmdbMovie.Title
-		movie.OriginalTitle = tmdbMovie.OriginalTitle
-		movie.TMDbID = tmdbMovie.ID
-		movie.Overview = tmdbMovie.Overview
-		if tmdbMovie.ReleaseDate != "" {
-			releaseDate, err := time.Parse("2006-01-02", tmdbMovie.ReleaseDate)
-			if err == nil {
-				movie.ReleaseDate = releaseDate
-			} else {
-				s.appLogger.Warn().Err(err).Str("date_str", tmdbMovie.ReleaseDate).Msg("Failed to parse release date")
-			}
-		}
-		if tmdbMovie.BackdropPath != nil {
-			movie.BackdropPath = *tmdbMovie.BackdropPath
-		}
-		if tmdbMovie.PosterPath != nil {
-			movie.PosterPath = *tmdbMovie.PosterPath
-		}
-		movie.VoteAverage = tmdbMovie.VoteAverage
-		movie.VoteCount = tmdbMovie.VoteCount
-	} else {
+	if tmdbMovie == nil {
 		movie.Title = movieInfo.Title
+		// Mark for the deferred matching queue so the item isn't left
+		// metadata-less forever
+		movie.MetadataPending = true
+		report.unmatched++
+
+		// Nothing more to fetch for this movie, so it can ride a batch
+		// insert instead of paying for its own round trip
+		if err := s.queueMovie(ctx, state, movie); err != nil {
+			return fmt.Errorf("failed to store movie batch: %w", err)
+		}
+		return nil
 	}
 
+	s.applyMovieMatch(movie, tmdbMovie)
+
 	if err := s.movieRepo.Store(ctx, movie); err != nil {
 		return fmt.Errorf("failed to store movie: %w", err)
 	}
+	report.added++
+	s.bus.Publish(ctx, events.ItemCreated, events.ItemPayload{
+		LibraryID: lib.ID,
+		ItemID:    movie.LibraryItem.ID,
+		MediaType: "movie",
+		Path:      filePath,
+	})
+
+	// 8. Pull cast, crew, genres and studios for the matched movie
+	s.storeMovieExtras(ctx, movie, tmdbMovie)
 
 	return nil
 }
+
+// relocateMovie re-points an existing movie at its file's new path when
+// the file was renamed or moved: same content hash, old path gone. The
+// entity keeps its ID, so watch history, ratings and manual metadata
+// matches survive the move.
+func (s *service) relocateMovie(ctx context.Context, filePath, contentHash string) (bool, error) {
+	if contentHash == "" {
+		return false, nil
+	}
+
+	candidates, err := s.movieRepo.FindByContentHash(ctx, contentHash)
+	if err != nil {
+		return false, fmt.Errorf("error checking for moved movie: %w", err)
+	}
+
+	for _, movie := range candidates {
+		if !fileGone(movie.FilePath) {
+			continue
+		}
+		oldPath := movie.FilePath
+		movie.FilePath = pathutil.Canonical(filePath)
+		movie.LastScanned = time.Now()
+		if err := s.movieRepo.Update(ctx, movie); err != nil {
+			return false, fmt.Errorf("failed to update moved movie: %w", err)
+		}
+		s.appLogger.Info().
+			Str("title", movie.Title).
+			Str("from", oldPath).
+			Str("to", filePath).
+			Msg("Detected moved movie file")
+		return true, nil
+	}
+	return false, nil
+}
+
+// groupMovieVersion attaches the file to an already imported movie with
+// the same TMDb identity as an alternate version. It reports false when no
+// such movie exists and a new movie row should be created instead.
+func (s *service) groupMovieVersion(ctx context.Context, lib *entity.Library, filePath string, fileMeta *ffmpeg.MediaMetadata, contentHash string, fileSize int64, tmdbID int) (bool, error) {
+	existing, err := s.movieRepo.FindByTMDbID(ctx, tmdbID)
+	if err != nil {
+		return false, fmt.Errorf("error checking for existing movie by TMDb ID: %w", err)
+	}
+	if existing == nil {
+		return false, nil
+	}
+
+	version := &entity.MovieVersion{
+		LibraryItem: entity.LibraryItem{
+			LibraryID:        lib.ID,
+			DateAdded:        time.Now(),
+			FilePath:         filePath,
+			Container:        fileMeta.Container,
+			Codec:            fileMeta.Codec,
+			ResolutionWidth:  fileMeta.ResolutionWidth,
+			ResolutionHeight: fileMeta.ResolutionHeight,
+			ContentHash:      contentHash,
+			FileSize:         fileSize,
+		},
+		MovieID: existing.ID,
+		Label:   versionLabel(filePath, fileMeta.ResolutionHeight),
+	}
+	if len(fileMeta.AudioTracks) > 0 {
+		version.LibraryItem.AudioChannels = fileMeta.AudioTracks[0].Channels
+		version.LibraryItem.AudioLanguages = audioLanguages(fileMeta.AudioTracks)
+	}
+
+	if err := s.movieRepo.AddVersion(ctx, version); err != nil {
+		return false, fmt.Errorf("failed to store movie version: %w", err)
+	}
+	s.appLogger.Info().
+		Str("title", existing.Title).
+		Str("label", version.Label).
+		Str("path", filePath).
+		Msg("Grouped file as alternate movie version")
+	return true, nil
+}
+
+// versionLabel derives a human-readable version name: an edition keyword
+// from the filename when one is present, the resolution bucket otherwise.
+func versionLabel(filePath string, height int) string {
+	lower := strings.ToLower(filepath.Base(filePath))
+	lower = strings.NewReplacer(".", " ", "_", " ", "'", "").Replace(lower)
+	editions := []struct{ token, label string }{
+		{"directors cut", "Director's Cut"},
+		{"director s cut", "Director's Cut"},
+		{"extended", "Extended"},
+		{"theatrical", "Theatrical"},
+		{"unrated", "Unrated"},
+		{"remastered", "Remastered"},
+		{"imax", "IMAX"},
+	}
+	for _, edition := range editions {
+		if strings.Contains(lower, edition.token) {
+			return edition.label
+		}
+	}
+
+	switch {
+	case height >= 2000:
+		return "2160p"
+	case height >= 1000:
+		return "1080p"
+	case height >= 700:
+		return "720p"
+	case height > 0:
+		return "SD"
+	}
+	return "Alternate"
+}
+
+// applyMovieMatch copies the TMDb search result onto the movie entity.
+func (s *service) applyMovieMatch(movie *entity.Movie, tmdbMovie *metadata.Movie) {
+	movie.Title = tmdbMovie.Title
+	movie.OriginalTitle = tmdbMovie.OriginalTitle
+	movie.TMDbID = tmdbMovie.ID
+	movie.Overview = tmdbMovie.Overview
+	if tmdbMovie.ReleaseDate != "" {
+		releaseDate, err := time.Parse("2006-01-02", tmdbMovie.ReleaseDate)
+		if err == nil {
+			movie.ReleaseDate = releaseDate
+		} else {
+			s.appLogger.Warn().Err(err).Str("date_str", tmdbMovie.ReleaseDate).Msg("Failed to parse release date")
+		}
+	}
+	if tmdbMovie.BackdropPath != nil {
+		movie.BackdropPath = *tmdbMovie.BackdropPath
+	}
+	if tmdbMovie.PosterPath != nil {
+		movie.PosterPath = *tmdbMovie.PosterPath
+	}
+	movie.VoteAverage = tmdbMovie.VoteAverage
+	movie.VoteCount = tmdbMovie.VoteCount
+	movie.OriginalLanguage = tmdbMovie.OriginalLanguage
+	movie.MetadataPending = false
+}
+
+// storeMovieExtras pulls cast, crew, taxonomy, collection and translations
+// for a matched movie. Failures are logged but never fail the import.
+func (s *service) storeMovieExtras(ctx context.Context, movie *entity.Movie, tmdbMovie *metadata.Movie) {
+	// Runtime and production countries only come with the full record
+	if details, err := s.tmdb.GetMovieDetails(ctx, tmdbMovie.ID); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to fetch movie details")
+	} else {
+		movie.Runtime = details.Runtime
+		movie.ProductionCountries = strings.Join(metadata.CountryCodes(details.ProductionCountries), ",")
+		if cert, err := s.tmdb.GetMovieCertification(ctx, tmdbMovie.ID); err != nil {
+			s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to fetch movie certification")
+		} else {
+			movie.Certification = cert
+		}
+		if err := s.movieRepo.Update(ctx, movie); err != nil {
+			s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to store movie details")
+		}
+	}
+
+	credits, err := s.tmdb.GetMovieCredits(ctx, tmdbMovie.ID)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to fetch movie credits")
+	} else if err := s.storeCredits(ctx, "movie", movie.ID, credits); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to store movie credits")
+	}
+
+	if err := s.storeMovieTaxonomy(ctx, movie, tmdbMovie); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to store movie taxonomy")
+	}
+
+	if err := s.storeMovieCollection(ctx, movie); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to store movie collection")
+	}
+
+	if err := s.storeMovieTranslations(ctx, movie, tmdbMovie.ID); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to store movie translations")
+	}
+}
@@ -0,0 +1,150 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/pathutil"
+)
+
+// processMusicFile imports one audio file: the ID3/Vorbis tags extracted by
+// ffprobe drive the artist/album/track hierarchy, with filename fallbacks
+// for untagged files.
+func (s *service) processMusicFile(ctx context.Context, lib *entity.Library, filePath string, report *scanReport) error {
+	// 1. Check if the track already exists (by path)
+	existingTrack, err := s.trackRepo.FindByPath(ctx, filePath)
+	if err != nil {
+		return fmt.Errorf("error checking for existing track: %w", err)
+	}
+	if existingTrack != nil {
+		report.updated++
+		return nil
+	}
+
+	// 2. Extract technical metadata and tags
+	fileMeta, err := s.mediaExtractor.Extract(ctx, pathutil.Long(filePath))
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to extract audio metadata")
+	}
+
+	artistName := musicTag(fileMeta.Tags, "album_artist", "artist")
+	if artistName == "" {
+		artistName = "Unknown Artist"
+	}
+	albumTitle := musicTag(fileMeta.Tags, "album")
+	if albumTitle == "" {
+		albumTitle = "Unknown Album"
+	}
+	trackTitle := musicTag(fileMeta.Tags, "title")
+	if trackTitle == "" {
+		trackTitle = strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
+	}
+
+	// 3. Find or create the artist and album
+	artist, err := s.artistRepo.FindByName(ctx, artistName)
+	if err != nil {
+		return fmt.Errorf("error checking for existing artist: %w", err)
+	}
+	if artist == nil {
+		artist = &entity.Artist{Name: artistName}
+		if err := s.artistRepo.Store(ctx, artist); err != nil {
+			return fmt.Errorf("failed to store artist: %w", err)
+		}
+	}
+
+	album, err := s.albumRepo.FindByArtistAndTitle(ctx, artist.ID, albumTitle)
+	if err != nil {
+		return fmt.Errorf("error checking for existing album: %w", err)
+	}
+	if album == nil {
+		album = &entity.Album{
+			ArtistID: artist.ID,
+			Title:    albumTitle,
+			Year:     musicTagYear(fileMeta.Tags),
+		}
+		if err := s.albumRepo.Store(ctx, album); err != nil {
+			return fmt.Errorf("failed to store album: %w", err)
+		}
+	}
+
+	// 4. Create and store the track
+	track := &entity.Track{
+		LibraryItem: entity.LibraryItem{
+			LibraryID: lib.ID,
+			DateAdded: time.Now(),
+			FilePath:  filePath,
+			Container: fileMeta.Container,
+		},
+		AlbumID:     album.ID,
+		ArtistID:    artist.ID,
+		Title:       trackTitle,
+		TrackNumber: musicTagNumber(fileMeta.Tags, "track"),
+		DiscNumber:  musicTagNumber(fileMeta.Tags, "disc"),
+		Duration:    int(fileMeta.Duration.Seconds()),
+	}
+	if len(fileMeta.AudioTracks) > 0 {
+		track.LibraryItem.Codec = fileMeta.AudioTracks[0].Codec
+		track.LibraryItem.AudioChannels = fileMeta.AudioTracks[0].Channels
+	}
+
+	if err := s.trackRepo.Store(ctx, track); err != nil {
+		return fmt.Errorf("failed to store track: %w", err)
+	}
+	report.added++
+	s.bus.Publish(ctx, events.ItemCreated, events.ItemPayload{
+		LibraryID: lib.ID,
+		ItemID:    track.LibraryItem.ID,
+		MediaType: "track",
+		Path:      filePath,
+	})
+
+	return nil
+}
+
+// musicTag returns the first non-empty tag among keys, matched
+// case-insensitively since ID3 and Vorbis taggers disagree about casing.
+func musicTag(tags map[string]string, keys ...string) string {
+	for _, key := range keys {
+		for tag, value := range tags {
+			if strings.EqualFold(tag, key) && strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+// musicTagNumber parses a numeric tag, tolerating the "3/12" form used for
+// track and disc positions.
+func musicTagNumber(tags map[string]string, key string) int {
+	value := musicTag(tags, key)
+	if value == "" {
+		return 0
+	}
+	value, _, _ = strings.Cut(value, "/")
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// musicTagYear extracts a year from the date/year tags, which range from a
+// bare "1994" to full timestamps.
+func musicTagYear(tags map[string]string) int {
+	value := musicTag(tags, "date", "year")
+	if len(value) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(value[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
@@ -0,0 +1,172 @@
+package scanner
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/exif"
+	"github.com/samcharles93/cinea/internal/pathutil"
+)
+
+// processPhotoFile imports one image: EXIF data supplies the capture time,
+// camera and location, the parent directory becomes the album, and a JPEG
+// thumbnail is written for the browser grid.
+func (s *service) processPhotoFile(ctx context.Context, lib *entity.Library, filePath string, report *scanReport) error {
+	// 1. Check if the photo already exists (by path)
+	existingPhoto, err := s.photoRepo.FindByPath(ctx, filePath)
+	if err != nil {
+		return fmt.Errorf("error checking for existing photo: %w", err)
+	}
+	if existingPhoto != nil {
+		report.updated++
+		return nil
+	}
+
+	info, err := os.Stat(pathutil.Long(filePath))
+	if err != nil {
+		return fmt.Errorf("failed to stat photo: %w", err)
+	}
+
+	// 2. Read EXIF metadata; non-JPEG formats simply yield an empty result
+	meta, err := readPhotoExif(filePath)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to read photo EXIF data")
+		meta = &exif.Metadata{}
+	}
+
+	takenAt := meta.TakenAt
+	if takenAt.IsZero() {
+		takenAt = info.ModTime()
+	}
+
+	photo := &entity.Photo{
+		LibraryItem: entity.LibraryItem{
+			LibraryID: lib.ID,
+			DateAdded: time.Now(),
+			FilePath:  filePath,
+			Container: strings.TrimPrefix(strings.ToLower(filepath.Ext(filePath)), "."),
+		},
+		Title:       strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath)),
+		Album:       filepath.Base(filepath.Dir(filePath)),
+		TakenAt:     takenAt,
+		CameraMake:  meta.CameraMake,
+		CameraModel: meta.CameraModel,
+		Latitude:    meta.Latitude,
+		Longitude:   meta.Longitude,
+		HasGPS:      meta.HasGPS,
+	}
+
+	// 3. Decode the image for its dimensions and generate a thumbnail; an
+	// undecodable image is still imported, just without either
+	if img, decodeErr := decodePhoto(filePath); decodeErr != nil {
+		s.appLogger.Warn().Err(decodeErr).Str("filepath", filePath).Msg("Failed to decode photo")
+	} else {
+		bounds := img.Bounds()
+		photo.ResolutionWidth = bounds.Dx()
+		photo.ResolutionHeight = bounds.Dy()
+
+		thumbPath, thumbErr := s.writeThumbnail(img, filePath)
+		if thumbErr != nil {
+			s.appLogger.Warn().Err(thumbErr).Str("filepath", filePath).Msg("Failed to generate photo thumbnail")
+		} else {
+			photo.ThumbnailPath = thumbPath
+		}
+	}
+
+	if err := s.photoRepo.Store(ctx, photo); err != nil {
+		return fmt.Errorf("failed to store photo: %w", err)
+	}
+	report.added++
+	s.bus.Publish(ctx, events.ItemCreated, events.ItemPayload{
+		LibraryID: lib.ID,
+		ItemID:    photo.LibraryItem.ID,
+		MediaType: "photo",
+		Path:      filePath,
+	})
+
+	return nil
+}
+
+// readPhotoExif opens the file and extracts its EXIF metadata.
+func readPhotoExif(filePath string) (*exif.Metadata, error) {
+	file, err := os.Open(pathutil.Long(filePath))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return exif.Decode(file)
+}
+
+// decodePhoto decodes the image using whichever stdlib format matches.
+func decodePhoto(filePath string) (image.Image, error) {
+	file, err := os.Open(pathutil.Long(filePath))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	return img, err
+}
+
+// writeThumbnail scales the image down to the configured width and writes it
+// as a JPEG named after the source path's hash, returning the thumbnail path.
+func (s *service) writeThumbnail(img image.Image, filePath string) (string, error) {
+	dir := s.config.PhotoThumbnailDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create thumbnail directory: %w", err)
+	}
+
+	sum := sha1.Sum([]byte(pathutil.Fold(filePath)))
+	thumbPath := filepath.Join(dir, hex.EncodeToString(sum[:])+".jpg")
+
+	out, err := os.Create(thumbPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create thumbnail: %w", err)
+	}
+	defer out.Close()
+
+	if err := jpeg.Encode(out, scaleImage(img, s.config.PhotoThumbnailWidth()), &jpeg.Options{Quality: 80}); err != nil {
+		return "", fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return thumbPath, nil
+}
+
+// scaleImage downsizes img to at most maxWidth pixels wide, preserving the
+// aspect ratio. Nearest-neighbour sampling is plenty for grid thumbnails and
+// avoids pulling in an image processing dependency.
+func scaleImage(img image.Image, maxWidth int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxWidth || width == 0 {
+		return img
+	}
+
+	outWidth := maxWidth
+	outHeight := height * maxWidth / width
+	if outHeight < 1 {
+		outHeight = 1
+	}
+
+	out := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < outHeight; y++ {
+		srcY := bounds.Min.Y + y*height/outHeight
+		for x := 0; x < outWidth; x++ {
+			srcX := bounds.Min.X + x*width/outWidth
+			out.Set(x, y, img.At(srcX, srcY))
+		}
+	}
+	return out
+}
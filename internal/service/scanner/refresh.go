@@ -0,0 +1,131 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service/metadata"
+)
+
+// pendingBatchSize caps how many queued items a single run matches so one
+// run can't monopolize the TMDb rate budget.
+const pendingBatchSize = 50
+
+// MetadataTask adapts the scanner's deferred matching queue to the
+// scheduler's TaskExecutor interface under the "metadata" task type.
+type MetadataTask struct {
+	scanner Service
+}
+
+func NewMetadataTask(scanner Service) *MetadataTask {
+	return &MetadataTask{scanner: scanner}
+}
+
+// Execute implements the scheduler.TaskExecutor interface
+func (t *MetadataTask) Execute(ctx context.Context, config string) error {
+	return t.scanner.ProcessPendingMetadata(ctx)
+}
+
+func (t *MetadataTask) Description() string {
+	return "Matches imported items that are still waiting for TMDb metadata."
+}
+
+// ProcessPendingMetadata works through the queue of items imported with
+// filename-derived titles and fills in their TMDb metadata. When TMDb is
+// unavailable the remaining items stay queued for the next run.
+func (s *service) ProcessPendingMetadata(ctx context.Context) error {
+	movies, err := s.movieRepo.FindMetadataPending(ctx, pendingBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, movie := range movies {
+		if err := s.refreshMovie(ctx, movie); err != nil {
+			if errors.Is(err, metadata.ErrUnavailable) {
+				s.appLogger.Warn().Msg("TMDb unavailable, leaving remaining items queued")
+				return nil
+			}
+			s.appLogger.Warn().Err(err).Str("title", movie.Title).Msg("Failed to match queued movie")
+		}
+	}
+
+	shows, err := s.seriesRepo.FindMetadataPending(ctx, pendingBatchSize)
+	if err != nil {
+		return err
+	}
+	for _, series := range shows {
+		if err := s.refreshSeries(ctx, series); err != nil {
+			if errors.Is(err, metadata.ErrUnavailable) {
+				s.appLogger.Warn().Msg("TMDb unavailable, leaving remaining items queued")
+				return nil
+			}
+			s.appLogger.Warn().Err(err).Str("title", series.Title).Msg("Failed to match queued series")
+		}
+	}
+
+	return nil
+}
+
+func (s *service) refreshMovie(ctx context.Context, movie *entity.Movie) error {
+	if movie.ManualMatch {
+		return nil
+	}
+
+	movieInfo := extractMovieInfo(movie.FilePath)
+	searchResult, err := s.tmdb.SearchMovie(ctx, movieInfo.Title, metadata.WithMovieYear(movieInfo.Year))
+	if err != nil {
+		return err
+	}
+	if searchResult == nil || len(searchResult.Results) == 0 {
+		// Leave the item queued; a later run (or a manual identify) may
+		// still find a match
+		s.appLogger.Debug().Str("title", movieInfo.Title).Msg("Still no TMDb match for queued movie")
+		return nil
+	}
+
+	tmdbMovie := s.pickMovieMatch(ctx, movieInfo, 0, searchResult.Results)
+	if tmdbMovie == nil {
+		// Below the confidence threshold; leave queued for manual identify
+		return nil
+	}
+	s.applyMovieMatch(movie, tmdbMovie)
+	movie.LastScanned = time.Now()
+	if err := s.movieRepo.Update(ctx, movie); err != nil {
+		return err
+	}
+
+	s.storeMovieExtras(ctx, movie, tmdbMovie)
+	s.appLogger.Info().Str("title", movie.Title).Int("tmdb_id", movie.TMDbID).Msg("Matched queued movie on TMDb")
+	return nil
+}
+
+func (s *service) refreshSeries(ctx context.Context, series *entity.Series) error {
+	if series.ManualMatch {
+		return nil
+	}
+
+	searchResult, err := s.tmdb.SearchTV(ctx, series.Title, metadata.WithPage(1))
+	if err != nil {
+		return err
+	}
+	if searchResult == nil || len(searchResult.Results) == 0 {
+		s.appLogger.Debug().Str("title", series.Title).Msg("Still no TMDb match for queued series")
+		return nil
+	}
+
+	tmdbShow := s.pickSeriesMatch(series.Title, searchResult.Results)
+	if tmdbShow == nil {
+		// Below the confidence threshold; leave queued for manual identify
+		return nil
+	}
+	s.applySeriesMatch(series, tmdbShow)
+	series.LastScanned = time.Now()
+	if err := s.seriesRepo.Update(ctx, series); err != nil {
+		return err
+	}
+
+	s.storeSeriesExtras(ctx, series, tmdbShow)
+	s.appLogger.Info().Str("title", series.Title).Uint("tmdb_id", series.TMDbID).Msg("Matched queued series on TMDb")
+	return nil
+}
@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/events"
+)
+
+// scanReport accumulates what happened during a scan so a summary can be
+// persisted with the task run and dispatched as a notification.
+type scanReport struct {
+	started   time.Time
+	added     int
+	updated   int
+	moved     int
+	skipped   int
+	unmatched int
+	errors    int
+}
+
+func newScanReport() *scanReport {
+	return &scanReport{started: time.Now()}
+}
+
+// Summary renders the report as a single human-readable line.
+func (r *scanReport) Summary() string {
+	return fmt.Sprintf("%d added, %d updated, %d moved, %d skipped, %d unmatched, %d errors in %s",
+		r.added, r.updated, r.moved, r.skipped, r.unmatched, r.errors, time.Since(r.started).Round(time.Second))
+}
+
+// finishReport records the summary for the task run and publishes
+// scan.completed; the notification system and other integrations subscribe
+// to it on the event bus.
+func (s *service) finishReport(ctx context.Context, scope string, report *scanReport) {
+	summary := report.Summary()
+	s.lastSummary = summary
+
+	s.appLogger.Info().
+		Str("scope", scope).
+		Int("added", report.added).
+		Int("updated", report.updated).
+		Int("moved", report.moved).
+		Int("skipped", report.skipped).
+		Int("unmatched", report.unmatched).
+		Int("errors", report.errors).
+		Msg("Scan finished: " + summary)
+
+	s.bus.Publish(ctx, events.ScanCompleted, events.ScanPayload{
+		Scope:     scope,
+		Summary:   summary,
+		Added:     report.added,
+		Updated:   report.updated,
+		Moved:     report.moved,
+		Skipped:   report.skipped,
+		Unmatched: report.unmatched,
+		Errors:    report.errors,
+	})
+}
+
+// Summary implements the scheduler's Summarizer interface so the last scan
+// report is persisted with the task run.
+func (s *service) Summary() string {
+	return s.lastSummary
+}
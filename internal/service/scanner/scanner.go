@@ -2,12 +2,15 @@ package scanner
 
 import (
 	"context"
-	"os"
+	"encoding/json"
+	"fmt"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/samcharles93/cinea/config"
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
 	"github.com/samcharles93/cinea/internal/logger"
 	"github.com/samcharles93/cinea/internal/repository"
 	"github.com/samcharles93/cinea/internal/service/extractor"
@@ -17,7 +20,9 @@ import (
 type Service interface {
 	ScanLibrary(ctx context.Context, lib *entity.Library) error
 	ScanLibraries(ctx context.Context) error
-	scanPath(ctx context.Context, lib *entity.Library, path string) error
+	ScanImportedPath(ctx context.Context, path string) error
+	ProcessPendingMetadata(ctx context.Context) error
+	scanPath(ctx context.Context, lib *entity.Library, path string, pathType entity.LibraryType, state *walkState) error
 
 	// Task scheduler methods
 	Execute(ctx context.Context, config string) error
@@ -25,21 +30,46 @@ type Service interface {
 }
 
 type service struct {
-	config         *config.Config
-	appLogger      logger.Logger
-	libraryRepo    repository.LibraryRepository
-	movieRepo      repository.MovieRepository
-	seriesRepo     repository.SeriesRepository
-	seasonRepo     repository.SeasonRepository
-	episodeRepo    repository.EpisodeRepository
-	tmdb           *metadata.TMDbService
-	mediaExtractor extractor.Service
+	config          *config.Config
+	appLogger       logger.Logger
+	txManager       repository.TxManager
+	libraryRepo     repository.LibraryRepository
+	movieRepo       repository.MovieRepository
+	seriesRepo      repository.SeriesRepository
+	seasonRepo      repository.SeasonRepository
+	episodeRepo     repository.EpisodeRepository
+	personRepo      repository.PersonRepository
+	creditRepo      repository.CreditRepository
+	taxonomyRepo    repository.TaxonomyRepository
+	collectionRepo  repository.CollectionRepository
+	translationRepo repository.TranslationRepository
+	artistRepo      repository.ArtistRepository
+	albumRepo       repository.AlbumRepository
+	trackRepo       repository.TrackRepository
+	photoRepo       repository.PhotoRepository
+	audiobookRepo   repository.AudiobookRepository
+	tmdb            *metadata.TMDbService
+	mediaExtractor  extractor.Service
+	bus             events.Bus
+	extensions      *extensionSet
+	exclusions      *excludeRules
+
+	// lastSummary holds the report of the most recent scan so the scheduler
+	// can persist it with the task run.
+	lastSummary string
+
+	// progress is the scheduler's callback for live per-library progress;
+	// nil outside scheduled runs.
+	progress func(current, total int, message string)
 }
 
 type tvShowInfo struct {
 	Title   string
 	Season  int
 	Episode int
+	// Absolute marks Episode as an absolute number (anime naming) still to
+	// be mapped onto a season once the show is matched
+	Absolute bool
 }
 
 type mediaInfo struct {
@@ -50,72 +80,251 @@ type mediaInfo struct {
 func NewScannerService(
 	cfg *config.Config,
 	appLogger logger.Logger,
+	txManager repository.TxManager,
 	libraryRepo repository.LibraryRepository,
 	movieRepo repository.MovieRepository,
 	seriesRepo repository.SeriesRepository,
 	seasonRepo repository.SeasonRepository,
 	episodeRepo repository.EpisodeRepository,
+	personRepo repository.PersonRepository,
+	creditRepo repository.CreditRepository,
+	taxonomyRepo repository.TaxonomyRepository,
+	collectionRepo repository.CollectionRepository,
+	translationRepo repository.TranslationRepository,
+	artistRepo repository.ArtistRepository,
+	albumRepo repository.AlbumRepository,
+	trackRepo repository.TrackRepository,
+	photoRepo repository.PhotoRepository,
+	audiobookRepo repository.AudiobookRepository,
 	tmdb *metadata.TMDbService,
 	mediaExtractor extractor.Service,
+	bus events.Bus,
 ) Service {
 	return &service{
-		config:         cfg,
-		appLogger:      appLogger,
-		libraryRepo:    libraryRepo,
-		movieRepo:      movieRepo,
-		seriesRepo:     seriesRepo,
-		seasonRepo:     seasonRepo,
-		episodeRepo:    episodeRepo,
-		tmdb:           tmdb,
-		mediaExtractor: mediaExtractor,
+		config:          cfg,
+		appLogger:       appLogger,
+		txManager:       txManager,
+		libraryRepo:     libraryRepo,
+		movieRepo:       movieRepo,
+		seriesRepo:      seriesRepo,
+		seasonRepo:      seasonRepo,
+		episodeRepo:     episodeRepo,
+		personRepo:      personRepo,
+		creditRepo:      creditRepo,
+		taxonomyRepo:    taxonomyRepo,
+		collectionRepo:  collectionRepo,
+		translationRepo: translationRepo,
+		artistRepo:      artistRepo,
+		albumRepo:       albumRepo,
+		trackRepo:       trackRepo,
+		photoRepo:       photoRepo,
+		audiobookRepo:   audiobookRepo,
+		tmdb:            tmdb,
+		mediaExtractor:  mediaExtractor,
+		bus:             bus,
+		extensions:      newExtensionSet(cfg),
+		exclusions:      newExcludeRules(cfg),
+	}
+}
+
+// taskConfig is the typed schema for a scanner task's Config field, so a
+// scheduled task can target one library rather than scanning everything
+// (e.g. scan the TV library hourly and movies daily).
+type taskConfig struct {
+	LibraryID uint   `json:"library_id,omitempty"` // Scan only this library; 0 scans all auto-scan libraries
+	Library   string `json:"library,omitempty"`    // Alternative to LibraryID: match the library by name
+}
+
+// parseTaskConfig parses a task's Config JSON; an empty string yields the
+// zero config, which scans all libraries.
+func parseTaskConfig(configStr string) (taskConfig, error) {
+	var cfg taskConfig
+	if configStr == "" {
+		return cfg, nil
+	}
+
+	// Unknown fields are rejected so typos surface when the config is saved
+	decoder := json.NewDecoder(strings.NewReader(configStr))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("invalid scanner task config: %w", err)
 	}
+	return cfg, nil
+}
+
+// ValidateConfig implements the scheduler.ConfigValidator interface so bad
+// task configs are rejected when saved rather than at run time.
+func (s *service) ValidateConfig(config string) error {
+	_, err := parseTaskConfig(config)
+	return err
 }
 
 // Execute implements the scheduler.TaskExecutor interface
 func (s *service) Execute(ctx context.Context, config string) error {
-	// TODO - Parse the configStr to get scanner configuration (e.g. which libraries to scan)
+	taskCfg, err := parseTaskConfig(config)
+	if err != nil {
+		return err
+	}
 
-	s.appLogger.Info().Str("package", "scanner").Msg("Starting scan from the scheduler")
-	return s.ScanLibraries(ctx)
+	if taskCfg.LibraryID == 0 && taskCfg.Library == "" {
+		s.appLogger.Info().Str("package", "scanner").Msg("Starting scan from the scheduler")
+		return s.ScanLibraries(ctx)
+	}
+
+	lib, err := s.findTaskLibrary(ctx, taskCfg)
+	if err != nil {
+		return err
+	}
+
+	s.appLogger.Info().Str("package", "scanner").Str("library", lib.Name).Msg("Starting library scan from the scheduler")
+	return s.ScanLibrary(ctx, lib)
+}
+
+// findTaskLibrary resolves the library a task config points at, by ID first
+// and by name otherwise.
+func (s *service) findTaskLibrary(ctx context.Context, taskCfg taskConfig) (*entity.Library, error) {
+	if taskCfg.LibraryID != 0 {
+		lib, err := s.libraryRepo.GetLibrary(ctx, taskCfg.LibraryID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load library %d: %w", taskCfg.LibraryID, err)
+		}
+		if lib == nil {
+			return nil, fmt.Errorf("library %d does not exist", taskCfg.LibraryID)
+		}
+		return lib, nil
+	}
+
+	libraries, err := s.libraryRepo.ListLibraries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, lib := range libraries {
+		if lib.Name == taskCfg.Library {
+			return lib, nil
+		}
+	}
+	return nil, fmt.Errorf("library %q does not exist", taskCfg.Library)
 }
 
 func (s *service) Description() string {
 	return "Scans media libraries for new files."
 }
 
+// SetProgressFunc implements the scheduler's ProgressReporter interface;
+// scheduled scans report per-library progress through fn.
+func (s *service) SetProgressFunc(fn func(current, total int, message string)) {
+	s.progress = fn
+}
+
+// reportProgress invokes the progress callback when one is registered.
+func (s *service) reportProgress(current, total int, message string) {
+	if s.progress != nil {
+		s.progress(current, total, message)
+	}
+}
+
 func (s *service) ScanLibraries(ctx context.Context) error {
 	libraries, err := s.libraryRepo.ListLibraries(ctx)
 	if err != nil {
 		return err
 	}
 
+	s.bus.Publish(ctx, events.ScanStarted, events.ScanStartedPayload{Scope: "all libraries"})
+
+	// One report across all libraries so the task run gets a single summary
+	report := newScanReport()
+	total := 0
+	for _, lib := range libraries {
+		if lib.AutoScan {
+			total++
+		}
+	}
+	scanned := 0
 	for _, lib := range libraries {
 		if !lib.AutoScan {
 			continue
 		}
 
-		if err := s.ScanLibrary(ctx, lib); err != nil {
+		scanned++
+		s.reportProgress(scanned, total, "Scanning "+lib.Name)
+		if err := s.scanLibrary(ctx, lib, report); err != nil {
+			report.errors++
 			s.appLogger.Error().
 				Err(err).
 				Str("library", lib.Name).
 				Msg("Failed to scan library")
 		}
 	}
+
+	s.finishReport(ctx, "all libraries", report)
 	return nil
 }
 
 func (s *service) ScanLibrary(ctx context.Context, lib *entity.Library) error {
+	s.bus.Publish(ctx, events.ScanStarted, events.ScanStartedPayload{Scope: lib.Name})
+
+	report := newScanReport()
+	err := s.scanLibrary(ctx, lib, report)
+	s.finishReport(ctx, lib.Name, report)
+	return err
+}
+
+// ScanImportedPath scans a single directory that an external import tool
+// (Sonarr, Radarr) just wrote to, resolving the library that contains it,
+// so an import shows up without walking the whole library.
+func (s *service) ScanImportedPath(ctx context.Context, path string) error {
+	libs, err := s.libraryRepo.ListLibraries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list libraries: %w", err)
+	}
+
+	cleaned := filepath.Clean(path)
+	for _, lib := range libs {
+		for _, libPath := range lib.Paths {
+			if !libPath.Enabled || !pathContains(libPath.Path, cleaned) {
+				continue
+			}
+
+			scope := fmt.Sprintf("%s (import)", lib.Name)
+			s.bus.Publish(ctx, events.ScanStarted, events.ScanStartedPayload{Scope: scope})
+
+			report := newScanReport()
+			state := newWalkState(report)
+			err := s.scanPath(ctx, lib, cleaned, libPath.ContentType, state)
+			if err != nil {
+				report.errors++
+			}
+			s.finishReport(ctx, scope, report)
+			return err
+		}
+	}
+
+	return fmt.Errorf("path '%s' is not inside any library", path)
+}
+
+// pathContains reports whether candidate equals root or sits below it.
+func pathContains(root, candidate string) bool {
+	root = filepath.Clean(root)
+	return candidate == root || strings.HasPrefix(candidate, root+string(filepath.Separator))
+}
+
+func (s *service) scanLibrary(ctx context.Context, lib *entity.Library, report *scanReport) error {
 	s.appLogger.Info().
 		Str("library", lib.Name).
 		Str("type", string(lib.Type)).
 		Msg("Starting library scan")
 
+	// Shared across the library's paths so hardlinked copies under
+	// multiple paths only import once
+	state := newWalkState(report)
+
 	for _, path := range lib.Paths {
 		if !path.Enabled {
 			continue
 		}
 
-		if err := s.scanPath(ctx, lib, path.Path); err != nil {
+		if err := s.scanPath(ctx, lib, path.Path, path.ContentType, state); err != nil {
+			report.errors++
 			s.appLogger.Error().
 				Err(err).
 				Str("library", lib.Name).
@@ -128,25 +337,44 @@ func (s *service) ScanLibrary(ctx context.Context, lib *entity.Library) error {
 	return s.libraryRepo.UpdateLibrary(ctx, lib)
 }
 
-func (s *service) scanPath(ctx context.Context, lib *entity.Library, path string) error {
-	return filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+// scanPath walks one library path, draining any buffered batch inserts
+// once the walk finishes. pathType is the path's content type override;
+// empty inherits the library type.
+func (s *service) scanPath(ctx context.Context, lib *entity.Library, path string, pathType entity.LibraryType, state *walkState) error {
+	err := s.walkDir(ctx, lib, path, pathType, state)
+	s.flushBatches(ctx, state)
+	return err
+}
 
-		if info.IsDir() || !isVideoFile(filePath) {
-			return nil
-		}
+func (s *service) processFile(ctx context.Context, lib *entity.Library, pathType entity.LibraryType, filePath string, state *walkState) error {
+	effective := pathType
+	if effective == "" {
+		effective = lib.Type
+	}
 
-		return s.processFile(ctx, lib, filePath)
-	})
-}
+	switch effective {
+	case entity.LibraryTypeMusic:
+		return s.processMusicFile(ctx, lib, filePath, state.report)
+	case entity.LibraryTypePhotos:
+		return s.processPhotoFile(ctx, lib, filePath, state.report)
+	case entity.LibraryTypeAudiobook:
+		return s.processAudiobookFile(ctx, lib, filePath, state.report)
+	}
+
+	// A path explicitly tagged movie or tv dispatches straight to its
+	// processor; only untagged video paths fall back to the filename
+	// heuristic
+	switch pathType {
+	case entity.LibraryTypeMovie:
+		return s.processMovieFile(ctx, lib, filePath, state)
+	case entity.LibraryTypeTV:
+		return s.processSeriesFile(ctx, lib, filePath, state)
+	}
 
-func (s *service) processFile(ctx context.Context, lib *entity.Library, filePath string) error {
 	// Determine if file is likely tv show episode or a movie
 	if isLikelyTVFile(filePath) {
-		return s.processSeriesFile(ctx, lib, filePath)
+		return s.processSeriesFile(ctx, lib, filePath, state)
 	} else {
-		return s.processMovieFile(ctx, lib, filePath)
+		return s.processMovieFile(ctx, lib, filePath, state)
 	}
 }
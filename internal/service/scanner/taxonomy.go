@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service/metadata"
+)
+
+// storeMovieTaxonomy persists the genres and production companies for a
+// movie matched on TMDb.
+func (s *service) storeMovieTaxonomy(ctx context.Context, movie *entity.Movie, tmdbMovie *metadata.Movie) error {
+	genres, err := s.tmdb.ResolveMovieGenres(ctx, tmdbMovie.GenreIDs)
+	if err != nil {
+		return fmt.Errorf("failed to resolve movie genres: %w", err)
+	}
+	if err := s.storeGenres(ctx, "movie", movie.ID, genres); err != nil {
+		return err
+	}
+
+	companies, err := s.tmdb.GetMovieCompanies(ctx, tmdbMovie.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch movie companies: %w", err)
+	}
+	return s.storeStudios(ctx, "movie", movie.ID, companies)
+}
+
+// storeSeriesTaxonomy persists the genres and networks for a show matched
+// on TMDb.
+func (s *service) storeSeriesTaxonomy(ctx context.Context, series *entity.Series, tmdbShow *metadata.Series) error {
+	genres, err := s.tmdb.ResolveTVGenres(ctx, tmdbShow.GenreIDs)
+	if err != nil {
+		return fmt.Errorf("failed to resolve tv genres: %w", err)
+	}
+	if err := s.storeGenres(ctx, "series", series.ID, genres); err != nil {
+		return err
+	}
+
+	networks, err := s.tmdb.GetTVNetworks(ctx, tmdbShow.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tv networks: %w", err)
+	}
+	return s.storeStudios(ctx, "series", series.ID, networks)
+}
+
+func (s *service) storeGenres(ctx context.Context, mediaType string, mediaID uint, genres []metadata.Genre) error {
+	entities := make([]*entity.Genre, 0, len(genres))
+	for _, genre := range genres {
+		stored, err := s.taxonomyRepo.FindOrCreateGenre(ctx, genre.ID, genre.Name)
+		if err != nil {
+			return fmt.Errorf("failed to store genre '%s': %w", genre.Name, err)
+		}
+		entities = append(entities, stored)
+	}
+	return s.taxonomyRepo.SetMediaGenres(ctx, mediaType, mediaID, entities)
+}
+
+func (s *service) storeStudios(ctx context.Context, mediaType string, mediaID uint, companies []metadata.Company) error {
+	entities := make([]*entity.Studio, 0, len(companies))
+	for _, company := range companies {
+		stored, err := s.taxonomyRepo.FindOrCreateStudio(ctx, company.ID, company.Name)
+		if err != nil {
+			return fmt.Errorf("failed to store studio '%s': %w", company.Name, err)
+		}
+		entities = append(entities, stored)
+	}
+	return s.taxonomyRepo.SetMediaStudios(ctx, mediaType, mediaID, entities)
+}
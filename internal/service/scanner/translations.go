@@ -0,0 +1,62 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/service/metadata"
+)
+
+// storeMovieTranslations persists the localized titles and overviews for a
+// movie in the languages configured under meta.tmdb.languages.
+func (s *service) storeMovieTranslations(ctx context.Context, movie *entity.Movie, tmdbID int) error {
+	if len(s.config.Meta.TMDb.Languages) == 0 {
+		return nil
+	}
+
+	translations, err := s.tmdb.GetMovieTranslations(ctx, tmdbID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch movie translations: %w", err)
+	}
+	return s.storeTranslations(ctx, "movie", movie.ID, translations)
+}
+
+// storeSeriesTranslations persists the localized names and overviews for a
+// show in the languages configured under meta.tmdb.languages.
+func (s *service) storeSeriesTranslations(ctx context.Context, series *entity.Series, tmdbID uint) error {
+	if len(s.config.Meta.TMDb.Languages) == 0 {
+		return nil
+	}
+
+	translations, err := s.tmdb.GetTVTranslations(ctx, tmdbID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tv translations: %w", err)
+	}
+	return s.storeTranslations(ctx, "series", series.ID, translations)
+}
+
+func (s *service) storeTranslations(ctx context.Context, mediaType string, mediaID uint, translations []metadata.Translation) error {
+	wanted := make(map[string]bool, len(s.config.Meta.TMDb.Languages))
+	for _, lang := range s.config.Meta.TMDb.Languages {
+		wanted[lang] = true
+	}
+
+	for _, translation := range translations {
+		if !wanted[translation.Language] {
+			continue
+		}
+		err := s.translationRepo.Upsert(ctx, &entity.MediaTranslation{
+			MediaType: mediaType,
+			MediaID:   mediaID,
+			Language:  translation.Language,
+			Title:     translation.Title,
+			Overview:  translation.Overview,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store translation '%s': %w", translation.Language, err)
+		}
+	}
+
+	return nil
+}
@@ -3,15 +3,26 @@ package scanner
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/pathutil"
 	"github.com/samcharles93/cinea/internal/service/metadata"
 )
 
-func (s *service) processSeriesFile(ctx context.Context, lib *entity.Library, filePath string) error {
-	// 1. Extract show name, season, episode from filename
+func (s *service) processSeriesFile(ctx context.Context, lib *entity.Library, filePath string, state *walkState) error {
+	report := state.report
+
+	// 1. Extract show name, season, episode from filename. Anime libraries
+	// get a second pass for fansub-style names with absolute numbering
 	tvInfo := extractTVShowInfo(filePath)
+	if lib.AnimeNaming && (tvInfo.Season == 0 || tvInfo.Episode == 0) {
+		if animeInfo, ok := extractAnimeInfo(filePath); ok {
+			tvInfo = animeInfo
+		}
+	}
 	if tvInfo.Season == 0 || tvInfo.Episode == 0 {
 		s.appLogger.Warn().Str("filepath", filePath).Msg("Could not extract TV show info from filename")
 		return nil
@@ -24,126 +35,286 @@ func (s *service) processSeriesFile(ctx context.Context, lib *entity.Library, fi
 	}
 	if existingEpisode != nil {
 		existingEpisode.LastScanned = time.Now()
-		return s.episodeRepo.UpdateEpisode(ctx, existingEpisode)
+		// Backfill the content hash for items imported before hashing
+		if existingEpisode.ContentHash == "" {
+			s.stampContentHash(filePath, &existingEpisode.ContentHash, &existingEpisode.FileSize)
+		}
+		if err := s.episodeRepo.UpdateEpisode(ctx, existingEpisode); err != nil {
+			return err
+		}
+		report.updated++
+		return nil
 	}
 
-	// 3. Extract technical metadata
-	fileMeta, err := s.mediaExtractor.Extract(ctx, filePath)
-	if err != nil {
-		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to extract TV show metadata")
+	// 3. Fingerprint the file; a known hash whose old path vanished means
+	// the file was renamed or moved, so the existing entity is re-pointed
+	// rather than deleted and re-imported, keeping watch history and
+	// ratings attached
+	var contentHash string
+	var fileSize int64
+	s.stampContentHash(filePath, &contentHash, &fileSize)
+	if moved, err := s.relocateEpisode(ctx, filePath, contentHash); err != nil {
+		return err
+	} else if moved {
+		report.moved++
+		return nil
 	}
 
-	// 4. Search TMDb for the *show*.
-	searchResult, err := s.tmdb.SearchTV(ctx, tvInfo.Title, metadata.WithPage(1))
+	// 4. Extract technical metadata
+	fileMeta, err := s.mediaExtractor.Extract(ctx, pathutil.Long(filePath))
 	if err != nil {
-		s.appLogger.Error().Err(err).Str("title", tvInfo.Title).Msg("TMDb search failed for TV show")
+		s.appLogger.Warn().Err(err).Str("filepath", filePath).Msg("Failed to extract TV show metadata")
 	}
 
+	// 5. Search TMDb for the *show*, unless matching is deferred to the queue
 	var tmdbShow *metadata.Series
+	if !s.config.Jobs.Scanner.DeferMetadata {
+		searchResult, err := s.tmdb.SearchTV(ctx, tvInfo.Title, metadata.WithPage(1))
+		if err != nil {
+			s.appLogger.Error().Err(err).Str("title", tvInfo.Title).Msg("TMDb search failed for TV show")
+		}
+
+		if searchResult != nil && len(searchResult.Results) > 0 {
+			tmdbShow = s.pickSeriesMatch(tvInfo.Title, searchResult.Results)
+			if tmdbShow != nil {
+				s.appLogger.Info().Str("title", tmdbShow.Name).Uint("tmdb_id", tmdbShow.ID).Msg("Found TV show on TMDb")
+			}
+		} else {
+			s.appLogger.Warn().Str("title", tvInfo.Title).Msg("No results found on TMDb for TV show")
+		}
+	}
 
-	if searchResult != nil && len(searchResult.Results) > 0 {
-		tmdbShow = &searchResult.Results[0]
-		s.appLogger.Info().Str("title", tmdbShow.Name).Uint("tmdb_id", tmdbShow.ID).Msg("Found TV show on TMDb")
-	} else {
-		s.appLogger.Warn().Str("title", tvInfo.Title).Msg("No results found on TMDb for TV show")
+	// An absolute episode number only becomes a season/episode pair once
+	// the show is matched and its season layout is known
+	if tvInfo.Absolute && tmdbShow != nil {
+		tvInfo.Season, tvInfo.Episode = s.resolveAbsoluteEpisode(ctx, tmdbShow.ID, tvInfo.Episode)
 	}
 
-	// 5. Create/Update Series, Season, and Episode entities.
+	// 6. Create/Update Series, Season, and Episode entities inside one
+	// transaction, so a failure partway can't leave a season without its
+	// series or an episode without its season. TMDb extras stay outside
+	// the transaction; they make network calls and never fail the import.
+	var (
+		series    *entity.Series
+		episode   *entity.Episode
+		newSeries bool
+		newSeason bool
+	)
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// 6.1 Find or Create Series
+		var err error
+		if tmdbShow != nil {
+			series, err = s.seriesRepo.FindByID(ctx, tmdbShow.ID)
+			if err != nil {
+				return fmt.Errorf("error checking for existing series: %w", err)
+			}
+		} else {
+			// Without a TMDb match the filename-derived title is the only key
+			series, err = s.seriesRepo.FindByTitle(ctx, tvInfo.Title)
+			if err != nil {
+				return fmt.Errorf("error checking for existing series: %w", err)
+			}
+		}
+
+		if series == nil {
+			newSeries = true
+			series = &entity.Series{
+				LibraryItem: entity.LibraryItem{
+					LibraryID: lib.ID,
+					DateAdded: time.Now(),
+				},
+				Title:       tvInfo.Title,
+				LastScanned: time.Now(),
+			}
+			if tmdbShow != nil {
+				s.applySeriesMatch(series, tmdbShow)
+			} else {
+				// Mark for the deferred matching queue so the show isn't left
+				// metadata-less forever
+				series.MetadataPending = true
+			}
+			if err := s.seriesRepo.Store(ctx, series); err != nil {
+				return fmt.Errorf("failed to store series: %w", err)
+			}
+		} else {
+			series.LastScanned = time.Now()
+			s.seriesRepo.Update(ctx, series)
+		}
 
-	// 5.1 Find or Create Series
-	var series *entity.Series
-	if tmdbShow != nil {
-		series, err = s.seriesRepo.FindByID(ctx, tmdbShow.ID)
+		// 6.2 Find or Create Season
+		season, err := s.seasonRepo.FindBySeriesID(ctx, series.ID)
 		if err != nil {
-			return fmt.Errorf("error checking for existing series: %w", err)
+			return fmt.Errorf("error checking for existing season: %w", err)
+		}
+
+		if season == nil {
+			newSeason = true
+			season = &entity.Season{
+				SeriesID:     series.ID,
+				SeasonNumber: tvInfo.Season,
+				LibraryItem: entity.LibraryItem{
+					LibraryID: lib.ID,
+					DateAdded: time.Now(),
+				},
+			}
+			if err := s.seasonRepo.AddSeason(ctx, season); err != nil {
+				return fmt.Errorf("failed to store season: %w", err)
+			}
+		} else {
+			season.LastScanned = time.Now()
+			s.seasonRepo.UpdateSeason(ctx, season)
 		}
-	}
 
-	if series == nil {
-		series = &entity.Series{
+		// 6.3 Create Episode
+		episode = &entity.Episode{
 			LibraryItem: entity.LibraryItem{
-				LibraryID: lib.ID,
-				DateAdded: time.Now(),
+				LibraryID:        lib.ID,
+				DateAdded:        time.Now(),
+				FilePath:         filePath,
+				Container:        fileMeta.Container,
+				Codec:            fileMeta.Codec,
+				ResolutionWidth:  fileMeta.ResolutionWidth,
+				ResolutionHeight: fileMeta.ResolutionHeight,
+				ContentHash:      contentHash,
+				FileSize:         fileSize,
 			},
-			Title:       tvInfo.Title,
-			LastScanned: time.Now(),
+			SeriesID:      series.ID,
+			SeasonID:      season.ID,
+			EpisodeNumber: tvInfo.Episode,
+			Title:         fmt.Sprintf("Episode %d", tvInfo.Episode),
+			LastScanned:   time.Now(),
 		}
-		if tmdbShow != nil {
-			series.Title = tmdbShow.Name
-			series.OriginalTitle = tmdbShow.OriginalName
-			series.TMDbID = tmdbShow.ID
-			series.Overview = tmdbShow.Overview
-			if tmdbShow.FirstAirDate != "" {
-				firstAirDate, _ := time.Parse("2006-01-02", tmdbShow.FirstAirDate)
-				series.FirstAirDate = firstAirDate
-			}
-			if tmdbShow.BackdropPath != nil {
-				series.BackdropPath = *tmdbShow.BackdropPath
-			}
-			if tmdbShow.PosterPath != nil {
-				series.PosterPath = *tmdbShow.PosterPath
-			}
+		if len(fileMeta.AudioTracks) > 0 {
+			episode.LibraryItem.AudioChannels = fileMeta.AudioTracks[0].Channels
+			episode.LibraryItem.AudioLanguages = audioLanguages(fileMeta.AudioTracks)
+		}
+		episode.LibraryItem.HasAudioDescription = hasAudioDescription(fileMeta.AudioTracks)
+		episode.LibraryItem.HasSDHSubtitles = hasSDHSubtitles(fileMeta.SubtitleTracks)
+
+		// TODO: Look into getting episode title/overview from TMDb.
 
-			series.VoteAverage = tmdbShow.VoteAverage
-			series.VoteCount = tmdbShow.VoteCount
+		// An episode appended to an existing show rides a batch insert;
+		// only a freshly created hierarchy stores its first episode
+		// atomically alongside the series and season rows
+		if !newSeries && !newSeason {
+			return nil
 		}
-		if err := s.seriesRepo.Store(ctx, series); err != nil {
-			return fmt.Errorf("failed to store series: %w", err)
+		if err := s.episodeRepo.AddEpisode(ctx, episode); err != nil {
+			return fmt.Errorf("failed to store episode: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if newSeries && series.MetadataPending {
+		report.unmatched++
+	}
+	// Pull cast, crew, genres and networks for the matched show
+	if newSeries && tmdbShow != nil {
+		s.storeSeriesExtras(ctx, series, tmdbShow)
+	}
+
+	if !newSeries && !newSeason {
+		if err := s.queueEpisode(ctx, state, episode); err != nil {
+			return fmt.Errorf("failed to store episode batch: %w", err)
 		}
-	} else {
-		series.LastScanned = time.Now()
-		s.seriesRepo.Update(ctx, series)
+		return nil
+	}
+
+	report.added++
+	s.bus.Publish(ctx, events.ItemCreated, events.ItemPayload{
+		LibraryID: lib.ID,
+		ItemID:    episode.LibraryItem.ID,
+		MediaType: "episode",
+		Path:      filePath,
+	})
+
+	return nil
+}
+
+// relocateEpisode re-points an existing episode at its file's new path
+// when the file was renamed or moved: same content hash, old path gone.
+// The entity keeps its ID, so watch history and ratings survive the move.
+func (s *service) relocateEpisode(ctx context.Context, filePath, contentHash string) (bool, error) {
+	if contentHash == "" {
+		return false, nil
 	}
 
-	// 5.2 Find or Create Season
-	season, err := s.seasonRepo.FindBySeriesID(ctx, series.ID)
+	candidates, err := s.episodeRepo.FindByContentHash(ctx, contentHash)
 	if err != nil {
-		return fmt.Errorf("error checking for existing season: %w", err)
+		return false, fmt.Errorf("error checking for moved episode: %w", err)
 	}
 
-	if season == nil {
-		season = &entity.Season{
-			SeriesID:     series.ID,
-			SeasonNumber: tvInfo.Season,
-			LibraryItem: entity.LibraryItem{
-				LibraryID: lib.ID,
-				DateAdded: time.Now(),
-			},
+	for _, episode := range candidates {
+		if !fileGone(episode.FilePath) {
+			continue
 		}
-		if err := s.seasonRepo.AddSeason(ctx, season); err != nil {
-			return fmt.Errorf("failed to store season: %w", err)
+		oldPath := episode.FilePath
+		episode.FilePath = pathutil.Canonical(filePath)
+		episode.LastScanned = time.Now()
+		if err := s.episodeRepo.UpdateEpisode(ctx, episode); err != nil {
+			return false, fmt.Errorf("failed to update moved episode: %w", err)
 		}
-	} else {
-		season.LastScanned = time.Now()
-		s.seasonRepo.UpdateSeason(ctx, season)
+		s.appLogger.Info().
+			Str("title", episode.Title).
+			Str("from", oldPath).
+			Str("to", filePath).
+			Msg("Detected moved episode file")
+		return true, nil
 	}
+	return false, nil
+}
 
-	// 5.3 Create Episode
-	episode := &entity.Episode{
-		LibraryItem: entity.LibraryItem{
-			LibraryID:        lib.ID,
-			DateAdded:        time.Now(),
-			FilePath:         filePath,
-			Container:        fileMeta.Container,
-			Codec:            fileMeta.Codec,
-			ResolutionWidth:  fileMeta.ResolutionWidth,
-			ResolutionHeight: fileMeta.ResolutionHeight,
-		},
-		SeriesID:      series.ID,
-		SeasonID:      season.ID,
-		EpisodeNumber: tvInfo.Episode,
-		Title:         fmt.Sprintf("Episode %d", tvInfo.Episode),
-		LastScanned:   time.Now(),
+// applySeriesMatch copies the TMDb search result onto the series entity.
+func (s *service) applySeriesMatch(series *entity.Series, tmdbShow *metadata.Series) {
+	series.Title = tmdbShow.Name
+	series.OriginalTitle = tmdbShow.OriginalName
+	series.TMDbID = tmdbShow.ID
+	series.Overview = tmdbShow.Overview
+	if tmdbShow.FirstAirDate != "" {
+		firstAirDate, _ := time.Parse("2006-01-02", tmdbShow.FirstAirDate)
+		series.FirstAirDate = firstAirDate
+	}
+	if tmdbShow.BackdropPath != nil {
+		series.BackdropPath = *tmdbShow.BackdropPath
+	}
+	if tmdbShow.PosterPath != nil {
+		series.PosterPath = *tmdbShow.PosterPath
 	}
-	if len(fileMeta.AudioTracks) > 0 {
-		episode.LibraryItem.AudioChannels = fileMeta.AudioTracks[0].Channels
+
+	series.VoteAverage = tmdbShow.VoteAverage
+	series.VoteCount = tmdbShow.VoteCount
+	series.OriginalLanguage = tmdbShow.OriginalLanguage
+	series.ProductionCountries = strings.Join(tmdbShow.OriginCountry, ",")
+	series.MetadataPending = false
+}
+
+// storeSeriesExtras pulls cast, crew, taxonomy and translations for a
+// matched show. Failures are logged but never fail the import.
+func (s *service) storeSeriesExtras(ctx context.Context, series *entity.Series, tmdbShow *metadata.Series) {
+	if cert, err := s.tmdb.GetTVCertification(ctx, tmdbShow.ID); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", series.Title).Msg("Failed to fetch TV certification")
+	} else if cert != series.Certification {
+		series.Certification = cert
+		if err := s.seriesRepo.Update(ctx, series); err != nil {
+			s.appLogger.Warn().Err(err).Str("title", series.Title).Msg("Failed to store TV certification")
+		}
 	}
 
-	// TODO: Look into getting episode title/overview from TMDb.
+	credits, err := s.tmdb.GetTVCredits(ctx, tmdbShow.ID)
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("title", series.Title).Msg("Failed to fetch TV credits")
+	} else if err := s.storeCredits(ctx, "series", series.ID, credits); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", series.Title).Msg("Failed to store TV credits")
+	}
 
-	if err := s.episodeRepo.AddEpisode(ctx, episode); err != nil {
-		return fmt.Errorf("failed to store episode: %w", err)
+	if err := s.storeSeriesTaxonomy(ctx, series, tmdbShow); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", series.Title).Msg("Failed to store TV taxonomy")
 	}
 
-	return nil
+	if err := s.storeSeriesTranslations(ctx, series, tmdbShow.ID); err != nil {
+		s.appLogger.Warn().Err(err).Str("title", series.Title).Msg("Failed to store TV translations")
+	}
 }
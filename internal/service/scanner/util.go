@@ -7,22 +7,6 @@ import (
 	"strings"
 )
 
-func isVideoFile(path string) bool {
-	ext := strings.ToLower(filepath.Ext(path))
-	videoExts := map[string]bool{
-		".mp4":  true,
-		".mkv":  true,
-		".avi":  true,
-		".mov":  true,
-		".m4v":  true,
-		".webm": true,
-		".wmv":  true, // Added .wmv
-		".flv":  true, // Added .flv
-		".ts":   true, // Added .ts (Transport Stream - common for recordings)
-	}
-	return videoExts[ext]
-}
-
 func isLikelyTVFile(path string) bool {
 	filename := filepath.Base(path)
 	return strings.Contains(filename, "S0") || strings.Contains(filename, "E0") || strings.Contains(strings.ToLower(filename), "s0") || strings.Contains(strings.ToLower(filename), "e0")
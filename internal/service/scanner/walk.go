@@ -0,0 +1,115 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/pathutil"
+)
+
+// walkState tracks directories and files already visited during a library
+// scan so symlink cycles don't loop forever and hardlinked copies of the
+// same file only import once.
+type walkState struct {
+	visitedDirs map[string]bool // resolved directory paths
+	seenFiles   map[string]bool // file IDs (device/inode on unix)
+	report      *scanReport     // counters for the scan's summary
+
+	// Buffers for batch inserts; items without follow-up work queue here
+	// and flush in chunks instead of one INSERT per file
+	pendingMovies   []*entity.Movie
+	pendingEpisodes []*entity.Episode
+}
+
+func newWalkState(report *scanReport) *walkState {
+	return &walkState{
+		visitedDirs: make(map[string]bool),
+		seenFiles:   make(map[string]bool),
+		report:      report,
+	}
+}
+
+// walkDir walks the directory tree rooted at dir, following symlinked
+// directories when enabled in the config. pathType is the owning library
+// path's content type override.
+func (s *service) walkDir(ctx context.Context, lib *entity.Library, dir string, pathType entity.LibraryType, state *walkState) error {
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path '%s': %w", dir, err)
+	}
+
+	if state.visitedDirs[resolved] {
+		s.appLogger.Debug().Str("path", dir).Msg("Skipping already visited directory (symlink cycle)")
+		return nil
+	}
+	state.visitedDirs[resolved] = true
+
+	entries, err := os.ReadDir(pathutil.Long(dir))
+	if err != nil {
+		return fmt.Errorf("failed to read directory '%s': %w", dir, err)
+	}
+
+	for _, entry := range entries {
+		fullPath := filepath.Join(dir, entry.Name())
+
+		info, err := entry.Info()
+		if err != nil {
+			s.appLogger.Warn().Err(err).Str("path", fullPath).Msg("Failed to stat directory entry")
+			continue
+		}
+
+		// Resolve symlinks to their target when configured to follow them
+		if info.Mode()&os.ModeSymlink != 0 {
+			if !s.config.Jobs.Scanner.FollowSymlinks {
+				continue
+			}
+			info, err = os.Stat(pathutil.Long(fullPath))
+			if err != nil {
+				s.appLogger.Warn().Err(err).Str("path", fullPath).Msg("Failed to resolve symlink")
+				continue
+			}
+		}
+
+		if info.IsDir() {
+			if skipDir(entry.Name()) {
+				s.appLogger.Debug().Str("path", fullPath).Msg("Skipping ignored directory")
+				continue
+			}
+			if err := s.walkDir(ctx, lib, fullPath, pathType, state); err != nil {
+				s.appLogger.Warn().Err(err).Str("path", fullPath).Msg("Failed to walk directory")
+			}
+			continue
+		}
+
+		if !s.shouldScan(lib, pathType, fullPath) {
+			continue
+		}
+
+		// Junk filtering (samples, trailers, undersized files) runs after
+		// the extension check so only would-be media counts as skipped
+		if reason, skip := s.exclusions.exclude(info, fullPath, s.extensions.typeOf(fullPath) == fileTypeVideo); skip {
+			state.report.skipped++
+			s.appLogger.Debug().Str("path", fullPath).Str("reason", reason).Msg("Skipping excluded file")
+			continue
+		}
+
+		// Skip hardlinked copies of files already seen this scan
+		if id := fileID(info); id != "" {
+			if state.seenFiles[id] {
+				s.appLogger.Debug().Str("path", fullPath).Msg("Skipping hardlinked copy of already imported file")
+				continue
+			}
+			state.seenFiles[id] = true
+		}
+
+		if err := s.processFile(ctx, lib, pathType, fullPath, state); err != nil {
+			state.report.errors++
+			s.appLogger.Error().Err(err).Str("path", fullPath).Msg("Failed to process file")
+		}
+	}
+
+	return nil
+}
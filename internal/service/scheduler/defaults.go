@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// defaultTasks builds the default scanner, cleanup and metadata refresh
+// tasks, using intervals from the config where they are set.
+func (s *scheduler) defaultTasks() []*entity.ScheduledTask {
+	scanInterval := validInterval(s.config.Jobs.Scanner.ScanInterval, "12h")
+	cleanInterval := validInterval(s.config.Jobs.Cleanup.CleanInterval, "24h")
+
+	return []*entity.ScheduledTask{
+		{
+			Name:        "Library Scan",
+			Type:        "scanner",
+			Description: "Scans media libraries for new files.",
+			Enabled:     s.config.Jobs.Scanner.AutoScan,
+			Interval:    scanInterval,
+			Status:      entity.StatusIdle,
+		},
+		{
+			Name:        "Library Cleanup",
+			Type:        "cleanup",
+			Description: "Removes missing and orphaned library entries.",
+			Enabled:     s.config.Jobs.Cleanup.Enabled,
+			Interval:    cleanInterval,
+			Status:      entity.StatusIdle,
+		},
+		{
+			Name:        "Metadata Refresh",
+			Type:        "metadata",
+			Description: "Refreshes metadata for stale library items.",
+			Enabled:     true,
+			Interval:    "168h",
+			Status:      entity.StatusIdle,
+		},
+		{
+			Name:        "Database Maintenance",
+			Type:        "maintenance",
+			Description: "Runs database maintenance (vacuum, optimise, analyse).",
+			// The hourly trigger only fires the task inside the
+			// maintenance window; runs outside it are deferred
+			Enabled:  s.config.MaintenanceWindowConfigured(),
+			Interval: "1h",
+			Status:   entity.StatusIdle,
+		},
+		{
+			Name:        "Recommendations Refresh",
+			Type:        "recommendations",
+			Description: "Regenerates personalized recommendation rows for all users.",
+			Enabled:     true,
+			Interval:    "24h",
+			Status:      entity.StatusIdle,
+		},
+		{
+			Name:        "Subtitle Drop Folder",
+			Type:        "subtitles",
+			Description: "Matches subtitle files from the drop folder to library items.",
+			Enabled:     s.config.Jobs.Subtitles.DropDir != "",
+			Interval:    validInterval(s.config.Jobs.Subtitles.SweepInterval, "15m"),
+			Status:      entity.StatusIdle,
+		},
+		{
+			Name:        "Database Backup",
+			Type:        "backup",
+			Description: "Creates a database backup archive and prunes old ones.",
+			Enabled:     s.config.Backup.Enabled && s.config.Backup.AutoBackup && s.config.Backup.BackupDir != "",
+			Interval:    validInterval(s.config.Backup.Interval, "24h"),
+			Status:      entity.StatusIdle,
+		},
+		{
+			Name:        "Artwork Pregeneration",
+			Type:        "artwork",
+			Description: "Downloads library artwork and pre-generates WebP/AVIF variants at common sizes.",
+			Enabled:     true,
+			Interval:    "24h",
+			Status:      entity.StatusIdle,
+		},
+	}
+}
+
+// validInterval returns the configured interval if it parses as a duration,
+// otherwise the fallback.
+func validInterval(configured, fallback string) string {
+	if configured == "" {
+		return fallback
+	}
+	if _, err := time.ParseDuration(configured); err != nil {
+		return fallback
+	}
+	return configured
+}
+
+// EnsureDefaultTasks creates the default tasks when the task table is empty,
+// so scans run on a fresh install without manual setup.
+func (s *scheduler) EnsureDefaultTasks(ctx context.Context) error {
+	tasks, err := s.repo.ListTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+	if len(tasks) > 0 {
+		return nil
+	}
+
+	s.appLogger.Info().Msg("No scheduled tasks found, creating defaults")
+	for _, task := range s.defaultTasks() {
+		if err := s.repo.AddTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to create default task '%s': %w", task.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// RestoreDefaultTasks recreates any missing default tasks and resets existing
+// ones to their default type, interval and enabled state.
+func (s *scheduler) RestoreDefaultTasks(ctx context.Context) error {
+	tasks, err := s.repo.ListTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	byName := make(map[string]*entity.ScheduledTask, len(tasks))
+	for _, task := range tasks {
+		byName[task.Name] = task
+	}
+
+	for _, defaultTask := range s.defaultTasks() {
+		existing, ok := byName[defaultTask.Name]
+		if !ok {
+			if err := s.repo.AddTask(ctx, defaultTask); err != nil {
+				return fmt.Errorf("failed to restore default task '%s': %w", defaultTask.Name, err)
+			}
+			continue
+		}
+
+		existing.Type = defaultTask.Type
+		existing.Description = defaultTask.Description
+		existing.Enabled = defaultTask.Enabled
+		existing.Interval = defaultTask.Interval
+		if err := s.repo.UpdateTask(ctx, existing); err != nil {
+			return fmt.Errorf("failed to reset default task '%s': %w", defaultTask.Name, err)
+		}
+	}
+
+	return nil
+}
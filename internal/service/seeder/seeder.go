@@ -0,0 +1,242 @@
+package seeder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+type Service interface {
+	Seed(ctx context.Context, fixturesPath string) error
+}
+
+type service struct {
+	appLogger     logger.Logger
+	libraryRepo   repository.LibraryRepository
+	userRepo      repository.UserRepository
+	schedulerRepo repository.SchedulerRepository
+}
+
+// Fixtures is the on-disk format accepted by `cinea seed --fixtures=path`.
+type Fixtures struct {
+	Libraries []LibraryFixture `yaml:"libraries" json:"libraries"`
+	Users     []UserFixture    `yaml:"users" json:"users"`
+	Tasks     []TaskFixture    `yaml:"tasks" json:"tasks"`
+}
+
+type LibraryFixture struct {
+	Name         string   `yaml:"name" json:"name"`
+	Type         string   `yaml:"type" json:"type"`
+	Description  string   `yaml:"description" json:"description"`
+	Paths        []string `yaml:"paths" json:"paths"`
+	AutoScan     bool     `yaml:"auto_scan" json:"auto_scan"`
+	ScanInterval string   `yaml:"scan_interval" json:"scan_interval"`
+}
+
+type UserFixture struct {
+	Username string `yaml:"username" json:"username"`
+	Email    string `yaml:"email" json:"email"`
+	Password string `yaml:"password" json:"password"`
+	Role     string `yaml:"role" json:"role"`
+}
+
+type TaskFixture struct {
+	Name        string `yaml:"name" json:"name"`
+	Type        string `yaml:"type" json:"type"`
+	Description string `yaml:"description" json:"description"`
+	Enabled     bool   `yaml:"enabled" json:"enabled"`
+	Interval    string `yaml:"interval" json:"interval"`
+	Config      string `yaml:"config" json:"config"`
+}
+
+func NewSeederService(
+	appLogger logger.Logger,
+	libraryRepo repository.LibraryRepository,
+	userRepo repository.UserRepository,
+	schedulerRepo repository.SchedulerRepository,
+) Service {
+	return &service{
+		appLogger:     appLogger,
+		libraryRepo:   libraryRepo,
+		userRepo:      userRepo,
+		schedulerRepo: schedulerRepo,
+	}
+}
+
+// Seed loads libraries, users and scheduled tasks from a fixture file.
+// Existing records (matched by name or username) are left untouched, so
+// seeding the same file twice is safe.
+func (s *service) Seed(ctx context.Context, fixturesPath string) error {
+	fixtures, err := loadFixtures(fixturesPath)
+	if err != nil {
+		return fmt.Errorf("failed to load fixtures: %w", err)
+	}
+
+	if err := s.seedLibraries(ctx, fixtures.Libraries); err != nil {
+		return err
+	}
+	if err := s.seedUsers(ctx, fixtures.Users); err != nil {
+		return err
+	}
+	if err := s.seedTasks(ctx, fixtures.Tasks); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func loadFixtures(path string) (*Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := &Fixtures{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		if err := json.Unmarshal(data, fixtures); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON fixtures: %w", err)
+		}
+	default:
+		if err := yaml.Unmarshal(data, fixtures); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML fixtures: %w", err)
+		}
+	}
+
+	return fixtures, nil
+}
+
+func (s *service) seedLibraries(ctx context.Context, fixtures []LibraryFixture) error {
+	existing, err := s.libraryRepo.ListLibraries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list libraries: %w", err)
+	}
+
+	byName := make(map[string]bool, len(existing))
+	for _, lib := range existing {
+		byName[lib.Name] = true
+	}
+
+	for _, fixture := range fixtures {
+		if byName[fixture.Name] {
+			s.appLogger.Debug().Str("library", fixture.Name).Msg("Library already exists, skipping")
+			continue
+		}
+
+		scanInterval := 12 * time.Hour
+		if fixture.ScanInterval != "" {
+			scanInterval, err = time.ParseDuration(fixture.ScanInterval)
+			if err != nil {
+				return fmt.Errorf("invalid scan interval '%s' for library '%s': %w", fixture.ScanInterval, fixture.Name, err)
+			}
+		}
+
+		lib := &entity.Library{
+			Name:         fixture.Name,
+			Type:         entity.LibraryType(fixture.Type),
+			Description:  fixture.Description,
+			AutoScan:     fixture.AutoScan,
+			ScanInterval: scanInterval,
+		}
+		for _, path := range fixture.Paths {
+			lib.Paths = append(lib.Paths, entity.LibraryPath{
+				Path:    os.ExpandEnv(path),
+				Enabled: true,
+			})
+		}
+
+		if err := s.libraryRepo.CreateLibrary(ctx, lib); err != nil {
+			return fmt.Errorf("failed to create library '%s': %w", fixture.Name, err)
+		}
+		s.appLogger.Info().Str("library", fixture.Name).Msg("Seeded library")
+	}
+
+	return nil
+}
+
+func (s *service) seedUsers(ctx context.Context, fixtures []UserFixture) error {
+	for _, fixture := range fixtures {
+		existing, err := s.userRepo.FindByUsername(ctx, fixture.Username)
+		if err != nil {
+			return fmt.Errorf("failed to check for existing user '%s': %w", fixture.Username, err)
+		}
+		if existing != nil {
+			s.appLogger.Debug().Str("username", fixture.Username).Msg("User already exists, skipping")
+			continue
+		}
+
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(fixture.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("failed to hash password for user '%s': %w", fixture.Username, err)
+		}
+
+		role := entity.UserRole(fixture.Role)
+		if role == "" {
+			role = entity.RoleUser
+		}
+
+		user := &entity.User{
+			Username: fixture.Username,
+			Email:    fixture.Email,
+			Password: string(hashedPassword),
+			Role:     role,
+		}
+
+		if err := s.userRepo.Store(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user '%s': %w", fixture.Username, err)
+		}
+		s.appLogger.Info().Str("username", fixture.Username).Msg("Seeded user")
+	}
+
+	return nil
+}
+
+func (s *service) seedTasks(ctx context.Context, fixtures []TaskFixture) error {
+	existing, err := s.schedulerRepo.ListTasks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	byName := make(map[string]bool, len(existing))
+	for _, task := range existing {
+		byName[task.Name] = true
+	}
+
+	for _, fixture := range fixtures {
+		if byName[fixture.Name] {
+			s.appLogger.Debug().Str("task", fixture.Name).Msg("Task already exists, skipping")
+			continue
+		}
+
+		if _, err := time.ParseDuration(fixture.Interval); err != nil {
+			return fmt.Errorf("invalid interval '%s' for task '%s': %w", fixture.Interval, fixture.Name, err)
+		}
+
+		task := &entity.ScheduledTask{
+			Name:        fixture.Name,
+			Type:        fixture.Type,
+			Description: fixture.Description,
+			Enabled:     fixture.Enabled,
+			Interval:    fixture.Interval,
+			Status:      entity.StatusIdle,
+			Config:      fixture.Config,
+		}
+
+		if err := s.schedulerRepo.AddTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to create task '%s': %w", fixture.Name, err)
+		}
+		s.appLogger.Info().Str("task", fixture.Name).Msg("Seeded task")
+	}
+
+	return nil
+}
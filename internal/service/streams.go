@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/logger"
+)
+
+// streamSessionIdleTimeout is how long a session survives without a
+// heartbeat before it stops counting against the quota; it covers clients
+// that disappear without ending their session.
+const streamSessionIdleTimeout = 2 * time.Minute
+
+// StreamSessionService tracks active stream sessions in memory and
+// enforces the configured per-user and server-wide concurrency limits.
+// Clients begin a session before streaming, heartbeat while playing and
+// end it when playback stops; a user over their limit can terminate one
+// of their own sessions remotely to free a slot.
+type StreamSessionService interface {
+	Begin(ctx context.Context, userID uint, mediaType string, mediaID uint, client string) (*dto.StreamSessionDTO, error)
+	Heartbeat(ctx context.Context, userID uint, sessionID string) error
+	End(ctx context.Context, userID uint, sessionID string) error
+	List(ctx context.Context, userID uint) ([]*dto.StreamSessionDTO, error)
+}
+
+type streamSession struct {
+	id        string
+	userID    uint
+	mediaType string
+	mediaID   uint
+	client    string
+	startedAt time.Time
+	lastSeen  time.Time
+}
+
+type streamSessionService struct {
+	config    *config.Config
+	appLogger logger.Logger
+
+	mu       sync.Mutex
+	sessions map[string]*streamSession
+}
+
+func NewStreamSessionService(cfg *config.Config, appLogger logger.Logger) StreamSessionService {
+	return &streamSessionService{
+		config:    cfg,
+		appLogger: appLogger,
+		sessions:  make(map[string]*streamSession),
+	}
+}
+
+// Begin registers a new stream session, refusing it when the user or the
+// server is at their configured limit. The quota error names the user's
+// active sessions so clients can offer to terminate one.
+func (s *streamSessionService) Begin(ctx context.Context, userID uint, mediaType string, mediaID uint, client string) (*dto.StreamSessionDTO, error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate session ID: %w", err)
+	}
+	id := hex.EncodeToString(raw)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+
+	var owned []*streamSession
+	for _, session := range s.sessions {
+		if session.userID == userID {
+			owned = append(owned, session)
+		}
+	}
+
+	if limit := s.config.Playback.MaxStreamsPerUser; limit > 0 && len(owned) >= limit {
+		return nil, fmt.Errorf("stream limit of %d reached; active sessions: %s: %w",
+			limit, describeStreamSessions(owned), errors.ErrQuotaExceeded)
+	}
+	if limit := s.config.Playback.MaxStreamsTotal; limit > 0 && len(s.sessions) >= limit {
+		return nil, fmt.Errorf("the server's stream limit of %d is reached: %w",
+			limit, errors.ErrQuotaExceeded)
+	}
+
+	now := time.Now()
+	session := &streamSession{
+		id:        id,
+		userID:    userID,
+		mediaType: mediaType,
+		mediaID:   mediaID,
+		client:    client,
+		startedAt: now,
+		lastSeen:  now,
+	}
+	s.sessions[id] = session
+
+	s.appLogger.Info().
+		Uint("userID", userID).
+		Str("mediaType", mediaType).
+		Uint("mediaID", mediaID).
+		Str("session", id).
+		Msg("Stream session started")
+	return streamSessionToDTO(session), nil
+}
+
+// Heartbeat marks the session as still playing, keeping it from being
+// reclaimed as idle.
+func (s *streamSessionService) Heartbeat(ctx context.Context, userID uint, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || session.userID != userID {
+		return errors.NotFoundError("stream session")
+	}
+	session.lastSeen = time.Now()
+	return nil
+}
+
+// End terminates one of the user's own sessions; ending someone else's
+// session reports not found rather than leaking that it exists.
+func (s *streamSessionService) End(ctx context.Context, userID uint, sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || session.userID != userID {
+		return errors.NotFoundError("stream session")
+	}
+	delete(s.sessions, sessionID)
+
+	s.appLogger.Info().
+		Uint("userID", userID).
+		Str("session", sessionID).
+		Msg("Stream session ended")
+	return nil
+}
+
+// List returns the user's active sessions, oldest first.
+func (s *streamSessionService) List(ctx context.Context, userID uint) ([]*dto.StreamSessionDTO, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruneLocked()
+
+	sessions := make([]*dto.StreamSessionDTO, 0)
+	for _, session := range s.sessions {
+		if session.userID == userID {
+			sessions = append(sessions, streamSessionToDTO(session))
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+// pruneLocked drops sessions whose heartbeat has gone quiet. Callers must
+// hold the mutex.
+func (s *streamSessionService) pruneLocked() {
+	cutoff := time.Now().Add(-streamSessionIdleTimeout)
+	for id, session := range s.sessions {
+		if session.lastSeen.Before(cutoff) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// describeStreamSessions renders sessions for the quota error message,
+// e.g. "movie 12 on Living Room TV (since 14:02)".
+func describeStreamSessions(sessions []*streamSession) string {
+	parts := make([]string, 0, len(sessions))
+	for _, session := range sessions {
+		part := fmt.Sprintf("%s %d", session.mediaType, session.mediaID)
+		if session.client != "" {
+			part += " on " + session.client
+		}
+		part += fmt.Sprintf(" (since %s)", session.startedAt.Format("15:04"))
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func streamSessionToDTO(session *streamSession) *dto.StreamSessionDTO {
+	return &dto.StreamSessionDTO{
+		ID:        session.id,
+		MediaType: session.mediaType,
+		MediaID:   session.mediaID,
+		Client:    session.client,
+		StartedAt: session.startedAt,
+	}
+}
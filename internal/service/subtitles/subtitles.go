@@ -0,0 +1,216 @@
+package subtitles
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/pathutil"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// subtitleExtensions are the file types picked up from the drop folder.
+var subtitleExtensions = map[string]bool{
+	".srt": true,
+	".sub": true,
+	".ass": true,
+	".ssa": true,
+	".vtt": true,
+}
+
+type Service interface {
+	SweepDropFolder(ctx context.Context) error
+
+	// Task scheduler methods
+	Execute(ctx context.Context, config string) error
+	Description() string
+}
+
+type service struct {
+	config       *config.Config
+	appLogger    logger.Logger
+	movieRepo    repository.MovieRepository
+	episodeRepo  repository.EpisodeRepository
+	subtitleRepo repository.SubtitleRepository
+}
+
+func NewService(
+	cfg *config.Config,
+	appLogger logger.Logger,
+	movieRepo repository.MovieRepository,
+	episodeRepo repository.EpisodeRepository,
+	subtitleRepo repository.SubtitleRepository,
+) Service {
+	return &service{
+		config:       cfg,
+		appLogger:    appLogger,
+		movieRepo:    movieRepo,
+		episodeRepo:  episodeRepo,
+		subtitleRepo: subtitleRepo,
+	}
+}
+
+// Execute implements the scheduler.TaskExecutor interface
+func (s *service) Execute(ctx context.Context, config string) error {
+	return s.SweepDropFolder(ctx)
+}
+
+func (s *service) Description() string {
+	return "Matches subtitle files from the drop folder to library items."
+}
+
+// SweepDropFolder checks the configured drop folder for subtitle files,
+// matches each one to a movie or episode by file name, moves it alongside
+// the media and registers it as a subtitle track. Files that can't be
+// matched stay in the folder for the next sweep.
+func (s *service) SweepDropFolder(ctx context.Context) error {
+	dropDir := s.config.Jobs.Subtitles.DropDir
+	if dropDir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(pathutil.Long(dropDir))
+	if err != nil {
+		return fmt.Errorf("failed to read subtitle drop folder '%s': %w", dropDir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !subtitleExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			continue
+		}
+
+		if err := s.processDrop(ctx, filepath.Join(dropDir, entry.Name())); err != nil {
+			s.appLogger.Warn().Err(err).Str("file", entry.Name()).Msg("Failed to process dropped subtitle")
+		}
+	}
+
+	return nil
+}
+
+func (s *service) processDrop(ctx context.Context, dropPath string) error {
+	name := filepath.Base(dropPath)
+	ext := filepath.Ext(name)
+	stem, lang := splitLanguageSuffix(strings.TrimSuffix(name, ext))
+
+	mediaType, mediaID, mediaPath, err := s.matchMedia(ctx, stem)
+	if err != nil {
+		return err
+	}
+	if mediaPath == "" {
+		s.appLogger.Debug().Str("file", name).Msg("No library item matches dropped subtitle, leaving in drop folder")
+		return nil
+	}
+
+	// Move the file alongside the media, named after it so players and the
+	// streamer pick it up
+	mediaBase := filepath.Base(mediaPath)
+	destName := strings.TrimSuffix(mediaBase, filepath.Ext(mediaBase))
+	if lang != "" {
+		destName += "." + lang
+	}
+	destPath := filepath.Join(filepath.Dir(mediaPath), destName+ext)
+
+	if err := moveFile(pathutil.Long(dropPath), pathutil.Long(destPath)); err != nil {
+		return fmt.Errorf("failed to move subtitle into place: %w", err)
+	}
+
+	existing, err := s.subtitleRepo.FindByPath(ctx, destPath)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		track := &entity.SubtitleTrack{
+			MediaType: mediaType,
+			MediaID:   mediaID,
+			Language:  lang,
+			FilePath:  destPath,
+		}
+		if err := s.subtitleRepo.Store(ctx, track); err != nil {
+			return err
+		}
+	}
+
+	s.appLogger.Info().
+		Str("file", name).
+		Str("media", mediaBase).
+		Str("language", lang).
+		Msg("Registered dropped subtitle")
+	return nil
+}
+
+// matchMedia finds the movie or episode whose file name matches the
+// subtitle's stem.
+func (s *service) matchMedia(ctx context.Context, stem string) (mediaType string, mediaID uint, mediaPath string, err error) {
+	movie, err := s.movieRepo.FindByFileStem(ctx, stem)
+	if err != nil {
+		return "", 0, "", err
+	}
+	if movie != nil {
+		return "movie", movie.ID, movie.FilePath, nil
+	}
+
+	episode, err := s.episodeRepo.FindByFileStem(ctx, stem)
+	if err != nil {
+		return "", 0, "", err
+	}
+	if episode != nil {
+		return "episode", episode.ID, episode.FilePath, nil
+	}
+
+	return "", 0, "", nil
+}
+
+// splitLanguageSuffix strips a trailing language tag like ".en" or ".eng"
+// from a subtitle file stem.
+func splitLanguageSuffix(stem string) (string, string) {
+	idx := strings.LastIndex(stem, ".")
+	if idx <= 0 {
+		return stem, ""
+	}
+
+	suffix := strings.ToLower(stem[idx+1:])
+	if len(suffix) != 2 && len(suffix) != 3 {
+		return stem, ""
+	}
+	for _, r := range suffix {
+		if r < 'a' || r > 'z' {
+			return stem, ""
+		}
+	}
+	return stem[:idx], suffix
+}
+
+// moveFile renames the file, falling back to copy-and-delete when the drop
+// folder is on a different filesystem than the library.
+func moveFile(src, dest string) error {
+	if err := os.Rename(src, dest); err == nil {
+		return nil
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dest)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
+}
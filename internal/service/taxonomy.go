@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+type TaxonomyService interface {
+	ListGenres(ctx context.Context) ([]*dto.GenreDTO, error)
+	ListStudios(ctx context.Context) ([]*dto.StudioDTO, error)
+	ListTags(ctx context.Context) ([]*dto.TagDTO, error)
+	CreateTag(ctx context.Context, name string, createdBy uint) (*dto.TagDTO, error)
+	TagMedia(ctx context.Context, mediaType string, mediaID uint, tagID uint) error
+	UntagMedia(ctx context.Context, mediaType string, mediaID uint, tagID uint) error
+}
+
+type taxonomyService struct {
+	taxonomyRepo repository.TaxonomyRepository
+}
+
+func NewTaxonomyService(taxonomyRepo repository.TaxonomyRepository) TaxonomyService {
+	return &taxonomyService{
+		taxonomyRepo: taxonomyRepo,
+	}
+}
+
+func (s *taxonomyService) ListGenres(ctx context.Context) ([]*dto.GenreDTO, error) {
+	genres, err := s.taxonomyRepo.ListGenres(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list genres: %w", err)
+	}
+	return dto.GenresToDTO(genres), nil
+}
+
+func (s *taxonomyService) ListStudios(ctx context.Context) ([]*dto.StudioDTO, error) {
+	studios, err := s.taxonomyRepo.ListStudios(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list studios: %w", err)
+	}
+	return dto.StudiosToDTO(studios), nil
+}
+
+func (s *taxonomyService) ListTags(ctx context.Context) ([]*dto.TagDTO, error) {
+	tags, err := s.taxonomyRepo.ListTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	return dto.TagsToDTO(tags), nil
+}
+
+func (s *taxonomyService) CreateTag(ctx context.Context, name string, createdBy uint) (*dto.TagDTO, error) {
+	if name == "" {
+		return nil, fmt.Errorf("tag name is required: %w", errors.ErrBadRequest)
+	}
+
+	tag, err := s.taxonomyRepo.FindOrCreateTag(ctx, name, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+	return dto.TagToDTO(tag), nil
+}
+
+func (s *taxonomyService) TagMedia(ctx context.Context, mediaType string, mediaID uint, tagID uint) error {
+	if err := validateTagMediaType(mediaType); err != nil {
+		return err
+	}
+	return s.taxonomyRepo.AddMediaTag(ctx, mediaType, mediaID, tagID)
+}
+
+func (s *taxonomyService) UntagMedia(ctx context.Context, mediaType string, mediaID uint, tagID uint) error {
+	if err := validateTagMediaType(mediaType); err != nil {
+		return err
+	}
+	return s.taxonomyRepo.RemoveMediaTag(ctx, mediaType, mediaID, tagID)
+}
+
+func validateTagMediaType(mediaType string) error {
+	switch mediaType {
+	case "movie", "series":
+		return nil
+	default:
+		return fmt.Errorf("unsupported media type '%s': %w", mediaType, errors.ErrBadRequest)
+	}
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// TrashService is the admin recycle bin over the media tables' soft
+// deletes: listing what sits in the bin, restoring entries and purging
+// them for good. The scheduled purge of old entries runs in the cleanup
+// job.
+type TrashService interface {
+	List(ctx context.Context) ([]*dto.TrashItemDTO, error)
+	Restore(ctx context.Context, mediaType string, id uint) error
+	Purge(ctx context.Context, mediaType string, id uint) error
+}
+
+type trashService struct {
+	appLogger   logger.Logger
+	movieRepo   repository.MovieRepository
+	seriesRepo  repository.SeriesRepository
+	episodeRepo repository.EpisodeRepository
+}
+
+func NewTrashService(appLogger logger.Logger, movieRepo repository.MovieRepository, seriesRepo repository.SeriesRepository, episodeRepo repository.EpisodeRepository) TrashService {
+	return &trashService{
+		appLogger:   appLogger,
+		movieRepo:   movieRepo,
+		seriesRepo:  seriesRepo,
+		episodeRepo: episodeRepo,
+	}
+}
+
+// List returns everything in the recycle bin, newest deletions first.
+func (s *trashService) List(ctx context.Context) ([]*dto.TrashItemDTO, error) {
+	var items []*dto.TrashItemDTO
+
+	movies, err := s.movieRepo.FindAllDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, movie := range movies {
+		items = append(items, &dto.TrashItemDTO{
+			ID:        movie.ID,
+			MediaType: "movie",
+			Title:     movie.Title,
+			FilePath:  movie.FilePath,
+			DeletedAt: movie.DeletedAt.Time,
+		})
+	}
+
+	shows, err := s.seriesRepo.FindAllDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, show := range shows {
+		items = append(items, &dto.TrashItemDTO{
+			ID:        show.ID,
+			MediaType: "series",
+			Title:     show.Title,
+			DeletedAt: show.DeletedAt.Time,
+		})
+	}
+
+	episodes, err := s.episodeRepo.FindAllDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, episode := range episodes {
+		items = append(items, &dto.TrashItemDTO{
+			ID:        episode.ID,
+			MediaType: "episode",
+			Title:     episode.Title,
+			FilePath:  episode.FilePath,
+			DeletedAt: episode.DeletedAt.Time,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// Restore brings a soft-deleted item back into the library.
+func (s *trashService) Restore(ctx context.Context, mediaType string, id uint) error {
+	var err error
+	switch mediaType {
+	case "movie":
+		err = s.movieRepo.Restore(ctx, id)
+	case "series":
+		err = s.seriesRepo.Restore(ctx, id)
+	case "episode":
+		err = s.episodeRepo.Restore(ctx, id)
+	default:
+		return fmt.Errorf("unsupported media type '%s'", mediaType)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.appLogger.Info().Str("media_type", mediaType).Uint("id", id).Msg("Restored item from the recycle bin")
+	return nil
+}
+
+// Purge removes an item from the recycle bin for good.
+func (s *trashService) Purge(ctx context.Context, mediaType string, id uint) error {
+	var err error
+	switch mediaType {
+	case "movie":
+		err = s.movieRepo.HardDelete(ctx, id)
+	case "series":
+		err = s.seriesRepo.HardDelete(ctx, id)
+	case "episode":
+		err = s.episodeRepo.HardDelete(ctx, id)
+	default:
+		return fmt.Errorf("unsupported media type '%s'", mediaType)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.appLogger.Info().Str("media_type", mediaType).Uint("id", id).Msg("Purged item from the recycle bin")
+	return nil
+}
@@ -1,27 +1,60 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/samcharles93/cinea/config"
+	"github.com/samcharles93/cinea/internal/dto"
 	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/events"
 	"github.com/samcharles93/cinea/internal/repository"
+	"github.com/samcharles93/cinea/internal/upload"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// Accepted playback speed range; matches what mainstream players offer.
+const (
+	playbackSpeedMin = 0.25
+	playbackSpeedMax = 4.0
+)
+
 type UserService interface {
 	Authenticate(ctx context.Context, username, password string) (*entity.User, error)
 	CreateUser(ctx context.Context, username, email, password string) (*entity.User, error)
 	ListUsers(ctx context.Context) ([]*entity.User, error)
+	CountUsers(ctx context.Context) (int64, error)
+	DeleteUser(ctx context.Context, id uint) error
+	UpdateLastSeen(ctx context.Context, id uint) error
+	UpdatePreferences(ctx context.Context, id uint, req *dto.UpdatePreferencesRequest) (*entity.User, error)
+	UpdateRestrictions(ctx context.Context, id uint, req *dto.UpdateRestrictionsRequest) (*entity.User, error)
+	SetAvatar(ctx context.Context, id uint, r io.Reader) (*entity.User, error)
+	AvatarPath(ctx context.Context, id uint) (string, error)
 }
 
 type userService struct {
-	userRepo repository.UserRepository
+	config      *config.Config
+	userRepo    repository.UserRepository
+	libraryRepo repository.LibraryRepository
+	bus         events.Bus
 }
 
-func NewUserService(userRepo repository.UserRepository) UserService {
+func NewUserService(cfg *config.Config, userRepo repository.UserRepository, libraryRepo repository.LibraryRepository, bus events.Bus) UserService {
 	return &userService{
-		userRepo: userRepo,
+		config:      cfg,
+		userRepo:    userRepo,
+		libraryRepo: libraryRepo,
+		bus:         bus,
 	}
 }
 
@@ -75,3 +108,274 @@ func (s *userService) CreateUser(ctx context.Context, username string, email str
 func (s *userService) ListUsers(ctx context.Context) ([]*entity.User, error) {
 	return s.userRepo.List(ctx)
 }
+
+// CountUsers reports how many accounts exist; zero means first-run setup
+// has not been completed yet.
+func (s *userService) CountUsers(ctx context.Context) (int64, error) {
+	return s.userRepo.Count(ctx)
+}
+
+// DeleteUser
+func (s *userService) DeleteUser(ctx context.Context, id uint) error {
+	// Resolve the username first so the audit trail names the account
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err := s.userRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	payload := events.UserPayload{UserID: id}
+	if user != nil {
+		payload.Username = user.Username
+	}
+	s.bus.Publish(ctx, events.UserDeleted, payload)
+	return nil
+}
+
+// UpdateLastSeen
+func (s *userService) UpdateLastSeen(ctx context.Context, id uint) error {
+	if err := s.userRepo.UpdateLastLogin(ctx, id); err != nil {
+		return fmt.Errorf("failed to update last seen: %w", err)
+	}
+	return nil
+}
+
+// UpdatePreferences applies a partial preference update and returns the
+// updated user.
+func (s *userService) UpdatePreferences(ctx context.Context, id uint, req *dto.UpdatePreferencesRequest) (*entity.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("user not found")
+	}
+
+	if req.PreferAudioDescription != nil {
+		user.PreferAudioDescription = *req.PreferAudioDescription
+	}
+	if req.PreferSDH != nil {
+		user.PreferSDH = *req.PreferSDH
+	}
+	if req.ShareActivity != nil {
+		user.ShareActivity = *req.ShareActivity
+	}
+	if req.NotifyNewEpisodes != nil {
+		user.NotifyNewEpisodes = *req.NotifyNewEpisodes
+	}
+	if req.NotifyTaskFailures != nil {
+		user.NotifyTaskFailures = *req.NotifyTaskFailures
+	}
+	if req.NotifyNewLogin != nil {
+		user.NotifyNewLogin = *req.NotifyNewLogin
+	}
+	if req.DefaultLandingPage != nil {
+		landing := strings.TrimSpace(*req.DefaultLandingPage)
+		switch {
+		case landing == "" || landing == entity.LandingPageDashboard:
+			user.DefaultLandingPage = entity.LandingPageDashboard
+		case strings.HasPrefix(landing, entity.LandingPageLibraryPrefix):
+			libraryID, err := strconv.ParseUint(strings.TrimPrefix(landing, entity.LandingPageLibraryPrefix), 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid landing page '%s': %w", landing, errors.ErrBadRequest)
+			}
+			lib, err := s.libraryRepo.GetLibrary(ctx, uint(libraryID))
+			if err != nil {
+				return nil, fmt.Errorf("failed to check landing library: %w", err)
+			}
+			if lib == nil {
+				return nil, errors.NotFoundError("library")
+			}
+			user.DefaultLandingPage = landing
+		default:
+			return nil, fmt.Errorf("invalid landing page '%s': %w", landing, errors.ErrBadRequest)
+		}
+	}
+
+	if req.PreferredAudioLanguage != nil {
+		user.PreferredAudioLanguage = *req.PreferredAudioLanguage
+	}
+	if req.PreferredSubtitleLanguage != nil {
+		user.PreferredSubtitleLanguage = *req.PreferredSubtitleLanguage
+	}
+	if req.SubtitleMode != nil {
+		switch *req.SubtitleMode {
+		case entity.SubtitleModeOff, entity.SubtitleModeForced, entity.SubtitleModeAlways:
+			user.SubtitleMode = *req.SubtitleMode
+		default:
+			return nil, fmt.Errorf("invalid subtitle mode '%s': %w", *req.SubtitleMode, errors.ErrBadRequest)
+		}
+	}
+	if req.DefaultQuality != nil {
+		user.DefaultQuality = *req.DefaultQuality
+	}
+	if req.PlaybackSpeed != nil {
+		if *req.PlaybackSpeed < playbackSpeedMin || *req.PlaybackSpeed > playbackSpeedMax {
+			return nil, fmt.Errorf("playback speed must be between %g and %g: %w", playbackSpeedMin, playbackSpeedMax, errors.ErrBadRequest)
+		}
+		user.PlaybackSpeed = *req.PlaybackSpeed
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+	return user, nil
+}
+
+// UpdateRestrictions applies a partial parental-control update. The
+// handler restricts it to administrators; the PIN is stored as a bcrypt
+// hash and an empty string removes it.
+func (s *userService) UpdateRestrictions(ctx context.Context, id uint, req *dto.UpdateRestrictionsRequest) (*entity.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.NotFoundError("user")
+	}
+
+	if req.MaxContentRating != nil {
+		rating := strings.ToUpper(strings.TrimSpace(*req.MaxContentRating))
+		if rating != "" {
+			if _, ok := certificationRank[rating]; !ok {
+				return nil, fmt.Errorf("unknown content rating '%s': %w", *req.MaxContentRating, errors.ErrBadRequest)
+			}
+		}
+		user.MaxContentRating = rating
+	}
+	if req.BlockedTags != nil {
+		tags := make([]string, 0, len(*req.BlockedTags))
+		for _, tag := range *req.BlockedTags {
+			if trimmed := strings.TrimSpace(tag); trimmed != "" {
+				tags = append(tags, trimmed)
+			}
+		}
+		user.BlockedTags = strings.Join(tags, ",")
+	}
+	if req.ParentalPIN != nil {
+		if *req.ParentalPIN == "" {
+			user.ParentalPIN = ""
+		} else {
+			if len(*req.ParentalPIN) < 4 {
+				return nil, fmt.Errorf("parental PIN must be at least 4 characters: %w", errors.ErrBadRequest)
+			}
+			hash, err := bcrypt.GenerateFromPassword([]byte(*req.ParentalPIN), bcrypt.DefaultCost)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash parental PIN: %w", err)
+			}
+			user.ParentalPIN = string(hash)
+		}
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update restrictions: %w", err)
+	}
+	return user, nil
+}
+
+// maxAvatarWidth is the width uploaded avatars are scaled down to; they
+// are only ever rendered small, so anything larger wastes disk and
+// bandwidth.
+const maxAvatarWidth = 256
+
+// SetAvatar validates, resizes and stores an avatar image for the user,
+// replacing any previous one. The upload runs through the shared pipeline
+// so metadata is stripped and the content type is sniffed, not trusted.
+func (s *userService) SetAvatar(ctx context.Context, id uint, r io.Reader) (*entity.User, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, errors.NotFoundError("user")
+	}
+
+	img, err := upload.ReadImage(r, upload.MaxImageSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid avatar image: %w", err)
+	}
+	data, err := scaleAvatar(img)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := s.config.AvatarAssetDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create avatar directory: %w", err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d%s", user.ID, img.Extension))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write avatar: %w", err)
+	}
+
+	// The extension can change between uploads; drop the replaced file.
+	// Best-effort: nothing references the old file anymore
+	if user.AvatarPath != "" && user.AvatarPath != path {
+		os.Remove(user.AvatarPath)
+	}
+
+	user.AvatarPath = path
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+	return user, nil
+}
+
+// AvatarPath returns the on-disk path of the user's avatar, or empty when
+// none has been uploaded.
+func (s *userService) AvatarPath(ctx context.Context, id uint) (string, error) {
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return "", errors.NotFoundError("user")
+	}
+	return user.AvatarPath, nil
+}
+
+// scaleAvatar downsizes a validated upload to at most maxAvatarWidth
+// pixels wide, keeping the format the upload pipeline chose. Images that
+// are already small enough pass through untouched.
+func scaleAvatar(img *upload.Image) ([]byte, error) {
+	decoded, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode avatar: %w", err)
+	}
+
+	bounds := decoded.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= maxAvatarWidth || width == 0 {
+		return img.Data, nil
+	}
+
+	// Nearest-neighbour sampling, as for photo thumbnails: good enough at
+	// avatar sizes and avoids an image processing dependency
+	outWidth := maxAvatarWidth
+	outHeight := height * maxAvatarWidth / width
+	if outHeight < 1 {
+		outHeight = 1
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < outHeight; y++ {
+		srcY := bounds.Min.Y + y*height/outHeight
+		for x := 0; x < outWidth; x++ {
+			srcX := bounds.Min.X + x*width/outWidth
+			scaled.Set(x, y, decoded.At(srcX, srcY))
+		}
+	}
+
+	var buf bytes.Buffer
+	if img.Extension == ".jpg" {
+		err = jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: 90})
+	} else {
+		err = png.Encode(&buf, scaled)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+type WatchHistoryService interface {
+	GetWatchHistory(ctx context.Context, userID uint) ([]entity.WatchHistory, error)
+	AddToWatchHistory(ctx context.Context, history *entity.WatchHistory) error
+	UpdateWatchProgress(ctx context.Context, historyID uint, progress float64) error
+	ClearHistory(ctx context.Context, userID uint) ([]entity.WatchHistory, error)
+	MarkWatched(ctx context.Context, userID uint, mediaType string, mediaID uint) error
+	MarkUnwatched(ctx context.Context, userID uint, mediaType string, mediaID uint) error
+}
+
+type watchHistoryService struct {
+	watchHistoryRepo repository.WatchHistoryRepository
+	seasonRepo       repository.SeasonRepository
+	episodeRepo      repository.EpisodeRepository
+}
+
+func NewWatchHistoryService(watchHistoryRepo repository.WatchHistoryRepository, seasonRepo repository.SeasonRepository, episodeRepo repository.EpisodeRepository) WatchHistoryService {
+	return &watchHistoryService{
+		watchHistoryRepo: watchHistoryRepo,
+		seasonRepo:       seasonRepo,
+		episodeRepo:      episodeRepo,
+	}
+}
+
+func (s *watchHistoryService) GetWatchHistory(ctx context.Context, userID uint) ([]entity.WatchHistory, error) {
+	history, err := s.watchHistoryRepo.GetWatchHistory(ctx, userID, auth.ActiveProfileID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watch history: %w", err)
+	}
+	return history, nil
+}
+
+func (s *watchHistoryService) AddToWatchHistory(ctx context.Context, history *entity.WatchHistory) error {
+	history.ProfileID = auth.ActiveProfileID(ctx)
+	if err := s.watchHistoryRepo.AddToWatchHistory(ctx, history); err != nil {
+		return fmt.Errorf("failed to add to watch history: %w", err)
+	}
+	return nil
+}
+
+func (s *watchHistoryService) UpdateWatchProgress(ctx context.Context, historyID uint, progress float64) error {
+	if err := s.watchHistoryRepo.UpdateWatchProgress(ctx, historyID, progress); err != nil {
+		return fmt.Errorf("failed to update watch progress: %w", err)
+	}
+	return nil
+}
+
+func (s *watchHistoryService) ClearHistory(ctx context.Context, userID uint) ([]entity.WatchHistory, error) {
+	history, err := s.watchHistoryRepo.ClearHistory(ctx, userID, auth.ActiveProfileID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear watch history: %w", err)
+	}
+	return history, nil
+}
+
+// MarkWatched records the item as watched. Seasons and series expand to
+// their episodes; episodes already in the history are skipped so repeated
+// calls stay idempotent.
+func (s *watchHistoryService) MarkWatched(ctx context.Context, userID uint, mediaType string, mediaID uint) error {
+	targetType, ids, err := s.expand(ctx, mediaType, mediaID)
+	if err != nil {
+		return err
+	}
+
+	profileID := auth.ActiveProfileID(ctx)
+	watched, err := s.watchHistoryRepo.FindWatchedSet(ctx, userID, profileID, targetType, ids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var entries []entity.WatchHistory
+	for _, id := range ids {
+		if watched[id] {
+			continue
+		}
+		entries = append(entries, entity.WatchHistory{
+			UserID:    userID,
+			ProfileID: profileID,
+			MediaType: targetType,
+			MediaID:   id,
+			WatchedAt: now,
+		})
+	}
+
+	if err := s.watchHistoryRepo.AddBatch(ctx, entries); err != nil {
+		return fmt.Errorf("failed to mark watched: %w", err)
+	}
+	return nil
+}
+
+// MarkUnwatched removes the item from the user's history. Seasons and
+// series expand to their episodes.
+func (s *watchHistoryService) MarkUnwatched(ctx context.Context, userID uint, mediaType string, mediaID uint) error {
+	targetType, ids, err := s.expand(ctx, mediaType, mediaID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.watchHistoryRepo.DeleteForMedia(ctx, userID, auth.ActiveProfileID(ctx), targetType, ids); err != nil {
+		return fmt.Errorf("failed to mark unwatched: %w", err)
+	}
+	return nil
+}
+
+// expand resolves a mark target to the history entries it covers: movies
+// and episodes map to themselves, seasons and series to their episodes.
+func (s *watchHistoryService) expand(ctx context.Context, mediaType string, mediaID uint) (string, []uint, error) {
+	switch mediaType {
+	case "movie", "episode":
+		return mediaType, []uint{mediaID}, nil
+	case "season":
+		season, err := s.seasonRepo.FindBySeriesID(ctx, mediaID)
+		if err != nil {
+			return "", nil, err
+		}
+		if season == nil {
+			return "", nil, fmt.Errorf("season %d does not exist: %w", mediaID, errors.ErrNotFound)
+		}
+		ids := make([]uint, 0, len(season.Episodes))
+		for _, episode := range season.Episodes {
+			ids = append(ids, episode.ID)
+		}
+		return "episode", ids, nil
+	case "series":
+		episodes, err := s.episodeRepo.ListBySeries(ctx, mediaID)
+		if err != nil {
+			return "", nil, err
+		}
+		ids := make([]uint, 0, len(episodes))
+		for _, episode := range episodes {
+			ids = append(ids, episode.ID)
+		}
+		return "episode", ids, nil
+	default:
+		return "", nil, fmt.Errorf("invalid media type %q: %w", mediaType, errors.ErrBadRequest)
+	}
+}
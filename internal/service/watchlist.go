@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+type WatchlistService interface {
+	GetWatchlist(ctx context.Context, userID uint) ([]entity.Watchlist, error)
+	AddToWatchlist(ctx context.Context, item *entity.Watchlist) error
+	RemoveFromWatchlist(ctx context.Context, userID uint, mediaID uint, mediaType string) error
+}
+
+type watchlistService struct {
+	watchlistRepo repository.WatchlistRepository
+}
+
+func NewWatchlistService(watchlistRepo repository.WatchlistRepository) WatchlistService {
+	return &watchlistService{
+		watchlistRepo: watchlistRepo,
+	}
+}
+
+func (s *watchlistService) GetWatchlist(ctx context.Context, userID uint) ([]entity.Watchlist, error) {
+	watchlist, err := s.watchlistRepo.GetWatchlist(ctx, userID, auth.ActiveProfileID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get watchlist: %w", err)
+	}
+	return watchlist, nil
+}
+
+func (s *watchlistService) AddToWatchlist(ctx context.Context, item *entity.Watchlist) error {
+	item.ProfileID = auth.ActiveProfileID(ctx)
+	if err := s.watchlistRepo.AddToWatchlist(ctx, item); err != nil {
+		return fmt.Errorf("failed to add to watchlist: %w", err)
+	}
+	return nil
+}
+
+func (s *watchlistService) RemoveFromWatchlist(ctx context.Context, userID uint, mediaID uint, mediaType string) error {
+	if err := s.watchlistRepo.RemoveFromWatchlist(ctx, userID, auth.ActiveProfileID(ctx), mediaID, mediaType); err != nil {
+		return fmt.Errorf("failed to remove from watchlist: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/dto"
+	"github.com/samcharles93/cinea/internal/entity"
+	"github.com/samcharles93/cinea/internal/errors"
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
+)
+
+// webhookEvents are the bus events webhooks may subscribe to.
+var webhookEvents = []events.Type{
+	events.ItemCreated,
+	events.ItemDeleted,
+	events.ScanStarted,
+	events.ScanCompleted,
+	events.UserRegistered,
+	events.UserDeleted,
+	events.LibraryDeleted,
+	events.PlaybackStarted,
+	events.TranscoderStatus,
+	events.TaskFailed,
+}
+
+const (
+	// webhookAttempts is how many times a delivery is tried before it is
+	// logged as failed; retries back off exponentially from webhookBackoff.
+	webhookAttempts = 3
+	webhookBackoff  = 2 * time.Second
+)
+
+// WebhookService manages admin-registered webhooks and delivers bus
+// events to them. Deliveries run off the publisher's goroutine, are
+// signed with the hook's secret and are recorded in a per-hook log.
+type WebhookService interface {
+	List(ctx context.Context) ([]*dto.WebhookDTO, error)
+	Create(ctx context.Context, req *dto.WebhookRequest) (*dto.WebhookDTO, error)
+	Update(ctx context.Context, id uint, req *dto.WebhookRequest) (*dto.WebhookDTO, error)
+	Delete(ctx context.Context, id uint) error
+	ListDeliveries(ctx context.Context, id uint) ([]*dto.WebhookDeliveryDTO, error)
+	EventTypes() []string
+}
+
+type webhookService struct {
+	appLogger   logger.Logger
+	webhookRepo repository.WebhookRepository
+	client      *http.Client
+}
+
+// NewWebhookService returns the webhook manager and subscribes it to the
+// deliverable bus events.
+func NewWebhookService(appLogger logger.Logger, webhookRepo repository.WebhookRepository, bus events.Bus) WebhookService {
+	s := &webhookService{
+		appLogger:   appLogger,
+		webhookRepo: webhookRepo,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, eventType := range webhookEvents {
+		bus.Subscribe(eventType, s.onEvent)
+	}
+	return s
+}
+
+func (s *webhookService) List(ctx context.Context) ([]*dto.WebhookDTO, error) {
+	webhooks, err := s.webhookRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return dto.WebhooksToDTO(webhooks), nil
+}
+
+func (s *webhookService) Create(ctx context.Context, req *dto.WebhookRequest) (*dto.WebhookDTO, error) {
+	if err := validateWebhookRequest(req); err != nil {
+		return nil, err
+	}
+
+	webhook := &entity.Webhook{
+		Name:    req.Name,
+		URL:     req.URL,
+		Secret:  req.Secret,
+		Events:  strings.Join(req.Events, ","),
+		Enabled: true,
+	}
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return dto.WebhookToDTO(webhook), nil
+}
+
+func (s *webhookService) Update(ctx context.Context, id uint, req *dto.WebhookRequest) (*dto.WebhookDTO, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, errors.NotFoundError("webhook")
+	}
+
+	if err := validateWebhookRequest(req); err != nil {
+		return nil, err
+	}
+
+	webhook.Name = req.Name
+	webhook.URL = req.URL
+	webhook.Events = strings.Join(req.Events, ",")
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if req.Enabled != nil {
+		webhook.Enabled = *req.Enabled
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return dto.WebhookToDTO(webhook), nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, id uint) error {
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		return errors.NotFoundError("webhook")
+	}
+	return s.webhookRepo.Delete(ctx, id)
+}
+
+func (s *webhookService) ListDeliveries(ctx context.Context, id uint) ([]*dto.WebhookDeliveryDTO, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, errors.NotFoundError("webhook")
+	}
+
+	deliveries, err := s.webhookRepo.ListDeliveries(ctx, id, 100)
+	if err != nil {
+		return nil, err
+	}
+	return dto.WebhookDeliveriesToDTO(deliveries), nil
+}
+
+// EventTypes returns the event types a webhook may subscribe to, for the
+// admin UI to offer.
+func (s *webhookService) EventTypes() []string {
+	types := make([]string, len(webhookEvents))
+	for i, eventType := range webhookEvents {
+		types[i] = string(eventType)
+	}
+	return types
+}
+
+func validateWebhookRequest(req *dto.WebhookRequest) error {
+	if req.Name == "" {
+		return fmt.Errorf("name is required: %w", errors.ErrBadRequest)
+	}
+
+	parsed, err := url.Parse(req.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("URL must be a valid http or https address: %w", errors.ErrBadRequest)
+	}
+
+	if len(req.Events) == 0 {
+		return fmt.Errorf("at least one event type is required: %w", errors.ErrBadRequest)
+	}
+	for _, event := range req.Events {
+		if !isWebhookEvent(event) {
+			return fmt.Errorf("unknown event type '%s': %w", event, errors.ErrBadRequest)
+		}
+	}
+	return nil
+}
+
+func isWebhookEvent(event string) bool {
+	for _, eventType := range webhookEvents {
+		if string(eventType) == event {
+			return true
+		}
+	}
+	return false
+}
+
+// onEvent fans a bus event out to the subscribed hooks. Bus handlers must
+// not block, so delivery happens on its own goroutine per hook; a failed
+// webhook never affects the operation that raised the event.
+func (s *webhookService) onEvent(ctx context.Context, event events.Event) {
+	webhooks, err := s.webhookRepo.FindEnabled(context.WithoutCancel(ctx))
+	if err != nil {
+		s.appLogger.Warn().Err(err).Msg("Failed to load webhooks for event delivery")
+		return
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"event":     string(event.Type),
+		"timestamp": event.Timestamp,
+		"data":      event.Payload,
+	})
+	if err != nil {
+		s.appLogger.Warn().Err(err).Str("event", string(event.Type)).Msg("Failed to encode webhook payload")
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if !webhook.SubscribesTo(string(event.Type)) {
+			continue
+		}
+		go s.deliver(context.WithoutCancel(ctx), webhook, string(event.Type), body)
+	}
+}
+
+// deliver posts the payload with retries and records the outcome in the
+// delivery log. Any 2xx response counts as delivered.
+func (s *webhookService) deliver(ctx context.Context, webhook *entity.Webhook, eventType string, body []byte) {
+	delivery := &entity.WebhookDelivery{
+		WebhookID: webhook.ID,
+		Event:     eventType,
+		Payload:   string(body),
+	}
+
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		delivery.Attempts = attempt
+		statusCode, err := s.post(ctx, webhook, eventType, body)
+		delivery.StatusCode = statusCode
+
+		if err == nil {
+			delivery.Success = true
+			delivery.Error = ""
+			break
+		}
+		delivery.Error = err.Error()
+
+		if attempt < webhookAttempts {
+			// 2s, 4s, 8s... between attempts
+			time.Sleep(webhookBackoff << (attempt - 1))
+		}
+	}
+
+	if !delivery.Success {
+		s.appLogger.Warn().
+			Str("webhook", webhook.Name).
+			Str("event", eventType).
+			Str("error", delivery.Error).
+			Msg("Webhook delivery failed")
+	}
+
+	if err := s.webhookRepo.AddDelivery(ctx, delivery); err != nil {
+		s.appLogger.Warn().Err(err).Str("webhook", webhook.Name).Msg("Failed to record webhook delivery")
+	}
+}
+
+func (s *webhookService) post(ctx context.Context, webhook *entity.Webhook, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Cinea-Event", eventType)
+	if webhook.Secret != "" {
+		req.Header.Set("X-Cinea-Signature", "sha256="+signWebhookPayload(webhook.Secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint returned %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// signWebhookPayload returns the hex HMAC-SHA256 of the payload, which
+// receivers recompute with the shared secret to verify the source.
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,73 @@
+// Package telemetry configures optional OpenTelemetry tracing. When
+// tracing is disabled in the config the global tracer provider stays a
+// no-op, so instrumentation elsewhere in the codebase costs nothing and
+// never needs to check a flag.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/samcharles93/cinea/config"
+)
+
+// serviceName identifies this server in exported traces.
+const serviceName = "cinea"
+
+// Tracer returns the application tracer. It resolves against the global
+// provider, so spans are no-ops until Init has run with tracing enabled.
+func Tracer() trace.Tracer {
+	return otel.Tracer(serviceName)
+}
+
+// Init installs the global tracer provider and propagator from the
+// config and returns a shutdown function that flushes pending spans.
+// With tracing disabled it returns a no-op shutdown.
+func Init(ctx context.Context, cfg *config.Config) (func(context.Context) error, error) {
+	if !cfg.Tracing.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	endpoint := cfg.Tracing.Endpoint
+	if endpoint == "" {
+		endpoint = "localhost:4318"
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint)}
+	if cfg.Tracing.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	ratio := cfg.Tracing.SampleRatio
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,10 @@
+// Package version carries the build version reported by the system info
+// endpoint and the SSDP announcer.
+package version
+
+// Version is stamped at build time:
+//
+//	go build -ldflags "-X github.com/samcharles93/cinea/internal/version.Version=v1.2.3"
+//
+// Development builds report "dev".
+var Version = "dev"
@@ -0,0 +1,292 @@
+// Package ws pushes bus events to connected clients over a WebSocket so
+// the dashboard updates live instead of polling. The server only ever
+// sends; client frames are read solely to answer pings and notice closes,
+// which keeps a dependency-free RFC 6455 implementation small.
+package ws
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/samcharles93/cinea/internal/events"
+	"github.com/samcharles93/cinea/internal/logger"
+)
+
+// handshakeGUID is the fixed GUID RFC 6455 mixes into the accept key.
+const handshakeGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxClientFrame caps inbound frames; clients only send control frames, so
+// anything large is a misbehaving peer.
+const maxClientFrame = 1 << 16
+
+const (
+	opcodeText  = 0x1
+	opcodeClose = 0x8
+	opcodePing  = 0x9
+	opcodePong  = 0xA
+)
+
+// forwardedEvents is the subset of bus events pushed to clients.
+var forwardedEvents = []events.Type{
+	events.ItemCreated,
+	events.ItemDeleted,
+	events.ScanStarted,
+	events.ScanCompleted,
+	events.PlaybackStarted,
+	events.TranscoderStatus,
+	events.TaskFailed,
+	events.TaskProgress,
+}
+
+// message is the JSON envelope sent to clients.
+type message struct {
+	Type      events.Type `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Payload   any         `json:"payload,omitempty"`
+}
+
+// Hub fans bus events out to every connected WebSocket client.
+type Hub struct {
+	appLogger logger.Logger
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+}
+
+type client struct {
+	conn net.Conn
+	send chan []byte
+	done chan struct{}
+
+	writeMu   sync.Mutex
+	closeOnce sync.Once
+}
+
+// NewHub subscribes a hub to the bus events the dashboard cares about.
+func NewHub(appLogger logger.Logger, bus events.Bus) *Hub {
+	h := &Hub{
+		appLogger: appLogger,
+		clients:   make(map[*client]struct{}),
+	}
+	for _, eventType := range forwardedEvents {
+		bus.Subscribe(eventType, h.forward)
+	}
+	return h
+}
+
+// forward runs on the publisher's goroutine, so it must not block: slow
+// clients have the event dropped rather than stalling the bus.
+func (h *Hub) forward(ctx context.Context, event events.Event) {
+	data, err := json.Marshal(message{
+		Type:      event.Type,
+		Timestamp: event.Timestamp,
+		Payload:   event.Payload,
+	})
+	if err != nil {
+		h.appLogger.Warn().Err(err).Str("event", string(event.Type)).Msg("Failed to encode event for WebSocket clients")
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for c := range h.clients {
+		select {
+		case c.send <- data:
+		default:
+			h.appLogger.Debug().Str("event", string(event.Type)).Msg("Dropping event for slow WebSocket client")
+		}
+	}
+}
+
+// ServeHTTP upgrades the connection and streams events until the client
+// disconnects.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !headerHasToken(r.Header.Get("Upgrade"), "websocket") || !headerHasToken(r.Header.Get("Connection"), "upgrade") {
+		http.Error(w, "Expected WebSocket upgrade", http.StatusBadRequest)
+		return
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "Missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "WebSocket not supported", http.StatusInternalServerError)
+		return
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err != nil {
+		h.appLogger.Warn().Err(err).Msg("Failed to hijack connection for WebSocket")
+		return
+	}
+
+	sum := sha1.Sum([]byte(key + handshakeGUID))
+	accept := base64.StdEncoding.EncodeToString(sum[:])
+	response := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + accept + "\r\n\r\n"
+	if _, err := rw.WriteString(response); err != nil {
+		conn.Close()
+		return
+	}
+	if err := rw.Flush(); err != nil {
+		conn.Close()
+		return
+	}
+
+	c := &client{
+		conn: conn,
+		send: make(chan []byte, 16),
+		done: make(chan struct{}),
+	}
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+
+	go h.writeLoop(c)
+	go h.readLoop(c, rw.Reader)
+}
+
+func (h *Hub) remove(c *client) {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+	h.mu.Lock()
+	delete(h.clients, c)
+	h.mu.Unlock()
+}
+
+func (h *Hub) writeLoop(c *client) {
+	defer h.remove(c)
+	for {
+		select {
+		case data := <-c.send:
+			if err := c.writeFrame(opcodeText, data); err != nil {
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// readLoop consumes client frames, answering pings and echoing closes; any
+// read error tears the connection down.
+func (h *Hub) readLoop(c *client, r *bufio.Reader) {
+	defer h.remove(c)
+	for {
+		opcode, payload, err := readFrame(r)
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case opcodeClose:
+			c.writeFrame(opcodeClose, payload)
+			return
+		case opcodePing:
+			if err := c.writeFrame(opcodePong, payload); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeFrame sends one unmasked server frame; a mutex serializes the event
+// stream with control frame replies.
+func (c *client) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	header := []byte{0x80 | opcode}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n < 1<<16:
+		header = append(header, 126, byte(n>>8), byte(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if _, err := c.conn.Write(header); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(payload)
+	return err
+}
+
+// readFrame parses one client frame, unmasking the payload.
+func readFrame(r *bufio.Reader) (byte, []byte, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	second, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	length := uint64(second & 0x7f)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(r, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(r, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if length > maxClientFrame {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+
+	var mask [4]byte
+	masked := second&0x80 != 0
+	if masked {
+		if _, err := io.ReadFull(r, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return first & 0x0f, payload, nil
+}
+
+// headerHasToken reports whether a comma-separated header value contains
+// the token, case-insensitively (Connection can be "keep-alive, Upgrade").
+func headerHasToken(header, token string) bool {
+	for _, part := range strings.Split(header, ",") {
+		if strings.EqualFold(strings.TrimSpace(part), token) {
+			return true
+		}
+	}
+	return false
+}
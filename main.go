@@ -14,154 +14,26 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5"
-	"github.com/go-chi/jwtauth/v5"
 	"github.com/samcharles93/cinea/config"
-	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/app"
+	"github.com/samcharles93/cinea/internal/discovery"
+	"github.com/samcharles93/cinea/internal/events"
 	"github.com/samcharles93/cinea/internal/ffmpeg"
-	"github.com/samcharles93/cinea/internal/handler"
 	"github.com/samcharles93/cinea/internal/logger"
 	"github.com/samcharles93/cinea/internal/repository"
 	"github.com/samcharles93/cinea/internal/router"
-	"github.com/samcharles93/cinea/internal/service"
-	"github.com/samcharles93/cinea/internal/service/cleanup"
-	"github.com/samcharles93/cinea/internal/service/extractor"
-	"github.com/samcharles93/cinea/internal/service/metadata"
+	"github.com/samcharles93/cinea/internal/service/backup"
+	"github.com/samcharles93/cinea/internal/service/maintenance"
 	"github.com/samcharles93/cinea/internal/service/scanner"
 	"github.com/samcharles93/cinea/internal/service/scheduler"
+	"github.com/samcharles93/cinea/internal/service/seeder"
+	"github.com/samcharles93/cinea/internal/telemetry"
 	"github.com/samcharles93/cinea/web"
-	"gorm.io/gorm"
 )
 
 //go:embed web/templates/* web/static/*
 var webFS embed.FS
 
-type app struct {
-	// Configuration
-	config *config.Config
-
-	// Core infrastructure
-	db        *gorm.DB
-	appLogger logger.Logger
-	tokenAuth *jwtauth.JWTAuth
-
-	// Repositories
-	repositories *repositories
-
-	// Services
-	services *services
-
-	// Handlers
-	handlers *handlers
-
-	// HTTP Server
-	router     *chi.Mux
-	server     *http.Server
-	webService *web.WebService
-
-	// Background Services
-	schedulerService *scheduler.Scheduler
-	ffmpegService    ffmpeg.Service
-}
-
-type repositories struct {
-	libraryRepo      repository.LibraryRepository
-	userRepo         repository.UserRepository
-	movieRepo        repository.MovieRepository
-	seriesRepo       repository.SeriesRepository
-	seasonRepo       repository.SeasonRepository
-	episodeRepo      repository.EpisodeRepository
-	schedulerRepo    repository.SchedulerRepository
-	watchHistoryRepo repository.WatchHistoryRepository
-	watchlistRepo    repository.WatchlistRepository
-	favoriteRepo     repository.FavoriteRepository
-	ratingRepo       repository.RatingRepository
-}
-
-type services struct {
-	authService      service.AuthService
-	userService      service.UserService
-	mediaService     service.MediaService
-	scannerService   scanner.Service
-	tmdbService      *metadata.TMDbService
-	cleanupService   cleanup.Service
-	extractorService extractor.Service
-}
-
-type handlers struct {
-	authHandler   handler.AuthHandler
-	movieHandler  handler.MovieHandler
-	seriesHandler handler.SeriesHandler
-	userHandler   handler.UserHandler
-	webHandler    handler.WebHandler
-}
-
-func (a *app) initServices() *services {
-	// Create the JWT auth once
-	tokenAuth := jwtauth.New("HS256", []byte(a.config.Auth.JWTSecret), nil)
-	a.tokenAuth = tokenAuth
-
-	// Initialise services
-	return &services{
-		authService: service.NewAuthService(a.repositories.userRepo, a.config, tokenAuth),
-		userService: service.NewUserService(a.repositories.userRepo),
-		mediaService: service.NewMediaService(
-			a.repositories.movieRepo,
-			a.repositories.seriesRepo,
-			a.repositories.seasonRepo,
-			a.repositories.episodeRepo,
-		),
-		tmdbService:      metadata.NewTMDbService(a.config),
-		extractorService: extractor.NewExtractor(a.appLogger, a.ffmpegService),
-		scannerService: scanner.NewScannerService(
-			a.config,
-			a.appLogger,
-			a.repositories.libraryRepo,
-			a.repositories.movieRepo,
-			a.repositories.seriesRepo,
-			a.repositories.seasonRepo,
-			a.repositories.episodeRepo,
-			a.services.tmdbService,
-			a.services.extractorService,
-		),
-		cleanupService: cleanup.NewCleanupService(a.config, a.appLogger, a.repositories.libraryRepo),
-	}
-}
-
-func (a *app) initHandlers() *handlers {
-	// Initialise the JWT verifier
-	jwtVerifier := auth.NewJWTVerifier(a.tokenAuth)
-
-	return &handlers{
-		authHandler:   handler.NewAuthHandler(a.services.authService, a.config),
-		movieHandler:  handler.NewMovieHandler(a.services.mediaService, jwtVerifier),
-		seriesHandler: handler.NewSeriesHandler(a.services.mediaService, jwtVerifier),
-		userHandler:   handler.NewUserHandler(a.services.userService, a.services.authService, jwtVerifier),
-	}
-}
-
-func (a *app) initWebService(webFS embed.FS) {
-	a.webService = web.NewWebService(
-		a.config,
-		a.appLogger,
-		a.services.userService,
-		a.services.mediaService,
-		a.tokenAuth,
-		webFS,
-	)
-}
-
-func (a *app) initRouter() {
-	handlers := a.initHandlers()
-	a.router = router.NewRouter(
-		a.config,
-		handler.movieHandler,
-		handler.seriesHandler,
-		handler.userHandler,
-		handler.authHandler,
-		handler.webHandler,
-	)
-}
-
 func initConfig() (*config.Config, error) {
 	configPath := flag.String("config", "config/config.yaml", "path to config file")
 	flag.Parse()
@@ -176,63 +48,150 @@ func initConfig() (*config.Config, error) {
 }
 
 func main() {
-	if err := run(context.Background()); err != nil {
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if err := runSeed(context.Background(), os.Args[2:]); err != nil {
+			log.Printf("Cinea failed to seed: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(context.Background(), webFS); err != nil {
 		log.Printf("Cinea failed to start: %v", err)
 		os.Exit(1)
 	}
 }
 
-func run(ctx context.Context, webFS embed.FS) error {
-	// Create app instance
-	app := &app{}
+// runSeed loads fixture data into the database for reproducible environments.
+func runSeed(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ExitOnError)
+	configPath := fs.String("config", "config/config.yaml", "path to config file")
+	fixturesPath := fs.String("fixtures", "", "path to a YAML or JSON fixture file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
 
+	if *fixturesPath == "" {
+		return fmt.Errorf("missing required flag: --fixtures")
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	appLogger, err := logger.NewLogger(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialise logger: %w", err)
+	}
+
+	db, err := repository.NewDB(cfg, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialise the database: %w", err)
+	}
+
+	repos := app.NewRepositories(cfg, db, appLogger)
+
+	seederService := seeder.NewSeederService(
+		appLogger,
+		repos.Library,
+		repos.User,
+		repos.Scheduler,
+	)
+
+	return seederService.Seed(ctx, *fixturesPath)
+}
+
+func run(ctx context.Context, webFS embed.FS) error {
 	// Initialise Configuration
 	cfg, err := initConfig()
 	if err != nil {
 		return fmt.Errorf("failed to initialise config: %w", err)
 	}
-	app.config = cfg
 
 	// Initialise Logger
 	appLogger, err := logger.NewLogger(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialise logger: %w", err)
 	}
-	app.appLogger = appLogger
 
-	// Initialize FFmpeg service
-	ffmpegService, err := ffmpeg.NewFFMpegService(appLogger)
+	// Optional OTLP tracing; a no-op unless enabled in the config
+	shutdownTracing, err := telemetry.Init(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to initialise FFmpeg service")
+		return fmt.Errorf("failed to initialise tracing: %w", err)
 	}
-	app.ffmpegService = ffmpegService
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			appLogger.Warn().Err(err).Msg("Failed to flush traces on shutdown")
+		}
+	}()
 
-	// Ensure FFmpeg binaries are installed
-	if err := ffmpegService.EnsureInstalled(); err != nil {
-		return fmt.Errorf("failed to verify FFmpeg is installed")
+	// Initialize FFmpeg service; an unavailable ffmpeg degrades the server
+	// rather than stopping it
+	ffmpegService, err := ffmpeg.NewFFMpegService(cfg, appLogger)
+	if err != nil {
+		return fmt.Errorf("failed to initialise FFmpeg service")
 	}
 
-	// Database and Repositories
+	// Database
 	db, err := repository.NewDB(cfg, appLogger)
 	if err != nil {
 		return fmt.Errorf("failed to initialise the database: %w", err)
 	}
-	app.db = db
 
-	app.repositories = app.initRepositories(db)
-	app.services = app.initServices()
-	app.initWebService(webFS)
-	app.initRouter()
+	// Composition root: each layer only sees what was built before it
+	repos := app.NewRepositories(cfg, db, appLogger)
+	services := app.NewServices(cfg, appLogger, repos, ffmpegService)
+
+	// Alert admins about degraded mode and keep retrying acquisition
+	if !ffmpegService.Available() {
+		services.Bus.Publish(ctx, events.TranscoderStatus, events.TranscoderPayload{
+			Available: false,
+			Reason:    "installation failed at startup",
+		})
+		go ffmpegService.RetryInstall(ctx, services.Bus)
+	} else if _, err := ffmpegService.Capabilities(ctx); err != nil {
+		// Prime the capability cache so the first playback doesn't pay for
+		// the probe
+		appLogger.Warn().Err(err).Msg("Failed to probe ffmpeg capabilities")
+	}
 
 	// Initialise Scheduler
-	schedulerService, err := scheduler.NewScheduler(app.appLogger, app.repositories.schedulerRepo)
+	schedulerService, err := scheduler.NewScheduler(cfg, appLogger, repos.Scheduler, services.Bus)
 	if err != nil {
 		return fmt.Errorf("failed to initialise scheduler: %w", err)
 	}
-	app.schedulerService = &schedulerService
 
-	schedulerService.RegisterTask("scanner", app.services.scannerService)
-	schedulerService.RegisterTask("cleanup", app.services.cleanupService)
+	webService := web.NewWebService(
+		cfg,
+		appLogger,
+		services.User,
+		services.Media,
+		services.Collection,
+		services.TokenAuth,
+		repos.RefreshToken,
+		repos.APIKey,
+		repos.User,
+		webFS,
+	)
+
+	backupService := backup.NewBackupService(cfg, appLogger, db)
+
+	handlers := app.NewHandlers(cfg, appLogger, repos, services, schedulerService, ffmpegService, backupService, webService)
+	mux := newRouter(cfg, appLogger, handlers)
+
+	schedulerService.RegisterTask("scanner", services.Scanner)
+	schedulerService.RegisterTask("cleanup", services.Cleanup)
+	schedulerService.RegisterTask("metadata", scanner.NewMetadataTask(services.Scanner))
+	schedulerService.RegisterTask("subtitles", services.Subtitles)
+	schedulerService.RegisterTask("artwork", services.Images)
+	schedulerService.RegisterTask("recommendations", services.Recommend)
+	schedulerService.RegisterTask("maintenance", maintenance.NewMaintenanceService(cfg, appLogger, db))
+	schedulerService.RegisterTask("backup", backupService)
+
+	if err := schedulerService.EnsureDefaultTasks(ctx); err != nil {
+		return fmt.Errorf("failed to create default scheduler tasks: %w", err)
+	}
 
 	if err := schedulerService.LoadTasks(ctx); err != nil {
 		return fmt.Errorf("failed to load scheduler tasks: %w", err)
@@ -241,20 +200,29 @@ func run(ctx context.Context, webFS embed.FS) error {
 	schedulerService.Start(ctx)
 	defer schedulerService.Shutdown(ctx)
 
+	// Announce the server on the LAN so client apps can auto-discover it
+	if cfg.Discovery.Enabled {
+		discoveryService := discovery.NewService(cfg, appLogger)
+		if err := discoveryService.Start(ctx); err != nil {
+			appLogger.Warn().Err(err).Msg("Failed to start SSDP discovery; continuing without it")
+		} else {
+			defer discoveryService.Shutdown()
+		}
+	}
+
 	// Initialise HTTP Server
 	server := &http.Server{
 		Addr:         ":" + strconv.Itoa(cfg.Server.Port),
-		Handler:      app.router,
+		Handler:      mux,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
-	app.server = server
 
 	go func() {
-		app.appLogger.Info().Msgf("Starting server on port %d", cfg.Server.Port)
-		if err := app.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			app.appLogger.Fatal().Err(err).Msg("Server failed to start")
+		appLogger.Info().Msgf("Starting server on port %d", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			appLogger.Fatal().Err(err).Msg("Server failed to start")
 		}
 	}()
 
@@ -262,16 +230,54 @@ func run(ctx context.Context, webFS embed.FS) error {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	app.appLogger.Info().Msg("Shutting down server...")
+	appLogger.Info().Msg("Shutting down server...")
 
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	if err := app.server.Shutdown(ctx); err != nil {
-		app.appLogger.Fatal().Err(err).Msg("Server forced to shutdown")
+	if err := server.Shutdown(ctx); err != nil {
+		appLogger.Fatal().Err(err).Msg("Server forced to shutdown")
 		return err
 	}
 
-	app.appLogger.Info().Msg("Server exiting")
+	appLogger.Info().Msg("Server exiting")
 	return nil
 }
+
+// newRouter adapts the handler bundle to the router's explicit parameters.
+func newRouter(cfg *config.Config, appLogger logger.Logger, handlers *app.Handlers) *chi.Mux {
+	return router.NewRouter(
+		cfg,
+		appLogger,
+		handlers.Movie,
+		handlers.Series,
+		handlers.Search,
+		handlers.Person,
+		handlers.Taxonomy,
+		handlers.Collection,
+		handlers.Identify,
+		handlers.DeepLink,
+		handlers.Image,
+		handlers.User,
+		handlers.Library,
+		handlers.Music,
+		handlers.Photo,
+		handlers.Audiobook,
+		handlers.Auth,
+		handlers.APIKey,
+		handlers.Playback,
+		handlers.Cast,
+		handlers.Activity,
+		handlers.Dashboard,
+		handlers.Scheduler,
+		handlers.Admin,
+		handlers.Webhook,
+		handlers.Integration,
+		handlers.System,
+		handlers.Branding,
+		handlers.Recommend,
+		handlers.Docs,
+		handlers.WS,
+		handlers.Web,
+	)
+}
@@ -0,0 +1,128 @@
+//go:build integration
+
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// client is a thin API client bound to one running server; it carries the
+// bearer token from login and fails the test on transport errors.
+type client struct {
+	t       *testing.T
+	baseURL string
+	token   string
+}
+
+// waitReady polls the API until the server answers HTTP, failing the test
+// if it never comes up.
+func (c *client) waitReady() {
+	c.t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := http.Get(c.baseURL + "/api/auth/me")
+		if err == nil {
+			resp.Body.Close()
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	c.t.Fatal("server did not become ready within 30s")
+}
+
+// do sends a JSON request and returns the status code and response body.
+func (c *client) do(method, path string, body interface{}) (int, []byte) {
+	c.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("failed to marshal request body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, reader)
+	if err != nil {
+		c.t.Fatalf("failed to build request: %v", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		c.t.Fatalf("failed to read response body: %v", err)
+	}
+	return resp.StatusCode, data
+}
+
+// doJSON sends a request, requires the expected status and decodes the
+// response into out when non-nil.
+func (c *client) doJSON(method, path string, body interface{}, expectStatus int, out interface{}) {
+	c.t.Helper()
+
+	status, data := c.do(method, path, body)
+	if status != expectStatus {
+		c.t.Fatalf("%s %s returned %d, want %d: %s", method, path, status, expectStatus, data)
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			c.t.Fatalf("failed to decode %s %s response: %v: %s", method, path, err, data)
+		}
+	}
+}
+
+// login authenticates as the seeded admin and stores the bearer token.
+func (c *client) login() {
+	c.t.Helper()
+
+	var auth struct {
+		Token string `json:"token"`
+	}
+	c.doJSON(http.MethodPost, "/api/auth/login", map[string]string{
+		"username": "admin",
+		"password": "integration",
+	}, http.StatusOK, &auth)
+	if auth.Token == "" {
+		c.t.Fatal("login returned an empty token")
+	}
+	c.token = auth.Token
+}
+
+// waitForMovie triggers nothing itself; it polls the movie list until the
+// scan has imported the fixture and returns its ID.
+func (c *client) waitForMovie() uint {
+	c.t.Helper()
+
+	deadline := time.Now().Add(60 * time.Second)
+	for time.Now().Before(deadline) {
+		var page struct {
+			Items []struct {
+				ID uint `json:"id"`
+			} `json:"items"`
+		}
+		c.doJSON(http.MethodGet, "/api/movies/", nil, http.StatusOK, &page)
+		if len(page.Items) > 0 {
+			return page.Items[0].ID
+		}
+		time.Sleep(time.Second)
+	}
+	c.t.Fatal("scan did not import the fixture movie within 60s")
+	return 0
+}
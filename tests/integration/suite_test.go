@@ -0,0 +1,110 @@
+//go:build integration
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSQLite(t *testing.T) {
+	runSuite(t, "sqlite")
+}
+
+func TestPostgres(t *testing.T) {
+	if !postgresAvailable() {
+		t.Skip("Postgres is not reachable; start it with docker compose -f tests/integration/docker-compose.yml up -d")
+	}
+	runSuite(t, "postgres")
+}
+
+// runSuite walks one server through the core flows in order: auth, scan,
+// browse, watch progress and playback. The subtests share state (the
+// bearer token, the imported movie ID), so they must run sequentially.
+func runSuite(t *testing.T, driver string) {
+	c := startServer(t, driver)
+
+	t.Run("auth", func(t *testing.T) {
+		if status, _ := c.do(http.MethodPost, "/api/auth/login", map[string]string{
+			"username": "admin",
+			"password": "wrong",
+		}); status != http.StatusUnauthorized {
+			t.Fatalf("login with a bad password returned %d, want 401", status)
+		}
+
+		c.login()
+
+		var me struct {
+			Username string `json:"username"`
+			Role     string `json:"role"`
+		}
+		c.doJSON(http.MethodGet, "/api/auth/me", nil, http.StatusOK, &me)
+		if me.Username != "admin" || me.Role != "admin" {
+			t.Fatalf("unexpected identity: %+v", me)
+		}
+	})
+
+	var movieID uint
+	t.Run("scan and browse", func(t *testing.T) {
+		var libraries []struct {
+			ID   uint   `json:"id"`
+			Name string `json:"name"`
+		}
+		c.doJSON(http.MethodGet, "/api/libraries/", nil, http.StatusOK, &libraries)
+		if len(libraries) != 1 || libraries[0].Name != "Movies" {
+			t.Fatalf("unexpected libraries: %+v", libraries)
+		}
+
+		c.doJSON(http.MethodPost, fmt.Sprintf("/api/libraries/%d/scan", libraries[0].ID), nil, http.StatusAccepted, nil)
+		movieID = c.waitForMovie()
+
+		var movie struct {
+			ID    uint   `json:"id"`
+			Title string `json:"title"`
+		}
+		c.doJSON(http.MethodGet, fmt.Sprintf("/api/movies/%d", movieID), nil, http.StatusOK, &movie)
+		if movie.Title == "" {
+			t.Fatal("imported movie has no title")
+		}
+	})
+
+	t.Run("watch progress", func(t *testing.T) {
+		if movieID == 0 {
+			t.Skip("no movie imported")
+		}
+		body := map[string]interface{}{"media_type": "movie", "media_id": movieID}
+		c.doJSON(http.MethodPost, "/api/user/watched", body, http.StatusNoContent, nil)
+		c.doJSON(http.MethodDelete, "/api/user/watched", body, http.StatusNoContent, nil)
+	})
+
+	t.Run("playback", func(t *testing.T) {
+		if movieID == 0 {
+			t.Skip("no movie imported")
+		}
+
+		var info struct {
+			Container  string `json:"container"`
+			VideoCodec string `json:"video_codec"`
+		}
+		c.doJSON(http.MethodGet, fmt.Sprintf("/api/playback/movie/%d", movieID), nil, http.StatusOK, &info)
+		if info.VideoCodec == "" {
+			t.Fatal("playback info has no video codec; the probe likely failed")
+		}
+
+		var session struct {
+			ID string `json:"id"`
+		}
+		c.doJSON(http.MethodPost, fmt.Sprintf("/api/playback/movie/%d/session?client=integration", movieID), nil, http.StatusCreated, &session)
+
+		var sessions []struct {
+			ID string `json:"id"`
+		}
+		c.doJSON(http.MethodGet, "/api/playback/sessions", nil, http.StatusOK, &sessions)
+		if len(sessions) != 1 || sessions[0].ID != session.ID {
+			t.Fatalf("unexpected sessions: %+v", sessions)
+		}
+
+		c.doJSON(http.MethodDelete, "/api/playback/sessions/"+session.ID, nil, http.StatusNoContent, nil)
+	})
+}
@@ -0,0 +1,339 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/samcharles93/cinea/internal/auth"
+	"github.com/samcharles93/cinea/internal/entity"
+)
+
+// DashboardHandler displays the dashboard page
+func (s *webService) DashboardHandler(w http.ResponseWriter, r *http.Request) {
+	s.servePage(w, r, "dashboard", nil)
+}
+
+// LoginHandler handles the login page and login requests
+func (s *webService) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		s.servePage(w, r, "login", nil)
+		return
+	}
+
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Username == "" || req.Password == "" {
+		s.servePage(w, r, "login", nil, "Missing credentials")
+		return
+	}
+
+	user, err := s.userService.Authenticate(r.Context(), req.Username, req.Password)
+	if err != nil {
+		s.servePage(w, r, "login", nil, "Invalid credentials")
+		return
+	}
+
+	tokenString, err := s.generateToken(user)
+	if err != nil {
+		s.appLogger.Error().Err(err).Str("username", user.Username).Msg("Failed to generate token")
+		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
+		return
+	}
+
+	// Check if this is an HTMX request
+	if r.Header.Get("HX-Request") == "true" {
+		resp := AuthResponse{
+			Token: tokenString,
+			User: struct {
+				ID       uint   `json:"id"`
+				Username string `json:"username"`
+				Email    string `json:"email"`
+				Role     string `json:"role"`
+			}{
+				ID:       user.ID,
+				Username: user.Username,
+				Email:    user.Email,
+				Role:     string(user.Role),
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	// For regular requests, set cookie and redirect to dashboard
+	http.SetCookie(w, &http.Cookie{
+		Name:     "token",
+		Value:    tokenString,
+		Path:     "/",
+		MaxAge:   86400, // 1 day
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// RegisterHandler handles the registration page and registration requests
+func (s *webService) RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		s.servePage(w, r, "register", nil)
+		return
+	}
+
+	// Process registration (POST)
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	// Validate input
+	if req.Username == "" || req.Password == "" || req.Email == "" {
+		s.servePage(w, r, "register", nil, "Missing required fields")
+		return
+	}
+
+	// Create user
+	user, err := s.userService.CreateUser(r.Context(), req.Username, req.Email, req.Password)
+	if err != nil {
+		s.appLogger.Error().Err(err).Str("username", req.Username).Msg("Failed to create user")
+		s.servePage(w, r, "register", nil, "Failed to create user")
+		return
+	}
+
+	// Generate token
+	tokenString, err := s.generateToken(user)
+	if err != nil {
+		s.appLogger.Error().Err(err).Str("username", user.Username).Msg("Failed to generate token")
+		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
+		return
+	}
+
+	// Check if this is an HTMX request
+	if r.Header.Get("HX-Request") == "true" {
+		resp := AuthResponse{
+			Token: tokenString,
+			User: struct {
+				ID       uint   `json:"id"`
+				Username string `json:"username"`
+				Email    string `json:"email"`
+				Role     string `json:"role"`
+			}{
+				ID:       user.ID,
+				Username: user.Username,
+				Email:    user.Email,
+				Role:     string(user.Role),
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	// For regular requests, set cookie and redirect to dashboard
+	http.SetCookie(w, &http.Cookie{
+		Name:     "token",
+		Value:    tokenString,
+		Path:     "/",
+		MaxAge:   86400, // 1 day
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// LogoutHandler handles logout requests
+func (s *webService) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	// Clear the token cookie
+	http.SetCookie(w, &http.Cookie{
+		Name:     "token",
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// Check if this is an HTMX request
+	if r.Header.Get("HX-Request") == "true" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// For regular requests, redirect to login page
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// GetCurrentUser returns the current user information
+func (s *webService) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	userData := map[string]interface{}{
+		"id":       user.ID,
+		"username": user.Username,
+		"email":    user.Email,
+		"role":     user.Role,
+	}
+
+	// Check if this is an HTMX request
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(userData)
+		return
+	}
+
+	s.servePage(w, r, "dashboard", userData)
+}
+
+// UserManagerHandler displays the user management page
+func (s *webService) UserManagerHandler(w http.ResponseWriter, r *http.Request) {
+	user, err := auth.GetUserFromContext(r.Context())
+	if err != nil || user.Role != entity.RoleAdmin {
+		http.Error(w, "Unauthorized", http.StatusForbidden)
+		return
+	}
+
+	users, err := s.userService.ListUsers(r.Context())
+	if err != nil {
+		s.appLogger.Error().Err(err).Msg("Failed to get users")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	s.servePage(w, r, "users", users)
+}
+
+// MediaBrowserHandler displays the media browser page
+func (s *webService) MediaBrowserHandler(w http.ResponseWriter, r *http.Request) {
+	// Get movies
+	movies, err := s.mediaService.GetAllMovies(r.Context())
+	if err != nil {
+		s.appLogger.Error().Err(err).Msg("Failed to get movies")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// Get TV shows
+	tvShows, err := s.mediaService.GetAllSeries(r.Context())
+	if err != nil {
+		s.appLogger.Error().Err(err).Msg("Failed to get TV shows")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// Convert to media items
+	mediaItems := []MediaItem{}
+	for _, m := range movies {
+		mediaItems = append(mediaItems, MediaItem{
+			ID:        m.ID,
+			Title:     m.Title,
+			Type:      "movie",
+			Overview:  m.Overview,
+			PosterURL: m.PosterPath,
+		})
+	}
+
+	for _, t := range tvShows {
+		mediaItems = append(mediaItems, MediaItem{
+			ID:        t.ID,
+			Title:     t.Title,
+			Type:      "tvshow",
+			Overview:  t.Overview,
+			PosterURL: t.PosterPath,
+		})
+	}
+
+	s.servePage(w, r, "media", mediaItems)
+}
+
+// CollectionsHandler displays the collection browser page
+func (s *webService) CollectionsHandler(w http.ResponseWriter, r *http.Request) {
+	collections, err := s.collectionService.ListCollections(r.Context())
+	if err != nil {
+		s.appLogger.Error().Err(err).Msg("Failed to get collections")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	s.servePage(w, r, "collections", collections)
+}
+
+// CollectionDetailsHandler displays a collection and its movies
+func (s *webService) CollectionDetailsHandler(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid collection ID", http.StatusBadRequest)
+		return
+	}
+
+	collection, err := s.collectionService.GetCollectionByID(r.Context(), uint(id))
+	if err != nil {
+		s.appLogger.Error().Err(err).Uint64("id", id).Msg("Error finding collection")
+		http.Error(w, "Collection not found", http.StatusNotFound)
+		return
+	}
+
+	s.servePage(w, r, "collection_details", collection)
+}
+
+// MediaDetailsHandler displays the details of a specific media item
+func (s *webService) MediaDetailsHandler(w http.ResponseWriter, r *http.Request) {
+	// Get the media ID from the URL
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid media ID", http.StatusBadRequest)
+		return
+	}
+
+	// Try to find a movie first
+	movie, err := s.mediaService.GetMovieByID(r.Context(), uint(id), "")
+	if err == nil && movie != nil {
+		// It's a movie
+		mediaItem := MediaItem{
+			ID:        movie.ID,
+			Title:     movie.Title,
+			Type:      "movie",
+			Overview:  movie.Overview,
+			PosterURL: movie.PosterPath,
+		}
+		s.servePage(w, r, "media_details", mediaItem)
+		return
+	}
+
+	// If it's not a movie, try to find a TV show
+	tvShow, err := s.mediaService.GetSeriesByID(r.Context(), uint(id), "")
+	if err != nil {
+		s.appLogger.Error().Err(err).Uint64("id", id).Msg("Error finding TV show")
+	}
+
+	if tvShow != nil {
+		// It's a TV show
+		mediaItem := MediaItem{
+			ID:        tvShow.ID,
+			Title:     tvShow.Title,
+			Type:      "tvshow",
+			Overview:  tvShow.Overview,
+			PosterURL: tvShow.PosterPath,
+		}
+		s.servePage(w, r, "media_details", mediaItem)
+		return
+	}
+
+	// If we didn't find either, return a 404
+	http.Error(w, "Media not found", http.StatusNotFound)
+}
@@ -72,6 +72,10 @@ func (s *webService) servePage(w http.ResponseWriter, r *http.Request, pageName
 		pageData.Title = "Media Browser - Cinea"
 	case "media_details":
 		pageData.Title = "Media Details - Cinea"
+	case "collections":
+		pageData.Title = "Collections - Cinea"
+	case "collection_details":
+		pageData.Title = "Collection - Cinea"
 	case "users":
 		pageData.Title = "User Manager - Cinea"
 	case "server":
@@ -99,6 +103,10 @@ func (s *webService) servePage(w http.ResponseWriter, r *http.Request, pageName
 		contentTemplateFile = "web/templates/media_browser.html"
 	case "media_details":
 		contentTemplateFile = "web/templates/media_details.html"
+	case "collections":
+		contentTemplateFile = "web/templates/collections.html"
+	case "collection_details":
+		contentTemplateFile = "web/templates/collection_details.html"
 	case "users":
 		contentTemplateFile = "web/templates/user_manager.html"
 	case "server":
@@ -10,6 +10,7 @@ import (
 	"github.com/samcharles93/cinea/config"
 	"github.com/samcharles93/cinea/internal/auth"
 	"github.com/samcharles93/cinea/internal/logger"
+	"github.com/samcharles93/cinea/internal/repository"
 	"github.com/samcharles93/cinea/internal/service"
 )
 
@@ -25,17 +26,20 @@ type WebService interface {
 	UserManagerHandler(w http.ResponseWriter, r *http.Request)
 	MediaBrowserHandler(w http.ResponseWriter, r *http.Request)
 	MediaDetailsHandler(w http.ResponseWriter, r *http.Request)
+	CollectionsHandler(w http.ResponseWriter, r *http.Request)
+	CollectionDetailsHandler(w http.ResponseWriter, r *http.Request)
 }
 
 type webService struct {
-	webFS        embed.FS
-	config       *config.Config
-	appLogger    logger.Logger
-	tokenAuth    *jwtauth.JWTAuth
-	templates    *template.Template
-	userService  service.UserService
-	mediaService service.MediaService
-	jwtVerifier  *auth.JWTVerifier
+	webFS             embed.FS
+	config            *config.Config
+	appLogger         logger.Logger
+	tokenAuth         *jwtauth.JWTAuth
+	templates         *template.Template
+	userService       service.UserService
+	mediaService      service.MediaService
+	collectionService service.CollectionService
+	jwtVerifier       *auth.JWTVerifier
 }
 
 // NewWebService creates a new web service
@@ -44,10 +48,14 @@ func NewWebService(
 	appLogger logger.Logger,
 	userService service.UserService,
 	mediaService service.MediaService,
+	collectionService service.CollectionService,
 	tokenAuth *jwtauth.JWTAuth,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
 	webFS embed.FS,
 ) WebService {
-	jwtVerifier := auth.NewJWTVerifier(tokenAuth)
+	jwtVerifier := auth.NewJWTVerifier(tokenAuth, refreshTokenRepo, apiKeyRepo, userRepo)
 
 	// Try to parse all templates
 	tmpl, err := template.ParseFS(webFS, "web/templates/**/*.html")
@@ -57,14 +65,15 @@ func NewWebService(
 	}
 
 	return &webService{
-		config:       cfg,
-		appLogger:    appLogger,
-		tokenAuth:    tokenAuth,
-		webFS:        webFS,
-		templates:    tmpl,
-		userService:  userService,
-		mediaService: mediaService,
-		jwtVerifier:  jwtVerifier,
+		config:            cfg,
+		appLogger:         appLogger,
+		tokenAuth:         tokenAuth,
+		webFS:             webFS,
+		templates:         tmpl,
+		userService:       userService,
+		mediaService:      mediaService,
+		collectionService: collectionService,
+		jwtVerifier:       jwtVerifier,
 	}
 }
 